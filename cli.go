@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/config"
+	"github.com/bhuvi1021/TripleA/database/migrations"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"github.com/bhuvi1021/TripleA/internal/service"
+)
+
+// runCommand executes a CLI subcommand instead of starting the server, eg `go run . migrate status`
+func runCommand(db *sql.DB, cfg *config.Config, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrateCommand(db, args[1:])
+	case "interest":
+		return runInterestCommand(db, cfg, args[1:])
+	case "reconcile":
+		return runReconcileCommand(db, args[1:])
+	case "apikey":
+		return runApiKeyCommand(db, cfg, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, expected one of: migrate, interest, reconcile, apikey", args[0])
+	}
+}
+
+// runMigrateCommand handles `migrate up`, `migrate down [steps]` and `migrate status`
+func runMigrateCommand(db *sql.DB, args []string) error {
+	usage := fmt.Errorf("usage: migrate up | migrate down [steps] | migrate status")
+	if len(args) == 0 {
+		return usage
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return usage
+			}
+		}
+		return migrator.Down(ctx, steps)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return usage
+	}
+}
+
+// runInterestCommand handles `interest run [-date YYYY-MM-DD] [-dry-run]`, accruing interest for every day before
+// date and paying every month before the month of date
+func runInterestCommand(db *sql.DB, cfg *config.Config, args []string) error {
+	usage := fmt.Errorf("usage: interest run [-date YYYY-MM-DD] [-dry-run]")
+	if len(args) == 0 || args[0] != "run" {
+		return usage
+	}
+
+	flags := flag.NewFlagSet("interest run", flag.ContinueOnError)
+	date := flags.String("date", time.Now().UTC().Format(time.DateOnly), "run as of this UTC day")
+	dryRun := flags.Bool("dry-run", false, "print what the run would do without writing anything")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() > 0 {
+		return usage
+	}
+	asOf, err := time.Parse(time.DateOnly, *date)
+	if err != nil {
+		return usage
+	}
+	if cfg.InterestExpenseAccountId <= 0 {
+		return fmt.Errorf("INTEREST_EXPENSE_ACCOUNT_ID must be set to pay interest")
+	}
+
+	interestService := service.NewInterestService(repository.NewInterestRepository(db), repository.NewAccountRepository(db), cfg.InterestExpenseAccountId)
+	result, err := interestService.RunInterest(context.Background(), asOf, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tDATE\tBALANCE\tRATE\tDAY COUNT\tINTEREST\tCURRENCY")
+	for _, a := range result.Accruals {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", a.AccountId, a.AccrualDate.Format(time.DateOnly), a.Balance, a.AnnualRate, a.DayCount, a.Amount, a.CurrencyCode)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ACCOUNT\tTHROUGH\tAMOUNT\tCURRENCY\tREFERENCE\tERROR")
+	for _, p := range result.Postings {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", p.AccountId, p.Through.Format(time.DateOnly), p.Amount, p.CurrencyCode, p.Reference, p.ErrorMessage)
+	}
+	if result.DryRun {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "dry run: nothing was written")
+	}
+	return w.Flush()
+}
+
+// runReconcileCommand handles `reconcile [-account ID] [-flag]`, listing the accounts whose balance does not match
+// their ledger. It fails when there is any, so that it can be run from a scheduler that alerts on failure
+func runReconcileCommand(db *sql.DB, args []string) error {
+	usage := fmt.Errorf("usage: reconcile [-account ID] [-flag]")
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	accountId := flags.Int64("account", 0, "check this account only")
+	flagMismatches := flags.Bool("flag", false, "record the mismatches in reconciliation_flags")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return usage
+	}
+
+	reconciliationService := service.NewReconciliationService(repository.NewReconciliationRepository(db))
+	report, err := reconciliationService.Reconcile(context.Background(), models.ReconcileRequest{AccountId: *accountId, Flag: *flagMismatches})
+	if err != nil {
+		return err
+	}
+
+	if len(report.Mismatches) == 0 {
+		fmt.Printf("%d accounts reconciled\n", report.AccountsChecked)
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tCURRENCY\tBALANCE\tLEDGER BALANCE\tLAST RECORDED\tDIFFERENCE")
+	for _, m := range report.Mismatches {
+		lastRecorded := "-"
+		if m.LastRecordedBalance != nil {
+			lastRecorded = m.LastRecordedBalance.String()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", m.AccountId, m.CurrencyCode, m.Balance, m.LedgerBalance, lastRecorded, m.Difference)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return fmt.Errorf("%d of %d accounts do not reconcile", len(report.Mismatches), report.AccountsChecked)
+}
+
+// runApiKeyCommand handles `apikey create -name NAME -scopes SCOPES [-client ID]`, `apikey list`, `apikey revoke ID`
+// and `apikey secret ID`. The key of a new API key and a new signing secret are printed once and cannot be shown again
+func runApiKeyCommand(db *sql.DB, cfg *config.Config, args []string) error {
+	usage := fmt.Errorf("usage: apikey create -name NAME -scopes SCOPE[,SCOPE...] [-client ID] | apikey list | apikey revoke ID | apikey secret ID")
+	if len(args) == 0 {
+		return usage
+	}
+
+	apiKeyService := service.NewApiKeyService(repository.NewApiKeyRepository(db))
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+		name := flags.String("name", "", "name of the client the key is for")
+		scopeList := flags.String("scopes", "", "comma separated scopes of the key")
+		clientId := flags.Int64("client", 0, "existing client to issue the key to, to rotate its keys")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() > 0 {
+			return usage
+		}
+		scopes, err := models.ParseApiKeyScopes(*scopeList)
+		if err != nil {
+			return err
+		}
+		created, err := apiKeyService.CreateApiKey(ctx, models.CreateApiKeyArgs{Name: *name, Scopes: scopes, ClientId: *clientId})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created API key %d for %s (client %d) with scopes %s\n", created.Id, created.Name, created.ClientId, joinScopes(created.Scopes))
+		fmt.Printf("key: %s\n", created.Key)
+		fmt.Println("store the key now, it cannot be shown again")
+		return nil
+	case "list":
+		if len(args) > 1 {
+			return usage
+		}
+		keys, err := apiKeyService.ListApiKeys(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCLIENT\tNAME\tPREFIX\tSCOPES\tCREATED AT\tREVOKED AT")
+		for _, k := range keys {
+			revokedAt := "-"
+			if k.RevokedAt != nil {
+				revokedAt = k.RevokedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n", k.Id, k.ClientId, k.Name, k.Prefix, joinScopes(k.Scopes), k.CreatedAt.Format("2006-01-02 15:04:05"), revokedAt)
+		}
+		return w.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return usage
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || id <= 0 {
+			return usage
+		}
+		if err := apiKeyService.RevokeApiKey(ctx, id); err != nil {
+			return err
+		}
+		fmt.Printf("revoked API key %d\n", id)
+		return nil
+	case "secret":
+		if len(args) != 2 {
+			return usage
+		}
+		id, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || id <= 0 {
+			return usage
+		}
+		signingService := service.NewRequestSigningService(repository.NewApiKeyRepository(db), repository.NewRequestNonceRepository(db), cfg.SignatureMaxSkew)
+		secret, err := signingService.CreateSigningSecret(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("signing secret of API key %d: %s\n", id, secret)
+		fmt.Println("store the secret now, it cannot be shown again; any previous secret of the key stops working")
+		return nil
+	default:
+		return usage
+	}
+}
+
+// joinScopes lists scopes comma separated, the way `apikey create` takes them
+func joinScopes(scopes []models.ApiKeyScope) string {
+	strs := make([]string, len(scopes))
+	for i, scope := range scopes {
+		strs[i] = string(scope)
+	}
+	return strings.Join(strs, ",")
+}
@@ -0,0 +1,340 @@
+package migrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// files holds the versioned SQL migrations shipped with the binary
+//
+//go:embed sql/*.sql
+var files embed.FS
+
+// advisoryLockKey is the pg_advisory_lock key held while migrating, so that replicas booting together do not race
+const advisoryLockKey int64 = 7_300_510_021
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration was modified")
+	ErrUnknownVersion   = errors.New("database has a migration version unknown to this build")
+	ErrOutOfOrder       = errors.New("pending migration is older than the latest applied one")
+	ErrNoDownMigration  = errors.New("migration has no down script")
+)
+
+// fileNamePattern matches file names like 0004_add_status.up.sql
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration represents one versioned schema change
+type Migration struct {
+	Version  int64
+	Name     string
+	UpSQL    string
+	DownSQL  string
+	Checksum string
+}
+
+// Status represents whether a migration has been applied to the database
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and rolls back migrations. Migrations are forward-only: versions only ever increase,
+// applied files must not change and a new file must have a higher version than every applied one
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the migrations embedded in the binary
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := Load(files)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads the *.up.sql and *.down.sql files of fsys (searched recursively) ordered by version.
+// The checksum of a migration covers its up script only
+func Load(fsys fs.FS) ([]Migration, error) {
+	byVersion := map[int64]*Migration{}
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		match := fileNamePattern.FindStringSubmatch(path.Base(p))
+		if match == nil {
+			return fmt.Errorf("invalid migration file name %q", p)
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return fmt.Errorf("invalid migration version in %q", p)
+		}
+
+		content, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return fmt.Errorf("migration version %d is used by both %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.UpSQL = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.DownSQL = string(content)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpSQL == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration, each one in its own transaction
+func (m *Migrator) Up(ctx context.Context) error {
+	fName := "Migrator.Up"
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		pending, err := m.pending(applied)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range pending {
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			log.Printf("[%s] applied migration %04d_%s", fName, mig.Version, mig.Name)
+		}
+
+		log.Printf("[%s] database migrations completed successfully, %d applied", fName, len(pending))
+		return nil
+	})
+}
+
+// Down rolls back the latest steps applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	fName := "Migrator.Down"
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if _, err := m.pending(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if mig.DownSQL == "" {
+				return fmt.Errorf("%w: %04d_%s", ErrNoDownMigration, mig.Version, mig.Name)
+			}
+			if err := m.rollback(ctx, conn, mig); err != nil {
+				return err
+			}
+			log.Printf("[%s] rolled back migration %04d_%s", fName, mig.Version, mig.Name)
+			steps--
+		}
+		return nil
+	})
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if _, err := m.pending(applied); err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			s := Status{Version: mig.Version, Name: mig.Name}
+			if rec, ok := applied[mig.Version]; ok {
+				s.Applied = true
+				s.AppliedAt = rec.appliedAt
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// appliedMigration is a row of schema_migrations
+type appliedMigration struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, advisoryLockKey); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, advisoryLockKey); err != nil {
+			log.Printf("[Migrator.withLock] failed to release migration lock: %v", err)
+		}
+	}()
+
+	createTable := `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum VARCHAR(64) NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+	`
+	if _, err := conn.ExecContext(ctx, createTable); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// applied reads schema_migrations
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, `SELECT version, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int64]appliedMigration{}
+	for rows.Next() {
+		var version int64
+		var rec appliedMigration
+		if err := rows.Scan(&version, &rec.checksum, &rec.appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = rec
+	}
+	return applied, rows.Err()
+}
+
+// pending verifies the applied migrations against the known ones and returns those still to apply
+func (m *Migrator) pending(applied map[int64]appliedMigration) ([]Migration, error) {
+	known := map[int64]bool{}
+	var latestApplied int64
+	for _, mig := range m.migrations {
+		known[mig.Version] = true
+		rec, ok := applied[mig.Version]
+		if !ok {
+			continue
+		}
+		if rec.checksum != mig.Checksum {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, mig.Version, mig.Name)
+		}
+		latestApplied = mig.Version
+	}
+
+	for version := range applied {
+		if !known[version] {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, version)
+		}
+	}
+
+	var pending []Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if mig.Version < latestApplied {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrOutOfOrder, mig.Version, mig.Name)
+		}
+		pending = append(pending, mig)
+	}
+	return pending, nil
+}
+
+// apply runs the up script of a migration and records it
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.UpSQL); err != nil {
+		return fmt.Errorf("migration %04d_%s failed: %w", mig.Version, mig.Name, err)
+	}
+
+	query := `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, query, mig.Version, mig.Name, mig.Checksum); err != nil {
+		return fmt.Errorf("failed to record migration %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+
+	return tx.Commit()
+}
+
+// rollback runs the down script of a migration and forgets it
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, mig.DownSQL); err != nil {
+		return fmt.Errorf("rollback of %04d_%s failed: %w", mig.Version, mig.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version); err != nil {
+		return fmt.Errorf("failed to forget migration %04d_%s: %w", mig.Version, mig.Name, err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,192 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name          string
+		fsys          fstest.MapFS
+		expectErr     bool
+		expectedOrder []int64
+	}{
+		{
+			name: "ordered by version",
+			fsys: fstest.MapFS{
+				"sql/0010_third.up.sql":   {Data: []byte("SELECT 3;")},
+				"sql/0002_second.up.sql":  {Data: []byte("SELECT 2;")},
+				"sql/0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+				"sql/0001_first.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			expectedOrder: []int64{1, 2, 10},
+		},
+		{
+			name:      "invalid file name",
+			fsys:      fstest.MapFS{"sql/first.sql": {Data: []byte("SELECT 1;")}},
+			expectErr: true,
+		},
+		{
+			name: "same version with two names",
+			fsys: fstest.MapFS{
+				"sql/0001_first.up.sql": {Data: []byte("SELECT 1;")},
+				"sql/0001_other.up.sql": {Data: []byte("SELECT 1;")},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "down script without up script",
+			fsys:      fstest.MapFS{"sql/0001_first.down.sql": {Data: []byte("SELECT 1;")}},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.fsys)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			versions := make([]int64, 0, len(migrations))
+			for _, m := range migrations {
+				versions = append(versions, m.Version)
+				assert.Len(t, m.Checksum, 64)
+			}
+			assert.Equal(t, tt.expectedOrder, versions)
+			assert.Equal(t, "SELECT -1;", migrations[0].DownSQL)
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Load(files)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrations)
+
+	for i, m := range migrations {
+		assert.Equal(t, int64(i+1), m.Version, "embedded migrations must not leave gaps")
+		assert.NotEmpty(t, m.DownSQL, "embedded migration %04d_%s needs a down script", m.Version, m.Name)
+	}
+}
+
+func TestMigrator_Up(t *testing.T) {
+	first := Migration{Version: 1, Name: "first", UpSQL: "CREATE TABLE a (id INT);", Checksum: "sum-1"}
+	second := Migration{Version: 2, Name: "second", UpSQL: "CREATE TABLE b (id INT);", Checksum: "sum-2"}
+	appliedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		expectErr error
+	}{
+		{
+			name: "applies pending migrations",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WithArgs(advisoryLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(1, "sum-1", appliedAt))
+				mock.ExpectBegin()
+				mock.ExpectExec("CREATE TABLE b").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO schema_migrations").
+					WithArgs(int64(2), "second", "sum-2").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+				mock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(advisoryLockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "applied migration was edited",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(1, "changed", appliedAt))
+				mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectErr: ErrChecksumMismatch,
+		},
+		{
+			name: "database is ahead of this build",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).
+						AddRow(1, "sum-1", appliedAt).
+						AddRow(2, "sum-2", appliedAt).
+						AddRow(3, "sum-3", appliedAt))
+				mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectErr: ErrUnknownVersion,
+		},
+		{
+			name: "new migration older than applied ones",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(2, "sum-2", appliedAt))
+				mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectErr: ErrOutOfOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(mock)
+
+			migrator := &Migrator{db: db, migrations: []Migration{first, second}}
+			err = migrator.Up(context.Background())
+
+			if tt.expectErr != nil {
+				assert.True(t, errors.Is(err, tt.expectErr), "expected %v, got %v", tt.expectErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMigrator_Down(t *testing.T) {
+	appliedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	all := []Migration{
+		{Version: 1, Name: "first", UpSQL: "CREATE TABLE a (id INT);", DownSQL: "DROP TABLE a;", Checksum: "sum-1"},
+		{Version: 2, Name: "second", UpSQL: "CREATE TABLE b (id INT);", DownSQL: "DROP TABLE b;", Checksum: "sum-2"},
+	}
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("SELECT pg_advisory_lock").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, checksum, applied_at FROM schema_migrations").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).
+			AddRow(1, "sum-1", appliedAt).
+			AddRow(2, "sum-2", appliedAt))
+	mock.ExpectBegin()
+	mock.ExpectExec("DROP TABLE b").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM schema_migrations").WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	migrator := &Migrator{db: db, migrations: all}
+	assert.NoError(t, migrator.Down(context.Background(), 1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS transactions (
+	id SERIAL PRIMARY KEY,
+	account_id BIGINT NOT NULL,
+	amount DECIMAL(20,5) NOT NULL,
+	currency_code VARCHAR(3) NOT NULL,
+	available_balance DECIMAL(20,5) NOT NULL,
+	is_credit BOOL,
+	reference VARCHAR(50) NOT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	deleted_at TIMESTAMP DEFAULT NULL,
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account ON transactions(account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_created ON transactions(account_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_transactions_reference ON transactions(reference);
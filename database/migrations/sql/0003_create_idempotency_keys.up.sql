@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+	idempotency_key VARCHAR(255) NOT NULL,
+	endpoint VARCHAR(50) NOT NULL,
+	request_hash VARCHAR(64) NOT NULL,
+	status_code INT DEFAULT NULL,
+	response_body BYTEA DEFAULT NULL,
+	created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (idempotency_key, endpoint)
+);
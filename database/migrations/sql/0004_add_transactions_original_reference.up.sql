@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS original_reference VARCHAR(50) DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_original_reference ON transactions(original_reference);
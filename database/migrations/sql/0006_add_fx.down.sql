@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS fx_quotes;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS destination_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS source_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS fx_rate;
@@ -0,0 +1,18 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(20,10) DEFAULT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS source_amount DECIMAL(20,5) DEFAULT NULL;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS destination_amount DECIMAL(20,5) DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS fx_quotes (
+	quote_id VARCHAR(50) PRIMARY KEY,
+	source_account_id BIGINT NOT NULL,
+	destination_account_id BIGINT NOT NULL,
+	source_currency_code CHAR(3) NOT NULL,
+	destination_currency_code CHAR(3) NOT NULL,
+	source_amount DECIMAL(20,5) NOT NULL,
+	destination_amount DECIMAL(20,5) NOT NULL,
+	rate NUMERIC(20,10) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP DEFAULT NULL,
+	reference VARCHAR(50) DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
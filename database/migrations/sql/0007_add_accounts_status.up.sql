@@ -0,0 +1,4 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'active'
+	CONSTRAINT accounts_status_check CHECK (status IN ('active', 'frozen', 'closed'));
+
+UPDATE accounts SET status = 'closed' WHERE deleted_at IS NOT NULL;
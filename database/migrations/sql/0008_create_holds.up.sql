@@ -0,0 +1,20 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS held_amount DECIMAL(20,5) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS holds (
+	hold_id VARCHAR(50) PRIMARY KEY,
+	source_account_id BIGINT NOT NULL,
+	destination_account_id BIGINT NOT NULL,
+	amount DECIMAL(20,5) NOT NULL,
+	currency_code CHAR(3) NOT NULL,
+	status VARCHAR(10) NOT NULL DEFAULT 'pending'
+		CONSTRAINT holds_status_check CHECK (status IN ('pending', 'captured', 'voided', 'expired')),
+	captured_amount DECIMAL(20,5) DEFAULT NULL,
+	reference VARCHAR(50) DEFAULT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (source_account_id) REFERENCES accounts(account_id),
+	FOREIGN KEY (destination_account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_holds_pending_expires ON holds(expires_at) WHERE status = 'pending';
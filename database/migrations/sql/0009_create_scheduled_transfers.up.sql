@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS scheduled_transfers (
+	scheduled_transfer_id VARCHAR(50) PRIMARY KEY,
+	source_account_id BIGINT NOT NULL,
+	destination_account_id BIGINT NOT NULL,
+	amount DECIMAL(20,5) NOT NULL,
+	schedule VARCHAR(100) DEFAULT NULL,
+	status VARCHAR(10) NOT NULL DEFAULT 'active'
+		CONSTRAINT scheduled_transfers_status_check CHECK (status IN ('active', 'paused', 'cancelled', 'completed')),
+	next_run_at TIMESTAMP DEFAULT NULL,
+	last_run_at TIMESTAMP DEFAULT NULL,
+	last_run_status VARCHAR(10) DEFAULT NULL,
+	last_run_reference VARCHAR(50) DEFAULT NULL,
+	last_run_error TEXT DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (source_account_id) REFERENCES accounts(account_id),
+	FOREIGN KEY (destination_account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_due ON scheduled_transfers(next_run_at) WHERE status = 'active';
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfers_source ON scheduled_transfers(source_account_id);
+
+CREATE TABLE IF NOT EXISTS scheduled_transfer_runs (
+	id BIGSERIAL PRIMARY KEY,
+	scheduled_transfer_id VARCHAR(50) NOT NULL,
+	scheduled_for TIMESTAMP NOT NULL,
+	executed_at TIMESTAMP NOT NULL,
+	status VARCHAR(10) NOT NULL
+		CONSTRAINT scheduled_transfer_runs_status_check CHECK (status IN ('succeeded', 'failed')),
+	reference VARCHAR(50) DEFAULT NULL,
+	error_message TEXT DEFAULT NULL,
+	FOREIGN KEY (scheduled_transfer_id) REFERENCES scheduled_transfers(scheduled_transfer_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_transfer_runs_transfer ON scheduled_transfer_runs(scheduled_transfer_id, scheduled_for);
@@ -0,0 +1,2 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS overdraft_limit DECIMAL(20,5) NOT NULL DEFAULT 0
+	CONSTRAINT accounts_overdraft_limit_check CHECK (overdraft_limit >= 0);
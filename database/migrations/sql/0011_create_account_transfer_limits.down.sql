@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_account_debits;
+DROP TABLE IF EXISTS account_transfer_limits;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS account_transfer_limits (
+	account_id BIGINT PRIMARY KEY,
+	max_amount DECIMAL(20,5) DEFAULT NULL
+		CONSTRAINT account_transfer_limits_max_amount_check CHECK (max_amount > 0),
+	max_daily_amount DECIMAL(20,5) DEFAULT NULL
+		CONSTRAINT account_transfer_limits_max_daily_amount_check CHECK (max_daily_amount > 0),
+	max_hourly_count INT DEFAULT NULL
+		CONSTRAINT account_transfer_limits_max_hourly_count_check CHECK (max_hourly_count > 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_account_debits ON transactions(account_id, created_at) WHERE is_credit = false AND original_reference IS NULL;
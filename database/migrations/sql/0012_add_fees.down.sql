@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS account_fee_schedules;
+
+DROP INDEX IF EXISTS idx_transactions_account_debits;
+CREATE INDEX IF NOT EXISTS idx_transactions_account_debits ON transactions(account_id, created_at) WHERE is_credit = false AND original_reference IS NULL;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS is_fee;
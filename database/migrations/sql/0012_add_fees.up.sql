@@ -0,0 +1,12 @@
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS is_fee BOOLEAN NOT NULL DEFAULT false;
+
+DROP INDEX IF EXISTS idx_transactions_account_debits;
+CREATE INDEX IF NOT EXISTS idx_transactions_account_debits ON transactions(account_id, created_at) WHERE is_credit = false AND original_reference IS NULL AND is_fee = false;
+
+CREATE TABLE IF NOT EXISTS account_fee_schedules (
+	account_id BIGINT PRIMARY KEY,
+	schedule JSONB NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
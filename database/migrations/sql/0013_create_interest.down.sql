@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS interest_accruals;
+DROP TABLE IF EXISTS account_interest_rates;
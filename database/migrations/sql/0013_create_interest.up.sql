@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS account_interest_rates (
+	account_id BIGINT PRIMARY KEY,
+	annual_rate DECIMAL(9,5) NOT NULL
+		CONSTRAINT account_interest_rates_annual_rate_check CHECK (annual_rate >= 0),
+	day_count VARCHAR(10) NOT NULL
+		CONSTRAINT account_interest_rates_day_count_check CHECK (day_count IN ('ACT/365', 'ACT/360', 'ACT/ACT')),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE TABLE IF NOT EXISTS interest_accruals (
+	id BIGSERIAL PRIMARY KEY,
+	account_id BIGINT NOT NULL,
+	accrual_date DATE NOT NULL,
+	balance DECIMAL(20,5) NOT NULL,
+	annual_rate DECIMAL(9,5) NOT NULL,
+	day_count VARCHAR(10) NOT NULL,
+	amount DECIMAL(20,5) NOT NULL,
+	currency_code CHAR(3) NOT NULL,
+	reference VARCHAR(50) DEFAULT NULL,
+	posted_at TIMESTAMP DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT interest_accruals_account_date_key UNIQUE (account_id, accrual_date),
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_interest_accruals_unposted ON interest_accruals(account_id, accrual_date) WHERE posted_at IS NULL;
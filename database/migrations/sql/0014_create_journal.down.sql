@@ -0,0 +1,7 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS journal_entry_id;
+
+DROP TABLE IF EXISTS postings;
+DROP TABLE IF EXISTS journal_entries;
+
+DROP FUNCTION IF EXISTS reject_posting_change();
+DROP FUNCTION IF EXISTS check_journal_entry_balanced();
@@ -0,0 +1,93 @@
+CREATE TABLE IF NOT EXISTS journal_entries (
+	id BIGSERIAL PRIMARY KEY,
+	reference VARCHAR(50) NOT NULL,
+	original_reference VARCHAR(50) DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference ON journal_entries(reference);
+
+-- A posting moves a signed amount in or out of an account: credits are positive and debits negative.
+-- Postings of kind fx carry the converted amounts of a cross-currency entry through the FX position, which is not
+-- an account, so that every currency of the entry sums to zero
+CREATE TABLE IF NOT EXISTS postings (
+	id BIGSERIAL PRIMARY KEY,
+	journal_entry_id BIGINT NOT NULL,
+	account_id BIGINT DEFAULT NULL,
+	kind VARCHAR(10) NOT NULL
+		CONSTRAINT postings_kind_check CHECK (kind IN ('transfer', 'fee', 'fx')),
+	amount DECIMAL(20,5) NOT NULL
+		CONSTRAINT postings_amount_check CHECK (amount <> 0),
+	currency_code CHAR(3) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	CONSTRAINT postings_account_check CHECK ((kind = 'fx') = (account_id IS NULL)),
+	FOREIGN KEY (journal_entry_id) REFERENCES journal_entries(id),
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_postings_journal_entry ON postings(journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_account ON postings(account_id);
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS journal_entry_id BIGINT DEFAULT NULL REFERENCES journal_entries(id);
+
+-- Every transfer written so far becomes one entry per reference, with the FX position taking up the difference
+-- of each currency that does not balance on its own
+INSERT INTO journal_entries (reference, original_reference, created_at)
+SELECT reference, MAX(original_reference), MIN(created_at)
+FROM transactions
+GROUP BY reference;
+
+UPDATE transactions t SET journal_entry_id = j.id FROM journal_entries j WHERE j.reference = t.reference;
+
+INSERT INTO postings (journal_entry_id, account_id, kind, amount, currency_code, created_at)
+SELECT journal_entry_id, account_id, CASE WHEN is_fee THEN 'fee' ELSE 'transfer' END,
+	CASE WHEN is_credit THEN amount ELSE -amount END, currency_code, created_at
+FROM transactions
+WHERE amount <> 0;
+
+INSERT INTO postings (journal_entry_id, account_id, kind, amount, currency_code, created_at)
+SELECT journal_entry_id, NULL, 'fx', -SUM(CASE WHEN is_credit THEN amount ELSE -amount END), currency_code, MIN(created_at)
+FROM transactions
+GROUP BY journal_entry_id, currency_code
+HAVING SUM(CASE WHEN is_credit THEN amount ELSE -amount END) <> 0;
+
+ALTER TABLE transactions ALTER COLUMN journal_entry_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_journal_entry ON transactions(journal_entry_id);
+
+-- An entry must have postings and they must sum to zero in every currency. The check is deferred to the commit so
+-- that the postings of an entry can be written one after another
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+DECLARE
+	entry_id BIGINT;
+BEGIN
+	IF TG_TABLE_NAME = 'journal_entries' THEN
+		entry_id := NEW.id;
+	ELSE
+		entry_id := NEW.journal_entry_id;
+	END IF;
+
+	IF NOT EXISTS (SELECT 1 FROM postings WHERE journal_entry_id = entry_id) THEN
+		RAISE EXCEPTION 'journal entry % has no postings', entry_id USING ERRCODE = 'check_violation';
+	END IF;
+	IF EXISTS (SELECT 1 FROM postings WHERE journal_entry_id = entry_id GROUP BY currency_code HAVING SUM(amount) <> 0) THEN
+		RAISE EXCEPTION 'postings of journal entry % do not sum to zero per currency', entry_id USING ERRCODE = 'check_violation';
+	END IF;
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER journal_entries_balanced AFTER INSERT ON journal_entries
+	DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+CREATE CONSTRAINT TRIGGER postings_balanced AFTER INSERT ON postings
+	DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Postings are never changed once written: a mistake is corrected by a new entry
+CREATE OR REPLACE FUNCTION reject_posting_change() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'postings cannot be updated or deleted' USING ERRCODE = 'check_violation';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER postings_append_only BEFORE UPDATE OR DELETE ON postings
+	FOR EACH ROW EXECUTE FUNCTION reject_posting_change();
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reconciliation_flags;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS initial_balance;
@@ -0,0 +1,15 @@
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS initial_balance DECIMAL(20,5) NOT NULL DEFAULT 0;
+
+-- The initial balance was not kept so far: existing accounts get the one their ledger implies, which takes any
+-- drift already there as part of it
+UPDATE accounts a SET initial_balance = a.balance - COALESCE(
+	(SELECT SUM(CASE WHEN t.is_credit THEN t.amount ELSE -t.amount END) FROM transactions t WHERE t.account_id = a.account_id), 0);
+
+CREATE TABLE IF NOT EXISTS reconciliation_flags (
+	account_id BIGINT PRIMARY KEY,
+	balance DECIMAL(20,5) NOT NULL,
+	ledger_balance DECIMAL(20,5) NOT NULL,
+	last_recorded_balance DECIMAL(20,5) DEFAULT NULL,
+	flagged_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
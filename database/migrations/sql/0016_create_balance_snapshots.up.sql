@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+	account_id BIGINT NOT NULL,
+	snapshot_date DATE NOT NULL,
+	balance DECIMAL(20,5) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	PRIMARY KEY (account_id, snapshot_date),
+	FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
@@ -0,0 +1,10 @@
+-- Only the SHA-256 of a key is stored: the key itself is shown once, when it is created
+CREATE TABLE IF NOT EXISTS api_keys (
+	id BIGSERIAL PRIMARY KEY,
+	name VARCHAR(100) NOT NULL,
+	key_prefix VARCHAR(16) NOT NULL,
+	key_hash CHAR(64) UNIQUE NOT NULL,
+	scopes TEXT[] NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	revoked_at TIMESTAMP DEFAULT NULL
+);
@@ -0,0 +1 @@
+ALTER TABLE accounts DROP COLUMN IF EXISTS owner_key_id;
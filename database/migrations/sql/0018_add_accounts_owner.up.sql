@@ -0,0 +1,3 @@
+-- The API key of the client owning the account. Accounts opened before API keys existed have none, and only
+-- admin clients can use them until an owner is set
+ALTER TABLE accounts ADD COLUMN IF NOT EXISTS owner_key_id BIGINT DEFAULT NULL REFERENCES api_keys(id);
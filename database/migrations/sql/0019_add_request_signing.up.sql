@@ -0,0 +1,13 @@
+-- The secret that a client signs its requests with. Unlike the key itself it is stored as is, since every
+-- signature has to be computed again from it
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS signing_secret VARCHAR(64) DEFAULT NULL;
+
+-- Nonces of signed requests, kept until the timestamp of their request is too old to be accepted anyway
+CREATE TABLE IF NOT EXISTS request_nonces (
+	api_key_id BIGINT NOT NULL REFERENCES api_keys(id),
+	nonce VARCHAR(64) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (api_key_id, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_nonces_expires_at ON request_nonces (expires_at);
@@ -0,0 +1,7 @@
+-- Accounts of a client go back to the first key of the client, which owned them before
+UPDATE accounts a SET owner_client_id = (SELECT MIN(k.id) FROM api_keys k WHERE k.client_id = a.owner_client_id)
+	WHERE owner_client_id IS NOT NULL;
+ALTER TABLE accounts RENAME COLUMN owner_client_id TO owner_key_id;
+ALTER TABLE accounts ADD CONSTRAINT accounts_owner_key_id_fkey FOREIGN KEY (owner_key_id) REFERENCES api_keys(id);
+DROP INDEX IF EXISTS idx_api_keys_client_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS client_id;
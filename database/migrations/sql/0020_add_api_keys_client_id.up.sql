@@ -0,0 +1,9 @@
+-- Keys issued to the same client share its client id, so that a key can be rotated or revoked without the client
+-- losing its accounts. Every existing key is its own client, numbered like the key
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS client_id BIGINT;
+UPDATE api_keys SET client_id = id WHERE client_id IS NULL;
+ALTER TABLE api_keys ALTER COLUMN client_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_api_keys_client_id ON api_keys (client_id);
+-- Accounts belong to a client rather than to one of its keys. The key ids stored so far are the client ids above
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS accounts_owner_key_id_fkey;
+ALTER TABLE accounts RENAME COLUMN owner_key_id TO owner_client_id;
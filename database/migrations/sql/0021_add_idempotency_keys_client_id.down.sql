@@ -0,0 +1,7 @@
+-- Of the keys several clients used, only the oldest one is kept
+DELETE FROM idempotency_keys k USING idempotency_keys o
+	WHERE k.idempotency_key = o.idempotency_key AND k.endpoint = o.endpoint
+	AND (k.created_at, k.client_id) > (o.created_at, o.client_id);
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS client_id;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key, endpoint);
@@ -0,0 +1,5 @@
+-- Idempotency keys are chosen by the clients, so two clients may pick the same one: each client gets its own keys.
+-- Keys stored before are left to client 0, as the client that sent them is not known
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS client_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (client_id, idempotency_key, endpoint);
@@ -0,0 +1,13 @@
+-- The global transfer limits, one row per currency since an amount only means something in a currency. They apply
+-- to the accounts in that currency unless an account has its own limit for the same cap
+CREATE TABLE IF NOT EXISTS currency_transfer_limits (
+	currency_code CHAR(3) PRIMARY KEY,
+	max_amount DECIMAL(20,5) DEFAULT NULL
+		CONSTRAINT currency_transfer_limits_max_amount_check CHECK (max_amount > 0),
+	max_daily_amount DECIMAL(20,5) DEFAULT NULL
+		CONSTRAINT currency_transfer_limits_max_daily_amount_check CHECK (max_daily_amount > 0),
+	max_hourly_count INT DEFAULT NULL
+		CONSTRAINT currency_transfer_limits_max_hourly_count_check CHECK (max_hourly_count > 0),
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,8 @@
+-- The global fee schedules, one per currency since the flat parts, bounds and tiers only mean something in a
+-- currency. They price the transfers of the accounts in that currency that have no schedule of their own
+CREATE TABLE IF NOT EXISTS currency_fee_schedules (
+	currency_code CHAR(3) PRIMARY KEY,
+	schedule JSONB NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
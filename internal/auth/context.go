@@ -0,0 +1,21 @@
+// Package auth carries the identity of the API client a request is authenticated as through its context
+package auth
+
+import (
+	"context"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+)
+
+type apiKeyContextKey struct{}
+
+// WithApiKey returns a copy of ctx carrying the API key the request was authenticated with
+func WithApiKey(ctx context.Context, key models.ApiKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, key)
+}
+
+// ApiKeyFromContext returns the API key the request of ctx was authenticated with, if any
+func ApiKeyFromContext(ctx context.Context) (models.ApiKey, bool) {
+	key, ok := ctx.Value(apiKeyContextKey{}).(models.ApiKey)
+	return key, ok
+}
@@ -19,9 +19,84 @@ var (
 	ErrInvalidBalanceFmt           = errors.New("invalid initial balance format")
 	ErrInvalidJsonFormat           = errors.New("invalid JSON format")
 	ErrInvalidAmount               = errors.New("invalid amount")
+	ErrInvalidAmountScale          = errors.New("amount cannot have more than 5 decimal places")
+	ErrAmountOutOfRange            = errors.New("amount must be between -92233720368547.75807 and 92233720368547.75807")
 	ErrAccountCreation             = errors.New("account creation failed")
 	ErrFailedToGetBalance          = errors.New("failed to get balance")
 	ErrInsufficientBalance         = errors.New("insufficient funds in sender account")
+	ErrInvalidCursor               = errors.New("invalid cursor")
+	ErrInvalidDateFormat           = errors.New("invalid date format, expected RFC3339")
+	ErrInvalidDateRange            = errors.New("from date must be before to date")
+	ErrInvalidDirection            = errors.New("direction must be either credit or debit")
+	ErrInvalidAmountRange          = errors.New("min amount must not be greater than max amount")
+	ErrInvalidLimit                = errors.New("limit must be between 1 and 100")
+	ErrInvalidReference            = errors.New("invalid transaction reference")
+	ErrTransactionNotFound         = errors.New("transaction not found")
+	ErrInvalidIdempotencyKey       = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyReused        = errors.New("idempotency key was already used with a different request")
+	ErrCannotReverseReversal       = errors.New("a reversal cannot be reversed")
+	ErrTransactionAlreadyReversed  = errors.New("transaction has already been fully reversed")
+	ErrReversalAmountExceeded      = errors.New("reversal amount exceeds the amount left to reverse")
+	ErrReversalInsufficientBalance = errors.New("insufficient funds in receiver account to reverse the transfer")
+	ErrUnsupportedCurrency         = errors.New("unsupported currency code")
+	ErrInvalidCurrencyPrecision    = errors.New("amount has more decimal places than the currency allows")
+	ErrCurrencyMismatch            = errors.New("sender and receiver accounts have different currencies")
+	ErrInvalidExchangeRate         = errors.New("exchange rate must be a positive decimal with at most 10 decimal places")
+	ErrExchangeRateUnavailable     = errors.New("no exchange rate available for the currency pair")
+	ErrInvalidQuoteId              = errors.New("invalid quote id")
+	ErrQuoteNotFound               = errors.New("quote not found")
+	ErrQuoteExpired                = errors.New("quote has expired")
+	ErrQuoteAlreadyUsed            = errors.New("quote has already been used")
+	ErrQuoteMismatch               = errors.New("transfer does not match the quote")
+	ErrSourceAccountFrozen         = errors.New("sender account is frozen")
+	ErrSourceAccountClosed         = errors.New("sender account is closed")
+	ErrDestinationAccountClosed    = errors.New("receiver account is closed")
+	ErrAccountClosed               = errors.New("account is closed")
+	ErrInvalidStatusTransition     = errors.New("account status does not allow this change")
+	ErrAccountBalanceNotZero       = errors.New("account balance must be zero to close it, or a sweep account must be given")
+	ErrInvalidSweepAccount         = errors.New("sweep account must be another open account in the same currency")
+	ErrAccountHasPendingHolds      = errors.New("account has pending holds")
+	ErrInvalidHoldId               = errors.New("invalid hold id")
+	ErrHoldNotFound                = errors.New("hold not found")
+	ErrHoldNotPending              = errors.New("hold has already been captured, voided or expired")
+	ErrHoldExpired                 = errors.New("hold has expired")
+	ErrCaptureAmountExceeded       = errors.New("capture amount exceeds the held amount")
+	ErrInvalidBatchMode            = errors.New("batch mode must be either atomic or best_effort")
+	ErrEmptyBatch                  = errors.New("batch must contain at least one transfer")
+	ErrBatchTooLarge               = errors.New("batch must contain at most 1000 transfers")
+	ErrInvalidScheduledTransferId  = errors.New("invalid scheduled transfer id")
+	ErrScheduledTransferNotFound   = errors.New("scheduled transfer not found")
+	ErrScheduleTimingRequired      = errors.New("exactly one of run_at and schedule must be given")
+	ErrInvalidRunAt                = errors.New("run_at must be a future RFC3339 time")
+	ErrInvalidSchedule             = errors.New("schedule must be a 5-field cron expression")
+	ErrInvalidScheduleTransition   = errors.New("scheduled transfer status does not allow this change")
+	ErrInvalidOverdraftLimit       = errors.New("overdraft limit must be a non-negative amount")
+	ErrInvalidTransferLimits       = errors.New("transfer limits must be positive")
+	ErrTransferAmountLimitExceeded = errors.New("amount exceeds the per-transfer limit of the source account")
+	ErrDailyAmountLimitExceeded    = errors.New("transfer would exceed the daily amount limit of the source account")
+	ErrHourlyCountLimitExceeded    = errors.New("source account has reached its hourly transfer limit")
+	ErrInvalidFeeSchedule          = errors.New("fee schedule amounts must be non-negative, tiers ascending and min not above max")
+	ErrFeeAccountUnavailable       = errors.New("fee revenue account cannot receive fees")
+	ErrInvalidInterestRate         = errors.New("interest rate must be a non-negative percent with a day count of ACT/365, ACT/360 or ACT/ACT")
+	ErrUnbalancedJournalEntry      = errors.New("journal entry postings do not balance")
+	ErrFundingAccountUnavailable   = errors.New("funding account cannot pay opening balances in this currency")
+	ErrInvalidStatementPeriod      = errors.New("from and to must be dates (YYYY-MM-DD) with from not after to")
+	ErrInvalidStatementFormat      = errors.New("format must be json, csv or pdf")
+	ErrInvalidAsOf                 = errors.New("as_of must be an RFC3339 time that is not in the future")
+	ErrAccountNotCreatedAsOf       = errors.New("account did not exist at as_of")
+	ErrUnauthenticated             = errors.New("missing or invalid API key")
+	ErrInsufficientScope           = errors.New("API key is not allowed to make this request")
+	ErrInvalidApiKeyName           = errors.New("API key name must be 1 to 100 characters")
+	ErrInvalidApiKeyScope          = errors.New("API key scopes must be one or more of accounts:read, accounts:write, transactions:read, transactions:write and admin")
+	ErrApiKeyNotFound              = errors.New("API key not found")
+	ErrAccountForbidden            = errors.New("account belongs to another client")
+	ErrInvalidOwnerClientId        = errors.New("invalid owner client id")
+	ErrClientNotFound              = errors.New("client not found")
+	ErrMalformedSignature          = errors.New("signed requests need X-Api-Key-Id, X-Timestamp, X-Nonce and X-Signature headers")
+	ErrInvalidSignature            = errors.New("invalid request signature")
+	ErrStaleRequest                = errors.New("request timestamp is too far from the current time")
+	ErrReplayedRequest             = errors.New("request nonce has already been used")
+	ErrRequestTooLarge             = errors.New("signed request body must be at most 1 MiB")
 	ErrTransactionFailed           = errors.New("transaction failed due to internal server error. Please contact support team")
 	ErrInternal                    = errors.New("internal server error")
 )
@@ -40,9 +115,93 @@ var HTTPStatusMap = map[error]int{
 	ErrDestinationAccountNotFound:  http.StatusNotFound,
 	ErrInvalidJsonFormat:           http.StatusBadRequest,
 	ErrInvalidAmount:               http.StatusBadRequest,
+	ErrInvalidAmountScale:          http.StatusBadRequest,
+	ErrAmountOutOfRange:            http.StatusBadRequest,
 	ErrInsufficientBalance:         http.StatusBadRequest,
+	ErrInvalidCursor:               http.StatusBadRequest,
+	ErrInvalidDateFormat:           http.StatusBadRequest,
+	ErrInvalidDateRange:            http.StatusBadRequest,
+	ErrInvalidDirection:            http.StatusBadRequest,
+	ErrInvalidAmountRange:          http.StatusBadRequest,
+	ErrInvalidLimit:                http.StatusBadRequest,
+	ErrInvalidReference:            http.StatusBadRequest,
+	ErrTransactionNotFound:         http.StatusNotFound,
+	ErrInvalidIdempotencyKey:       http.StatusBadRequest,
+	ErrIdempotencyKeyReused:        http.StatusUnprocessableEntity,
+	ErrCannotReverseReversal:       http.StatusBadRequest,
+	ErrTransactionAlreadyReversed:  http.StatusConflict,
+	ErrReversalAmountExceeded:      http.StatusBadRequest,
+	ErrReversalInsufficientBalance: http.StatusBadRequest,
+	ErrUnsupportedCurrency:         http.StatusBadRequest,
+	ErrInvalidCurrencyPrecision:    http.StatusBadRequest,
+	ErrCurrencyMismatch:            http.StatusUnprocessableEntity,
+	ErrInvalidExchangeRate:         http.StatusBadRequest,
+	ErrExchangeRateUnavailable:     http.StatusUnprocessableEntity,
+	ErrInvalidQuoteId:              http.StatusBadRequest,
+	ErrQuoteNotFound:               http.StatusNotFound,
+	ErrQuoteExpired:                http.StatusGone,
+	ErrQuoteAlreadyUsed:            http.StatusConflict,
+	ErrQuoteMismatch:               http.StatusBadRequest,
+	ErrSourceAccountFrozen:         http.StatusUnprocessableEntity,
+	ErrSourceAccountClosed:         http.StatusUnprocessableEntity,
+	ErrDestinationAccountClosed:    http.StatusUnprocessableEntity,
+	ErrAccountClosed:               http.StatusConflict,
+	ErrInvalidStatusTransition:     http.StatusConflict,
+	ErrAccountBalanceNotZero:       http.StatusConflict,
+	ErrInvalidSweepAccount:         http.StatusBadRequest,
+	ErrAccountHasPendingHolds:      http.StatusConflict,
+	ErrInvalidHoldId:               http.StatusBadRequest,
+	ErrHoldNotFound:                http.StatusNotFound,
+	ErrHoldNotPending:              http.StatusConflict,
+	ErrHoldExpired:                 http.StatusGone,
+	ErrCaptureAmountExceeded:       http.StatusBadRequest,
+	ErrInvalidBatchMode:            http.StatusBadRequest,
+	ErrEmptyBatch:                  http.StatusBadRequest,
+	ErrBatchTooLarge:               http.StatusRequestEntityTooLarge,
+	ErrInvalidScheduledTransferId:  http.StatusBadRequest,
+	ErrScheduledTransferNotFound:   http.StatusNotFound,
+	ErrScheduleTimingRequired:      http.StatusBadRequest,
+	ErrInvalidRunAt:                http.StatusBadRequest,
+	ErrInvalidSchedule:             http.StatusBadRequest,
+	ErrInvalidScheduleTransition:   http.StatusConflict,
+	ErrInvalidOverdraftLimit:       http.StatusBadRequest,
+	ErrInvalidTransferLimits:       http.StatusBadRequest,
+	ErrTransferAmountLimitExceeded: http.StatusUnprocessableEntity,
+	ErrDailyAmountLimitExceeded:    http.StatusUnprocessableEntity,
+	ErrHourlyCountLimitExceeded:    http.StatusTooManyRequests,
+	ErrInvalidFeeSchedule:          http.StatusBadRequest,
+	ErrFeeAccountUnavailable:       http.StatusServiceUnavailable,
+	ErrInvalidInterestRate:         http.StatusBadRequest,
+	ErrUnbalancedJournalEntry:      http.StatusInternalServerError,
+	ErrFundingAccountUnavailable:   http.StatusServiceUnavailable,
+	ErrInvalidStatementPeriod:      http.StatusBadRequest,
+	ErrInvalidStatementFormat:      http.StatusBadRequest,
+	ErrInvalidAsOf:                 http.StatusBadRequest,
+	ErrAccountNotCreatedAsOf:       http.StatusNotFound,
+	ErrUnauthenticated:             http.StatusUnauthorized,
+	ErrInsufficientScope:           http.StatusForbidden,
+	ErrInvalidApiKeyName:           http.StatusBadRequest,
+	ErrInvalidApiKeyScope:          http.StatusBadRequest,
+	ErrApiKeyNotFound:              http.StatusNotFound,
+	ErrAccountForbidden:            http.StatusForbidden,
+	ErrInvalidOwnerClientId:        http.StatusBadRequest,
+	ErrClientNotFound:              http.StatusNotFound,
+	ErrMalformedSignature:          http.StatusUnauthorized,
+	ErrInvalidSignature:            http.StatusUnauthorized,
+	ErrStaleRequest:                http.StatusUnauthorized,
+	ErrReplayedRequest:             http.StatusUnauthorized,
+	ErrRequestTooLarge:             http.StatusRequestEntityTooLarge,
 	ErrAccountCreation:             http.StatusInternalServerError,
 	ErrFailedToGetBalance:          http.StatusInternalServerError,
 	ErrTransactionFailed:           http.StatusInternalServerError,
 	ErrInternal:                    http.StatusInternalServerError,
 }
+
+// HTTPStatus returns the HTTP status of err together with the error to show to the client.
+// Errors without a status are reported as ErrInternal
+func HTTPStatus(err error) (int, error) {
+	if statusCode, ok := HTTPStatusMap[err]; ok {
+		return statusCode, err
+	}
+	return http.StatusInternalServerError, ErrInternal
+}
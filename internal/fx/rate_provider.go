@@ -0,0 +1,89 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+)
+
+// IRateProvider supplies the exchange rate between two currencies
+type IRateProvider interface {
+	// GetRate returns how many units of quote one unit of base buys.
+	// It returns ErrExchangeRateUnavailable when the pair is not known
+	GetRate(ctx context.Context, base, quote string) (models.ExchangeRate, error)
+}
+
+// StaticRateProvider serves a fixed table of rates. The inverse of a known pair is derived when only one
+// direction is configured
+type StaticRateProvider struct {
+	rates map[string]models.ExchangeRate
+}
+
+// NewStaticRateProvider creates a provider from rates keyed by "BASE/QUOTE", eg "USD/EUR"
+func NewStaticRateProvider(rates map[string]models.ExchangeRate) (*StaticRateProvider, error) {
+	normalized := make(map[string]models.ExchangeRate, len(rates))
+	for pair, rate := range rates {
+		base, quote, err := splitPair(pair)
+		if err != nil {
+			return nil, err
+		}
+		if !rate.IsValid() {
+			return nil, fmt.Errorf("fx: invalid rate for %s", pair)
+		}
+		normalized[base+"/"+quote] = rate
+	}
+	return &StaticRateProvider{rates: normalized}, nil
+}
+
+// rateFile is the layout of a rates file, eg {"rates": {"USD/EUR": "0.92"}}
+type rateFile struct {
+	Rates map[string]models.ExchangeRate `json:"rates"`
+}
+
+// LoadRateFile creates a static provider from a JSON rates file
+func LoadRateFile(path string) (*StaticRateProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("fx: failed to read rates file: %w", err)
+	}
+
+	var file rateFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("fx: failed to parse rates file %s: %w", path, err)
+	}
+	return NewStaticRateProvider(file.Rates)
+}
+
+// GetRate returns the configured rate of the pair, or the inverse of the opposite pair
+func (p *StaticRateProvider) GetRate(ctx context.Context, base, quote string) (models.ExchangeRate, error) {
+	base, quote = strings.ToUpper(base), strings.ToUpper(quote)
+	if base == quote {
+		return models.OneExchangeRate, nil
+	}
+	if rate, ok := p.rates[base+"/"+quote]; ok {
+		return rate, nil
+	}
+	if rate, ok := p.rates[quote+"/"+base]; ok {
+		return rate.Inverse(), nil
+	}
+	return models.ExchangeRate{}, appErr.ErrExchangeRateUnavailable
+}
+
+// splitPair validates a "BASE/QUOTE" key against the currency registry
+func splitPair(pair string) (string, string, error) {
+	base, quote, ok := strings.Cut(strings.ToUpper(strings.TrimSpace(pair)), "/")
+	if !ok {
+		return "", "", fmt.Errorf("fx: invalid currency pair %q, expected BASE/QUOTE", pair)
+	}
+	for _, code := range []string{base, quote} {
+		if _, err := models.LookupCurrency(code); err != nil {
+			return "", "", fmt.Errorf("fx: invalid currency pair %q: %w", pair, err)
+		}
+	}
+	return base, quote, nil
+}
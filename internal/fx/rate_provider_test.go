@@ -0,0 +1,71 @@
+package fx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticRateProvider_GetRate(t *testing.T) {
+	provider, err := NewStaticRateProvider(map[string]models.ExchangeRate{
+		"usd/eur": models.MustParseExchangeRate("0.8"),
+	})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		base        string
+		quote       string
+		expected    models.ExchangeRate
+		expectedErr error
+	}{
+		{name: "configured pair", base: "USD", quote: "EUR", expected: models.MustParseExchangeRate("0.8")},
+		{name: "inverse pair", base: "EUR", quote: "USD", expected: models.MustParseExchangeRate("1.25")},
+		{name: "same currency", base: "JPY", quote: "jpy", expected: models.OneExchangeRate},
+		{name: "unknown pair", base: "USD", quote: "JPY", expectedErr: appErr.ErrExchangeRateUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.GetRate(context.Background(), tt.base, tt.quote)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, rate)
+		})
+	}
+}
+
+func TestNewStaticRateProvider_InvalidPair(t *testing.T) {
+	rate := models.MustParseExchangeRate("1.1")
+	for _, pair := range []string{"USDEUR", "USD/XYZ", "/EUR"} {
+		_, err := NewStaticRateProvider(map[string]models.ExchangeRate{pair: rate})
+		assert.Error(t, err, pair)
+	}
+
+	_, err := NewStaticRateProvider(map[string]models.ExchangeRate{"USD/EUR": {}})
+	assert.Error(t, err)
+}
+
+func TestLoadRateFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "rates.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"rates": {"USD/EUR": "0.92", "GBP/USD": 1.27}}`), 0o600))
+	provider, err := LoadRateFile(path)
+	assert.NoError(t, err)
+	rate, err := provider.GetRate(context.Background(), "GBP", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, models.MustParseExchangeRate("1.27"), rate)
+
+	badPath := filepath.Join(dir, "bad.json")
+	assert.NoError(t, os.WriteFile(badPath, []byte(`{"rates": {"USD/EUR": "-1"}}`), 0o600))
+	_, err = LoadRateFile(badPath)
+	assert.Error(t, err)
+
+	_, err = LoadRateFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
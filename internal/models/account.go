@@ -5,25 +5,152 @@ import (
 	"time"
 )
 
+// AvailableBalance is the part of the balance that is not reserved by pending holds. It is negative while the
+// account is overdrawn
+func (a Account) AvailableBalance() Money {
+	return a.Balance.Sub(a.HeldAmount)
+}
+
+// AvailableToSpend is how much can still leave the account: the available balance plus the overdraft limit
+func (a Account) AvailableToSpend() Money {
+	return a.AvailableBalance().Add(a.OverdraftLimit)
+}
+
+// IsOwnedBy reports whether the account belongs to the client clientId
+func (a Account) IsOwnedBy(clientId int64) bool {
+	return a.OwnerClientId.Valid && a.OwnerClientId.Int64 == clientId
+}
+
+// AccountStatus is the lifecycle state of an account
+type AccountStatus string
+
+const (
+	// AccountStatusActive accounts can send and receive money
+	AccountStatusActive AccountStatus = "active"
+	// AccountStatusFrozen accounts can receive money but cannot be debited until they are unfrozen
+	AccountStatusFrozen AccountStatus = "frozen"
+	// AccountStatusClosed accounts can neither send nor receive money. Closing is final
+	AccountStatusClosed AccountStatus = "closed"
+)
+
+// AllowsDebit reports whether money can leave an account in this status
+func (s AccountStatus) AllowsDebit() bool {
+	return s != AccountStatusFrozen && s != AccountStatusClosed
+}
+
+// AllowsCredit reports whether money can be paid into an account in this status
+func (s AccountStatus) AllowsCredit() bool {
+	return s != AccountStatusClosed
+}
+
 // Account represents a financial account of the user. It maintains the current balance
 type Account struct {
-	Id        int64        `db:"id"`
-	AccountId int64        `db:"account_id"`
-	Balance   float64      `db:"balance"`
-	CreatedAt time.Time    `db:"created_at"`
-	UpdatedAt time.Time    `db:"updated_at"`
-	DeletedAt sql.NullTime `db:"deleted_at"`
+	Id             int64         `db:"id"`
+	AccountId      int64         `db:"account_id"`
+	Balance        Money         `db:"balance"`
+	CurrencyCode   string        `db:"currency_code"`
+	Status         AccountStatus `db:"status"`
+	HeldAmount     Money         `db:"held_amount"`
+	OverdraftLimit Money         `db:"overdraft_limit"`
+	OwnerClientId  sql.NullInt64 `db:"owner_client_id"`
+	CreatedAt      time.Time     `db:"created_at"`
+	UpdatedAt      time.Time     `db:"updated_at"`
+	DeletedAt      sql.NullTime  `db:"deleted_at"`
 }
 
 // CreateAccountRequest represents the request body for creating an account
 type CreateAccountRequest struct {
-	AccountId      int64  `json:"account_id,binding:required"`
-	InitialBalance string `json:"initial_balance,binding:required"`
+	AccountId      int64       `json:"account_id,binding:required"`
+	InitialBalance string      `json:"initial_balance,binding:required"`
+	CurrencyCode   string      `json:"currency_code"`
+	Idempotency    Idempotency `json:"-"`
 }
 
-// GetAccountResponse represents the response body for creating an account
+// GetAccountResponse represents the response body for creating an account. Balance is the ledger balance,
+// AvailableBalance what is left of it after pending holds and AvailableToSpend adds the overdraft limit to that
 type GetAccountResponse struct {
-	AccountId int64  `json:"account_id"`
-	Balance   string `json:"balance"`
-	IsDeleted bool   `json:"is_deleted,omitempty"`
+	AccountId        int64         `json:"account_id"`
+	Balance          Money         `json:"balance"`
+	AvailableBalance Money         `json:"available_balance"`
+	HeldAmount       Money         `json:"held_amount"`
+	OverdraftLimit   Money         `json:"overdraft_limit"`
+	AvailableToSpend Money         `json:"available_to_spend"`
+	CurrencyCode     string        `json:"currency_code"`
+	Status           AccountStatus `json:"status"`
+	OwnerClientId    *int64        `json:"owner_client_id,omitempty"`
+	IsDeleted        bool          `json:"is_deleted,omitempty"`
+}
+
+// NewGetAccountResponse builds the API view of an account
+func NewGetAccountResponse(account Account) GetAccountResponse {
+	return GetAccountResponse{
+		AccountId:        account.AccountId,
+		Balance:          account.Balance,
+		AvailableBalance: account.AvailableBalance(),
+		HeldAmount:       account.HeldAmount,
+		OverdraftLimit:   account.OverdraftLimit,
+		AvailableToSpend: account.AvailableToSpend(),
+		CurrencyCode:     account.CurrencyCode,
+		Status:           account.Status,
+		OwnerClientId:    ownerClientId(account),
+		IsDeleted:        account.DeletedAt.Valid,
+	}
+}
+
+// ownerClientId is the client owning account, nil when it has no owner
+func ownerClientId(account Account) *int64 {
+	if !account.OwnerClientId.Valid {
+		return nil
+	}
+	return &account.OwnerClientId.Int64
+}
+
+// BalanceAsOfResponse represents the response body for reading the balance of an account at a past instant
+type BalanceAsOfResponse struct {
+	AccountId    int64     `json:"account_id"`
+	CurrencyCode string    `json:"currency_code"`
+	AsOf         time.Time `json:"as_of"`
+	Balance      Money     `json:"balance"`
+}
+
+// SetOverdraftLimitRequest represents the request body for setting the overdraft limit of an account
+type SetOverdraftLimitRequest struct {
+	OverdraftLimit string `json:"overdraft_limit"`
+}
+
+// SetAccountOwnerRequest represents the request body for handing an account over to another client
+type SetAccountOwnerRequest struct {
+	OwnerClientId int64 `json:"owner_client_id"`
+}
+
+// CloseAccountRequest represents the request body for closing an account. The remaining balance, if any, is
+// moved to SweepAccountId
+type CloseAccountRequest struct {
+	SweepAccountId int64 `json:"sweep_account_id,omitempty"`
+}
+
+// CreateAccountArgs represents the internal service payload for creating an account. When FundingAccountId is set the
+// account is opened empty and its balance is paid from the funding account under Reference
+type CreateAccountArgs struct {
+	Account          Account
+	Idempotency      Idempotency
+	FundingAccountId int64
+	Reference        string
+}
+
+// CloseAccountArgs represents the internal service payload for closing an account
+type CloseAccountArgs struct {
+	AccountId      int64
+	SweepAccountId int64
+	Reference      string
+}
+
+// CloseAccountResponse represents the response body for closing an account. The sweep fields are only set
+// when a remaining balance was moved to the sweep account
+type CloseAccountResponse struct {
+	AccountId      int64         `json:"account_id"`
+	Status         AccountStatus `json:"status"`
+	SweepAccountId int64         `json:"sweep_account_id,omitempty"`
+	SweptAmount    *Money        `json:"swept_amount,omitempty"`
+	Reference      string        `json:"reference,omitempty"`
 }
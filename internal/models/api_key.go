@@ -0,0 +1,87 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// ApiKeyScope is a permission granted to an API key, checked against the scope each route requires
+type ApiKeyScope string
+
+const (
+	ScopeAccountsRead      ApiKeyScope = "accounts:read"
+	ScopeAccountsWrite     ApiKeyScope = "accounts:write"
+	ScopeTransactionsRead  ApiKeyScope = "transactions:read"
+	ScopeTransactionsWrite ApiKeyScope = "transactions:write"
+	ScopeAdmin             ApiKeyScope = "admin"
+)
+
+// ApiKeyScopes lists every scope an API key can be granted
+var ApiKeyScopes = []ApiKeyScope{ScopeAccountsRead, ScopeAccountsWrite, ScopeTransactionsRead, ScopeTransactionsWrite, ScopeAdmin}
+
+// IsValid reports whether s is a known scope
+func (s ApiKeyScope) IsValid() bool {
+	for _, scope := range ApiKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseApiKeyScopes parses a comma separated list of scopes, eg "accounts:read,transactions:write", dropping duplicates
+func ParseApiKeyScopes(s string) ([]ApiKeyScope, error) {
+	var scopes []ApiKeyScope
+	for _, field := range strings.Split(s, ",") {
+		scope := ApiKeyScope(strings.TrimSpace(field))
+		if !scope.IsValid() {
+			return nil, appErr.ErrInvalidApiKeyScope
+		}
+		if !containsScope(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// ApiKey identifies an API client. The key itself is never stored, only its hash, and Prefix is kept so that
+// the owner can tell their keys apart. The keys of a client share its ClientId, which owns its accounts
+type ApiKey struct {
+	Id        int64         `json:"id"`
+	ClientId  int64         `json:"client_id"`
+	Name      string        `json:"name"`
+	Prefix    string        `json:"prefix"`
+	Scopes    []ApiKeyScope `json:"scopes"`
+	CreatedAt time.Time     `json:"created_at"`
+	RevokedAt *time.Time    `json:"revoked_at,omitempty"`
+}
+
+// HasScope reports whether the key was granted scope
+func (k ApiKey) HasScope(scope ApiKeyScope) bool {
+	return containsScope(k.Scopes, scope)
+}
+
+// CreateApiKeyArgs represents the internal service payload for creating an API key
+type CreateApiKeyArgs struct {
+	Name   string
+	Scopes []ApiKeyScope
+	// ClientId is the existing client the key is issued to, to rotate its keys. Zero issues the key to a new client
+	ClientId int64
+}
+
+// CreatedApiKey is a new API key together with the key to hand to the client, which cannot be read back later
+type CreatedApiKey struct {
+	ApiKey
+	Key string `json:"key"`
+}
+
+func containsScope(scopes []ApiKeyScope, scope ApiKeyScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
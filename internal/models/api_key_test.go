@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApiKeyScopes(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []ApiKeyScope
+		expectedErr error
+	}{
+		{name: "single scope", input: "accounts:read", expected: []ApiKeyScope{ScopeAccountsRead}},
+		{name: "several scopes", input: "accounts:read, transactions:write,admin", expected: []ApiKeyScope{ScopeAccountsRead, ScopeTransactionsWrite, ScopeAdmin}},
+		{name: "duplicates dropped", input: "accounts:read,accounts:read", expected: []ApiKeyScope{ScopeAccountsRead}},
+		{name: "empty", input: "", expectedErr: appErr.ErrInvalidApiKeyScope},
+		{name: "unknown scope", input: "accounts:read,accounts:delete", expectedErr: appErr.ErrInvalidApiKeyScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, err := ParseApiKeyScopes(tt.input)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, scopes)
+		})
+	}
+}
+
+func TestApiKey_HasScope(t *testing.T) {
+	key := ApiKey{Scopes: []ApiKeyScope{ScopeAccountsRead, ScopeTransactionsWrite}}
+	assert.True(t, key.HasScope(ScopeAccountsRead))
+	assert.True(t, key.HasScope(ScopeTransactionsWrite))
+	assert.False(t, key.HasScope(ScopeAccountsWrite))
+	assert.False(t, key.HasScope(ScopeAdmin))
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// BalanceSnapshotDay reports the end-of-day balance snapshots taken for one UTC day, one per account
+type BalanceSnapshotDay struct {
+	Day      time.Time
+	Accounts int64
+}
@@ -0,0 +1,64 @@
+package models
+
+// BatchMode tells how a batch of transfers is executed
+type BatchMode string
+
+const (
+	// BatchModeAtomic runs every transfer in one DB transaction. Either all of them are posted or none is
+	BatchModeAtomic BatchMode = "atomic"
+	// BatchModeBestEffort runs each transfer on its own and reports the outcome of each
+	BatchModeBestEffort BatchMode = "best_effort"
+)
+
+// IsValid reports whether m is a known batch mode
+func (m BatchMode) IsValid() bool {
+	return m == BatchModeAtomic || m == BatchModeBestEffort
+}
+
+// CreateBatchTransactionRequest represents the request body for creating a batch of transfers
+type CreateBatchTransactionRequest struct {
+	Mode      BatchMode                  `json:"mode,binding:required"`
+	Transfers []CreateTransactionRequest `json:"transfers,binding:required"`
+}
+
+// CreateBatchTransactionArgs represents the internal service payload for creating a batch of transfers.
+// AmountErrors holds, by index, why the amount of a transfer could not be parsed; it is nil when every amount was
+// parsed. A best-effort batch reports them as the results of those transfers
+type CreateBatchTransactionArgs struct {
+	Mode         BatchMode
+	Transfers    []CreateTransactionArgs
+	AmountErrors []error
+}
+
+// AmountError returns the error found parsing the amount of the transfer at index, nil when there was none
+func (a CreateBatchTransactionArgs) AmountError(index int) error {
+	if index >= len(a.AmountErrors) {
+		return nil
+	}
+	return a.AmountErrors[index]
+}
+
+// BatchTransactionResult is the outcome of one transfer of a batch. Index is its position in the request
+type BatchTransactionResult struct {
+	Index        int                        `json:"index"`
+	StatusCode   int                        `json:"status_code"`
+	ErrorMessage string                     `json:"error_message,omitempty"`
+	Transaction  *CreateTransactionResponse `json:"transaction,omitempty"`
+}
+
+// CreateBatchTransactionResponse represents the response body for a batch of transfers, with one result per
+// transfer in request order. FailedIndex is only set when an atomic batch is rolled back
+type CreateBatchTransactionResponse struct {
+	Mode        BatchMode                `json:"mode"`
+	Succeeded   int                      `json:"succeeded"`
+	Failed      int                      `json:"failed"`
+	Results     []BatchTransactionResult `json:"results"`
+	FailedIndex *int                     `json:"-"`
+}
+
+// BatchErrorResponse represents the error response of a batch that was rejected as a whole because of one of
+// its transfers
+type BatchErrorResponse struct {
+	ErrorMessage string `json:"error_message"`
+	FailedIndex  int    `json:"failed_index"`
+}
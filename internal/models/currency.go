@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// DefaultCurrencyCode is the currency of accounts created without one, and of every account created before
+// accounts had a currency
+const DefaultCurrencyCode = "USD"
+
+// Currency is an ISO-4217 currency known to the ledger
+type Currency struct {
+	Code       string
+	MinorUnits int
+}
+
+// currencies is the registry of supported currencies and their minor-unit precision.
+// No currency may have more minor units than MoneyScale
+var currencies = map[string]Currency{
+	"AUD": {Code: "AUD", MinorUnits: 2},
+	"BHD": {Code: "BHD", MinorUnits: 3},
+	"CAD": {Code: "CAD", MinorUnits: 2},
+	"CHF": {Code: "CHF", MinorUnits: 2},
+	"CNY": {Code: "CNY", MinorUnits: 2},
+	"EUR": {Code: "EUR", MinorUnits: 2},
+	"GBP": {Code: "GBP", MinorUnits: 2},
+	"HKD": {Code: "HKD", MinorUnits: 2},
+	"INR": {Code: "INR", MinorUnits: 2},
+	"JPY": {Code: "JPY", MinorUnits: 0},
+	"KRW": {Code: "KRW", MinorUnits: 0},
+	"KWD": {Code: "KWD", MinorUnits: 3},
+	"MXN": {Code: "MXN", MinorUnits: 2},
+	"NZD": {Code: "NZD", MinorUnits: 2},
+	"SEK": {Code: "SEK", MinorUnits: 2},
+	"SGD": {Code: "SGD", MinorUnits: 2},
+	"USD": {Code: "USD", MinorUnits: 2},
+	"ZAR": {Code: "ZAR", MinorUnits: 2},
+}
+
+// LookupCurrency returns the registered currency for an ISO-4217 code. The code is case-insensitive
+func LookupCurrency(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, appErr.ErrUnsupportedCurrency
+	}
+	return currency, nil
+}
+
+// Allows reports whether the amount can be expressed in the currency's minor units, eg 10.5 is a valid USD
+// amount but not a valid JPY one
+func (c Currency) Allows(m Money) bool {
+	return m.units%c.step() == 0
+}
+
+// step is the number of 10^-5 units in one minor unit of the currency
+func (c Currency) step() int64 {
+	step := moneyFactor
+	for i := 0; i < c.MinorUnits; i++ {
+		step /= 10
+	}
+	return step
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	currency, err := LookupCurrency(" eur ")
+	assert.NoError(t, err)
+	assert.Equal(t, Currency{Code: "EUR", MinorUnits: 2}, currency)
+
+	_, err = LookupCurrency("XYZ")
+	assert.Equal(t, appErr.ErrUnsupportedCurrency, err)
+
+	for code, c := range currencies {
+		assert.Equal(t, code, c.Code)
+		assert.LessOrEqual(t, c.MinorUnits, MoneyScale, code)
+	}
+}
+
+func TestCurrency_Allows(t *testing.T) {
+	tests := []struct {
+		code     string
+		amount   string
+		expected bool
+	}{
+		{code: "USD", amount: "10.25", expected: true},
+		{code: "USD", amount: "10.255", expected: false},
+		{code: "JPY", amount: "1000", expected: true},
+		{code: "JPY", amount: "1000.5", expected: false},
+		{code: "KWD", amount: "1.125", expected: true},
+		{code: "KWD", amount: "1.1255", expected: false},
+		{code: "USD", amount: "-0.01", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code+" "+tt.amount, func(t *testing.T) {
+			currency, err := LookupCurrency(tt.code)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, currency.Allows(MustParseMoney(tt.amount)))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// ExchangeRateScale is the number of fractional digits kept for an exchange rate. It matches the NUMERIC(20,10) columns
+const ExchangeRateScale = 10
+
+// rateFactor is 10^ExchangeRateScale
+const rateFactor int64 = 10000000000
+
+// ExchangeRate is the exact number of quote currency units bought by one base currency unit,
+// stored as a whole number of 10^-10 units. ExchangeRate{} is not a usable rate
+type ExchangeRate struct {
+	units int64
+}
+
+// OneExchangeRate is the rate between a currency and itself
+var OneExchangeRate = ExchangeRate{units: rateFactor}
+
+// ParseExchangeRate parses a plain positive decimal like "0.92" or "151.3475"
+func ParseExchangeRate(s string) (ExchangeRate, error) {
+	units, err := parseDecimal(s, ExchangeRateScale, rateFactor)
+	if err != nil || units <= 0 {
+		return ExchangeRate{}, appErr.ErrInvalidExchangeRate
+	}
+	return ExchangeRate{units: units}, nil
+}
+
+// MustParseExchangeRate is like ParseExchangeRate but panics on invalid input. Meant for constants and tests
+func MustParseExchangeRate(s string) ExchangeRate {
+	r, err := ParseExchangeRate(s)
+	if err != nil {
+		panic(fmt.Sprintf("models.MustParseExchangeRate(%q): %v", s, err))
+	}
+	return r
+}
+
+// IsValid reports whether the rate is positive
+func (r ExchangeRate) IsValid() bool {
+	return r.units > 0
+}
+
+// Inverse returns 1/r rounded half away from zero to ExchangeRateScale digits
+func (r ExchangeRate) Inverse() ExchangeRate {
+	num := new(big.Int).Mul(big.NewInt(rateFactor), big.NewInt(rateFactor))
+	return ExchangeRate{units: divRound(num, big.NewInt(r.units)).Int64()}
+}
+
+// Convert returns amount × r expressed in the given currency, rounded half away from zero to its minor units
+func (r ExchangeRate) Convert(amount Money, to Currency) Money {
+	step := to.step()
+	num := new(big.Int).Mul(big.NewInt(amount.units), big.NewInt(r.units))
+	den := new(big.Int).Mul(big.NewInt(rateFactor), big.NewInt(step))
+	steps := divRound(num, den)
+	return Money{units: steps.Int64() * step}
+}
+
+// divRound returns num/den rounded half away from zero. den must be positive
+func divRound(num, den *big.Int) *big.Int {
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(rem), big.NewInt(2)).Cmp(den) >= 0 {
+		if num.Sign() < 0 {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
+
+// String formats the rate with exactly ExchangeRateScale fractional digits, eg "0.9200000000"
+func (r ExchangeRate) String() string {
+	return fmt.Sprintf("%d.%010d", r.units/rateFactor, r.units%rateFactor)
+}
+
+// MarshalJSON encodes the rate as a JSON string so that no precision is lost in clients
+func (r ExchangeRate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON accepts both a JSON string ("0.92") and a JSON number (0.92)
+func (r *ExchangeRate) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return appErr.ErrInvalidExchangeRate
+		}
+	}
+
+	parsed, err := ParseExchangeRate(s)
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns
+func (r *ExchangeRate) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return fmt.Errorf("models.ExchangeRate: cannot scan %T", src)
+	}
+
+	parsed, err := ParseExchangeRate(s)
+	if err != nil {
+		return fmt.Errorf("models.ExchangeRate: cannot scan %q: %w", s, err)
+	}
+	*r = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The rate is sent as text so Postgres keeps it exact
+func (r ExchangeRate) Value() (driver.Value, error) {
+	return r.String(), nil
+}
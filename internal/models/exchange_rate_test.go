@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseExchangeRate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		err      error
+	}{
+		{input: "0.92", expected: "0.9200000000"},
+		{input: "151.3475", expected: "151.3475000000"},
+		{input: "1", expected: "1.0000000000"},
+		{input: "0.0000000001", expected: "0.0000000001"},
+		{input: "0.00000000001", err: appErr.ErrInvalidExchangeRate},
+		{input: "0", err: appErr.ErrInvalidExchangeRate},
+		{input: "-1.2", err: appErr.ErrInvalidExchangeRate},
+		{input: "abc", err: appErr.ErrInvalidExchangeRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rate, err := ParseExchangeRate(tt.input)
+			assert.Equal(t, tt.err, err)
+			if tt.err == nil {
+				assert.Equal(t, tt.expected, rate.String())
+			}
+		})
+	}
+}
+
+func TestExchangeRate_Inverse(t *testing.T) {
+	assert.Equal(t, OneExchangeRate, OneExchangeRate.Inverse())
+	assert.Equal(t, "0.5000000000", MustParseExchangeRate("2").Inverse().String())
+	assert.Equal(t, "0.3333333333", MustParseExchangeRate("3").Inverse().String())
+	assert.Equal(t, "0.6666666667", MustParseExchangeRate("1.5").Inverse().String())
+}
+
+func TestExchangeRate_Convert(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     string
+		amount   string
+		currency string
+		expected string
+	}{
+		{name: "exact", rate: "0.92", amount: "100", currency: "EUR", expected: "92"},
+		{name: "rounds down", rate: "1.0001", amount: "10", currency: "USD", expected: "10"},
+		{name: "rounds up", rate: "1.0825", amount: "99.99", currency: "USD", expected: "108.24"},
+		{name: "rounds half away from zero", rate: "0.5", amount: "0.01", currency: "EUR", expected: "0.01"},
+		{name: "zero minor units", rate: "151.255", amount: "10.01", currency: "JPY", expected: "1514"},
+		{name: "three minor units", rate: "0.3075", amount: "10.01", currency: "KWD", expected: "3.078"},
+		{name: "negative rounds away from zero", rate: "0.5", amount: "-0.01", currency: "EUR", expected: "-0.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency, err := LookupCurrency(tt.currency)
+			assert.NoError(t, err)
+			converted := MustParseExchangeRate(tt.rate).Convert(MustParseMoney(tt.amount), currency)
+			assert.Equal(t, MustParseMoney(tt.expected), converted)
+		})
+	}
+}
+
+func TestExchangeRate_JSON(t *testing.T) {
+	data, err := json.Marshal(MustParseExchangeRate("0.92"))
+	assert.NoError(t, err)
+	assert.Equal(t, `"0.9200000000"`, string(data))
+
+	var rates map[string]ExchangeRate
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":"0.92","b":1.5}`), &rates))
+	assert.Equal(t, MustParseExchangeRate("0.92"), rates["a"])
+	assert.Equal(t, MustParseExchangeRate("1.5"), rates["b"])
+
+	var rate ExchangeRate
+	assert.Equal(t, appErr.ErrInvalidExchangeRate, json.Unmarshal([]byte(`"-1"`), &rate))
+}
+
+func TestExchangeRate_Scan(t *testing.T) {
+	var rate ExchangeRate
+	assert.NoError(t, rate.Scan([]byte("151.2550000000")))
+	assert.Equal(t, MustParseExchangeRate("151.255"), rate)
+
+	assert.NoError(t, rate.Scan("0.92"))
+	assert.Equal(t, MustParseExchangeRate("0.92"), rate)
+
+	assert.Error(t, rate.Scan(int64(1)))
+	assert.Error(t, rate.Scan("0"))
+
+	value, err := rate.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.9200000000", value)
+}
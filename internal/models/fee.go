@@ -0,0 +1,154 @@
+package models
+
+import (
+	"math/big"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// FeeSchedule prices the transfers leaving an account. Amounts are in the currency of the transfer and Percentage
+// is a percent of the amount, eg "1.5" is 1.5%. When Tiers is set the first tier whose UpTo covers the amount
+// replaces Flat and Percentage, the last tier covering everything above. Min and Max clamp the fee when set
+type FeeSchedule struct {
+	Flat       Money     `json:"flat"`
+	Percentage Money     `json:"percentage"`
+	Tiers      []FeeTier `json:"tiers,omitempty"`
+	Min        *Money    `json:"min,omitempty"`
+	Max        *Money    `json:"max,omitempty"`
+}
+
+// FeeTier is the price of the transfers up to and including UpTo. A nil UpTo covers every amount
+type FeeTier struct {
+	UpTo       *Money `json:"up_to"`
+	Flat       Money  `json:"flat"`
+	Percentage Money  `json:"percentage"`
+}
+
+// FeeBreakdown is how the fee of a transfer was priced. Amount is Flat plus PercentageAmount, clamped to the
+// schedule's Min and Max
+type FeeBreakdown struct {
+	Flat             Money `json:"flat"`
+	Percentage       Money `json:"percentage"`
+	PercentageAmount Money `json:"percentage_amount"`
+	Amount           Money `json:"amount"`
+}
+
+// Validate returns ErrInvalidFeeSchedule unless every amount is non-negative, the tiers are in ascending UpTo
+// order with only the last one open-ended, Flat and Percentage are unset when tiers are used and Min is not above Max
+func (s FeeSchedule) Validate() error {
+	if s.Flat.IsNegative() || s.Percentage.IsNegative() {
+		return appErr.ErrInvalidFeeSchedule
+	}
+	if len(s.Tiers) > 0 && (!s.Flat.IsZero() || !s.Percentage.IsZero()) {
+		return appErr.ErrInvalidFeeSchedule
+	}
+	for i, tier := range s.Tiers {
+		if tier.Flat.IsNegative() || tier.Percentage.IsNegative() {
+			return appErr.ErrInvalidFeeSchedule
+		}
+		if tier.UpTo == nil {
+			if i != len(s.Tiers)-1 {
+				return appErr.ErrInvalidFeeSchedule
+			}
+			continue
+		}
+		if !tier.UpTo.IsPositive() || (i > 0 && !tier.UpTo.GreaterThan(*s.Tiers[i-1].UpTo)) {
+			return appErr.ErrInvalidFeeSchedule
+		}
+	}
+	if (s.Min != nil && s.Min.IsNegative()) || (s.Max != nil && s.Max.IsNegative()) {
+		return appErr.ErrInvalidFeeSchedule
+	}
+	if s.Min != nil && s.Max != nil && s.Min.GreaterThan(*s.Max) {
+		return appErr.ErrInvalidFeeSchedule
+	}
+	return nil
+}
+
+// Fee prices a transfer of amount in the given currency. Every part is rounded half away from zero to the
+// minor units of the currency
+func (s FeeSchedule) Fee(amount Money, currency Currency) FeeBreakdown {
+	flat, percentage := s.Flat, s.Percentage
+	if tier, ok := s.tier(amount); ok {
+		flat, percentage = tier.Flat, tier.Percentage
+	}
+
+	breakdown := FeeBreakdown{
+		Flat:             currency.Round(flat),
+		Percentage:       percentage,
+		PercentageAmount: amount.Percent(percentage, currency),
+	}
+	fee := breakdown.Flat.Add(breakdown.PercentageAmount)
+	if s.Min != nil && fee.LessThan(*s.Min) {
+		fee = *s.Min
+	}
+	if s.Max != nil && fee.GreaterThan(*s.Max) {
+		fee = *s.Max
+	}
+	breakdown.Amount = currency.Round(fee)
+	return breakdown
+}
+
+// tier returns the tier pricing amount, false when the schedule has no tiers
+func (s FeeSchedule) tier(amount Money) (FeeTier, bool) {
+	if len(s.Tiers) == 0 {
+		return FeeTier{}, false
+	}
+	for _, tier := range s.Tiers {
+		if tier.UpTo == nil || !amount.GreaterThan(*tier.UpTo) {
+			return tier, true
+		}
+	}
+	return s.Tiers[len(s.Tiers)-1], true
+}
+
+// Percent returns pct percent of m in the given currency, rounded half away from zero to its minor units
+func (m Money) Percent(pct Money, currency Currency) Money {
+	step := currency.step()
+	num := new(big.Int).Mul(big.NewInt(m.units), big.NewInt(pct.units))
+	den := new(big.Int).Mul(big.NewInt(100*moneyFactor), big.NewInt(step))
+	return Money{units: divRound(num, den).Int64() * step}
+}
+
+// Round returns m rounded half away from zero to the minor units of the currency
+func (c Currency) Round(m Money) Money {
+	step := c.step()
+	return Money{units: divRound(big.NewInt(m.units), big.NewInt(step)).Int64() * step}
+}
+
+// TransferFee is the fee charged on top of a transfer. Breakdown.Amount is debited from the source account in the
+// currency of the transfer and RevenueAmount credited to the revenue account in RevenueCurrencyCode, FxRate being
+// the rate between the two
+type TransferFee struct {
+	Breakdown           FeeBreakdown
+	CurrencyCode        string
+	RevenueAccountId    int64
+	RevenueAmount       Money
+	RevenueCurrencyCode string
+	FxRate              ExchangeRate
+}
+
+// FeeResponse represents the fee of a transfer in API responses. TotalDebited is the amount of the transfer
+// plus the fee
+type FeeResponse struct {
+	FeeBreakdown
+	CurrencyCode     string `json:"currency_code"`
+	RevenueAccountId int64  `json:"revenue_account_id"`
+	TotalDebited     Money  `json:"total_debited"`
+}
+
+// CurrencyFeeScheduleResponse represents the response body of the global fee schedule endpoints. Schedule prices the
+// transfers of every account in CurrencyCode without a schedule of its own, nil when they are free
+type CurrencyFeeScheduleResponse struct {
+	CurrencyCode string       `json:"currency_code"`
+	Schedule     *FeeSchedule `json:"schedule"`
+}
+
+// FeeScheduleResponse represents the response body of the fee schedule endpoints. Schedule is the one in force for
+// the account, nil when its transfers are free, and IsOverride whether it was set for the account in place of the
+// global one
+type FeeScheduleResponse struct {
+	AccountId  int64        `json:"account_id"`
+	Schedule   *FeeSchedule `json:"schedule"`
+	IsOverride bool         `json:"is_override"`
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeSchedule_Fee(t *testing.T) {
+	usd, _ := LookupCurrency("USD")
+	jpy, _ := LookupCurrency("JPY")
+	tiered := FeeSchedule{Tiers: []FeeTier{
+		{UpTo: moneyPtr("1000"), Flat: MustParseMoney("1")},
+		{UpTo: moneyPtr("10000"), Percentage: MustParseMoney("0.5")},
+		{Flat: MustParseMoney("25")},
+	}}
+
+	tests := []struct {
+		name     string
+		schedule FeeSchedule
+		amount   string
+		currency Currency
+		expected FeeBreakdown
+	}{
+		{
+			name:     "flat and percentage",
+			schedule: FeeSchedule{Flat: MustParseMoney("0.25"), Percentage: MustParseMoney("1.5")},
+			amount:   "100",
+			currency: usd,
+			expected: FeeBreakdown{Flat: MustParseMoney("0.25"), Percentage: MustParseMoney("1.5"), PercentageAmount: MustParseMoney("1.5"), Amount: MustParseMoney("1.75")},
+		},
+		{
+			name:     "percentage rounded to the minor units",
+			schedule: FeeSchedule{Percentage: MustParseMoney("1.5")},
+			amount:   "33.33",
+			currency: usd,
+			expected: FeeBreakdown{Percentage: MustParseMoney("1.5"), PercentageAmount: MustParseMoney("0.5"), Amount: MustParseMoney("0.5")},
+		},
+		{
+			name:     "raised to the minimum",
+			schedule: FeeSchedule{Percentage: MustParseMoney("1"), Min: moneyPtr("1")},
+			amount:   "10",
+			currency: usd,
+			expected: FeeBreakdown{Percentage: MustParseMoney("1"), PercentageAmount: MustParseMoney("0.1"), Amount: MustParseMoney("1")},
+		},
+		{
+			name:     "capped to the maximum",
+			schedule: FeeSchedule{Percentage: MustParseMoney("2"), Max: moneyPtr("20")},
+			amount:   "5000",
+			currency: usd,
+			expected: FeeBreakdown{Percentage: MustParseMoney("2"), PercentageAmount: MustParseMoney("100"), Amount: MustParseMoney("20")},
+		},
+		{
+			name:     "first tier covers its upper bound",
+			schedule: tiered,
+			amount:   "1000",
+			currency: usd,
+			expected: FeeBreakdown{Flat: MustParseMoney("1"), Amount: MustParseMoney("1")},
+		},
+		{
+			name:     "second tier",
+			schedule: tiered,
+			amount:   "1000.01",
+			currency: usd,
+			expected: FeeBreakdown{Percentage: MustParseMoney("0.5"), PercentageAmount: MustParseMoney("5"), Amount: MustParseMoney("5")},
+		},
+		{
+			name:     "open-ended tier",
+			schedule: tiered,
+			amount:   "20000",
+			currency: usd,
+			expected: FeeBreakdown{Flat: MustParseMoney("25"), Amount: MustParseMoney("25")},
+		},
+		{
+			name:     "above every bounded tier",
+			schedule: FeeSchedule{Tiers: []FeeTier{{UpTo: moneyPtr("100"), Flat: MustParseMoney("1")}, {UpTo: moneyPtr("500"), Flat: MustParseMoney("2")}}},
+			amount:   "800",
+			currency: usd,
+			expected: FeeBreakdown{Flat: MustParseMoney("2"), Amount: MustParseMoney("2")},
+		},
+		{
+			name:     "currency without minor units",
+			schedule: FeeSchedule{Percentage: MustParseMoney("1.5")},
+			amount:   "1234",
+			currency: jpy,
+			expected: FeeBreakdown{Percentage: MustParseMoney("1.5"), PercentageAmount: MustParseMoney("19"), Amount: MustParseMoney("19")},
+		},
+		{
+			name:     "free",
+			schedule: FeeSchedule{},
+			amount:   "100",
+			currency: usd,
+			expected: FeeBreakdown{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.schedule.Fee(MustParseMoney(tt.amount), tt.currency))
+		})
+	}
+}
+
+func TestFeeSchedule_Validate(t *testing.T) {
+	assert.NoError(t, FeeSchedule{}.Validate())
+	assert.NoError(t, FeeSchedule{Flat: MustParseMoney("0.25"), Percentage: MustParseMoney("1.5"), Min: moneyPtr("1"), Max: moneyPtr("1")}.Validate())
+	assert.NoError(t, FeeSchedule{Tiers: []FeeTier{{UpTo: moneyPtr("100"), Flat: MustParseMoney("1")}, {Percentage: MustParseMoney("1")}}}.Validate())
+
+	invalid := []FeeSchedule{
+		{Flat: MustParseMoney("-1")},
+		{Percentage: MustParseMoney("-0.5")},
+		{Min: moneyPtr("5"), Max: moneyPtr("2")},
+		{Max: moneyPtr("-2")},
+		{Flat: MustParseMoney("1"), Tiers: []FeeTier{{Flat: MustParseMoney("1")}}},
+		{Tiers: []FeeTier{{Flat: MustParseMoney("1")}, {UpTo: moneyPtr("100"), Flat: MustParseMoney("2")}}},
+		{Tiers: []FeeTier{{UpTo: moneyPtr("100")}, {UpTo: moneyPtr("100")}}},
+		{Tiers: []FeeTier{{UpTo: moneyPtr("0")}}},
+		{Tiers: []FeeTier{{Flat: MustParseMoney("-1")}}},
+	}
+	for _, schedule := range invalid {
+		assert.Equal(t, appErr.ErrInvalidFeeSchedule, schedule.Validate())
+	}
+}
+
+func TestFeeSchedule_JSON(t *testing.T) {
+	var schedule FeeSchedule
+	assert.NoError(t, json.Unmarshal([]byte(`{"percentage":1.5,"tiers":[{"up_to":"100","flat":"1"}],"max":"20"}`), &schedule))
+	assert.Equal(t, FeeSchedule{Percentage: MustParseMoney("1.5"), Tiers: []FeeTier{{UpTo: moneyPtr("100"), Flat: MustParseMoney("1")}}, Max: moneyPtr("20")}, schedule)
+
+	body, err := json.Marshal(FeeSchedule{Flat: MustParseMoney("0.25"), Min: moneyPtr("1")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"flat":"0.25000","percentage":"0.00000","min":"1.00000"}`, string(body))
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// FxQuote is a guaranteed exchange rate for one cross-currency transfer. It can be executed once before it expires
+type FxQuote struct {
+	QuoteId                 string         `db:"quote_id"`
+	SourceAccountId         int64          `db:"source_account_id"`
+	DestinationAccountId    int64          `db:"destination_account_id"`
+	SourceCurrencyCode      string         `db:"source_currency_code"`
+	DestinationCurrencyCode string         `db:"destination_currency_code"`
+	SourceAmount            Money          `db:"source_amount"`
+	DestinationAmount       Money          `db:"destination_amount"`
+	Rate                    ExchangeRate   `db:"rate"`
+	ExpiresAt               time.Time      `db:"expires_at"`
+	UsedAt                  sql.NullTime   `db:"used_at"`
+	Reference               sql.NullString `db:"reference"`
+	CreatedAt               time.Time      `db:"created_at"`
+}
+
+// IsExpired reports whether the quote can no longer be executed at the given time
+func (q FxQuote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+// CreateQuoteRequest represents the request body for quoting a cross-currency transfer
+type CreateQuoteRequest struct {
+	SourceAccountId      int64  `json:"source_account_id,binding:required"`
+	DestinationAccountId int64  `json:"destination_account_id,binding:required"`
+	Amount               string `json:"amount,binding:required"`
+}
+
+// CreateQuoteArgs represents the internal service payload for quoting a cross-currency transfer.
+// Amount is expressed in the currency of the source account
+type CreateQuoteArgs struct {
+	SourceAccountId      int64
+	DestinationAccountId int64
+	Amount               Money
+}
+
+// QuoteResponse represents the response body for a quote
+type QuoteResponse struct {
+	QuoteId                 string       `json:"quote_id"`
+	SourceAccountId         int64        `json:"source_account_id"`
+	DestinationAccountId    int64        `json:"destination_account_id"`
+	SourceCurrencyCode      string       `json:"source_currency_code"`
+	DestinationCurrencyCode string       `json:"destination_currency_code"`
+	SourceAmount            Money        `json:"source_amount"`
+	DestinationAmount       Money        `json:"destination_amount"`
+	Rate                    ExchangeRate `json:"rate"`
+	ExpiresAt               time.Time    `json:"expires_at"`
+}
+
+// NewQuoteResponse builds the API view of a quote
+func NewQuoteResponse(q FxQuote) QuoteResponse {
+	return QuoteResponse{
+		QuoteId:                 q.QuoteId,
+		SourceAccountId:         q.SourceAccountId,
+		DestinationAccountId:    q.DestinationAccountId,
+		SourceCurrencyCode:      q.SourceCurrencyCode,
+		DestinationCurrencyCode: q.DestinationCurrencyCode,
+		SourceAmount:            q.SourceAmount,
+		DestinationAmount:       q.DestinationAmount,
+		Rate:                    q.Rate,
+		ExpiresAt:               q.ExpiresAt,
+	}
+}
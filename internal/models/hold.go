@@ -0,0 +1,101 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// HoldStatus is the lifecycle state of a hold
+type HoldStatus string
+
+const (
+	// HoldStatusPending holds reserve funds on the source account until they are captured, voided or expire
+	HoldStatusPending HoldStatus = "pending"
+	// HoldStatusCaptured holds were turned into a transfer
+	HoldStatusCaptured HoldStatus = "captured"
+	// HoldStatusVoided holds were released without moving money
+	HoldStatusVoided HoldStatus = "voided"
+	// HoldStatusExpired holds were released by the sweeper once their TTL ran out
+	HoldStatusExpired HoldStatus = "expired"
+)
+
+// Hold reserves an amount on a source account for a later transfer to a destination account. The reserved amount
+// is not available for other transfers but stays part of the ledger balance until the hold is captured
+type Hold struct {
+	HoldId               string         `db:"hold_id"`
+	SourceAccountId      int64          `db:"source_account_id"`
+	DestinationAccountId int64          `db:"destination_account_id"`
+	Amount               Money          `db:"amount"`
+	CurrencyCode         string         `db:"currency_code"`
+	Status               HoldStatus     `db:"status"`
+	CapturedAmount       *Money         `db:"captured_amount"`
+	Reference            sql.NullString `db:"reference"`
+	ExpiresAt            time.Time      `db:"expires_at"`
+	CreatedAt            time.Time      `db:"created_at"`
+	UpdatedAt            time.Time      `db:"updated_at"`
+}
+
+// IsExpired reports whether the hold can no longer be captured at the given time
+func (h Hold) IsExpired(now time.Time) bool {
+	return !now.Before(h.ExpiresAt)
+}
+
+// CreateHoldRequest represents the request body for reserving funds
+type CreateHoldRequest struct {
+	SourceAccountId      int64  `json:"source_account_id,binding:required"`
+	DestinationAccountId int64  `json:"destination_account_id,binding:required"`
+	Amount               string `json:"amount,binding:required"`
+}
+
+// CreateHoldArgs represents the internal service payload for reserving funds
+type CreateHoldArgs struct {
+	SourceAccountId      int64
+	DestinationAccountId int64
+	Amount               Money
+}
+
+// CaptureHoldRequest represents the request body for capturing a hold. Amount defaults to the whole hold
+type CaptureHoldRequest struct {
+	Amount string `json:"amount,omitempty"`
+}
+
+// CaptureHoldArgs represents the internal service payload for capturing a hold. A nil Amount captures the
+// whole hold; a smaller amount captures part of it and releases the rest
+type CaptureHoldArgs struct {
+	HoldId    string
+	Amount    *Money
+	Reference string
+	// Limits are the caps of the source account, whose velocity caps are checked when the capture is posted
+	Limits TransferLimits
+}
+
+// HoldResponse represents the response body of the hold endpoints. Reference and CapturedAmount are only set
+// once the hold is captured. AvailableBalance is the source account's available balance after the operation
+type HoldResponse struct {
+	HoldId               string     `json:"hold_id"`
+	SourceAccountId      int64      `json:"source_account_id"`
+	DestinationAccountId int64      `json:"destination_account_id"`
+	Amount               Money      `json:"amount"`
+	CurrencyCode         string     `json:"currency_code"`
+	Status               HoldStatus `json:"status"`
+	CapturedAmount       *Money     `json:"captured_amount,omitempty"`
+	Reference            string     `json:"reference,omitempty"`
+	AvailableBalance     Money      `json:"available_balance"`
+	ExpiresAt            time.Time  `json:"expires_at"`
+}
+
+// NewHoldResponse builds the API view of a hold
+func NewHoldResponse(h Hold, availableBalance Money) HoldResponse {
+	return HoldResponse{
+		HoldId:               h.HoldId,
+		SourceAccountId:      h.SourceAccountId,
+		DestinationAccountId: h.DestinationAccountId,
+		Amount:               h.Amount,
+		CurrencyCode:         h.CurrencyCode,
+		Status:               h.Status,
+		CapturedAmount:       h.CapturedAmount,
+		Reference:            h.Reference.String,
+		AvailableBalance:     availableBalance,
+		ExpiresAt:            h.ExpiresAt,
+	}
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+const (
+	// IdempotencyEndpointCreateAccount scopes idempotency keys sent to POST /accounts
+	IdempotencyEndpointCreateAccount = "POST /accounts"
+	// IdempotencyEndpointCreateTransaction scopes idempotency keys sent to POST /transactions
+	IdempotencyEndpointCreateTransaction = "POST /transactions"
+)
+
+// Idempotency carries the Idempotency-Key header of a request and the hash of its body. Keys are scoped to
+// ClientId, the client that sent the request, zero for requests made without an API key
+type Idempotency struct {
+	ClientId    int64
+	Key         string
+	RequestHash string
+}
+
+// IdempotencyRecord represents a stored idempotency key. StatusCode and ResponseBody are empty while the
+// first request holding the key is still in flight
+type IdempotencyRecord struct {
+	Key          string    `db:"idempotency_key"`
+	Endpoint     string    `db:"endpoint"`
+	RequestHash  string    `db:"request_hash"`
+	StatusCode   int       `db:"status_code"`
+	ResponseBody []byte    `db:"response_body"`
+	CreatedAt    time.Time `db:"created_at"`
+}
+
+// IsCompleted reports whether the original request finished and its response can be replayed
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// DayCount is the convention turning an annual rate into a daily one
+type DayCount string
+
+const (
+	// DayCountActual365 divides the annual rate by 365 every year
+	DayCountActual365 DayCount = "ACT/365"
+	// DayCountActual360 divides the annual rate by 360, paying slightly more than the annual rate over a year
+	DayCountActual360 DayCount = "ACT/360"
+	// DayCountActualActual divides the annual rate by the number of days of the year, 365 or 366
+	DayCountActualActual DayCount = "ACT/ACT"
+)
+
+// IsValid reports whether d is a supported convention
+func (d DayCount) IsValid() bool {
+	return d == DayCountActual365 || d == DayCountActual360 || d == DayCountActualActual
+}
+
+// DaysInYear returns the number of days the annual rate is spread over in the year of date
+func (d DayCount) DaysInYear(date time.Time) int64 {
+	switch d {
+	case DayCountActual360:
+		return 360
+	case DayCountActualActual:
+		year := date.Year()
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 366
+		}
+	}
+	return 365
+}
+
+// InterestRate is the interest an account earns. AnnualRate is a percent, eg "2.5" is 2.5% a year
+type InterestRate struct {
+	AnnualRate Money    `json:"annual_rate"`
+	DayCount   DayCount `json:"day_count"`
+}
+
+// IsValid reports whether the rate is a percent from 0 to below 10000 with a supported day count
+func (r InterestRate) IsValid() bool {
+	return !r.AnnualRate.IsNegative() && r.AnnualRate.LessThan(NewMoney(10000)) && r.DayCount.IsValid()
+}
+
+// DailyInterest returns the interest earned on balance over date, rounded half away from zero to MoneyScale digits.
+// Zero and negative balances earn nothing
+func (r InterestRate) DailyInterest(balance Money, date time.Time) Money {
+	if !balance.IsPositive() || !r.AnnualRate.IsPositive() {
+		return Money{}
+	}
+	num := new(big.Int).Mul(big.NewInt(balance.units), big.NewInt(r.AnnualRate.units))
+	den := big.NewInt(100 * moneyFactor * r.DayCount.DaysInYear(date))
+	return Money{units: divRound(num, den).Int64()}
+}
+
+// InterestAccount is an account earning interest, with the first day it has not accrued interest for yet
+type InterestAccount struct {
+	AccountId    int64
+	CurrencyCode string
+	Rate         InterestRate
+	AccrueFrom   time.Time
+}
+
+// InterestAccrual is the interest earned by an account over one UTC day on its end-of-day balance. Reference is
+// the transfer that paid it, set once it is posted
+type InterestAccrual struct {
+	AccountId    int64     `json:"account_id"`
+	AccrualDate  time.Time `json:"accrual_date"`
+	Balance      Money     `json:"balance"`
+	AnnualRate   Money     `json:"annual_rate"`
+	DayCount     DayCount  `json:"day_count"`
+	Amount       Money     `json:"amount"`
+	CurrencyCode string    `json:"currency_code"`
+	Reference    string    `json:"reference,omitempty"`
+}
+
+// InterestPosting is the payment of the accruals of an account up to and including Through. Amount is their sum
+// rounded to the minor units of the currency; ErrorMessage is set when the payment failed
+type InterestPosting struct {
+	AccountId    int64     `json:"account_id"`
+	Through      time.Time `json:"through"`
+	Amount       Money     `json:"amount"`
+	CurrencyCode string    `json:"currency_code"`
+	Reference    string    `json:"reference,omitempty"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+}
+
+// PostInterestArgs represents the repository payload for paying the accruals of an account up to and including
+// Through, from the interest expense account under Reference
+type PostInterestArgs struct {
+	AccountId        int64
+	ExpenseAccountId int64
+	Through          time.Time
+	Reference        string
+}
+
+// InterestRunResult is the outcome of one run of the interest job. In a dry run nothing is written and the
+// postings include the accruals of the run
+type InterestRunResult struct {
+	AsOf     time.Time         `json:"as_of"`
+	DryRun   bool              `json:"dry_run"`
+	Accruals []InterestAccrual `json:"accruals"`
+	Postings []InterestPosting `json:"postings"`
+}
+
+// InterestRateResponse represents the response body of the interest rate endpoint
+type InterestRateResponse struct {
+	AccountId int64 `json:"account_id"`
+	InterestRate
+}
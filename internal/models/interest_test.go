@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDayCount_DaysInYear(t *testing.T) {
+	leap := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	common := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, int64(365), DayCountActual365.DaysInYear(leap))
+	assert.Equal(t, int64(360), DayCountActual360.DaysInYear(leap))
+	assert.Equal(t, int64(366), DayCountActualActual.DaysInYear(leap))
+	assert.Equal(t, int64(365), DayCountActualActual.DaysInYear(common))
+	assert.Equal(t, int64(365), DayCountActualActual.DaysInYear(time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestInterestRate_DailyInterest(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		rate     InterestRate
+		balance  Money
+		expected Money
+	}{
+		{
+			name:     "ACT/365",
+			rate:     InterestRate{AnnualRate: MustParseMoney("3.65"), DayCount: DayCountActual365},
+			balance:  NewMoney(1000),
+			expected: MustParseMoney("0.1"),
+		},
+		{
+			name:     "ACT/360",
+			rate:     InterestRate{AnnualRate: MustParseMoney("3.6"), DayCount: DayCountActual360},
+			balance:  NewMoney(1000),
+			expected: MustParseMoney("0.1"),
+		},
+		{
+			name:     "ACT/ACT in a leap year, rounded half away from zero",
+			rate:     InterestRate{AnnualRate: MustParseMoney("2.5"), DayCount: DayCountActualActual},
+			balance:  NewMoney(1000),
+			expected: MustParseMoney("0.06831"),
+		},
+		{
+			name:    "negative balance earns nothing",
+			rate:    InterestRate{AnnualRate: MustParseMoney("2.5"), DayCount: DayCountActual365},
+			balance: NewMoney(-1000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.rate.DailyInterest(tt.balance, day))
+		})
+	}
+}
+
+func TestInterestRate_IsValid(t *testing.T) {
+	assert.True(t, InterestRate{AnnualRate: MustParseMoney("2.5"), DayCount: DayCountActual365}.IsValid())
+	assert.True(t, InterestRate{DayCount: DayCountActualActual}.IsValid())
+	assert.False(t, InterestRate{AnnualRate: MustParseMoney("-1"), DayCount: DayCountActual365}.IsValid())
+	assert.False(t, InterestRate{AnnualRate: NewMoney(10000), DayCount: DayCountActual365}.IsValid())
+	assert.False(t, InterestRate{AnnualRate: MustParseMoney("2.5"), DayCount: "30/360"}.IsValid())
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"time"
+)
+
+// PostingKind tells what a posting of a journal entry moves
+type PostingKind string
+
+const (
+	// PostingKindTransfer moves the amount of a transfer, a reversal, a hold capture, a closing sweep or an interest payment
+	PostingKindTransfer PostingKind = "transfer"
+	// PostingKindFee moves the fee of a transfer to the revenue account
+	PostingKindFee PostingKind = "fee"
+	// PostingKindFx carries a converted amount through the FX position, which is not an account
+	PostingKindFx PostingKind = "fx"
+)
+
+// Posting is one line of a journal entry. Amount is signed: credits are positive and debits negative.
+// AccountId is nil on FX postings only
+type Posting struct {
+	AccountId    *int64      `json:"account_id"`
+	Kind         PostingKind `json:"kind"`
+	Amount       Money       `json:"amount"`
+	CurrencyCode string      `json:"currency_code"`
+}
+
+// JournalEntry is the double-entry record of everything written under one reference. Its postings sum to zero in
+// every currency: a cross-currency entry balances through FX postings
+type JournalEntry struct {
+	Id                int64     `json:"id"`
+	Reference         string    `json:"reference"`
+	OriginalReference string    `json:"original_reference,omitempty"`
+	Postings          []Posting `json:"postings"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Validate returns ErrUnbalancedJournalEntry unless the entry has postings, none of them zero and none but the FX
+// ones without an account, summing to zero in every currency
+func (e JournalEntry) Validate() error {
+	if len(e.Postings) == 0 {
+		return appErr.ErrUnbalancedJournalEntry
+	}
+	sums := map[string]Money{}
+	for _, p := range e.Postings {
+		if p.Amount.IsZero() || (p.AccountId == nil) != (p.Kind == PostingKindFx) {
+			return appErr.ErrUnbalancedJournalEntry
+		}
+		sums[p.CurrencyCode] = sums[p.CurrencyCode].Add(p.Amount)
+	}
+	for _, sum := range sums {
+		if !sum.IsZero() {
+			return appErr.ErrUnbalancedJournalEntry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalEntry_Validate(t *testing.T) {
+	source, destination := int64(1), int64(2)
+
+	tests := []struct {
+		name     string
+		postings []Posting
+		expected error
+	}{
+		{
+			name: "same currency",
+			postings: []Posting{
+				{AccountId: &source, Kind: PostingKindTransfer, Amount: NewMoney(-100), CurrencyCode: "USD"},
+				{AccountId: &destination, Kind: PostingKindTransfer, Amount: NewMoney(100), CurrencyCode: "USD"},
+			},
+		},
+		{
+			name: "cross currency through the FX position",
+			postings: []Posting{
+				{AccountId: &source, Kind: PostingKindTransfer, Amount: NewMoney(-100), CurrencyCode: "USD"},
+				{AccountId: &destination, Kind: PostingKindTransfer, Amount: MustParseMoney("92.35"), CurrencyCode: "EUR"},
+				{Kind: PostingKindFx, Amount: NewMoney(100), CurrencyCode: "USD"},
+				{Kind: PostingKindFx, Amount: MustParseMoney("-92.35"), CurrencyCode: "EUR"},
+			},
+		},
+		{
+			name: "cross currency without FX postings",
+			postings: []Posting{
+				{AccountId: &source, Kind: PostingKindTransfer, Amount: NewMoney(-100), CurrencyCode: "USD"},
+				{AccountId: &destination, Kind: PostingKindTransfer, Amount: MustParseMoney("92.35"), CurrencyCode: "EUR"},
+			},
+			expected: appErr.ErrUnbalancedJournalEntry,
+		},
+		{
+			name: "amounts differ",
+			postings: []Posting{
+				{AccountId: &source, Kind: PostingKindTransfer, Amount: NewMoney(-100), CurrencyCode: "USD"},
+				{AccountId: &destination, Kind: PostingKindTransfer, Amount: MustParseMoney("99.99"), CurrencyCode: "USD"},
+			},
+			expected: appErr.ErrUnbalancedJournalEntry,
+		},
+		{
+			name: "transfer posting without an account",
+			postings: []Posting{
+				{Kind: PostingKindTransfer, Amount: NewMoney(-100), CurrencyCode: "USD"},
+				{AccountId: &destination, Kind: PostingKindTransfer, Amount: NewMoney(100), CurrencyCode: "USD"},
+			},
+			expected: appErr.ErrUnbalancedJournalEntry,
+		},
+		{
+			name:     "no postings",
+			expected: appErr.ErrUnbalancedJournalEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, JournalEntry{Reference: "TXN-123456", Postings: tt.postings}.Validate())
+		})
+	}
+}
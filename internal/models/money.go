@@ -0,0 +1,282 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// MoneyScale is the number of fractional digits kept for every amount. It matches the DECIMAL(20,5) columns
+const MoneyScale = 5
+
+// moneyFactor is 10^MoneyScale, the number of units in one whole amount
+const moneyFactor int64 = 100000
+
+// Money is an exact decimal amount stored as a whole number of 10^-5 units.
+// The zero value is a valid amount of 0. The supported range is ±92233720368547.75807, narrower than what the
+// DECIMAL(20,5) columns can hold: ParseMoney and Scan reject anything outside it, and Add and Sub saturate at
+// its bounds instead of wrapping around
+type Money struct {
+	units int64
+}
+
+// maxUnits bounds the amounts on both sides, so that every amount can be negated
+const maxUnits int64 = math.MaxInt64
+
+// NewMoney creates an amount from a whole number, eg NewMoney(10) is 10.00000. whole must be within the
+// supported range
+func NewMoney(whole int64) Money {
+	return Money{units: whole * moneyFactor}
+}
+
+// NewMoneyFromUnits creates an amount from a raw number of 10^-5 units
+func NewMoneyFromUnits(units int64) Money {
+	return Money{units: units}
+}
+
+// ParseMoney parses a plain decimal string like "250", "-10.5" or "0.00001".
+// Exponents, thousand separators and more than MoneyScale significant fractional digits are rejected
+func ParseMoney(s string) (Money, error) {
+	units, err := parseDecimal(s, MoneyScale, moneyFactor)
+	if err == errDecimalScale {
+		return Money{}, appErr.ErrInvalidAmountScale
+	}
+	if err == errDecimalRange {
+		return Money{}, appErr.ErrAmountOutOfRange
+	}
+	if err != nil {
+		return Money{}, appErr.ErrInvalidAmount
+	}
+	return Money{units: units}, nil
+}
+
+var (
+	errDecimalSyntax = errors.New("invalid decimal")
+	errDecimalScale  = errors.New("too many decimal places")
+	errDecimalRange  = errors.New("decimal out of range")
+)
+
+// parseDecimal parses a plain decimal string into a whole number of 10^-scale units, factor being 10^scale
+func parseDecimal(s string, scale int, factor int64) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errDecimalSyntax
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, errDecimalSyntax
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, errDecimalSyntax
+	}
+
+	// trailing zeros beyond the scale do not change the value, anything else would be silently rounded
+	if len(fracPart) > scale {
+		if strings.Trim(fracPart[scale:], "0") != "" {
+			return 0, errDecimalScale
+		}
+		fracPart = fracPart[:scale]
+	}
+	fracPart += strings.Repeat("0", scale-len(fracPart))
+
+	frac, _ := strconv.ParseInt(fracPart, 10, 64)
+	var whole int64
+	if intPart != "" {
+		var err error
+		whole, err = strconv.ParseInt(intPart, 10, 64)
+		if err != nil || whole > (math.MaxInt64-frac)/factor {
+			return 0, errDecimalRange
+		}
+	}
+
+	units := whole*factor + frac
+	if negative {
+		units = -units
+	}
+	return units, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input. Meant for constants and tests
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(fmt.Sprintf("models.MustParseMoney(%q): %v", s, err))
+	}
+	return m
+}
+
+// isDigits reports whether s only contains ASCII digits. An empty string is accepted
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Units returns the raw number of 10^-5 units
+func (m Money) Units() int64 {
+	return m.units
+}
+
+// Add returns m + o, saturated at the bounds of the supported range
+func (m Money) Add(o Money) Money {
+	switch {
+	case o.units > 0 && m.units > maxUnits-o.units:
+		return Money{units: maxUnits}
+	case o.units < 0 && m.units < -maxUnits-o.units:
+		return Money{units: -maxUnits}
+	}
+	return Money{units: m.units + o.units}
+}
+
+// Sub returns m - o, saturated at the bounds of the supported range
+func (m Money) Sub(o Money) Money {
+	return m.Add(o.Neg())
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	if m.units == math.MinInt64 {
+		return Money{units: maxUnits}
+	}
+	return Money{units: -m.units}
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m.units < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or greater than o
+func (m Money) Cmp(o Money) int {
+	switch {
+	case m.units < o.units:
+		return -1
+	case m.units > o.units:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// LessThan reports whether m < o
+func (m Money) LessThan(o Money) bool {
+	return m.units < o.units
+}
+
+// GreaterThan reports whether m > o
+func (m Money) GreaterThan(o Money) bool {
+	return m.units > o.units
+}
+
+// IsZero reports whether m is 0
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsNegative reports whether m < 0
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// IsPositive reports whether m > 0
+func (m Money) IsPositive() bool {
+	return m.units > 0
+}
+
+// String formats the amount with exactly MoneyScale fractional digits, eg "1250.00000"
+func (m Money) String() string {
+	units := m.units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+	whole := units / moneyFactor
+	frac := units % moneyFactor
+	if whole < 0 {
+		whole = -whole
+	}
+	if frac < 0 {
+		frac = -frac
+	}
+	return fmt.Sprintf("%s%d.%05d", sign, whole, frac)
+}
+
+// MarshalJSON encodes the amount as a JSON string so that no precision is lost in clients
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON accepts both a JSON string ("10.50") and a JSON number (10.50)
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return appErr.ErrInvalidAmount
+		}
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner for DECIMAL columns
+func (m *Money) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	case int64:
+		if v > maxUnits/moneyFactor || v < -maxUnits/moneyFactor {
+			return fmt.Errorf("models.Money: cannot scan %d: %w", v, appErr.ErrAmountOutOfRange)
+		}
+		*m = NewMoney(v)
+		return nil
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("models.Money: cannot scan %T", src)
+	}
+
+	parsed, err := ParseMoney(s)
+	if err != nil {
+		return fmt.Errorf("models.Money: cannot scan %q: %w", s, err)
+	}
+	*m = parsed
+	return nil
+}
+
+// Value implements driver.Valuer. The amount is sent as text so Postgres keeps it exact
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
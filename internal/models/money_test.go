@@ -0,0 +1,133 @@
+package models
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{name: "whole number", input: "250", expected: "250.00000"},
+		{name: "fraction", input: "10.5", expected: "10.50000"},
+		{name: "smallest unit", input: "0.00001", expected: "0.00001"},
+		{name: "leading dot", input: ".25", expected: "0.25000"},
+		{name: "trailing dot", input: "7.", expected: "7.00000"},
+		{name: "negative", input: "-10.5", expected: "-10.50000"},
+		{name: "negative fraction only", input: "-0.5", expected: "-0.50000"},
+		{name: "explicit plus", input: "+3", expected: "3.00000"},
+		{name: "surrounding spaces", input: " 42.1 ", expected: "42.10000"},
+		{name: "extra trailing zeros", input: "1.50000000", expected: "1.50000"},
+		{name: "empty", input: "", expectedErr: appErr.ErrInvalidAmount},
+		{name: "only dot", input: ".", expectedErr: appErr.ErrInvalidAmount},
+		{name: "letters", input: "abc", expectedErr: appErr.ErrInvalidAmount},
+		{name: "exponent", input: "1e5", expectedErr: appErr.ErrInvalidAmount},
+		{name: "two dots", input: "1.2.3", expectedErr: appErr.ErrInvalidAmount},
+		{name: "double sign", input: "--1", expectedErr: appErr.ErrInvalidAmount},
+		{name: "largest amount", input: "92233720368547.75807", expected: "92233720368547.75807"},
+		{name: "smallest amount", input: "-92233720368547.75807", expected: "-92233720368547.75807"},
+		{name: "just above the range", input: "92233720368547.75808", expectedErr: appErr.ErrAmountOutOfRange},
+		{name: "just below the range", input: "-92233720368547.75808", expectedErr: appErr.ErrAmountOutOfRange},
+		{name: "fits DECIMAL(20,5) but not the range", input: "999999999999999.99999", expectedErr: appErr.ErrAmountOutOfRange},
+		{name: "overflow", input: "99999999999999999999", expectedErr: appErr.ErrAmountOutOfRange},
+		{name: "too many decimals", input: "0.000001", expectedErr: appErr.ErrInvalidAmountScale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMoney(tt.input)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, m.String())
+			}
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := MustParseMoney("0.1")
+	b := MustParseMoney("0.2")
+
+	assert.Equal(t, MustParseMoney("0.3"), a.Add(b))
+	assert.Equal(t, MustParseMoney("-0.1"), a.Sub(b))
+	assert.Equal(t, MustParseMoney("0.1"), a.Sub(b).Abs())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 1, b.Cmp(a))
+	assert.Equal(t, 0, a.Cmp(MustParseMoney("0.10")))
+	assert.True(t, a.LessThan(b))
+	assert.True(t, b.GreaterThan(a))
+	assert.True(t, a.Sub(a).IsZero())
+	assert.True(t, a.Neg().IsNegative())
+	assert.True(t, a.IsPositive())
+	assert.Equal(t, NewMoney(3), NewMoneyFromUnits(300000))
+
+	largest := NewMoneyFromUnits(math.MaxInt64)
+	assert.Equal(t, largest, largest.Add(a))
+	assert.Equal(t, largest, largest.Sub(a.Neg()))
+	assert.Equal(t, largest.Neg(), largest.Neg().Sub(a))
+	assert.Equal(t, largest.Neg(), largest.Neg().Add(largest.Neg()))
+	assert.Equal(t, largest, NewMoneyFromUnits(math.MinInt64).Neg())
+	assert.Equal(t, NewMoneyFromUnits(math.MaxInt64-10000), largest.Sub(a))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	out, err := json.Marshal(struct {
+		Amount Money `json:"amount"`
+	}{Amount: MustParseMoney("12.3")})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":"12.30000"}`, string(out))
+
+	var in struct {
+		A Money `json:"a"`
+		B Money `json:"b"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":"1.25","b":2.5}`), &in))
+	assert.Equal(t, MustParseMoney("1.25"), in.A)
+	assert.Equal(t, MustParseMoney("2.5"), in.B)
+
+	assert.Equal(t, appErr.ErrInvalidAmountScale, json.Unmarshal([]byte(`"1.123456"`), &in.A))
+}
+
+func TestMoney_Scan(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       interface{}
+		expected  Money
+		expectErr bool
+	}{
+		{name: "bytes", src: []byte("100.50000"), expected: MustParseMoney("100.5")},
+		{name: "string", src: "0.00001", expected: NewMoneyFromUnits(1)},
+		{name: "int64", src: int64(7), expected: NewMoney(7)},
+		{name: "int64 out of range", src: int64(math.MaxInt64 / 10000), expectErr: true},
+		{name: "negative int64 out of range", src: int64(math.MinInt64 / 10000), expectErr: true},
+		{name: "string out of range", src: "100000000000000.00000", expectErr: true},
+		{name: "float64", src: 150.75, expected: MustParseMoney("150.75")},
+		{name: "nil", src: nil, expectErr: true},
+		{name: "garbage", src: []byte("abc"), expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := m.Scan(tt.src)
+			if tt.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, m)
+		})
+	}
+
+	v, err := MustParseMoney("-3.2").Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "-3.20000", v)
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// AccountReconciliation compares the balance of an account with its ledger. LedgerBalance is the initial balance
+// plus every entry of the account, and LastRecordedBalance the balance written on its latest entry, nil when it
+// has none. Difference is Balance minus LedgerBalance
+type AccountReconciliation struct {
+	AccountId           int64  `json:"account_id"`
+	CurrencyCode        string `json:"currency_code"`
+	Balance             Money  `json:"balance"`
+	LedgerBalance       Money  `json:"ledger_balance"`
+	LastRecordedBalance *Money `json:"last_recorded_balance"`
+	Difference          Money  `json:"difference"`
+}
+
+// IsReconciled reports whether the balance matches both the ledger and the latest entry
+func (a AccountReconciliation) IsReconciled() bool {
+	return a.Balance.Cmp(a.LedgerBalance) == 0 && (a.LastRecordedBalance == nil || a.LastRecordedBalance.Cmp(a.Balance) == 0)
+}
+
+// ReconcileRequest represents the request body of the reconciliation endpoint. AccountId limits the check to one
+// account, and Flag records the mismatches found in reconciliation_flags
+type ReconcileRequest struct {
+	AccountId int64 `json:"account_id,omitempty"`
+	Flag      bool  `json:"flag,omitempty"`
+}
+
+// ReconciliationReport is the outcome of a reconciliation run, listing the accounts that do not reconcile
+type ReconciliationReport struct {
+	CheckedAt       time.Time               `json:"checked_at"`
+	AccountsChecked int                     `json:"accounts_checked"`
+	Flagged         bool                    `json:"flagged"`
+	Mismatches      []AccountReconciliation `json:"mismatches"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountReconciliation_IsReconciled(t *testing.T) {
+	balance := NewMoney(100)
+	other := NewMoney(90)
+
+	assert.True(t, AccountReconciliation{Balance: balance, LedgerBalance: balance, LastRecordedBalance: &balance}.IsReconciled())
+	assert.True(t, AccountReconciliation{Balance: balance, LedgerBalance: balance}.IsReconciled(), "an account without entries has nothing recorded")
+	assert.False(t, AccountReconciliation{Balance: balance, LedgerBalance: other, LastRecordedBalance: &balance}.IsReconciled())
+	assert.False(t, AccountReconciliation{Balance: balance, LedgerBalance: balance, LastRecordedBalance: &other}.IsReconciled())
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// SignedRequest is a request signed with the signing secret of an API key instead of carrying the key itself
+type SignedRequest struct {
+	ApiKeyId int64
+	Method   string
+	// Path is the path of the request with its query string, as sent
+	Path string
+	// Timestamp is when the request was signed, in unix seconds
+	Timestamp int64
+	Nonce     string
+	Body      []byte
+	// Signature is the hex HMAC-SHA256 of SigningPayload under the signing secret
+	Signature string
+}
+
+// SigningPayload is the content a request signature covers: the method, path, timestamp and nonce each followed
+// by a newline, then the body as sent
+func (r SignedRequest) SigningPayload() []byte {
+	var b bytes.Buffer
+	b.WriteString(r.Method)
+	b.WriteByte('\n')
+	b.WriteString(r.Path)
+	b.WriteByte('\n')
+	b.WriteString(strconv.FormatInt(r.Timestamp, 10))
+	b.WriteByte('\n')
+	b.WriteString(r.Nonce)
+	b.WriteByte('\n')
+	b.Write(r.Body)
+	return b.Bytes()
+}
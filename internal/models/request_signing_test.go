@@ -0,0 +1,23 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignedRequest_SigningPayload(t *testing.T) {
+	req := SignedRequest{
+		ApiKeyId:  3,
+		Method:    "POST",
+		Path:      "/transactions",
+		Timestamp: 1743498000,
+		Nonce:     "9f8c1e",
+		Body:      []byte(`{"amount":"10"}`),
+		Signature: "ignored",
+	}
+	assert.Equal(t, "POST\n/transactions\n1743498000\n9f8c1e\n{\"amount\":\"10\"}", string(req.SigningPayload()))
+
+	req.Method, req.Path, req.Body = "GET", "/accounts/7/transactions?limit=10", nil
+	assert.Equal(t, "GET\n/accounts/7/transactions?limit=10\n1743498000\n9f8c1e\n", string(req.SigningPayload()))
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/internal/schedule"
+)
+
+// ScheduledTransferStatus is the lifecycle state of a scheduled transfer
+type ScheduledTransferStatus string
+
+const (
+	// ScheduledTransferStatusActive transfers are run by the scheduler when they are due
+	ScheduledTransferStatusActive ScheduledTransferStatus = "active"
+	// ScheduledTransferStatusPaused transfers are skipped until they are resumed
+	ScheduledTransferStatusPaused ScheduledTransferStatus = "paused"
+	// ScheduledTransferStatusCancelled transfers will never run again
+	ScheduledTransferStatusCancelled ScheduledTransferStatus = "cancelled"
+	// ScheduledTransferStatusCompleted one-off transfers have run, successfully or not
+	ScheduledTransferStatusCompleted ScheduledTransferStatus = "completed"
+)
+
+// ScheduledRunStatus is the outcome of one run of a scheduled transfer
+type ScheduledRunStatus string
+
+const (
+	ScheduledRunStatusSucceeded ScheduledRunStatus = "succeeded"
+	ScheduledRunStatusFailed    ScheduledRunStatus = "failed"
+)
+
+// ScheduledTransfer is a transfer run once at a future time, or repeatedly following a cron Schedule.
+// Schedule is not set for one-off transfers. The outcome of the latest run is kept on the transfer
+type ScheduledTransfer struct {
+	ScheduledTransferId  string                  `db:"scheduled_transfer_id"`
+	SourceAccountId      int64                   `db:"source_account_id"`
+	DestinationAccountId int64                   `db:"destination_account_id"`
+	Amount               Money                   `db:"amount"`
+	Schedule             sql.NullString          `db:"schedule"`
+	Status               ScheduledTransferStatus `db:"status"`
+	NextRunAt            sql.NullTime            `db:"next_run_at"`
+	LastRunAt            sql.NullTime            `db:"last_run_at"`
+	LastRunStatus        sql.NullString          `db:"last_run_status"`
+	LastRunReference     sql.NullString          `db:"last_run_reference"`
+	LastRunError         sql.NullString          `db:"last_run_error"`
+	CreatedAt            time.Time               `db:"created_at"`
+	UpdatedAt            time.Time               `db:"updated_at"`
+}
+
+// NextRunAfter returns the first run of a recurring transfer strictly after the given time. It is not valid for
+// one-off transfers and for schedules that will never match again
+func (st ScheduledTransfer) NextRunAfter(after time.Time) sql.NullTime {
+	if !st.Schedule.Valid {
+		return sql.NullTime{}
+	}
+	cron, err := schedule.Parse(st.Schedule.String)
+	if err != nil {
+		return sql.NullTime{}
+	}
+	next := cron.Next(after.UTC())
+	return sql.NullTime{Time: next, Valid: !next.IsZero()}
+}
+
+// ScheduledTransferRun records one execution of a scheduled transfer. ScheduledFor is the run time it was due at
+type ScheduledTransferRun struct {
+	Id                  int64              `db:"id"`
+	ScheduledTransferId string             `db:"scheduled_transfer_id"`
+	ScheduledFor        time.Time          `db:"scheduled_for"`
+	ExecutedAt          time.Time          `db:"executed_at"`
+	Status              ScheduledRunStatus `db:"status"`
+	Reference           sql.NullString     `db:"reference"`
+	ErrorMessage        sql.NullString     `db:"error_message"`
+}
+
+// CreateScheduledTransferRequest represents the request body for scheduling a transfer. Exactly one of RunAt, an
+// RFC3339 time for a one-off transfer, and Schedule, a cron expression for a recurring one, must be given
+type CreateScheduledTransferRequest struct {
+	SourceAccountId      int64  `json:"source_account_id,binding:required"`
+	DestinationAccountId int64  `json:"destination_account_id,binding:required"`
+	Amount               string `json:"amount,binding:required"`
+	RunAt                string `json:"run_at,omitempty"`
+	Schedule             string `json:"schedule,omitempty"`
+}
+
+// CreateScheduledTransferArgs represents the internal service payload for scheduling a transfer
+type CreateScheduledTransferArgs struct {
+	SourceAccountId      int64
+	DestinationAccountId int64
+	Amount               Money
+	RunAt                *time.Time
+	Schedule             string
+}
+
+// ScheduledTransferRunResponse represents the outcome of the latest run of a scheduled transfer
+type ScheduledTransferRunResponse struct {
+	ExecutedAt   time.Time          `json:"executed_at"`
+	Status       ScheduledRunStatus `json:"status"`
+	Reference    string             `json:"reference,omitempty"`
+	ErrorMessage string             `json:"error_message,omitempty"`
+}
+
+// ScheduledTransferResponse represents the response body for a scheduled transfer
+type ScheduledTransferResponse struct {
+	ScheduledTransferId  string                        `json:"scheduled_transfer_id"`
+	SourceAccountId      int64                         `json:"source_account_id"`
+	DestinationAccountId int64                         `json:"destination_account_id"`
+	Amount               Money                         `json:"amount"`
+	Schedule             string                        `json:"schedule,omitempty"`
+	Status               ScheduledTransferStatus       `json:"status"`
+	NextRunAt            *time.Time                    `json:"next_run_at,omitempty"`
+	LastRun              *ScheduledTransferRunResponse `json:"last_run,omitempty"`
+	CreatedAt            time.Time                     `json:"created_at"`
+}
+
+// NewScheduledTransferResponse builds the API view of a scheduled transfer
+func NewScheduledTransferResponse(st ScheduledTransfer) ScheduledTransferResponse {
+	resp := ScheduledTransferResponse{
+		ScheduledTransferId:  st.ScheduledTransferId,
+		SourceAccountId:      st.SourceAccountId,
+		DestinationAccountId: st.DestinationAccountId,
+		Amount:               st.Amount,
+		Schedule:             st.Schedule.String,
+		Status:               st.Status,
+		CreatedAt:            st.CreatedAt,
+	}
+	if st.NextRunAt.Valid {
+		nextRunAt := st.NextRunAt.Time
+		resp.NextRunAt = &nextRunAt
+	}
+	if st.LastRunAt.Valid {
+		resp.LastRun = &ScheduledTransferRunResponse{
+			ExecutedAt:   st.LastRunAt.Time,
+			Status:       ScheduledRunStatus(st.LastRunStatus.String),
+			Reference:    st.LastRunReference.String,
+			ErrorMessage: st.LastRunError.String,
+		}
+	}
+	return resp
+}
+
+// ListScheduledTransfersResponse represents the response body for listing the scheduled transfers of an account
+type ListScheduledTransfersResponse struct {
+	ScheduledTransfers []ScheduledTransferResponse `json:"scheduled_transfers"`
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+)
+
+// StatementDateLayout is the layout of the from and to dates of a statement
+const StatementDateLayout = "2006-01-02"
+
+// StatementFormat is how a statement is rendered
+type StatementFormat string
+
+const (
+	StatementFormatJSON StatementFormat = "json"
+	StatementFormatCSV  StatementFormat = "csv"
+	StatementFormatPDF  StatementFormat = "pdf"
+)
+
+// IsValid reports whether f is a supported statement format
+func (f StatementFormat) IsValid() bool {
+	switch f {
+	case StatementFormatJSON, StatementFormatCSV, StatementFormatPDF:
+		return true
+	}
+	return false
+}
+
+// StatementArgs represents the internal service payload for building the statement of an account. From and To are
+// UTC days, both included
+type StatementArgs struct {
+	AccountId int64
+	From      time.Time
+	To        time.Time
+}
+
+// ParseStatementPeriod reads the from and to dates of a statement, which are both required and may not be reversed
+func ParseStatementPeriod(from, to string) (time.Time, time.Time, error) {
+	fromDay, err := time.Parse(StatementDateLayout, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, appErr.ErrInvalidStatementPeriod
+	}
+	toDay, err := time.Parse(StatementDateLayout, to)
+	if err != nil || toDay.Before(fromDay) {
+		return time.Time{}, time.Time{}, appErr.ErrInvalidStatementPeriod
+	}
+	return fromDay, toDay, nil
+}
+
+// StatementLine is one ledger entry of a statement. Exactly one of Debit and Credit is set, and Balance is the
+// balance of the account once the entry was written
+type StatementLine struct {
+	Id                int64     `json:"id"`
+	CreatedAt         time.Time `json:"created_at"`
+	Reference         string    `json:"reference"`
+	OriginalReference string    `json:"original_reference,omitempty"`
+	IsFee             bool      `json:"is_fee,omitempty"`
+	Debit             *Money    `json:"debit,omitempty"`
+	Credit            *Money    `json:"credit,omitempty"`
+	Balance           Money     `json:"balance"`
+}
+
+// Statement lists the ledger entries of an account over a period, oldest first, between its opening and closing
+// balance. The closing balance is the opening balance plus TotalCredits minus TotalDebits
+type Statement struct {
+	AccountId      int64           `json:"account_id"`
+	CurrencyCode   string          `json:"currency_code"`
+	From           string          `json:"from"`
+	To             string          `json:"to"`
+	OpeningBalance Money           `json:"opening_balance"`
+	TotalDebits    Money           `json:"total_debits"`
+	TotalCredits   Money           `json:"total_credits"`
+	ClosingBalance Money           `json:"closing_balance"`
+	Lines          []StatementLine `json:"lines"`
+	GeneratedAt    time.Time       `json:"generated_at"`
+}
+
+// NewStatement builds the statement of account from its balance before the period and the entries of the period,
+// oldest first. The running balance of each line is the balance recorded on its entry
+func NewStatement(account Account, args StatementArgs, openingBalance Money, transactions []Transaction) Statement {
+	statement := Statement{
+		AccountId:      account.AccountId,
+		CurrencyCode:   account.CurrencyCode,
+		From:           args.From.Format(StatementDateLayout),
+		To:             args.To.Format(StatementDateLayout),
+		OpeningBalance: openingBalance,
+		ClosingBalance: openingBalance,
+		Lines:          make([]StatementLine, 0, len(transactions)),
+	}
+	for _, t := range transactions {
+		amount := t.Amount
+		line := StatementLine{
+			Id:                t.Id,
+			CreatedAt:         t.CreatedAt,
+			Reference:         t.Reference,
+			OriginalReference: t.OriginalReference.String,
+			IsFee:             t.IsFee,
+			Balance:           t.AvailableBalance,
+		}
+		if t.IsCredit {
+			line.Credit = &amount
+			statement.TotalCredits = statement.TotalCredits.Add(amount)
+		} else {
+			line.Debit = &amount
+			statement.TotalDebits = statement.TotalDebits.Add(amount)
+		}
+		statement.Lines = append(statement.Lines, line)
+		statement.ClosingBalance = t.AvailableBalance
+	}
+	return statement
+}
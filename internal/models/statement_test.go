@@ -0,0 +1,68 @@
+package models
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStatementPeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		from, to    string
+		expectedErr error
+	}{
+		{name: "month", from: "2025-03-01", to: "2025-03-31"},
+		{name: "single day", from: "2025-03-01", to: "2025-03-01"},
+		{name: "missing from", to: "2025-03-31", expectedErr: appErr.ErrInvalidStatementPeriod},
+		{name: "missing to", from: "2025-03-01", expectedErr: appErr.ErrInvalidStatementPeriod},
+		{name: "not a date", from: "2025-03-01T00:00:00Z", to: "2025-03-31", expectedErr: appErr.ErrInvalidStatementPeriod},
+		{name: "reversed", from: "2025-03-31", to: "2025-03-01", expectedErr: appErr.ErrInvalidStatementPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ParseStatementPeriod(tt.from, tt.to)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, tt.from, from.Format(StatementDateLayout))
+				assert.Equal(t, tt.to, to.Format(StatementDateLayout))
+			}
+		})
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	at := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+	args := StatementArgs{AccountId: 1, From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+	account := Account{AccountId: 1, CurrencyCode: "USD"}
+	transactions := []Transaction{
+		{Id: 5, Amount: NewMoney(25), AvailableBalance: NewMoney(75), Reference: "TXN-5", CreatedAt: at},
+		{Id: 6, Amount: MustParseMoney("0.5"), AvailableBalance: MustParseMoney("74.5"), Reference: "TXN-5", IsFee: true, CreatedAt: at},
+		{Id: 9, Amount: NewMoney(10), AvailableBalance: MustParseMoney("84.5"), IsCredit: true, Reference: "TXN-9",
+			OriginalReference: sql.NullString{String: "TXN-2", Valid: true}, CreatedAt: at},
+	}
+
+	statement := NewStatement(account, args, NewMoney(100), transactions)
+	assert.Equal(t, "2025-03-01", statement.From)
+	assert.Equal(t, "2025-03-31", statement.To)
+	assert.Equal(t, NewMoney(100), statement.OpeningBalance)
+	assert.Equal(t, MustParseMoney("25.5"), statement.TotalDebits)
+	assert.Equal(t, NewMoney(10), statement.TotalCredits)
+	assert.Equal(t, MustParseMoney("84.5"), statement.ClosingBalance)
+	assert.Equal(t, statement.OpeningBalance.Add(statement.TotalCredits).Sub(statement.TotalDebits), statement.ClosingBalance)
+
+	assert.Len(t, statement.Lines, 3)
+	assert.Equal(t, NewMoney(25), *statement.Lines[0].Debit)
+	assert.Nil(t, statement.Lines[0].Credit)
+	assert.True(t, statement.Lines[1].IsFee)
+	assert.Equal(t, NewMoney(10), *statement.Lines[2].Credit)
+	assert.Equal(t, "TXN-2", statement.Lines[2].OriginalReference)
+
+	empty := NewStatement(account, args, NewMoney(100), nil)
+	assert.Equal(t, NewMoney(100), empty.ClosingBalance, "a period without entries closes at its opening balance")
+	assert.Empty(t, empty.Lines)
+}
@@ -5,17 +5,25 @@ import (
 	"time"
 )
 
-// Transaction represents a money transfer transaction
+// Transaction represents a money transfer transaction. Amount and AvailableBalance are in the currency of the leg's
+// account; FxRate, SourceAmount and DestinationAmount describe the whole transfer and are nil on entries written
+// before they were recorded. IsFee marks the legs moving the fee of a transfer to the revenue account
 type Transaction struct {
-	Id           int64        `db:"id"`
-	AccountId    int64        `db:"account_id"`
-	Amount       float64      `db:"amount"`
-	CurrencyCode string       `db:"currency_code"`
-	IsCredit     bool         `db:"is_credit"`
-	Reference    string       `db:"reference"`
-	CreatedAt    time.Time    `db:"created_at"`
-	UpdatedAt    time.Time    `db:"updated_at"`
-	DeletedAt    sql.NullTime `db:"deleted_at"`
+	Id                int64          `db:"id"`
+	AccountId         int64          `db:"account_id"`
+	Amount            Money          `db:"amount"`
+	CurrencyCode      string         `db:"currency_code"`
+	AvailableBalance  Money          `db:"available_balance"`
+	IsCredit          bool           `db:"is_credit"`
+	Reference         string         `db:"reference"`
+	OriginalReference sql.NullString `db:"original_reference"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+	DeletedAt         sql.NullTime   `db:"deleted_at"`
+	FxRate            *ExchangeRate  `db:"fx_rate"`
+	SourceAmount      *Money         `db:"source_amount"`
+	DestinationAmount *Money         `db:"destination_amount"`
+	IsFee             bool           `db:"is_fee"`
 }
 
 // CreateTransactionRequest represents the request body for creating a transaction
@@ -23,19 +31,153 @@ type CreateTransactionRequest struct {
 	SourceAccountId      int64  `json:"source_account_id,binding:required"`
 	DestinationAccountId int64  `json:"destination_account_id,binding:required"`
 	Amount               string `json:"amount,binding:required"`
+	QuoteId              string `json:"quote_id,omitempty"`
 }
 
-// CreateTransactionArgs represents the internal service payload for creating a transaction
+// CreateTransactionArgs represents the internal service payload for creating a transaction.
+// Amount is debited in CurrencyCode and DestinationAmount is credited in DestinationCurrencyCode, FxRate being
+// the rate between the two. Limits are the caps of the source account, checked when the transfer is posted,
+// and Fee is charged on top of Amount when set
 type CreateTransactionArgs struct {
-	SourceAccountId      int64
-	DestinationAccountId int64
-	Amount               float64
-	CurrencyCode         string
-	Reference            string
+	SourceAccountId         int64
+	DestinationAccountId    int64
+	Amount                  Money
+	CurrencyCode            string
+	DestinationAmount       Money
+	DestinationCurrencyCode string
+	FxRate                  ExchangeRate
+	QuoteId                 string
+	Reference               string
+	Idempotency             Idempotency
+	Limits                  TransferLimits
+	Fee                     *TransferFee
 }
 
-// CreateTransactionResponse represents the response body for creating a transaction
+// CreateTransactionResponse represents the response body for creating a transaction.
+// The destination amount and the rate are only set for cross-currency transfers and the fee for charged ones
 type CreateTransactionResponse struct {
-	SourceAccountId  int64  `json:"source_account_id"`
-	AvailableBalance string `json:"available_balance"`
+	SourceAccountId         int64         `json:"source_account_id"`
+	AvailableBalance        Money         `json:"available_balance"`
+	Reference               string        `json:"reference"`
+	DestinationAmount       *Money        `json:"destination_amount,omitempty"`
+	DestinationCurrencyCode string        `json:"destination_currency_code,omitempty"`
+	FxRate                  *ExchangeRate `json:"fx_rate,omitempty"`
+	Fee                     *FeeResponse  `json:"fee,omitempty"`
+}
+
+// ListTransactionsArgs represents the internal service payload for listing the ledger entries of an account
+type ListTransactionsArgs struct {
+	AccountId int64
+	From      *time.Time
+	To        *time.Time
+	IsCredit  *bool
+	MinAmount *Money
+	MaxAmount *Money
+	Cursor    string
+	Limit     int
+}
+
+// TransactionFilter represents the repository filter for reading ledger entries newest-first.
+// When CursorId is set only entries older than (CursorCreatedAt, CursorId) are returned
+type TransactionFilter struct {
+	AccountId       int64
+	From            *time.Time
+	To              *time.Time
+	IsCredit        *bool
+	MinAmount       *Money
+	MaxAmount       *Money
+	CursorCreatedAt time.Time
+	CursorId        int64
+	Limit           int
+}
+
+// TransactionResponse represents a single ledger entry in API responses
+type TransactionResponse struct {
+	Id                int64         `json:"id"`
+	AccountId         int64         `json:"account_id"`
+	Amount            Money         `json:"amount"`
+	CurrencyCode      string        `json:"currency_code"`
+	IsCredit          bool          `json:"is_credit"`
+	AvailableBalance  Money         `json:"available_balance"`
+	Reference         string        `json:"reference"`
+	OriginalReference string        `json:"original_reference,omitempty"`
+	FxRate            *ExchangeRate `json:"fx_rate,omitempty"`
+	SourceAmount      *Money        `json:"source_amount,omitempty"`
+	DestinationAmount *Money        `json:"destination_amount,omitempty"`
+	IsFee             bool          `json:"is_fee,omitempty"`
+	CreatedAt         time.Time     `json:"created_at"`
+}
+
+// ListTransactionsResponse represents the response body for listing the ledger entries of an account
+type ListTransactionsResponse struct {
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"next_cursor,omitempty"`
+}
+
+// TransferResponse represents the response body for looking up a transfer by its reference. Amount is debited
+// in CurrencyCode and DestinationAmount is credited in DestinationCurrencyCode.
+// Legs holds every ledger entry sharing the reference, in the order they were written
+type TransferResponse struct {
+	Reference                   string                `json:"reference"`
+	OriginalReference           string                `json:"original_reference,omitempty"`
+	SourceAccountId             int64                 `json:"source_account_id"`
+	DestinationAccountId        int64                 `json:"destination_account_id"`
+	Amount                      Money                 `json:"amount"`
+	CurrencyCode                string                `json:"currency_code"`
+	DestinationAmount           Money                 `json:"destination_amount"`
+	DestinationCurrencyCode     string                `json:"destination_currency_code"`
+	FxRate                      ExchangeRate          `json:"fx_rate"`
+	SourceAvailableBalance      Money                 `json:"source_available_balance"`
+	DestinationAvailableBalance Money                 `json:"destination_available_balance"`
+	CreatedAt                   time.Time             `json:"created_at"`
+	UpdatedAt                   time.Time             `json:"updated_at"`
+	Legs                        []TransactionResponse `json:"legs"`
+}
+
+// NewTransactionResponse builds the API view of a ledger entry
+func NewTransactionResponse(t Transaction) TransactionResponse {
+	return TransactionResponse{
+		Id:                t.Id,
+		AccountId:         t.AccountId,
+		Amount:            t.Amount,
+		CurrencyCode:      t.CurrencyCode,
+		IsCredit:          t.IsCredit,
+		AvailableBalance:  t.AvailableBalance,
+		Reference:         t.Reference,
+		OriginalReference: t.OriginalReference.String,
+		FxRate:            t.FxRate,
+		SourceAmount:      t.SourceAmount,
+		DestinationAmount: t.DestinationAmount,
+		IsFee:             t.IsFee,
+		CreatedAt:         t.CreatedAt,
+	}
+}
+
+// ReverseTransactionRequest represents the request body for reversing a transfer. The amount is in the currency
+// of the original sender, an empty amount reverses everything that is left of the original transfer
+type ReverseTransactionRequest struct {
+	Amount string `json:"amount"`
+}
+
+// ReverseTransactionArgs represents the internal service payload for reversing a transfer
+type ReverseTransactionArgs struct {
+	OriginalReference string
+	Amount            *Money
+	Reference         string
+}
+
+// ReverseTransactionResponse represents the response body for reversing a transfer. The reversal debits
+// DebitedAmount from the original receiver (SourceAccountId) and credits Amount to the original sender
+// (DestinationAccountId). Both amounts are equal unless the original transfer was cross-currency
+type ReverseTransactionResponse struct {
+	Reference            string `json:"reference"`
+	OriginalReference    string `json:"original_reference"`
+	SourceAccountId      int64  `json:"source_account_id"`
+	DestinationAccountId int64  `json:"destination_account_id"`
+	Amount               Money  `json:"amount"`
+	CurrencyCode         string `json:"currency_code"`
+	AvailableBalance     Money  `json:"available_balance"`
+	DebitedAmount        Money  `json:"debited_amount"`
+	DebitedCurrencyCode  string `json:"debited_currency_code"`
+	RemainingReversible  Money  `json:"remaining_reversible_amount"`
 }
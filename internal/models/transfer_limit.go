@@ -0,0 +1,72 @@
+package models
+
+import appErr "github.com/bhuvi1021/TripleA/internal/errors"
+
+// TransferLimits caps the transfers leaving an account. Amounts are in the currency of the account and a nil
+// field means no cap. MaxDailyAmount and MaxHourlyCount are counted over the last 24 hours and the last hour
+type TransferLimits struct {
+	MaxAmount      *Money `json:"max_amount"`
+	MaxDailyAmount *Money `json:"max_daily_amount"`
+	MaxHourlyCount *int   `json:"max_hourly_count"`
+}
+
+// Override returns l with every cap that is set in o replaced by the one of o
+func (l TransferLimits) Override(o TransferLimits) TransferLimits {
+	if o.MaxAmount != nil {
+		l.MaxAmount = o.MaxAmount
+	}
+	if o.MaxDailyAmount != nil {
+		l.MaxDailyAmount = o.MaxDailyAmount
+	}
+	if o.MaxHourlyCount != nil {
+		l.MaxHourlyCount = o.MaxHourlyCount
+	}
+	return l
+}
+
+// IsValid reports whether every cap that is set is positive
+func (l TransferLimits) IsValid() bool {
+	return (l.MaxAmount == nil || l.MaxAmount.IsPositive()) &&
+		(l.MaxDailyAmount == nil || l.MaxDailyAmount.IsPositive()) &&
+		(l.MaxHourlyCount == nil || *l.MaxHourlyCount > 0)
+}
+
+// HasVelocity reports whether a cap that depends on the past transfers of the account is set
+func (l TransferLimits) HasVelocity() bool {
+	return l.MaxDailyAmount != nil || l.MaxHourlyCount != nil
+}
+
+// CheckAmount returns ErrTransferAmountLimitExceeded when amount is over the per-transfer cap
+func (l TransferLimits) CheckAmount(amount Money) error {
+	if l.MaxAmount != nil && amount.GreaterThan(*l.MaxAmount) {
+		return appErr.ErrTransferAmountLimitExceeded
+	}
+	return nil
+}
+
+// CheckVelocity returns the error of the first cap that a transfer of amount would break, given the amount and
+// the number of transfers that left the account in the last 24 hours and the last hour
+func (l TransferLimits) CheckVelocity(amount, dailyAmount Money, hourlyCount int) error {
+	if l.MaxDailyAmount != nil && dailyAmount.Add(amount).GreaterThan(*l.MaxDailyAmount) {
+		return appErr.ErrDailyAmountLimitExceeded
+	}
+	if l.MaxHourlyCount != nil && hourlyCount >= *l.MaxHourlyCount {
+		return appErr.ErrHourlyCountLimitExceeded
+	}
+	return nil
+}
+
+// CurrencyTransferLimitsResponse represents the response body of the global transfer limit endpoints, which cap the
+// transfers of every account in CurrencyCode
+type CurrencyTransferLimitsResponse struct {
+	CurrencyCode string         `json:"currency_code"`
+	Limits       TransferLimits `json:"limits"`
+}
+
+// TransferLimitsResponse represents the response body of the transfer limit endpoints. Limits are the caps in force
+// for the account and Overrides the ones set for it in place of the global ones
+type TransferLimitsResponse struct {
+	AccountId int64          `json:"account_id"`
+	Limits    TransferLimits `json:"limits"`
+	Overrides TransferLimits `json:"overrides"`
+}
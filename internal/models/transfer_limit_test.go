@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func moneyPtr(s string) *Money {
+	m := MustParseMoney(s)
+	return &m
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestTransferLimits_Override(t *testing.T) {
+	global := TransferLimits{MaxAmount: moneyPtr("10000"), MaxDailyAmount: moneyPtr("50000"), MaxHourlyCount: intPtr(20)}
+
+	assert.Equal(t, global, global.Override(TransferLimits{}))
+	assert.Equal(t, TransferLimits{MaxAmount: moneyPtr("25000"), MaxDailyAmount: moneyPtr("50000"), MaxHourlyCount: intPtr(5)},
+		global.Override(TransferLimits{MaxAmount: moneyPtr("25000"), MaxHourlyCount: intPtr(5)}))
+	assert.Equal(t, TransferLimits{MaxHourlyCount: intPtr(5)}, TransferLimits{}.Override(TransferLimits{MaxHourlyCount: intPtr(5)}))
+}
+
+func TestTransferLimits_IsValid(t *testing.T) {
+	assert.True(t, TransferLimits{}.IsValid())
+	assert.True(t, TransferLimits{MaxAmount: moneyPtr("0.01"), MaxHourlyCount: intPtr(1)}.IsValid())
+	assert.False(t, TransferLimits{MaxAmount: moneyPtr("0")}.IsValid())
+	assert.False(t, TransferLimits{MaxDailyAmount: moneyPtr("-5")}.IsValid())
+	assert.False(t, TransferLimits{MaxHourlyCount: intPtr(0)}.IsValid())
+}
+
+func TestTransferLimits_Check(t *testing.T) {
+	limits := TransferLimits{MaxAmount: moneyPtr("10000"), MaxDailyAmount: moneyPtr("50000"), MaxHourlyCount: intPtr(20)}
+
+	tests := []struct {
+		name        string
+		amount      string
+		dailyAmount string
+		hourlyCount int
+		expectedErr error
+	}{
+		{name: "within every cap", amount: "10000", dailyAmount: "40000", hourlyCount: 19},
+		{name: "over the per-transfer cap", amount: "10000.01", dailyAmount: "0", expectedErr: appErr.ErrTransferAmountLimitExceeded},
+		{name: "over the daily cap", amount: "0.01", dailyAmount: "50000", expectedErr: appErr.ErrDailyAmountLimitExceeded},
+		{name: "hourly count reached", amount: "1", dailyAmount: "0", hourlyCount: 20, expectedErr: appErr.ErrHourlyCountLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount := MustParseMoney(tt.amount)
+			err := limits.CheckAmount(amount)
+			if err == nil {
+				err = limits.CheckVelocity(amount, MustParseMoney(tt.dailyAmount), tt.hourlyCount)
+			}
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+
+	assert.NoError(t, TransferLimits{}.CheckAmount(MustParseMoney("1000000")))
+	assert.NoError(t, TransferLimits{}.CheckVelocity(MustParseMoney("1000000"), MustParseMoney("1000000"), 1000))
+}
+
+func TestTransferLimits_JSON(t *testing.T) {
+	var limits TransferLimits
+	assert.NoError(t, json.Unmarshal([]byte(`{"max_amount":"25000","max_daily_amount":null,"max_hourly_count":5}`), &limits))
+	assert.Equal(t, TransferLimits{MaxAmount: moneyPtr("25000"), MaxHourlyCount: intPtr(5)}, limits)
+
+	body, err := json.Marshal(limits)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"max_amount":"25000.00000","max_daily_amount":null,"max_hourly_count":5}`, string(body))
+}
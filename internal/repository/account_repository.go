@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"log"
+	"net/http"
+	"time"
 )
 
 // AccountRepository handles account database operations
@@ -18,32 +21,124 @@ func NewAccountRepository(db *sql.DB) *AccountRepository {
 }
 
 type IAccountRepository interface {
-	CreateAccount(account models.Account) error
+	CreateAccount(args models.CreateAccountArgs) error
 	GetByAccountId(accountId int64) (*models.Account, error)
-	UpdateBalance(tx *sql.Tx, accountId int64, newBalance float64) error
-	GetBalanceForUpdate(tx *sql.Tx, accountId int64) (float64, error)
+	GetBalanceAsOf(accountId int64, asOf time.Time) (models.Money, error)
+	UpdateBalance(tx *sql.Tx, accountId int64, newBalance models.Money) error
+	UpdateHeldAmount(tx *sql.Tx, accountId int64, heldAmount models.Money) error
+	SetOverdraftLimit(accountId int64, limit models.Money) error
+	SetOwner(accountId, ownerClientId int64) error
+	GetForUpdate(tx *sql.Tx, accountId int64) (*models.Account, error)
+	SetStatus(accountId int64, from, to models.AccountStatus) error
+	CloseAccount(args models.CloseAccountArgs) (models.CloseAccountResponse, error)
 }
 
-// Create creates a new account
-func (r *AccountRepository) CreateAccount(account models.Account) error {
+// Create creates a new account. When an idempotency key is given, a replay of an already completed request is a no-op.
+// When a funding account is given, the account is opened empty and its balance is paid from the funding account
+// within the same transaction, so that it is recorded in the ledger like any other transfer
+func (r *AccountRepository) CreateAccount(args models.CreateAccountArgs) error {
 	fName := "AccountRepository.CreateAccount"
-	query := `INSERT INTO accounts (account_id, balance, created_at, updated_at) VALUES ($1, $2, $3, $4)`
-	_, err := r.db.Exec(query, account.AccountId, account.Balance, account.CreatedAt, account.UpdatedAt)
+	account, idem := args.Account, args.Idempotency
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return appErr.ErrAccountCreation
+	}
+	defer tx.Rollback()
+
+	if idem.Key != "" {
+		record, err := r.getIdempotencyRepository().Acquire(tx, idem, models.IdempotencyEndpointCreateAccount)
+		if err != nil {
+			if err == appErr.ErrIdempotencyKeyReused {
+				return err
+			}
+			return appErr.ErrAccountCreation
+		}
+		if record != nil {
+			return nil
+		}
+	}
+
+	funded := args.FundingAccountId > 0 && account.Balance.IsPositive()
+	initialBalance := account.Balance
+	if funded {
+		initialBalance = models.Money{}
+	}
+	query := `INSERT INTO accounts (account_id, balance, initial_balance, currency_code, owner_client_id, created_at, updated_at) VALUES ($1, $2, $2, $3, $4, $5, $6)`
+	_, err = tx.Exec(query, account.AccountId, initialBalance, account.CurrencyCode, account.OwnerClientId, account.CreatedAt, account.UpdatedAt)
 	if err != nil {
 		log.Printf("[%s] failed due to error %+v. ", fName, err)
 		return appErr.ErrAccountCreation
 	}
+
+	if funded {
+		if err := r.fundOpeningBalance(tx, args); err != nil {
+			return err
+		}
+	}
+
+	if idem.Key != "" {
+		if err := r.getIdempotencyRepository().Complete(tx, idem, models.IdempotencyEndpointCreateAccount, http.StatusOK, []byte(`{}`)); err != nil {
+			return appErr.ErrAccountCreation
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return appErr.ErrAccountCreation
+	}
+	return nil
+}
+
+// fundOpeningBalance pays the balance of the account just created within tx from its funding account. The funding
+// account must be open, in the same currency and able to cover the payment (a funded account or one with an overdraft limit)
+func (r *AccountRepository) fundOpeningBalance(tx *sql.Tx, args models.CreateAccountArgs) error {
+	fName := "AccountRepository.fundOpeningBalance"
+	account := args.Account
+
+	accounts, err := r.getTransactionRepository().lockAccounts(tx, args.FundingAccountId, account.AccountId)
+	if err != nil {
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		if err == appErr.ErrAccountNotFound {
+			return appErr.ErrFundingAccountUnavailable
+		}
+		return appErr.ErrAccountCreation
+	}
+	if accounts[args.FundingAccountId].CurrencyCode != account.CurrencyCode {
+		log.Printf("[%s] funding account %d does not hold %s", fName, args.FundingAccountId, account.CurrencyCode)
+		return appErr.ErrFundingAccountUnavailable
+	}
+
+	_, _, err = r.getTransactionRepository().applyEntry(tx, accounts, args.Reference, "", transferLegs{
+		debitAccountId:     args.FundingAccountId,
+		creditAccountId:    account.AccountId,
+		amount:             account.Balance,
+		currencyCode:       account.CurrencyCode,
+		creditAmount:       account.Balance,
+		creditCurrencyCode: account.CurrencyCode,
+		fxRate:             models.OneExchangeRate,
+	})
+	if err != nil {
+		log.Printf("[%s] failed to pay the opening balance of account %d: %v", fName, account.AccountId, err)
+		switch err {
+		case appErr.ErrSourceAccountClosed, appErr.ErrSourceAccountFrozen, appErr.ErrInsufficientBalance:
+			return appErr.ErrFundingAccountUnavailable
+		}
+		return appErr.ErrAccountCreation
+	}
 	return nil
 }
 
 // GetByAccountId retrieves an account by AccountId
 func (r *AccountRepository) GetByAccountId(accountId int64) (*models.Account, error) {
 	fName := "AccountRepository.GetByAccountId"
-	query := `SELECT id, account_id, balance, created_at, updated_at, deleted_at FROM accounts WHERE account_id = $1`
+	query := `SELECT id, account_id, balance, currency_code, status, held_amount, overdraft_limit, owner_client_id, created_at, updated_at, deleted_at FROM accounts WHERE account_id = $1`
 	row := r.db.QueryRow(query, accountId)
 
 	var account models.Account
-	err := row.Scan(&account.Id, &account.AccountId, &account.Balance, &account.CreatedAt, &account.UpdatedAt, &account.DeletedAt)
+	err := row.Scan(&account.Id, &account.AccountId, &account.Balance, &account.CurrencyCode, &account.Status, &account.HeldAmount, &account.OverdraftLimit,
+		&account.OwnerClientId, &account.CreatedAt, &account.UpdatedAt, &account.DeletedAt)
 	if err != nil {
 		log.Printf("[%s] failed due to error %+v. ", fName, err)
 		if err == sql.ErrNoRows {
@@ -55,8 +150,64 @@ func (r *AccountRepository) GetByAccountId(accountId int64) (*models.Account, er
 	return &account, nil
 }
 
+// GetBalanceAsOf reconstructs the balance of an account at asOf from its ledger: the balance recorded on its latest
+// entry at or before asOf. Only the entries after the latest end-of-day snapshot taken before asOf are looked at, and
+// without any the balance is the snapshot's. An account with neither is replayed from its initial balance, since
+// nothing was posted to it up to asOf. Everything is read from one snapshot so that concurrent transfers are not mixed in
+func (r *AccountRepository) GetBalanceAsOf(accountId int64, asOf time.Time) (models.Money, error) {
+	fName := "AccountRepository.GetBalanceAsOf"
+	var balance models.Money
+
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return balance, appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	// the snapshot of a day holds the balance at the end of it, so only the days before the day of asOf can be used.
+	// Snapshot days are UTC days, and the day is passed as a date so that the session time zone cannot shift it
+	asOf = asOf.UTC()
+	var snapshotDay sql.NullTime
+	var snapshotBalance models.Money
+	query := `SELECT snapshot_date, balance FROM balance_snapshots WHERE account_id = $1 AND snapshot_date < $2::DATE ORDER BY snapshot_date DESC LIMIT 1`
+	err = tx.QueryRow(query, accountId, asOf.Format(time.DateOnly)).Scan(&snapshotDay, &snapshotBalance)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("[%s] failed to read the latest snapshot: %v", fName, err)
+		return balance, appErr.ErrInternal
+	}
+
+	since := time.Time{}
+	if snapshotDay.Valid {
+		since = snapshotDay.Time.AddDate(0, 0, 1)
+	}
+	query = `SELECT available_balance FROM transactions WHERE account_id = $1 AND created_at >= $2 AND created_at <= $3 ORDER BY created_at DESC, id DESC LIMIT 1`
+	err = tx.QueryRow(query, accountId, since, asOf).Scan(&balance)
+	switch {
+	case err == nil:
+		return balance, nil
+	case err != sql.ErrNoRows:
+		log.Printf("[%s] failed to read the latest entry: %v", fName, err)
+		return balance, appErr.ErrInternal
+	case snapshotDay.Valid:
+		return snapshotBalance, nil
+	}
+
+	query = `SELECT a.initial_balance + COALESCE((SELECT SUM(CASE WHEN t.is_credit THEN t.amount ELSE -t.amount END)
+		FROM transactions t WHERE t.account_id = a.account_id AND t.created_at <= $2), 0)
+		FROM accounts a WHERE a.account_id = $1`
+	if err := tx.QueryRow(query, accountId, asOf).Scan(&balance); err != nil {
+		log.Printf("[%s] failed to replay the ledger: %v", fName, err)
+		if err == sql.ErrNoRows {
+			return balance, appErr.ErrAccountNotFound
+		}
+		return balance, appErr.ErrInternal
+	}
+	return balance, nil
+}
+
 // UpdateBalance updates an account's balance within a transaction
-func (r *AccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBalance float64) error {
+func (r *AccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBalance models.Money) error {
 	fName := "AccountRepository.UpdateBalance"
 	query := `UPDATE accounts SET balance = $1, updated_at = CURRENT_TIMESTAMP WHERE account_id = $2`
 	result, err := tx.Exec(query, newBalance, accountId)
@@ -79,21 +230,209 @@ func (r *AccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBalanc
 	return nil
 }
 
-// GetBalanceForUpdate retrieves an account's balance with row locking
-func (r *AccountRepository) GetBalanceForUpdate(tx *sql.Tx, accountId int64) (float64, error) {
-	fName := "AccountRepository.GetBalanceForUpdate"
-	query := `SELECT balance FROM accounts WHERE account_id = $1 FOR UPDATE`
+// UpdateHeldAmount updates the amount reserved by pending holds on an account within a transaction
+func (r *AccountRepository) UpdateHeldAmount(tx *sql.Tx, accountId int64, heldAmount models.Money) error {
+	fName := "AccountRepository.UpdateHeldAmount"
+	query := `UPDATE accounts SET held_amount = $1, updated_at = CURRENT_TIMESTAMP WHERE account_id = $2`
+	result, err := tx.Exec(query, heldAmount, accountId)
+	if err != nil {
+		log.Printf("[%s] failed to update held amount: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get rows affected: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return appErr.ErrAccountNotFound
+	}
+	return nil
+}
+
+// SetOverdraftLimit sets how far the balance of an open account may go below zero
+func (r *AccountRepository) SetOverdraftLimit(accountId int64, limit models.Money) error {
+	fName := "AccountRepository.SetOverdraftLimit"
+	query := `UPDATE accounts SET overdraft_limit = $1, updated_at = CURRENT_TIMESTAMP WHERE account_id = $2 AND status <> $3`
+	result, err := r.db.Exec(query, limit, accountId, models.AccountStatusClosed)
+	if err != nil {
+		log.Printf("[%s] failed to update overdraft limit: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get rows affected: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return appErr.ErrAccountClosed
+	}
+	return nil
+}
+
+// SetOwner hands an existing account over to the client ownerClientId. It fails with ErrClientNotFound when there is
+// no such client or all its keys were revoked
+func (r *AccountRepository) SetOwner(accountId, ownerClientId int64) error {
+	fName := "AccountRepository.SetOwner"
+	query := `UPDATE accounts SET owner_client_id = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE account_id = $1 AND EXISTS (SELECT 1 FROM api_keys WHERE client_id = $2 AND revoked_at IS NULL)`
+	result, err := r.db.Exec(query, accountId, ownerClientId)
+	if err != nil {
+		log.Printf("[%s] failed to update owner: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get rows affected: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return appErr.ErrClientNotFound
+	}
+	return nil
+}
+
+// GetForUpdate retrieves an account's balance, currency, status, held amount and overdraft limit with row locking.
+// A lock wait aborted by Postgres to break a deadlock is reported as errRetryable
+func (r *AccountRepository) GetForUpdate(tx *sql.Tx, accountId int64) (*models.Account, error) {
+	fName := "AccountRepository.GetForUpdate"
+	query := `SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts WHERE account_id = $1 FOR UPDATE`
 	row := tx.QueryRow(query, accountId)
 
-	var balance float64
-	err := row.Scan(&balance)
+	account := models.Account{AccountId: accountId}
+	err := row.Scan(&account.Balance, &account.CurrencyCode, &account.Status, &account.HeldAmount, &account.OverdraftLimit)
 	if err != nil {
 		log.Printf("[%s] failed due to error %+v. ", fName, err)
 		if err == sql.ErrNoRows {
-			return 0, appErr.ErrAccountNotFound
+			return nil, appErr.ErrAccountNotFound
 		}
-		return 0, appErr.ErrInternal
+		return nil, retryableOr(err, appErr.ErrInternal)
 	}
 
-	return balance, nil
+	return &account, nil
+}
+
+// SetStatus moves an account from one status to another. It fails with ErrInvalidStatusTransition when the
+// account is no longer in the from status, eg because it was changed concurrently
+func (r *AccountRepository) SetStatus(accountId int64, from, to models.AccountStatus) error {
+	fName := "AccountRepository.SetStatus"
+	query := `UPDATE accounts SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE account_id = $2 AND status = $3`
+	result, err := r.db.Exec(query, to, accountId, from)
+	if err != nil {
+		log.Printf("[%s] failed to update status: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get rows affected: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return appErr.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// CloseAccount closes an account for good. A positive balance is first moved to the sweep account under
+// args.Reference; without a sweep account only an account with a zero balance can be closed.
+// It is run again when Postgres aborts it because of a deadlock or a serialization failure
+func (r *AccountRepository) CloseAccount(args models.CloseAccountArgs) (models.CloseAccountResponse, error) {
+	var resp models.CloseAccountResponse
+	err := withRetry("AccountRepository.CloseAccount", func() error {
+		var err error
+		resp, err = r.closeAccount(args)
+		return err
+	})
+	return resp, err
+}
+
+// closeAccount runs a single attempt of CloseAccount
+func (r *AccountRepository) closeAccount(args models.CloseAccountArgs) (models.CloseAccountResponse, error) {
+	fName := "AccountRepository.CloseAccount"
+	var resp models.CloseAccountResponse
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return resp, appErr.ErrTransactionFailed
+	}
+	defer tx.Rollback()
+
+	accountIds := []int64{args.AccountId}
+	if args.SweepAccountId != 0 {
+		accountIds = append(accountIds, args.SweepAccountId)
+	}
+	accounts, err := r.getTransactionRepository().lockAccounts(tx, accountIds...)
+	if err != nil {
+		if err == errRetryable || err == appErr.ErrAccountNotFound {
+			return resp, err
+		}
+		return resp, appErr.ErrTransactionFailed
+	}
+
+	account := accounts[args.AccountId]
+	if account.Status == models.AccountStatusClosed {
+		return resp, appErr.ErrAccountClosed
+	}
+	if !account.HeldAmount.IsZero() {
+		return resp, appErr.ErrAccountHasPendingHolds
+	}
+
+	resp.AccountId = args.AccountId
+	resp.Status = models.AccountStatusClosed
+	if !account.Balance.IsZero() {
+		if args.SweepAccountId == 0 || account.Balance.IsNegative() {
+			return models.CloseAccountResponse{}, appErr.ErrAccountBalanceNotZero
+		}
+		sweepAccount := accounts[args.SweepAccountId]
+		if sweepAccount.CurrencyCode != account.CurrencyCode || !sweepAccount.Status.AllowsCredit() {
+			return models.CloseAccountResponse{}, appErr.ErrInvalidSweepAccount
+		}
+
+		sweptAmount := account.Balance
+		_, _, err := r.getTransactionRepository().applyEntry(tx, accounts, args.Reference, "", transferLegs{
+			debitAccountId:     args.AccountId,
+			creditAccountId:    args.SweepAccountId,
+			amount:             sweptAmount,
+			currencyCode:       account.CurrencyCode,
+			creditAmount:       sweptAmount,
+			creditCurrencyCode: account.CurrencyCode,
+			fxRate:             models.OneExchangeRate,
+		})
+		if err != nil {
+			return models.CloseAccountResponse{}, err
+		}
+
+		resp.SweepAccountId = args.SweepAccountId
+		resp.SweptAmount = &sweptAmount
+		resp.Reference = args.Reference
+	}
+
+	query := `UPDATE accounts SET status = $1, deleted_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE account_id = $2`
+	if _, err := tx.Exec(query, models.AccountStatusClosed, args.AccountId); err != nil {
+		log.Printf("[%s] failed to close account: %v", fName, err)
+		return models.CloseAccountResponse{}, appErr.ErrTransactionFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return models.CloseAccountResponse{}, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	return resp, nil
+}
+
+// getIdempotencyRepository returns an idempotency repository instance
+// This is a helper method to reserve idempotency keys within transactions
+func (r *AccountRepository) getIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{db: r.db}
+}
+
+// getTransactionRepository returns a transaction repository instance
+// This is a helper method to post the opening balance and the sweep transfer of an account within its transaction
+func (r *AccountRepository) getTransactionRepository() *TransactionRepository {
+	return &TransactionRepository{db: r.db}
 }
@@ -19,42 +19,232 @@ func TestAccountRepository_CreateAccount(t *testing.T) {
 	repo := NewAccountRepository(db)
 
 	account := models.Account{
-		AccountId: 101,
-		Balance:   1000.50,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		AccountId:     101,
+		Balance:       models.MustParseMoney("1000.50"),
+		CurrencyCode:  "EUR",
+		OwnerClientId: sql.NullInt64{Int64: 7, Valid: true},
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
 	}
+	idem := models.Idempotency{ClientId: 3, Key: "key-1", RequestHash: "hash-1"}
+	idemColumns := []string{"idempotency_key", "endpoint", "request_hash", "status_code", "response_body", "created_at"}
 
 	tests := []struct {
 		name        string
+		idem        models.Idempotency
 		mockQuery   func()
 		expectedErr error
 	}{
 		{
 			name: "success",
 			mockQuery: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO accounts").
-					WithArgs(account.AccountId, account.Balance, account.CreatedAt, account.UpdatedAt).
+					WithArgs(account.AccountId, account.Balance, account.CurrencyCode, account.OwnerClientId, account.CreatedAt, account.UpdatedAt).
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			expectedErr: nil,
 		},
 		{
 			name: "db error",
 			mockQuery: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec("INSERT INTO accounts").
-					WithArgs(account.AccountId, account.Balance, account.CreatedAt, account.UpdatedAt).
+					WithArgs(account.AccountId, account.Balance, account.CurrencyCode, account.OwnerClientId, account.CreatedAt, account.UpdatedAt).
 					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
 			},
 			expectedErr: appErr.ErrAccountCreation,
 		},
+		{
+			name: "success with idempotency key",
+			idem: idem,
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WithArgs(idem.ClientId, idem.Key, models.IdempotencyEndpointCreateAccount, idem.RequestHash).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys (.+) FOR UPDATE").
+					WithArgs(idem.ClientId, idem.Key, models.IdempotencyEndpointCreateAccount).
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(idem.Key, models.IdempotencyEndpointCreateAccount, idem.RequestHash, 0, nil, time.Now()))
+				mock.ExpectExec("INSERT INTO accounts").
+					WithArgs(account.AccountId, account.Balance, account.CurrencyCode, account.OwnerClientId, account.CreatedAt, account.UpdatedAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE idempotency_keys SET status_code").
+					WithArgs(200, []byte(`{}`), idem.ClientId, idem.Key, models.IdempotencyEndpointCreateAccount).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "replay of completed request",
+			idem: idem,
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(idem.Key, models.IdempotencyEndpointCreateAccount, idem.RequestHash, 200, []byte(`{}`), time.Now()))
+				mock.ExpectRollback()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "key reused with different request",
+			idem: idem,
+			mockQuery: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(idem.Key, models.IdempotencyEndpointCreateAccount, "other-hash", 200, []byte(`{}`), time.Now()))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrIdempotencyKeyReused,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockQuery()
-			err := repo.CreateAccount(account)
+			err := repo.CreateAccount(models.CreateAccountArgs{Account: account, Idempotency: tt.idem})
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAccountRepository_CreateAccount_Funded(t *testing.T) {
+	lockColumns := []string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}
+	reference := "TXN-0d6c1f8e-3a5b-4c2e-9f71-8b2d4e6a1c33"
+	now := time.Now()
+	account := models.Account{AccountId: 101, Balance: models.MustParseMoney("250.5"), CurrencyCode: "USD", CreatedAt: now, UpdatedAt: now}
+	args := models.CreateAccountArgs{Account: account, FundingAccountId: 1, Reference: reference}
+
+	tests := []struct {
+		name        string
+		args        models.CreateAccountArgs
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "opening balance paid from the funding account",
+			args: args,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").
+					WithArgs(int64(101), models.Money{}, "USD", sql.NullInt64{}, now, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("-1000.00000", "USD", "active", "0.00000", "1000000.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(101)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("-1250.5"), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("250.5"), int64(101)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(1), models.MustParseMoney("250.5"), "USD", models.MustParseMoney("-1250.5"), false, reference, sql.NullString{},
+						models.OneExchangeRate, models.MustParseMoney("250.5"), models.MustParseMoney("250.5"), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(101), models.MustParseMoney("250.5"), "USD", models.MustParseMoney("250.5"), true, reference, sql.NullString{},
+						models.OneExchangeRate, models.MustParseMoney("250.5"), models.MustParseMoney("250.5"), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "empty account is not funded",
+			args: models.CreateAccountArgs{Account: models.Account{AccountId: 101, CurrencyCode: "USD", CreatedAt: now, UpdatedAt: now}, FundingAccountId: 1},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").
+					WithArgs(int64(101), models.Money{}, "USD", sql.NullInt64{}, now, now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "funding account not found",
+			args: args,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+		{
+			name: "funding account in another currency",
+			args: args,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "EUR", "active", "0.00000", "1000000.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(101)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+		{
+			name: "frozen funding account",
+			args: args,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "frozen", "0.00000", "1000000.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(101)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+		{
+			name: "funding account cannot cover the balance",
+			args: args,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO accounts").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("100.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(101)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			err := NewAccountRepository(db).CreateAccount(tt.args)
 			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -75,11 +265,11 @@ func TestAccountRepository_GetByAccountId(t *testing.T) {
 		{
 			name: "success",
 			mockQuery: func() {
-				mock.ExpectQuery("SELECT id, account_id, balance, created_at, updated_at, deleted_at FROM accounts").
+				mock.ExpectQuery("SELECT id, account_id, balance, currency_code, status, held_amount, overdraft_limit, owner_client_id, created_at, updated_at, deleted_at FROM accounts").
 					WithArgs(accountId).
 					WillReturnRows(sqlmock.NewRows([]string{
-						"id", "account_id", "balance", "created_at", "updated_at", "deleted_at",
-					}).AddRow(1, accountId, 500.0, time.Now(), time.Now(), sql.NullTime{}))
+						"id", "account_id", "balance", "currency_code", "status", "held_amount", "overdraft_limit", "owner_client_id", "created_at", "updated_at", "deleted_at",
+					}).AddRow(1, accountId, 500.0, "EUR", "frozen", "20.00000", "50.00000", int64(7), time.Now(), time.Now(), sql.NullTime{}))
 			},
 			expectedErr: nil,
 			expectNil:   false,
@@ -115,17 +305,65 @@ func TestAccountRepository_GetByAccountId(t *testing.T) {
 				assert.Nil(t, acc)
 			} else {
 				assert.NotNil(t, acc)
+				assert.Equal(t, "EUR", acc.CurrencyCode)
+				assert.Equal(t, models.AccountStatusFrozen, acc.Status)
+				assert.Equal(t, models.MustParseMoney("480"), acc.AvailableBalance())
+				assert.Equal(t, models.MustParseMoney("530"), acc.AvailableToSpend())
+				assert.True(t, acc.IsOwnedBy(7))
 			}
 		})
 	}
 }
 
+func TestAccountRepository_SetOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "owner set",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE accounts SET owner_client_id = \$2, updated_at = CURRENT_TIMESTAMP\s+WHERE account_id = \$1 AND EXISTS \(SELECT 1 FROM api_keys WHERE client_id = \$2 AND revoked_at IS NULL\)`).
+					WithArgs(int64(101), int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "unknown client or all its keys revoked",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE accounts SET owner_client_id").WithArgs(int64(101), int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: appErr.ErrClientNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE accounts SET owner_client_id").WithArgs(int64(101), int64(7)).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			err = NewAccountRepository(db).SetOwner(101, 7)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestUpdateBalance(t *testing.T) {
 	type testCase struct {
 		name        string
 		setupMock   func(sqlmock.Sqlmock)
 		accountId   int64
-		newBalance  float64
+		newBalance  models.Money
 		expectedErr error
 	}
 
@@ -135,12 +373,12 @@ func TestUpdateBalance(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE accounts").
-					WithArgs(100.50, int64(1)).
+					WithArgs(models.MustParseMoney("100.50"), int64(1)).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 				mock.ExpectCommit()
 			},
 			accountId:   1,
-			newBalance:  100.50,
+			newBalance:  models.MustParseMoney("100.50"),
 			expectedErr: nil,
 		},
 		{
@@ -148,12 +386,12 @@ func TestUpdateBalance(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE accounts").
-					WithArgs(200.75, int64(2)).
+					WithArgs(models.MustParseMoney("200.75"), int64(2)).
 					WillReturnResult(sqlmock.NewResult(0, 0))
 				mock.ExpectRollback()
 			},
 			accountId:   2,
-			newBalance:  200.75,
+			newBalance:  models.MustParseMoney("200.75"),
 			expectedErr: appErr.ErrAccountNotFound,
 		},
 		{
@@ -161,12 +399,12 @@ func TestUpdateBalance(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE accounts").
-					WithArgs(300.00, int64(3)).
+					WithArgs(models.MustParseMoney("300.00"), int64(3)).
 					WillReturnError(errors.New("query error"))
 				mock.ExpectRollback()
 			},
 			accountId:   3,
-			newBalance:  300.00,
+			newBalance:  models.MustParseMoney("300.00"),
 			expectedErr: appErr.ErrInternal,
 		},
 		{
@@ -174,12 +412,12 @@ func TestUpdateBalance(t *testing.T) {
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
 				mock.ExpectExec("UPDATE accounts").
-					WithArgs(400.00, int64(4)).
+					WithArgs(models.MustParseMoney("400.00"), int64(4)).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("rows affected error")))
 				mock.ExpectRollback()
 			},
 			accountId:   4,
-			newBalance:  400.00,
+			newBalance:  models.MustParseMoney("400.00"),
 			expectedErr: appErr.ErrInternal,
 		},
 	}
@@ -210,56 +448,56 @@ func TestUpdateBalance(t *testing.T) {
 	}
 }
 
-func TestGetBalanceForUpdate(t *testing.T) {
+func TestGetForUpdate(t *testing.T) {
 	type testCase struct {
 		name        string
 		setupMock   func(sqlmock.Sqlmock)
 		accountId   int64
-		expectedBal float64
+		expectedBal models.Money
 		expectedErr error
 	}
 
 	tests := []testCase{
 		{
-			name: "successful balance fetch",
+			name: "successful fetch",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				rows := sqlmock.NewRows([]string{"balance"}).AddRow(150.75)
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				rows := sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow(150.75, "USD", "frozen", "0.00000", "0.00000")
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(int64(1)).
 					WillReturnRows(rows)
 				mock.ExpectCommit()
 			},
 			accountId:   1,
-			expectedBal: 150.75,
+			expectedBal: models.MustParseMoney("150.75"),
 			expectedErr: nil,
 		},
 		{
 			name: "account not found",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(int64(2)).
 					WillReturnError(sql.ErrNoRows)
 				mock.ExpectRollback()
 			},
 			accountId:   2,
-			expectedBal: 0,
+			expectedBal: models.Money{},
 			expectedErr: appErr.ErrAccountNotFound,
 		},
 		{
 			name: "scan error",
 			setupMock: func(mock sqlmock.Sqlmock) {
 				mock.ExpectBegin()
-				rows := sqlmock.NewRows([]string{"balance"}).
-					AddRow(nil)
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				rows := sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).
+					AddRow(nil, "USD", "active", "0.00000", "0.00000")
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(int64(3)).
 					WillReturnRows(rows)
 				mock.ExpectRollback()
 			},
 			accountId:   3,
-			expectedBal: 0,
+			expectedBal: models.Money{},
 			expectedErr: appErr.ErrInternal,
 		},
 	}
@@ -276,10 +514,15 @@ func TestGetBalanceForUpdate(t *testing.T) {
 			assert.NoError(t, err)
 
 			repo := NewAccountRepository(db)
-			balance, err := repo.GetBalanceForUpdate(tx, tc.accountId)
+			account, err := repo.GetForUpdate(tx, tc.accountId)
 
-			assert.Equal(t, tc.expectedBal, balance)
 			assert.Equal(t, tc.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, tc.expectedBal, account.Balance)
+				assert.Equal(t, models.AccountStatusFrozen, account.Status)
+			} else {
+				assert.Nil(t, account)
+			}
 
 			if err == nil {
 				assert.NoError(t, tx.Commit())
@@ -291,3 +534,342 @@ func TestGetBalanceForUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountRepository_SetStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "status changed",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE accounts SET status = \\$1, updated_at = CURRENT_TIMESTAMP WHERE account_id = \\$2 AND status = \\$3").
+					WithArgs(models.AccountStatusFrozen, int64(7), models.AccountStatusActive).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "account no longer in the expected status",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE accounts SET status").
+					WithArgs(models.AccountStatusFrozen, int64(7), models.AccountStatusActive).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: appErr.ErrInvalidStatusTransition,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE accounts SET status").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			err = NewAccountRepository(db).SetStatus(7, models.AccountStatusActive, models.AccountStatusFrozen)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAccountRepository_SetOverdraftLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "limit set", rowsAffected: 1},
+		{name: "account closed", rowsAffected: 0, expectedErr: appErr.ErrAccountClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectExec("UPDATE accounts SET overdraft_limit = \\$1, updated_at = CURRENT_TIMESTAMP WHERE account_id = \\$2 AND status <> \\$3").
+				WithArgs(models.MustParseMoney("500"), int64(7), models.AccountStatusClosed).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err = NewAccountRepository(db).SetOverdraftLimit(7, models.MustParseMoney("500"))
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAccountRepository_GetBalanceAsOf(t *testing.T) {
+	asOf := time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC)
+	day := "2025-03-31"
+	snapshotDay := time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)
+	// the entries are read from the day after the snapshot
+	since := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	snapshotQuery := `SELECT snapshot_date, balance FROM balance_snapshots WHERE account_id = \$1 AND snapshot_date < \$2::DATE`
+	entryQuery := `SELECT available_balance FROM transactions WHERE account_id = \$1 AND created_at >= \$2 AND created_at <= \$3 ORDER BY created_at DESC, id DESC LIMIT 1`
+	replayQuery := `SELECT a.initial_balance \+ COALESCE`
+
+	tests := []struct {
+		name        string
+		asOf        time.Time
+		setupMock   func(sqlmock.Sqlmock)
+		expected    models.Money
+		expectedErr error
+	}{
+		{
+			name: "latest entry after the snapshot",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WithArgs(int64(7), day).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot_date", "balance"}).AddRow(snapshotDay, "100.00000"))
+				mock.ExpectQuery(entryQuery).WithArgs(int64(7), since, asOf).
+					WillReturnRows(sqlmock.NewRows([]string{"available_balance"}).AddRow("75.00000"))
+				mock.ExpectRollback()
+			},
+			expected: models.NewMoney(75),
+		},
+		{
+			name: "day of asOf taken in UTC",
+			// 1 April in Sydney, still 31 March in UTC
+			asOf: asOf.In(time.FixedZone("AEDT", 11*60*60)),
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WithArgs(int64(7), day).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot_date", "balance"}).AddRow(snapshotDay, "100.00000"))
+				mock.ExpectQuery(entryQuery).WithArgs(int64(7), since, asOf).
+					WillReturnRows(sqlmock.NewRows([]string{"available_balance"}).AddRow("75.00000"))
+				mock.ExpectRollback()
+			},
+			expected: models.NewMoney(75),
+		},
+		{
+			name: "snapshot without later entries",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WithArgs(int64(7), day).
+					WillReturnRows(sqlmock.NewRows([]string{"snapshot_date", "balance"}).AddRow(snapshotDay, "100.00000"))
+				mock.ExpectQuery(entryQuery).WithArgs(int64(7), since, asOf).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expected: models.NewMoney(100),
+		},
+		{
+			name: "latest entry without a snapshot",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WithArgs(int64(7), day).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(entryQuery).WithArgs(int64(7), time.Time{}, asOf).
+					WillReturnRows(sqlmock.NewRows([]string{"available_balance"}).AddRow("60.00000"))
+				mock.ExpectRollback()
+			},
+			expected: models.NewMoney(60),
+		},
+		{
+			name: "replayed from the initial balance",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WithArgs(int64(7), day).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(entryQuery).WithArgs(int64(7), time.Time{}, asOf).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(replayQuery).WithArgs(int64(7), asOf).
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("50.00000"))
+				mock.ExpectRollback()
+			},
+			expected: models.NewMoney(50),
+		},
+		{
+			name: "account not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(entryQuery).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(replayQuery).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(snapshotQuery).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			if tt.asOf.IsZero() {
+				tt.asOf = asOf
+			}
+			balance, err := NewAccountRepository(db).GetBalanceAsOf(7, tt.asOf)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, balance)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAccountRepository_CloseAccount(t *testing.T) {
+	lockColumns := []string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+
+	tests := []struct {
+		name         string
+		args         models.CloseAccountArgs
+		setupMock    func(sqlmock.Sqlmock)
+		expectedErr  error
+		expectedResp models.CloseAccountResponse
+	}{
+		{
+			name: "zero balance",
+			args: models.CloseAccountArgs{AccountId: 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "frozen", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET status = \\$1, deleted_at = CURRENT_TIMESTAMP").
+					WithArgs(models.AccountStatusClosed, int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedResp: models.CloseAccountResponse{AccountId: 7, Status: models.AccountStatusClosed},
+		},
+		{
+			name: "balance swept to another account",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 3, Reference: reference},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("10.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("25.50000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(0), int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("35.5"), int64(3)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(7), models.MustParseMoney("25.5"), "USD", models.NewMoney(0), false, reference, sql.NullString{},
+						models.OneExchangeRate, models.MustParseMoney("25.5"), models.MustParseMoney("25.5"), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(3), models.MustParseMoney("25.5"), "USD", models.MustParseMoney("35.5"), true, reference, sql.NullString{},
+						models.OneExchangeRate, models.MustParseMoney("25.5"), models.MustParseMoney("25.5"), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectExec("UPDATE accounts SET status = \\$1, deleted_at = CURRENT_TIMESTAMP").
+					WithArgs(models.AccountStatusClosed, int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedResp: func() models.CloseAccountResponse {
+				swept := models.MustParseMoney("25.5")
+				return models.CloseAccountResponse{AccountId: 7, Status: models.AccountStatusClosed, SweepAccountId: 3, SweptAmount: &swept, Reference: reference}
+			}(),
+		},
+		{
+			name: "balance left without a sweep account",
+			args: models.CloseAccountArgs{AccountId: 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.01000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrAccountBalanceNotZero,
+		},
+		{
+			name: "frozen account cannot be swept",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8, Reference: reference},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("25.50000", "USD", "frozen", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(8)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrSourceAccountFrozen,
+		},
+		{
+			name: "sweep account closed concurrently",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8, Reference: reference},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("25.50000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(8)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "closed", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrInvalidSweepAccount,
+		},
+		{
+			name: "pending holds",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8, Reference: reference},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("25.50000", "USD", "active", "5.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(8)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrAccountHasPendingHolds,
+		},
+		{
+			name: "already closed",
+			args: models.CloseAccountArgs{AccountId: 7},
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(lockColumns).AddRow("0.00000", "USD", "closed", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrAccountClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			resp, err := NewAccountRepository(db).CloseAccount(tt.args)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"log"
+)
+
+// apiKeyColumns is the column list read by scanApiKey
+const apiKeyColumns = `id, client_id, name, key_prefix, scopes, created_at, revoked_at`
+
+// ApiKeyRepository handles API key database operations
+type ApiKeyRepository struct {
+	db *sql.DB
+}
+
+// NewApiKeyRepository creates a new API key repository
+func NewApiKeyRepository(db *sql.DB) *ApiKeyRepository {
+	return &ApiKeyRepository{db: db}
+}
+
+type IApiKeyRepository interface {
+	CreateApiKey(key models.ApiKey, keyHash string) (*models.ApiKey, error)
+	GetActiveApiKey(keyHash string) (*models.ApiKey, error)
+	ListApiKeys() ([]models.ApiKey, error)
+	RevokeApiKey(id int64) error
+	SetSigningSecret(id int64, secret string) error
+	GetActiveSigningKey(id int64) (*models.ApiKey, string, error)
+}
+
+// CreateApiKey stores a new API key under the hash of the key, returning it with its id and creation time. The key
+// joins the client key.ClientId, which fails with ErrClientNotFound when no key was ever issued to it, or starts a
+// new client numbered like the key when key.ClientId is zero
+func (r *ApiKeyRepository) CreateApiKey(key models.ApiKey, keyHash string) (*models.ApiKey, error) {
+	fName := "ApiKeyRepository.CreateApiKey"
+	query := `WITH new_key AS (SELECT nextval(pg_get_serial_sequence('api_keys', 'id')) AS id)
+		INSERT INTO api_keys (id, client_id, name, key_prefix, key_hash, scopes)
+		SELECT new_key.id, COALESCE($5::BIGINT, new_key.id), $1, $2, $3, $4 FROM new_key
+		WHERE $5::BIGINT IS NULL OR EXISTS (SELECT 1 FROM api_keys WHERE client_id = $5::BIGINT)
+		RETURNING ` + apiKeyColumns
+
+	clientId := sql.NullInt64{Int64: key.ClientId, Valid: key.ClientId != 0}
+	created, err := scanApiKey(r.db.QueryRow(query, key.Name, key.Prefix, keyHash, pq.Array(scopeStrings(key.Scopes)), clientId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrClientNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return created, nil
+}
+
+// GetActiveApiKey retrieves the API key with the given hash, unless it was revoked
+func (r *ApiKeyRepository) GetActiveApiKey(keyHash string) (*models.ApiKey, error) {
+	fName := "ApiKeyRepository.GetActiveApiKey"
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	key, err := scanApiKey(r.db.QueryRow(query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrApiKeyNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return key, nil
+}
+
+// ListApiKeys returns every API key, revoked ones included, oldest first
+func (r *ApiKeyRepository) ListApiKeys() ([]models.ApiKey, error) {
+	fName := "ApiKeyRepository.ListApiKeys"
+	rows, err := r.db.Query(`SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY id`)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	var keys []models.ApiKey
+	for rows.Next() {
+		key, err := scanApiKey(rows)
+		if err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		keys = append(keys, *key)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return keys, nil
+}
+
+// RevokeApiKey revokes the API key with the given id, which fails with ErrApiKeyNotFound when there is no such
+// key or it was already revoked
+func (r *ApiKeyRepository) RevokeApiKey(id int64) error {
+	fName := "ApiKeyRepository.RevokeApiKey"
+	result, err := r.db.Exec(`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to read the revoked count: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if revoked == 0 {
+		return appErr.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// SetSigningSecret sets the secret that the API key with the given id signs requests with, replacing any previous
+// one. It fails with ErrApiKeyNotFound when there is no such key or it was revoked
+func (r *ApiKeyRepository) SetSigningSecret(id int64, secret string) error {
+	fName := "ApiKeyRepository.SetSigningSecret"
+	result, err := r.db.Exec(`UPDATE api_keys SET signing_secret = $2 WHERE id = $1 AND revoked_at IS NULL`, id, secret)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to read the updated count: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if updated == 0 {
+		return appErr.ErrApiKeyNotFound
+	}
+	return nil
+}
+
+// GetActiveSigningKey retrieves the API key with the given id together with its signing secret. It fails with
+// ErrApiKeyNotFound when there is no such key, it was revoked or it has no signing secret
+func (r *ApiKeyRepository) GetActiveSigningKey(id int64) (*models.ApiKey, string, error) {
+	fName := "ApiKeyRepository.GetActiveSigningKey"
+	query := `SELECT ` + apiKeyColumns + `, signing_secret FROM api_keys WHERE id = $1 AND revoked_at IS NULL AND signing_secret IS NOT NULL`
+
+	var secret string
+	key, err := scanApiKey(r.db.QueryRow(query, id), &secret)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, "", appErr.ErrApiKeyNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, "", appErr.ErrInternal
+	}
+	return key, secret, nil
+}
+
+// scanApiKey reads a row of apiKeyColumns, followed by the columns of extra when a query selects more
+func scanApiKey(row interface{ Scan(dest ...any) error }, extra ...any) (*models.ApiKey, error) {
+	var key models.ApiKey
+	var scopes pq.StringArray
+	var revokedAt sql.NullTime
+	if err := row.Scan(append([]any{&key.Id, &key.ClientId, &key.Name, &key.Prefix, &scopes, &key.CreatedAt, &revokedAt}, extra...)...); err != nil {
+		return nil, err
+	}
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, models.ApiKeyScope(scope))
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
+
+// scopeStrings converts scopes for storing them as a TEXT[]
+func scopeStrings(scopes []models.ApiKeyScope) []string {
+	strs := make([]string, len(scopes))
+	for i, scope := range scopes {
+		strs[i] = string(scope)
+	}
+	return strs
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var apiKeyRowColumns = []string{"id", "client_id", "name", "key_prefix", "scopes", "created_at", "revoked_at"}
+
+func TestApiKeyRepository_CreateApiKey(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	scopes := pq.Array([]string{"accounts:read", "transactions:write"})
+
+	tests := []struct {
+		name        string
+		clientId    int64
+		setupMock   func(sqlmock.Sqlmock)
+		expected    *models.ApiKey
+		expectedErr error
+	}{
+		{
+			name: "key of a new client",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`INSERT INTO api_keys \(id, client_id, name, key_prefix, key_hash, scopes\)\s+SELECT new_key.id, COALESCE\(\$5::BIGINT, new_key.id\), \$1, \$2, \$3, \$4 FROM new_key`).
+					WithArgs("payments", "tak_abcdefgh", "hash", scopes, sql.NullInt64{}).
+					WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).AddRow(int64(1), int64(1), "payments", "tak_abcdefgh", "{accounts:read,transactions:write}", createdAt, nil))
+			},
+			expected: &models.ApiKey{Id: 1, ClientId: 1, Name: "payments", Prefix: "tak_abcdefgh", Scopes: []models.ApiKeyScope{models.ScopeAccountsRead, models.ScopeTransactionsWrite}, CreatedAt: createdAt},
+		},
+		{
+			name:     "key rotated for an existing client",
+			clientId: 1,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`WHERE \$5::BIGINT IS NULL OR EXISTS \(SELECT 1 FROM api_keys WHERE client_id = \$5::BIGINT\)`).
+					WithArgs("payments", "tak_abcdefgh", "hash", scopes, sql.NullInt64{Int64: 1, Valid: true}).
+					WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).AddRow(int64(4), int64(1), "payments", "tak_abcdefgh", "{accounts:read,transactions:write}", createdAt, nil))
+			},
+			expected: &models.ApiKey{Id: 4, ClientId: 1, Name: "payments", Prefix: "tak_abcdefgh", Scopes: []models.ApiKeyScope{models.ScopeAccountsRead, models.ScopeTransactionsWrite}, CreatedAt: createdAt},
+		},
+		{
+			name:     "unknown client",
+			clientId: 9,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO api_keys").WithArgs("payments", "tak_abcdefgh", "hash", scopes, sql.NullInt64{Int64: 9, Valid: true}).
+					WillReturnRows(sqlmock.NewRows(apiKeyRowColumns))
+			},
+			expectedErr: appErr.ErrClientNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("INSERT INTO api_keys").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(mock)
+
+			key, err := NewApiKeyRepository(db).CreateApiKey(models.ApiKey{
+				ClientId: tt.clientId,
+				Name:     "payments",
+				Prefix:   "tak_abcdefgh",
+				Scopes:   []models.ApiKeyScope{models.ScopeAccountsRead, models.ScopeTransactionsWrite},
+			}, "hash")
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, key)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestApiKeyRepository_GetActiveApiKey(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    *models.ApiKey
+		expectedErr error
+	}{
+		{
+			name: "active key",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM api_keys WHERE key_hash = \$1 AND revoked_at IS NULL`).WithArgs("hash").
+					WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).AddRow(int64(1), int64(1), "payments", "tak_abcdefgh", "{admin}", createdAt, nil))
+			},
+			expected: &models.ApiKey{Id: 1, ClientId: 1, Name: "payments", Prefix: "tak_abcdefgh", Scopes: []models.ApiKeyScope{models.ScopeAdmin}, CreatedAt: createdAt},
+		},
+		{
+			name: "unknown or revoked key",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_keys").WithArgs("hash").WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrApiKeyNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_keys").WithArgs("hash").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			key, err := NewApiKeyRepository(db).GetActiveApiKey("hash")
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, key)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestApiKeyRepository_ListApiKeys(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	revokedAt := time.Date(2025, 4, 2, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT id, client_id, name, key_prefix, scopes, created_at, revoked_at FROM api_keys ORDER BY id`).
+		WillReturnRows(sqlmock.NewRows(apiKeyRowColumns).
+			AddRow(int64(1), int64(1), "old", "tak_11111111", "{accounts:read}", createdAt, revokedAt).
+			AddRow(int64(2), int64(1), "payments", "tak_22222222", "{transactions:write}", createdAt, nil))
+
+	keys, err := NewApiKeyRepository(db).ListApiKeys()
+	assert.NoError(t, err)
+	assert.Equal(t, []models.ApiKey{
+		{Id: 1, ClientId: 1, Name: "old", Prefix: "tak_11111111", Scopes: []models.ApiKeyScope{models.ScopeAccountsRead}, CreatedAt: createdAt, RevokedAt: &revokedAt},
+		{Id: 2, ClientId: 1, Name: "payments", Prefix: "tak_22222222", Scopes: []models.ApiKeyScope{models.ScopeTransactionsWrite}, CreatedAt: createdAt},
+	}, keys)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApiKeyRepository_RevokeApiKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "revoked",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE id = \$1 AND revoked_at IS NULL`).
+					WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "unknown or already revoked",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys").WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: appErr.ErrApiKeyNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys").WithArgs(int64(1)).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			err = NewApiKeyRepository(db).RevokeApiKey(1)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestApiKeyRepository_SetSigningSecret(t *testing.T) {
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "set",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE api_keys SET signing_secret = \$2 WHERE id = \$1 AND revoked_at IS NULL`).
+					WithArgs(int64(1), "tas_secret").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "unknown or revoked",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys").WithArgs(int64(1), "tas_secret").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: appErr.ErrApiKeyNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys").WithArgs(int64(1), "tas_secret").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			err = NewApiKeyRepository(db).SetSigningSecret(1, "tas_secret")
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestApiKeyRepository_GetActiveSigningKey(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	signingKeyRowColumns := append(append([]string{}, apiKeyRowColumns...), "signing_secret")
+
+	tests := []struct {
+		name           string
+		setupMock      func(sqlmock.Sqlmock)
+		expected       *models.ApiKey
+		expectedSecret string
+		expectedErr    error
+	}{
+		{
+			name: "key with a signing secret",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`, signing_secret FROM api_keys WHERE id = \$1 AND revoked_at IS NULL AND signing_secret IS NOT NULL`).WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(signingKeyRowColumns).AddRow(int64(1), int64(1), "payments", "tak_abcdefgh", "{transactions:write}", createdAt, nil, "tas_secret"))
+			},
+			expected:       &models.ApiKey{Id: 1, ClientId: 1, Name: "payments", Prefix: "tak_abcdefgh", Scopes: []models.ApiKeyScope{models.ScopeTransactionsWrite}, CreatedAt: createdAt},
+			expectedSecret: "tas_secret",
+		},
+		{
+			name: "unknown, revoked or without signing secret",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_keys").WithArgs(int64(1)).WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrApiKeyNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_keys").WithArgs(int64(1)).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			key, secret, err := NewApiKeyRepository(db).GetActiveSigningKey(1)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, key)
+			assert.Equal(t, tt.expectedSecret, secret)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"log"
+	"time"
+)
+
+// BalanceSnapshotRepository stores the end-of-day balances that point-in-time balance lookups start from
+type BalanceSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewBalanceSnapshotRepository creates a new balance snapshot repository
+func NewBalanceSnapshotRepository(db *sql.DB) *BalanceSnapshotRepository {
+	return &BalanceSnapshotRepository{db: db}
+}
+
+type IBalanceSnapshotRepository interface {
+	GetLatestSnapshotDay() (*time.Time, error)
+	SaveSnapshots(day time.Time) (int64, error)
+}
+
+// GetLatestSnapshotDay returns the latest UTC day that snapshots were taken for, nil when none was taken yet
+func (r *BalanceSnapshotRepository) GetLatestSnapshotDay() (*time.Time, error) {
+	fName := "BalanceSnapshotRepository.GetLatestSnapshotDay"
+	var day sql.NullTime
+	if err := r.db.QueryRow(`SELECT MAX(snapshot_date) FROM balance_snapshots`).Scan(&day); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	if !day.Valid {
+		return nil, nil
+	}
+	latest := day.Time.UTC()
+	return &latest, nil
+}
+
+// SaveSnapshots records the balance at the end of the given UTC day of every account that existed and was not yet
+// closed that day: the balance recorded on its latest entry of the day or before, or its initial balance without
+// one. Snapshots already taken for the day are kept. It returns the number of snapshots taken
+func (r *BalanceSnapshotRepository) SaveSnapshots(day time.Time) (int64, error) {
+	fName := "BalanceSnapshotRepository.SaveSnapshots"
+	dayEnd := day.AddDate(0, 0, 1)
+	query := `INSERT INTO balance_snapshots (account_id, snapshot_date, balance)
+		SELECT a.account_id, $1, COALESCE(
+			(SELECT t.available_balance FROM transactions t WHERE t.account_id = a.account_id AND t.created_at < $2 ORDER BY t.created_at DESC, t.id DESC LIMIT 1),
+			a.initial_balance)
+		FROM accounts a
+		WHERE a.created_at < $2 AND (a.deleted_at IS NULL OR a.deleted_at >= $1)
+		ON CONFLICT (account_id, snapshot_date) DO NOTHING`
+
+	result, err := r.db.Exec(query, day, dayEnd)
+	if err != nil {
+		log.Printf("[%s] failed to take the snapshots of %s: %v", fName, day.Format(time.DateOnly), err)
+		return 0, appErr.ErrInternal
+	}
+	saved, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get rows affected: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	return saved, nil
+}
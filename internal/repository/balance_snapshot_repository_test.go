@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceSnapshotRepository_GetLatestSnapshotDay(t *testing.T) {
+	day := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    *time.Time
+		expectedErr error
+	}{
+		{
+			name: "latest day",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT MAX\(snapshot_date\) FROM balance_snapshots`).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(day))
+			},
+			expected: &day,
+		},
+		{
+			name: "no snapshot yet",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT MAX\(snapshot_date\) FROM balance_snapshots`).
+					WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+			},
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT MAX").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			latest, err := NewBalanceSnapshotRepository(db).GetLatestSnapshotDay()
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, latest)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestBalanceSnapshotRepository_SaveSnapshots(t *testing.T) {
+	day := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	dayEnd := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    int64
+		expectedErr error
+	}{
+		{
+			name: "snapshots taken",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO balance_snapshots (.+) FROM accounts a WHERE a.created_at < \$2 AND \(a.deleted_at IS NULL OR a.deleted_at >= \$1\) ON CONFLICT`).
+					WithArgs(day, dayEnd).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+			expected: 3,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO balance_snapshots").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			saved, err := NewBalanceSnapshotRepository(db).SaveSnapshots(day)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, saved)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
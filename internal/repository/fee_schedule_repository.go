@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+)
+
+// FeeScheduleRepository handles the global fee schedule of each currency and the ones set for single accounts
+type FeeScheduleRepository struct {
+	db *sql.DB
+}
+
+// NewFeeScheduleRepository creates a new fee schedule repository
+func NewFeeScheduleRepository(db *sql.DB) *FeeScheduleRepository {
+	return &FeeScheduleRepository{db: db}
+}
+
+type IFeeScheduleRepository interface {
+	GetByAccountId(accountId int64) (*models.FeeSchedule, error)
+	Upsert(accountId int64, schedule models.FeeSchedule) error
+	Delete(accountId int64) error
+	GetByCurrency(currencyCode string) (*models.FeeSchedule, error)
+	UpsertCurrency(currencyCode string, schedule models.FeeSchedule) error
+	DeleteCurrency(currencyCode string) error
+}
+
+// GetByAccountId returns the fee schedule set for an account, nil when the account has none of its own
+func (r *FeeScheduleRepository) GetByAccountId(accountId int64) (*models.FeeSchedule, error) {
+	fName := "FeeScheduleRepository.GetByAccountId"
+	query := `SELECT schedule FROM account_fee_schedules WHERE account_id = $1`
+
+	var body []byte
+	err := r.db.QueryRow(query, accountId).Scan(&body)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	var schedule models.FeeSchedule
+	if err := json.Unmarshal(body, &schedule); err != nil {
+		log.Printf("[%s] failed to decode schedule of account %d: %v", fName, accountId, err)
+		return nil, appErr.ErrInternal
+	}
+	return &schedule, nil
+}
+
+// Upsert replaces the fee schedule set for an account
+func (r *FeeScheduleRepository) Upsert(accountId int64, schedule models.FeeSchedule) error {
+	fName := "FeeScheduleRepository.Upsert"
+	body, err := json.Marshal(schedule)
+	if err != nil {
+		log.Printf("[%s] failed to encode schedule: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	query := `INSERT INTO account_fee_schedules (account_id, schedule) VALUES ($1, $2)
+		ON CONFLICT (account_id) DO UPDATE SET schedule = EXCLUDED.schedule, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Exec(query, accountId, body); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// Delete removes the fee schedule set for an account, so that the global one applies again
+func (r *FeeScheduleRepository) Delete(accountId int64) error {
+	fName := "FeeScheduleRepository.Delete"
+	if _, err := r.db.Exec(`DELETE FROM account_fee_schedules WHERE account_id = $1`, accountId); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// GetByCurrency returns the global fee schedule of a currency, nil when its transfers are free
+func (r *FeeScheduleRepository) GetByCurrency(currencyCode string) (*models.FeeSchedule, error) {
+	fName := "FeeScheduleRepository.GetByCurrency"
+	query := `SELECT schedule FROM currency_fee_schedules WHERE currency_code = $1`
+
+	var body []byte
+	err := r.db.QueryRow(query, currencyCode).Scan(&body)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	var schedule models.FeeSchedule
+	if err := json.Unmarshal(body, &schedule); err != nil {
+		log.Printf("[%s] failed to decode schedule of %s: %v", fName, currencyCode, err)
+		return nil, appErr.ErrInternal
+	}
+	return &schedule, nil
+}
+
+// UpsertCurrency replaces the global fee schedule of a currency
+func (r *FeeScheduleRepository) UpsertCurrency(currencyCode string, schedule models.FeeSchedule) error {
+	fName := "FeeScheduleRepository.UpsertCurrency"
+	body, err := json.Marshal(schedule)
+	if err != nil {
+		log.Printf("[%s] failed to encode schedule: %v", fName, err)
+		return appErr.ErrInternal
+	}
+
+	query := `INSERT INTO currency_fee_schedules (currency_code, schedule) VALUES ($1, $2)
+		ON CONFLICT (currency_code) DO UPDATE SET schedule = EXCLUDED.schedule, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Exec(query, currencyCode, body); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// DeleteCurrency removes the global fee schedule of a currency, so that the transfers in it are free again
+func (r *FeeScheduleRepository) DeleteCurrency(currencyCode string) error {
+	fName := "FeeScheduleRepository.DeleteCurrency"
+	if _, err := r.db.Exec(`DELETE FROM currency_fee_schedules WHERE currency_code = $1`, currencyCode); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
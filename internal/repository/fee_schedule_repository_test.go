@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeScheduleRepository_GetByAccountId(t *testing.T) {
+	maxFee := models.MustParseMoney("20")
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    *models.FeeSchedule
+		expectedErr error
+	}{
+		{
+			name: "schedule set",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT schedule FROM account_fee_schedules WHERE account_id = \\$1").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows([]string{"schedule"}).AddRow(`{"flat": "0.25000", "percentage": "1.50000", "max": "20.00000"}`))
+			},
+			expected: &models.FeeSchedule{Flat: models.MustParseMoney("0.25"), Percentage: models.MustParseMoney("1.5"), Max: &maxFee},
+		},
+		{
+			name: "no schedule of its own",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT schedule").WithArgs(int64(7)).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT schedule").WithArgs(int64(7)).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			schedule, err := NewFeeScheduleRepository(db).GetByAccountId(7)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, schedule)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFeeScheduleRepository_UpsertAndDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO account_fee_schedules (.+) ON CONFLICT \\(account_id\\) DO UPDATE").
+		WithArgs(int64(7), []byte(`{"flat":"1.00000","percentage":"0.00000"}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM account_fee_schedules WHERE account_id = \\$1").
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewFeeScheduleRepository(db)
+	assert.NoError(t, repo.Upsert(7, models.FeeSchedule{Flat: models.NewMoney(1)}))
+	assert.NoError(t, repo.Delete(7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFeeScheduleRepository_CurrencySchedules(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT schedule FROM currency_fee_schedules WHERE currency_code = \\$1").
+		WithArgs("JPY").
+		WillReturnRows(sqlmock.NewRows([]string{"schedule"}).AddRow(`{"flat": "30.00000", "percentage": "0.00000"}`))
+	mock.ExpectQuery("FROM currency_fee_schedules").WithArgs("EUR").WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec("INSERT INTO currency_fee_schedules (.+) ON CONFLICT \\(currency_code\\) DO UPDATE").
+		WithArgs("JPY", []byte(`{"flat":"30.00000","percentage":"0.00000"}`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM currency_fee_schedules WHERE currency_code = \\$1").
+		WithArgs("JPY").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewFeeScheduleRepository(db)
+	schedule, err := repo.GetByCurrency("JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, &models.FeeSchedule{Flat: models.NewMoney(30)}, schedule)
+
+	schedule, err = repo.GetByCurrency("EUR")
+	assert.NoError(t, err)
+	assert.Nil(t, schedule)
+
+	assert.NoError(t, repo.UpsertCurrency("JPY", models.FeeSchedule{Flat: models.NewMoney(30)}))
+	assert.NoError(t, repo.DeleteCurrency("JPY"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+	"time"
+)
+
+// fxQuoteColumns is the column list read by scanFxQuote
+const fxQuoteColumns = `quote_id, source_account_id, destination_account_id, source_currency_code, destination_currency_code, source_amount, destination_amount, rate, expires_at, used_at, reference, created_at`
+
+// FxQuoteRepository handles FX quote database operations
+type FxQuoteRepository struct {
+	db *sql.DB
+}
+
+// NewFxQuoteRepository creates a new FX quote repository
+func NewFxQuoteRepository(db *sql.DB) *FxQuoteRepository {
+	return &FxQuoteRepository{db: db}
+}
+
+type IFxQuoteRepository interface {
+	CreateQuote(quote models.FxQuote) error
+	GetQuote(quoteId string) (*models.FxQuote, error)
+	Consume(tx *sql.Tx, quoteId, reference string, now time.Time) (*models.FxQuote, error)
+}
+
+// CreateQuote stores a new quote
+func (r *FxQuoteRepository) CreateQuote(quote models.FxQuote) error {
+	fName := "FxQuoteRepository.CreateQuote"
+	query := `INSERT INTO fx_quotes (quote_id, source_account_id, destination_account_id, source_currency_code, destination_currency_code, source_amount, destination_amount, rate, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+	_, err := r.db.Exec(query, quote.QuoteId, quote.SourceAccountId, quote.DestinationAccountId, quote.SourceCurrencyCode, quote.DestinationCurrencyCode,
+		quote.SourceAmount, quote.DestinationAmount, quote.Rate, quote.ExpiresAt, quote.CreatedAt)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// GetQuote retrieves a quote by its id
+func (r *FxQuoteRepository) GetQuote(quoteId string) (*models.FxQuote, error) {
+	fName := "FxQuoteRepository.GetQuote"
+	query := `SELECT ` + fxQuoteColumns + ` FROM fx_quotes WHERE quote_id = $1`
+
+	quote, err := scanFxQuote(r.db.QueryRow(query, quoteId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrQuoteNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return quote, nil
+}
+
+// Consume locks the quote and marks it used by the transfer with the given reference, within the transfer's
+// transaction. A quote can only be consumed once and only before it expires
+func (r *FxQuoteRepository) Consume(tx *sql.Tx, quoteId, reference string, now time.Time) (*models.FxQuote, error) {
+	fName := "FxQuoteRepository.Consume"
+	query := `SELECT ` + fxQuoteColumns + ` FROM fx_quotes WHERE quote_id = $1 FOR UPDATE`
+
+	quote, err := scanFxQuote(tx.QueryRow(query, quoteId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrQuoteNotFound
+		}
+		log.Printf("[%s] failed to lock quote: %v", fName, err)
+		return nil, retryableOr(err, appErr.ErrInternal)
+	}
+	if quote.UsedAt.Valid {
+		return nil, appErr.ErrQuoteAlreadyUsed
+	}
+	if quote.IsExpired(now) {
+		return nil, appErr.ErrQuoteExpired
+	}
+
+	updateQuery := `UPDATE fx_quotes SET used_at = $1, reference = $2 WHERE quote_id = $3`
+	if _, err := tx.Exec(updateQuery, now, reference, quoteId); err != nil {
+		log.Printf("[%s] failed to mark quote used: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return quote, nil
+}
+
+// scanFxQuote reads a quote row selected with fxQuoteColumns
+func scanFxQuote(row *sql.Row) (*models.FxQuote, error) {
+	var q models.FxQuote
+	err := row.Scan(&q.QuoteId, &q.SourceAccountId, &q.DestinationAccountId, &q.SourceCurrencyCode, &q.DestinationCurrencyCode,
+		&q.SourceAmount, &q.DestinationAmount, &q.Rate, &q.ExpiresAt, &q.UsedAt, &q.Reference, &q.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
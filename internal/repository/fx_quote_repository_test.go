@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var fxQuoteTestColumns = []string{"quote_id", "source_account_id", "destination_account_id", "source_currency_code", "destination_currency_code",
+	"source_amount", "destination_amount", "rate", "expires_at", "used_at", "reference", "created_at"}
+
+const testQuoteId = "QTE-0f8fad5b-d9cb-469f-a165-70867728950e"
+
+func TestFxQuoteRepository_GetQuote(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewFxQuoteRepository(db)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		mockQuery   func()
+		expectedErr error
+	}{
+		{
+			name: "found",
+			mockQuery: func() {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes WHERE quote_id = \\$1").
+					WithArgs(testQuoteId).
+					WillReturnRows(sqlmock.NewRows(fxQuoteTestColumns).
+						AddRow(testQuoteId, 1, 3, "USD", "EUR", "100.00000", "92.00000", "0.9200000000", now.Add(time.Minute), nil, nil, now))
+			},
+		},
+		{
+			name: "not found",
+			mockQuery: func() {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").WithArgs(testQuoteId).WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrQuoteNotFound,
+		},
+		{
+			name: "db error",
+			mockQuery: func() {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").WithArgs(testQuoteId).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockQuery()
+			quote, err := repo.GetQuote(testQuoteId)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, models.MustParseMoney("92"), quote.DestinationAmount)
+				assert.Equal(t, models.MustParseExchangeRate("0.92"), quote.Rate)
+				assert.False(t, quote.UsedAt.Valid)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestFxQuoteRepository_Consume(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+
+	quoteRow := func(expiresAt time.Time, usedAt interface{}) *sqlmock.Rows {
+		return sqlmock.NewRows(fxQuoteTestColumns).
+			AddRow(testQuoteId, 1, 3, "USD", "EUR", "100.00000", "92.00000", "0.9200000000", expiresAt, usedAt, nil, now.Add(-time.Minute))
+	}
+
+	tests := []struct {
+		name        string
+		mockQuery   func(mock sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "consumed",
+			mockQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes WHERE quote_id = \\$1 FOR UPDATE").
+					WithArgs(testQuoteId).
+					WillReturnRows(quoteRow(now.Add(time.Second), nil))
+				mock.ExpectExec("UPDATE fx_quotes SET used_at = \\$1, reference = \\$2 WHERE quote_id = \\$3").
+					WithArgs(now, reference, testQuoteId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "already used",
+			mockQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").
+					WithArgs(testQuoteId).
+					WillReturnRows(quoteRow(now.Add(time.Second), now.Add(-time.Second)))
+			},
+			expectedErr: appErr.ErrQuoteAlreadyUsed,
+		},
+		{
+			name: "expired",
+			mockQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").
+					WithArgs(testQuoteId).
+					WillReturnRows(quoteRow(now, nil))
+			},
+			expectedErr: appErr.ErrQuoteExpired,
+		},
+		{
+			name: "not found",
+			mockQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").WithArgs(testQuoteId).WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrQuoteNotFound,
+		},
+		{
+			name: "update fails",
+			mockQuery: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM fx_quotes").
+					WithArgs(testQuoteId).
+					WillReturnRows(quoteRow(now.Add(time.Second), nil))
+				mock.ExpectExec("UPDATE fx_quotes").WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			mock.ExpectBegin()
+			tt.mockQuery(mock)
+			tx, err := db.Begin()
+			assert.NoError(t, err)
+
+			quote, err := NewFxQuoteRepository(db).Consume(tx, testQuoteId, reference, now)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, int64(3), quote.DestinationAccountId)
+			} else {
+				assert.Nil(t, quote)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"log"
+	"sort"
+	"time"
+)
+
+// holdColumns is the column list read by scanHold
+const holdColumns = `hold_id, source_account_id, destination_account_id, amount, currency_code, status, captured_amount, reference, expires_at, created_at, updated_at`
+
+// HoldRepository handles hold database operations
+type HoldRepository struct {
+	db *sql.DB
+}
+
+// NewHoldRepository creates a new hold repository
+func NewHoldRepository(db *sql.DB) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+type IHoldRepository interface {
+	CreateHold(hold models.Hold) (models.Money, error)
+	GetHold(holdId string) (*models.Hold, error)
+	CaptureHold(args models.CaptureHoldArgs, now time.Time) (*models.Hold, models.Money, error)
+	VoidHold(holdId string) (*models.Hold, models.Money, error)
+	ExpireHolds(now time.Time, limit int) (int, error)
+}
+
+// CreateHold reserves the hold amount on the source account and stores the hold. It returns the available balance
+// of the source account after the reservation
+func (r *HoldRepository) CreateHold(hold models.Hold) (models.Money, error) {
+	fName := "HoldRepository.CreateHold"
+	var zero models.Money
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return zero, appErr.ErrTransactionFailed
+	}
+	defer tx.Rollback()
+
+	source, err := r.getAccountRepository().GetForUpdate(tx, hold.SourceAccountId)
+	if err != nil {
+		if err == appErr.ErrAccountNotFound {
+			return zero, appErr.ErrSourceAccountNotFound
+		}
+		return zero, appErr.ErrTransactionFailed
+	}
+	switch {
+	case source.Status == models.AccountStatusClosed:
+		return zero, appErr.ErrSourceAccountClosed
+	case !source.Status.AllowsDebit():
+		return zero, appErr.ErrSourceAccountFrozen
+	case source.AvailableToSpend().LessThan(hold.Amount):
+		return zero, appErr.ErrInsufficientBalance
+	}
+
+	heldAmount := source.HeldAmount.Add(hold.Amount)
+	if err := r.getAccountRepository().UpdateHeldAmount(tx, hold.SourceAccountId, heldAmount); err != nil {
+		return zero, appErr.ErrTransactionFailed
+	}
+
+	query := `INSERT INTO holds (hold_id, source_account_id, destination_account_id, amount, currency_code, status, expires_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err = tx.Exec(query, hold.HoldId, hold.SourceAccountId, hold.DestinationAccountId, hold.Amount, hold.CurrencyCode, models.HoldStatusPending,
+		hold.ExpiresAt, hold.CreatedAt, hold.UpdatedAt)
+	if err != nil {
+		log.Printf("[%s] failed to create hold: %v", fName, err)
+		return zero, appErr.ErrTransactionFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return zero, appErr.ErrTransactionFailed
+	}
+	return source.Balance.Sub(heldAmount), nil
+}
+
+// CaptureHold releases the hold and transfers the captured amount from the source to the destination account
+// under args.Reference. Capturing less than the hold releases the rest.
+// It is run again when Postgres aborts it because of a deadlock or a serialization failure
+func (r *HoldRepository) CaptureHold(args models.CaptureHoldArgs, now time.Time) (*models.Hold, models.Money, error) {
+	var hold *models.Hold
+	var availableBalance models.Money
+	err := withRetry("HoldRepository.CaptureHold", func() error {
+		var err error
+		hold, availableBalance, err = r.captureHold(args, now)
+		return err
+	})
+	return hold, availableBalance, err
+}
+
+// captureHold runs a single attempt of CaptureHold
+func (r *HoldRepository) captureHold(args models.CaptureHoldArgs, now time.Time) (*models.Hold, models.Money, error) {
+	fName := "HoldRepository.CaptureHold"
+	var zero models.Money
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+	defer tx.Rollback()
+
+	hold, err := r.lockPendingHold(tx, args.HoldId)
+	if err != nil {
+		return nil, zero, err
+	}
+	if hold.IsExpired(now) {
+		return nil, zero, appErr.ErrHoldExpired
+	}
+
+	amount := hold.Amount
+	if args.Amount != nil {
+		amount = *args.Amount
+	}
+	if amount.GreaterThan(hold.Amount) {
+		return nil, zero, appErr.ErrCaptureAmountExceeded
+	}
+	if currency, err := models.LookupCurrency(hold.CurrencyCode); err == nil && !currency.Allows(amount) {
+		return nil, zero, appErr.ErrInvalidCurrencyPrecision
+	}
+
+	accounts, err := r.getTransactionRepository().lockAccounts(tx, hold.SourceAccountId, hold.DestinationAccountId)
+	if err != nil {
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		return nil, zero, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+
+	// Release the whole hold first so that the captured amount is covered by the available balance again
+	source := accounts[hold.SourceAccountId]
+	source.HeldAmount = source.HeldAmount.Sub(hold.Amount)
+	if err := r.getAccountRepository().UpdateHeldAmount(tx, hold.SourceAccountId, source.HeldAmount); err != nil {
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+
+	availableBalance, _, err := r.getTransactionRepository().applyEntry(tx, accounts, args.Reference, "", transferLegs{
+		debitAccountId:     hold.SourceAccountId,
+		creditAccountId:    hold.DestinationAccountId,
+		amount:             amount,
+		currencyCode:       hold.CurrencyCode,
+		creditAmount:       amount,
+		creditCurrencyCode: hold.CurrencyCode,
+		fxRate:             models.OneExchangeRate,
+		limits:             args.Limits,
+	})
+	if err != nil {
+		return nil, zero, err
+	}
+
+	query := `UPDATE holds SET status = $1, captured_amount = $2, reference = $3, updated_at = $4 WHERE hold_id = $5`
+	if _, err := tx.Exec(query, models.HoldStatusCaptured, amount, args.Reference, now, hold.HoldId); err != nil {
+		log.Printf("[%s] failed to mark hold captured: %v", fName, err)
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return nil, zero, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+
+	hold.Status = models.HoldStatusCaptured
+	hold.CapturedAmount = &amount
+	hold.Reference = sql.NullString{String: args.Reference, Valid: true}
+	hold.UpdatedAt = now
+	return hold, availableBalance, nil
+}
+
+// VoidHold releases a pending hold without moving money. A hold past its expiry that the sweeper has not
+// released yet can still be voided. It returns the available balance of the source account after the release
+func (r *HoldRepository) VoidHold(holdId string) (*models.Hold, models.Money, error) {
+	fName := "HoldRepository.VoidHold"
+	var zero models.Money
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+	defer tx.Rollback()
+
+	hold, err := r.lockPendingHold(tx, holdId)
+	if err != nil {
+		return nil, zero, err
+	}
+
+	source, err := r.getAccountRepository().GetForUpdate(tx, hold.SourceAccountId)
+	if err != nil {
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+	heldAmount := source.HeldAmount.Sub(hold.Amount)
+	if err := r.getAccountRepository().UpdateHeldAmount(tx, hold.SourceAccountId, heldAmount); err != nil {
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+
+	query := `UPDATE holds SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE hold_id = $2`
+	if _, err := tx.Exec(query, models.HoldStatusVoided, holdId); err != nil {
+		log.Printf("[%s] failed to mark hold voided: %v", fName, err)
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return nil, zero, appErr.ErrTransactionFailed
+	}
+
+	hold.Status = models.HoldStatusVoided
+	return hold, source.Balance.Sub(heldAmount), nil
+}
+
+// ExpireHolds releases up to limit pending holds whose expiry is not after now and returns how many were released.
+// Holds locked by a concurrent capture or void are skipped, so several sweepers can run at the same time
+func (r *HoldRepository) ExpireHolds(now time.Time, limit int) (int, error) {
+	fName := "HoldRepository.ExpireHolds"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + holdColumns + ` FROM holds WHERE status = $1 AND expires_at <= $2 ORDER BY expires_at LIMIT $3 FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(query, models.HoldStatusPending, now, limit)
+	if err != nil {
+		log.Printf("[%s] failed to select expired holds: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	var holdIds []string
+	released := make(map[int64]models.Money)
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			rows.Close()
+			log.Printf("[%s] failed to scan hold: %v", fName, err)
+			return 0, appErr.ErrInternal
+		}
+		holdIds = append(holdIds, hold.HoldId)
+		released[hold.SourceAccountId] = released[hold.SourceAccountId].Add(hold.Amount)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating holds: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	if len(holdIds) == 0 {
+		return 0, nil
+	}
+
+	accountIds := make([]int64, 0, len(released))
+	for accountId := range released {
+		accountIds = append(accountIds, accountId)
+	}
+	sort.Slice(accountIds, func(i, j int) bool { return accountIds[i] < accountIds[j] })
+	accounts, err := r.getTransactionRepository().lockAccounts(tx, accountIds...)
+	if err != nil {
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	for _, accountId := range accountIds {
+		if err := r.getAccountRepository().UpdateHeldAmount(tx, accountId, accounts[accountId].HeldAmount.Sub(released[accountId])); err != nil {
+			return 0, appErr.ErrInternal
+		}
+	}
+
+	updateQuery := `UPDATE holds SET status = $1, updated_at = $2 WHERE hold_id = ANY($3)`
+	if _, err := tx.Exec(updateQuery, models.HoldStatusExpired, now, pq.Array(holdIds)); err != nil {
+		log.Printf("[%s] failed to mark holds expired: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	return len(holdIds), nil
+}
+
+// GetHold retrieves a hold by its id
+func (r *HoldRepository) GetHold(holdId string) (*models.Hold, error) {
+	fName := "HoldRepository.GetHold"
+	query := `SELECT ` + holdColumns + ` FROM holds WHERE hold_id = $1`
+
+	hold, err := scanHold(r.db.QueryRow(query, holdId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrHoldNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return hold, nil
+}
+
+// lockPendingHold locks a hold within tx and checks it is still pending
+func (r *HoldRepository) lockPendingHold(tx *sql.Tx, holdId string) (*models.Hold, error) {
+	fName := "HoldRepository.lockPendingHold"
+	query := `SELECT ` + holdColumns + ` FROM holds WHERE hold_id = $1 FOR UPDATE`
+
+	hold, err := scanHold(tx.QueryRow(query, holdId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrHoldNotFound
+		}
+		log.Printf("[%s] failed to lock hold: %v", fName, err)
+		return nil, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	if hold.Status != models.HoldStatusPending {
+		return nil, appErr.ErrHoldNotPending
+	}
+	return hold, nil
+}
+
+// scanHold reads a hold row selected with holdColumns
+func scanHold(row interface{ Scan(dest ...any) error }) (*models.Hold, error) {
+	var h models.Hold
+	err := row.Scan(&h.HoldId, &h.SourceAccountId, &h.DestinationAccountId, &h.Amount, &h.CurrencyCode, &h.Status, &h.CapturedAmount,
+		&h.Reference, &h.ExpiresAt, &h.CreatedAt, &h.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+// getAccountRepository returns an account repository instance
+// This is a helper method to reserve and release funds within transactions
+func (r *HoldRepository) getAccountRepository() *AccountRepository {
+	return &AccountRepository{db: r.db}
+}
+
+// getTransactionRepository returns a transaction repository instance
+// This is a helper method to post the transfer of a captured hold within its transaction
+func (r *HoldRepository) getTransactionRepository() *TransactionRepository {
+	return &TransactionRepository{db: r.db}
+}
@@ -0,0 +1,389 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	holdTestColumns = []string{"hold_id", "source_account_id", "destination_account_id", "amount", "currency_code", "status",
+		"captured_amount", "reference", "expires_at", "created_at", "updated_at"}
+	accountLockColumns = []string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}
+)
+
+const (
+	testHoldId    = "HLD-7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	testReference = "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+)
+
+func TestHoldRepository_CreateHold(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	hold := models.Hold{
+		HoldId:               testHoldId,
+		SourceAccountId:      1,
+		DestinationAccountId: 2,
+		Amount:               models.NewMoney(40),
+		CurrencyCode:         "USD",
+		ExpiresAt:            now.Add(time.Hour),
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+
+	tests := []struct {
+		name              string
+		setupMock         func(mock sqlmock.Sqlmock)
+		expectedErr       error
+		expectedAvailable models.Money
+	}{
+		{
+			name: "funds reserved",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "10.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+					WithArgs(models.NewMoney(50), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO holds").
+					WithArgs(testHoldId, int64(1), int64(2), models.NewMoney(40), "USD", models.HoldStatusPending, hold.ExpiresAt, now, now).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedAvailable: models.NewMoney(50),
+		},
+		{
+			name: "available balance too low",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "70.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrInsufficientBalance,
+		},
+		{
+			name: "source account frozen",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "frozen", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrSourceAccountFrozen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			available, err := NewHoldRepository(db).CreateHold(hold)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedAvailable, available)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_CaptureHold(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	pendingHold := func(status models.HoldStatus, expiresAt time.Time) *sqlmock.Rows {
+		return sqlmock.NewRows(holdTestColumns).
+			AddRow(testHoldId, 1, 2, "40.00000", "USD", string(status), nil, nil, expiresAt, now.Add(-time.Hour), now.Add(-time.Hour))
+	}
+	partial := models.NewMoney(25)
+	tooMuch := models.NewMoney(41)
+
+	tests := []struct {
+		name              string
+		amount            *models.Money
+		setupMock         func(mock sqlmock.Sqlmock)
+		expectedErr       error
+		expectedCaptured  models.Money
+		expectedAvailable models.Money
+	}{
+		{
+			name: "whole hold captured",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds WHERE hold_id = \\$1 FOR UPDATE").
+					WithArgs(testHoldId).
+					WillReturnRows(pendingHold(models.HoldStatusPending, now.Add(time.Minute)))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "50.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("5.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+					WithArgs(models.NewMoney(10), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(60), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(45), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(1), models.NewMoney(40), "USD", models.NewMoney(60), false, testReference, sql.NullString{},
+						models.OneExchangeRate, models.NewMoney(40), models.NewMoney(40), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(2), models.NewMoney(40), "USD", models.NewMoney(45), true, testReference, sql.NullString{},
+						models.OneExchangeRate, models.NewMoney(40), models.NewMoney(40), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectExec("UPDATE holds SET status = \\$1, captured_amount = \\$2, reference = \\$3").
+					WithArgs(models.HoldStatusCaptured, models.NewMoney(40), testReference, now, testHoldId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedCaptured:  models.NewMoney(40),
+			expectedAvailable: models.NewMoney(50),
+		},
+		{
+			name:   "partial capture releases the rest",
+			amount: &partial,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds").
+					WithArgs(testHoldId).
+					WillReturnRows(pendingHold(models.HoldStatusPending, now.Add(time.Minute)))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "40.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("0.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+					WithArgs(models.NewMoney(0), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(75), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(25), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectExec("UPDATE holds SET status").
+					WithArgs(models.HoldStatusCaptured, models.NewMoney(25), testReference, now, testHoldId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedCaptured:  models.NewMoney(25),
+			expectedAvailable: models.NewMoney(75),
+		},
+		{
+			name:   "capture more than held",
+			amount: &tooMuch,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds").
+					WithArgs(testHoldId).
+					WillReturnRows(pendingHold(models.HoldStatusPending, now.Add(time.Minute)))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrCaptureAmountExceeded,
+		},
+		{
+			name: "expired",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds").
+					WithArgs(testHoldId).
+					WillReturnRows(pendingHold(models.HoldStatusPending, now))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrHoldExpired,
+		},
+		{
+			name: "already voided",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds").
+					WithArgs(testHoldId).
+					WillReturnRows(pendingHold(models.HoldStatusVoided, now.Add(time.Minute)))
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrHoldNotPending,
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM holds").WithArgs(testHoldId).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrHoldNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			hold, available, err := NewHoldRepository(db).CaptureHold(models.CaptureHoldArgs{HoldId: testHoldId, Amount: tt.amount, Reference: testReference}, now)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, models.HoldStatusCaptured, hold.Status)
+				assert.Equal(t, &tt.expectedCaptured, hold.CapturedAmount)
+				assert.Equal(t, testReference, hold.Reference.String)
+				assert.Equal(t, tt.expectedAvailable, available)
+			} else {
+				assert.Nil(t, hold)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_GetHold(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT (.+) FROM holds WHERE hold_id = \\$1$").
+					WithArgs(testHoldId).
+					WillReturnRows(sqlmock.NewRows(holdTestColumns).
+						AddRow(testHoldId, 1, 2, "40.00000", "USD", "pending", nil, nil, now.Add(time.Hour), now, now))
+			},
+		},
+		{
+			name: "not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM holds").WithArgs(testHoldId).WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrHoldNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM holds").WithArgs(testHoldId).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			hold, err := NewHoldRepository(db).GetHold(testHoldId)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, int64(1), hold.SourceAccountId)
+				assert.Equal(t, models.HoldStatusPending, hold.Status)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestHoldRepository_VoidHold(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM holds WHERE hold_id = \\$1 FOR UPDATE").
+		WithArgs(testHoldId).
+		WillReturnRows(sqlmock.NewRows(holdTestColumns).
+			AddRow(testHoldId, 1, 2, "40.00000", "USD", "pending", nil, nil, now.Add(-time.Minute), now, now))
+	mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "frozen", "40.00000", "0.00000"))
+	mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+		WithArgs(models.NewMoney(0), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE holds SET status = \\$1").
+		WithArgs(models.HoldStatusVoided, testHoldId).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	hold, available, err := NewHoldRepository(db).VoidHold(testHoldId)
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldStatusVoided, hold.Status)
+	assert.Equal(t, models.NewMoney(100), available)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHoldRepository_ExpireHolds(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("releases expired holds", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		secondHoldId := "HLD-9b2e4d0f-3c1a-4e5b-8f7d-6a1c2b3d4e5f"
+		thirdHoldId := "HLD-1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d"
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM holds WHERE status = \\$1 AND expires_at <= \\$2 ORDER BY expires_at LIMIT \\$3 FOR UPDATE SKIP LOCKED").
+			WithArgs(models.HoldStatusPending, now, 100).
+			WillReturnRows(sqlmock.NewRows(holdTestColumns).
+				AddRow(testHoldId, 3, 2, "40.00000", "USD", "pending", nil, nil, now, now, now).
+				AddRow(secondHoldId, 1, 2, "5.00000", "USD", "pending", nil, nil, now, now, now).
+				AddRow(thirdHoldId, 3, 1, "10.00000", "USD", "pending", nil, nil, now, now, now))
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "5.00000", "0.00000"))
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(int64(3)).
+			WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow("100.00000", "USD", "active", "60.00000", "0.00000"))
+		mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+			WithArgs(models.NewMoney(0), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET held_amount = \\$1").
+			WithArgs(models.NewMoney(10), int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE holds SET status = \\$1, updated_at = \\$2 WHERE hold_id = ANY\\(\\$3\\)").
+			WithArgs(models.HoldStatusExpired, now, pq.Array([]string{testHoldId, secondHoldId, thirdHoldId})).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		expired, err := NewHoldRepository(db).ExpireHolds(now, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, expired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to release", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM holds").WillReturnRows(sqlmock.NewRows(holdTestColumns))
+		mock.ExpectRollback()
+
+		expired, err := NewHoldRepository(db).ExpireHolds(now, 100)
+		assert.NoError(t, err)
+		assert.Zero(t, expired)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+)
+
+// IdempotencyRepository handles idempotency key database operations
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+type IIdempotencyRepository interface {
+	Get(idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error)
+	Acquire(tx *sql.Tx, idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error)
+	Complete(tx *sql.Tx, idem models.Idempotency, endpoint string, statusCode int, body []byte) error
+}
+
+// Get retrieves a stored idempotency key of the client of idem. It returns nil when the client never used the key
+func (r *IdempotencyRepository) Get(idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error) {
+	fName := "IdempotencyRepository.Get"
+	query := `SELECT idempotency_key, endpoint, request_hash, COALESCE(status_code, 0), response_body, created_at FROM idempotency_keys WHERE client_id = $1 AND idempotency_key = $2 AND endpoint = $3`
+
+	var record models.IdempotencyRecord
+	err := r.db.QueryRow(query, idem.ClientId, idem.Key, endpoint).Scan(&record.Key, &record.Endpoint, &record.RequestHash, &record.StatusCode, &record.ResponseBody, &record.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	return &record, nil
+}
+
+// Acquire reserves the idempotency key of the client of idem within a transaction and locks it until the transaction
+// ends. A concurrent request of the client with the same key blocks here until the first one commits or rolls back.
+// It returns the stored record when the key already holds a completed response, or nil when the caller
+// should go ahead and execute the request
+func (r *IdempotencyRepository) Acquire(tx *sql.Tx, idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error) {
+	fName := "IdempotencyRepository.Acquire"
+
+	insertQuery := `INSERT INTO idempotency_keys (client_id, idempotency_key, endpoint, request_hash) VALUES ($1, $2, $3, $4) ON CONFLICT (client_id, idempotency_key, endpoint) DO NOTHING`
+	if _, err := tx.Exec(insertQuery, idem.ClientId, idem.Key, endpoint, idem.RequestHash); err != nil {
+		log.Printf("[%s] failed to reserve key: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	selectQuery := `SELECT idempotency_key, endpoint, request_hash, COALESCE(status_code, 0), response_body, created_at FROM idempotency_keys WHERE client_id = $1 AND idempotency_key = $2 AND endpoint = $3 FOR UPDATE`
+	var record models.IdempotencyRecord
+	err := tx.QueryRow(selectQuery, idem.ClientId, idem.Key, endpoint).Scan(&record.Key, &record.Endpoint, &record.RequestHash, &record.StatusCode, &record.ResponseBody, &record.CreatedAt)
+	if err != nil {
+		log.Printf("[%s] failed to lock key: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	if record.RequestHash != idem.RequestHash {
+		return nil, appErr.ErrIdempotencyKeyReused
+	}
+
+	if !record.IsCompleted() {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// Complete stores the response of the request holding the key, within the same transaction that performed it
+func (r *IdempotencyRepository) Complete(tx *sql.Tx, idem models.Idempotency, endpoint string, statusCode int, body []byte) error {
+	fName := "IdempotencyRepository.Complete"
+	query := `UPDATE idempotency_keys SET status_code = $1, response_body = $2 WHERE client_id = $3 AND idempotency_key = $4 AND endpoint = $5`
+	if _, err := tx.Exec(query, statusCode, body, idem.ClientId, idem.Key, endpoint); err != nil {
+		log.Printf("[%s] failed to store response: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRepository_Get(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewIdempotencyRepository(db)
+	endpoint := models.IdempotencyEndpointCreateTransaction
+	columns := []string{"idempotency_key", "endpoint", "request_hash", "status_code", "response_body", "created_at"}
+
+	tests := []struct {
+		name        string
+		mockQuery   func()
+		expectedErr error
+		expectNil   bool
+	}{
+		{
+			name: "found",
+			mockQuery: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM idempotency_keys WHERE client_id = \$1 AND idempotency_key = \$2 AND endpoint = \$3`).
+					WithArgs(int64(3), "key-1", endpoint).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow("key-1", endpoint, "hash", 200, []byte(`{}`), time.Now()))
+			},
+		},
+		{
+			name: "never used",
+			mockQuery: func() {
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WithArgs(int64(3), "key-1", endpoint).
+					WillReturnError(sql.ErrNoRows)
+			},
+			expectNil: true,
+		},
+		{
+			name: "db error",
+			mockQuery: func() {
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WithArgs(int64(3), "key-1", endpoint).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+			expectNil:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockQuery()
+			record, err := repo.Get(models.Idempotency{ClientId: 3, Key: "key-1"}, endpoint)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectNil {
+				assert.Nil(t, record)
+			} else {
+				assert.True(t, record.IsCompleted())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
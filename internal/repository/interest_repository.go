@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+	"time"
+)
+
+// InterestRepository handles interest rates, accruals and their posting
+type InterestRepository struct {
+	db *sql.DB
+}
+
+// NewInterestRepository creates a new interest repository
+func NewInterestRepository(db *sql.DB) *InterestRepository {
+	return &InterestRepository{db: db}
+}
+
+type IInterestRepository interface {
+	SetRate(accountId int64, rate models.InterestRate) error
+	ListInterestAccounts() ([]models.InterestAccount, error)
+	GetEndOfDayBalance(accountId int64, day time.Time) (models.Money, error)
+	SaveAccruals(accruals []models.InterestAccrual) error
+	ListUnpostedAccruals(through time.Time) ([]models.InterestAccrual, error)
+	PostInterest(args models.PostInterestArgs) (models.InterestPosting, error)
+}
+
+// SetRate replaces the interest rate of an account. The new rate applies from the first day not accrued yet
+func (r *InterestRepository) SetRate(accountId int64, rate models.InterestRate) error {
+	fName := "InterestRepository.SetRate"
+	query := `INSERT INTO account_interest_rates (account_id, annual_rate, day_count) VALUES ($1, $2, $3)
+		ON CONFLICT (account_id) DO UPDATE SET annual_rate = EXCLUDED.annual_rate, day_count = EXCLUDED.day_count, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Exec(query, accountId, rate.AnnualRate, rate.DayCount); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// ListInterestAccounts returns every open account with an interest rate, with the day after its latest accrual,
+// or the day its rate was first set when it has none
+func (r *InterestRepository) ListInterestAccounts() ([]models.InterestAccount, error) {
+	fName := "InterestRepository.ListInterestAccounts"
+	query := `SELECT r.account_id, a.currency_code, r.annual_rate, r.day_count, COALESCE(MAX(i.accrual_date) + 1, r.created_at::date)
+		FROM account_interest_rates r
+		JOIN accounts a ON a.account_id = r.account_id
+		LEFT JOIN interest_accruals i ON i.account_id = r.account_id
+		WHERE a.status <> $1
+		GROUP BY r.account_id, a.currency_code, r.annual_rate, r.day_count, r.created_at
+		ORDER BY r.account_id`
+
+	rows, err := r.db.Query(query, models.AccountStatusClosed)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	var accounts []models.InterestAccount
+	for rows.Next() {
+		var a models.InterestAccount
+		if err := rows.Scan(&a.AccountId, &a.CurrencyCode, &a.Rate.AnnualRate, &a.Rate.DayCount, &a.AccrueFrom); err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		accounts = append(accounts, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return accounts, nil
+}
+
+// GetEndOfDayBalance returns the balance of an account at the end of the given UTC day: its current balance with
+// every entry written after that day taken back out
+func (r *InterestRepository) GetEndOfDayBalance(accountId int64, day time.Time) (models.Money, error) {
+	fName := "InterestRepository.GetEndOfDayBalance"
+	query := `SELECT a.balance - COALESCE(SUM(CASE WHEN t.is_credit THEN t.amount ELSE -t.amount END), 0)
+		FROM accounts a
+		LEFT JOIN transactions t ON t.account_id = a.account_id AND t.created_at >= $2
+		WHERE a.account_id = $1
+		GROUP BY a.account_id, a.balance`
+
+	var balance models.Money
+	if err := r.db.QueryRow(query, accountId, day.AddDate(0, 0, 1)).Scan(&balance); err != nil {
+		log.Printf("[%s] failed to get balance of account %d on %s: %v", fName, accountId, day.Format(time.DateOnly), err)
+		if err == sql.ErrNoRows {
+			return models.Money{}, appErr.ErrAccountNotFound
+		}
+		return models.Money{}, appErr.ErrInternal
+	}
+	return balance, nil
+}
+
+// SaveAccruals stores accruals in one DB transaction. An accrual already stored for the same account and day is
+// kept, so that a run repeated for the same day accrues nothing twice
+func (r *InterestRepository) SaveAccruals(accruals []models.InterestAccrual) error {
+	fName := "InterestRepository.SaveAccruals"
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO interest_accruals (account_id, accrual_date, balance, annual_rate, day_count, amount, currency_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (account_id, accrual_date) DO NOTHING`
+	for _, a := range accruals {
+		if _, err := tx.Exec(query, a.AccountId, a.AccrualDate, a.Balance, a.AnnualRate, a.DayCount, a.Amount, a.CurrencyCode); err != nil {
+			log.Printf("[%s] failed to save accrual of account %d: %v", fName, a.AccountId, err)
+			return appErr.ErrInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// ListUnpostedAccruals returns the accruals of open accounts up to and including through that are not paid yet,
+// by account then day
+func (r *InterestRepository) ListUnpostedAccruals(through time.Time) ([]models.InterestAccrual, error) {
+	fName := "InterestRepository.ListUnpostedAccruals"
+	query := `SELECT i.account_id, i.accrual_date, i.balance, i.annual_rate, i.day_count, i.amount, i.currency_code
+		FROM interest_accruals i
+		JOIN accounts a ON a.account_id = i.account_id
+		WHERE i.posted_at IS NULL AND i.accrual_date <= $1 AND a.status <> $2
+		ORDER BY i.account_id, i.accrual_date`
+
+	rows, err := r.db.Query(query, through, models.AccountStatusClosed)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	var accruals []models.InterestAccrual
+	for rows.Next() {
+		var a models.InterestAccrual
+		if err := rows.Scan(&a.AccountId, &a.AccrualDate, &a.Balance, &a.AnnualRate, &a.DayCount, &a.Amount, &a.CurrencyCode); err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		accruals = append(accruals, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return accruals, nil
+}
+
+// PostInterest pays the unposted accruals of an account up to and including args.Through as one credit from the
+// interest expense account, and marks them posted in the same DB transaction. The sum is rounded half away from
+// zero to the minor units of the currency; accruals rounding to nothing are marked posted without a transfer.
+// It is run again when Postgres aborts it because of a deadlock or a serialization failure
+func (r *InterestRepository) PostInterest(args models.PostInterestArgs) (models.InterestPosting, error) {
+	var posting models.InterestPosting
+	err := withRetry("InterestRepository.PostInterest", func() error {
+		var err error
+		posting, err = r.postInterest(args)
+		return err
+	})
+	return posting, err
+}
+
+// postInterest runs a single attempt of PostInterest
+func (r *InterestRepository) postInterest(args models.PostInterestArgs) (models.InterestPosting, error) {
+	fName := "InterestRepository.PostInterest"
+	posting := models.InterestPosting{AccountId: args.AccountId, Through: args.Through}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return posting, appErr.ErrTransactionFailed
+	}
+	defer tx.Rollback()
+
+	// Lock the accruals so that two runs cannot pay them twice
+	query := `SELECT amount, currency_code FROM interest_accruals WHERE account_id = $1 AND posted_at IS NULL AND accrual_date <= $2 FOR UPDATE`
+	rows, err := tx.Query(query, args.AccountId, args.Through)
+	if err != nil {
+		log.Printf("[%s] failed to lock accruals: %v", fName, err)
+		return posting, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	var sum models.Money
+	count := 0
+	for rows.Next() {
+		var amount models.Money
+		if err := rows.Scan(&amount, &posting.CurrencyCode); err != nil {
+			rows.Close()
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return posting, appErr.ErrTransactionFailed
+		}
+		sum = sum.Add(amount)
+		count++
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return posting, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	if count == 0 {
+		return posting, nil
+	}
+
+	currency, err := models.LookupCurrency(posting.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to look up currency %q: %v", fName, posting.CurrencyCode, err)
+		return posting, appErr.ErrTransactionFailed
+	}
+	posting.Amount = currency.Round(sum)
+
+	reference := sql.NullString{}
+	if posting.Amount.IsPositive() {
+		accounts, err := r.getTransactionRepository().lockAccounts(tx, args.ExpenseAccountId, args.AccountId)
+		if err != nil {
+			log.Printf("[%s] failed to lock accounts: %v", fName, err)
+			return posting, retryableOr(err, appErr.ErrTransactionFailed)
+		}
+		if accounts[args.ExpenseAccountId].CurrencyCode != posting.CurrencyCode {
+			return posting, appErr.ErrCurrencyMismatch
+		}
+
+		_, _, err = r.getTransactionRepository().applyEntry(tx, accounts, args.Reference, "", transferLegs{
+			debitAccountId:     args.ExpenseAccountId,
+			creditAccountId:    args.AccountId,
+			amount:             posting.Amount,
+			currencyCode:       posting.CurrencyCode,
+			creditAmount:       posting.Amount,
+			creditCurrencyCode: posting.CurrencyCode,
+			fxRate:             models.OneExchangeRate,
+		})
+		if err != nil {
+			return posting, err
+		}
+		reference = sql.NullString{String: args.Reference, Valid: true}
+		posting.Reference = args.Reference
+	}
+
+	query = `UPDATE interest_accruals SET posted_at = CURRENT_TIMESTAMP, reference = $1 WHERE account_id = $2 AND posted_at IS NULL AND accrual_date <= $3`
+	if _, err := tx.Exec(query, reference, args.AccountId, args.Through); err != nil {
+		log.Printf("[%s] failed to mark accruals posted: %v", fName, err)
+		return posting, appErr.ErrTransactionFailed
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return posting, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	return posting, nil
+}
+
+// getTransactionRepository returns a transaction repository instance
+// This is a helper method to post the interest payment within its transaction
+func (r *InterestRepository) getTransactionRepository() *TransactionRepository {
+	return &TransactionRepository{db: r.db}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterestRepository_GetEndOfDayBalance(t *testing.T) {
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    models.Money
+		expectedErr error
+	}{
+		{
+			name: "entries after the day taken back out",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.balance - COALESCE(.+) FROM accounts a LEFT JOIN transactions t (.+) t.created_at >= \\$2").
+					WithArgs(int64(7), day.AddDate(0, 0, 1)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("950.00000"))
+			},
+			expected: models.NewMoney(950),
+		},
+		{
+			name: "account not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT a.balance").WillReturnError(sql.ErrNoRows)
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			balance, err := NewInterestRepository(db).GetEndOfDayBalance(7, day)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, balance)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestInterestRepository_PostInterest(t *testing.T) {
+	args := models.PostInterestArgs{
+		AccountId:        7,
+		ExpenseAccountId: 9,
+		Through:          time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		Reference:        "TXN-123456",
+	}
+	lockAccruals := func(mock sqlmock.Sqlmock, amounts ...string) {
+		rows := sqlmock.NewRows([]string{"amount", "currency_code"})
+		for _, amount := range amounts {
+			rows.AddRow(amount, "USD")
+		}
+		mock.ExpectQuery("SELECT amount, currency_code FROM interest_accruals (.+) FOR UPDATE").
+			WithArgs(int64(7), args.Through).
+			WillReturnRows(rows)
+	}
+	lockAccount := func(mock sqlmock.Sqlmock, accountId int64, balance, currencyCode string) {
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(accountId).
+			WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow(balance, currencyCode, "active", "0.00000", "0.00000"))
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		expected  models.InterestPosting
+		expectErr error
+	}{
+		{
+			name: "rounded sum paid from the expense account",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccruals(mock, "0.06831", "0.06831", "0.06831")
+				lockAccount(mock, 7, "1000.00000", "USD")
+				lockAccount(mock, 9, "500.00000", "USD")
+				mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.MustParseMoney("499.8"), int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.MustParseMoney("1000.2"), int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectExec("UPDATE interest_accruals SET posted_at").
+					WithArgs(sql.NullString{String: "TXN-123456", Valid: true}, int64(7), args.Through).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+			expected: models.InterestPosting{AccountId: 7, Through: args.Through, Amount: models.MustParseMoney("0.2"), CurrencyCode: "USD", Reference: "TXN-123456"},
+		},
+		{
+			name: "sum rounding to nothing marked posted without a transfer",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccruals(mock, "0.00100", "0.00100")
+				mock.ExpectExec("UPDATE interest_accruals SET posted_at").
+					WithArgs(sql.NullString{}, int64(7), args.Through).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expected: models.InterestPosting{AccountId: 7, Through: args.Through, CurrencyCode: "USD"},
+		},
+		{
+			name: "expense account in another currency",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccruals(mock, "1.00000")
+				lockAccount(mock, 7, "1000.00000", "USD")
+				lockAccount(mock, 9, "500.00000", "EUR")
+				mock.ExpectRollback()
+			},
+			expected:  models.InterestPosting{AccountId: 7, Through: args.Through, Amount: models.NewMoney(1), CurrencyCode: "USD"},
+			expectErr: appErr.ErrCurrencyMismatch,
+		},
+		{
+			name: "expense account cannot cover the interest",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccruals(mock, "1.00000")
+				lockAccount(mock, 7, "1000.00000", "USD")
+				lockAccount(mock, 9, "0.50000", "USD")
+				mock.ExpectRollback()
+			},
+			expected:  models.InterestPosting{AccountId: 7, Through: args.Through, Amount: models.NewMoney(1), CurrencyCode: "USD"},
+			expectErr: appErr.ErrInsufficientBalance,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			posting, err := NewInterestRepository(db).PostInterest(args)
+			assert.Equal(t, tt.expectErr, err)
+			assert.Equal(t, tt.expected, posting)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+	"strings"
+)
+
+// JournalRepository handles the double-entry journal: entries, their postings and the transaction rows showing
+// them on the statements of the accounts
+type JournalRepository struct {
+	db *sql.DB
+}
+
+// journalEntry is a journal entry being built within a DB transaction, with the transaction rows of its legs
+type journalEntry struct {
+	models.JournalEntry
+	transactions []models.Transaction
+}
+
+// newJournalEntry starts the journal entry of everything written under reference. originalReference is set on
+// reversals only
+func newJournalEntry(reference, originalReference string) *journalEntry {
+	return &journalEntry{JournalEntry: models.JournalEntry{Reference: reference, OriginalReference: originalReference}}
+}
+
+// addLegs records the debit and the credit of legs, leaving the debited and the credited account with the given
+// balances. A cross-currency legs gets two more postings carrying the converted amounts through the FX position
+func (e *journalEntry) addLegs(legs transferLegs, newDebitBalance, newCreditBalance models.Money) {
+	kind := models.PostingKindTransfer
+	if legs.isFee {
+		kind = models.PostingKindFee
+	}
+	debitAccountId, creditAccountId := legs.debitAccountId, legs.creditAccountId
+	e.Postings = append(e.Postings,
+		models.Posting{AccountId: &debitAccountId, Kind: kind, Amount: legs.amount.Neg(), CurrencyCode: legs.currencyCode},
+		models.Posting{AccountId: &creditAccountId, Kind: kind, Amount: legs.creditAmount, CurrencyCode: legs.creditCurrencyCode},
+	)
+	if legs.currencyCode != legs.creditCurrencyCode {
+		e.Postings = append(e.Postings,
+			models.Posting{Kind: models.PostingKindFx, Amount: legs.amount, CurrencyCode: legs.currencyCode},
+			models.Posting{Kind: models.PostingKindFx, Amount: legs.creditAmount.Neg(), CurrencyCode: legs.creditCurrencyCode},
+		)
+	}
+
+	originalReference := sql.NullString{String: e.OriginalReference, Valid: e.OriginalReference != ""}
+	fxRate, sourceAmount, destinationAmount := legs.fxRate, legs.amount, legs.creditAmount
+	e.transactions = append(e.transactions,
+		models.Transaction{AccountId: legs.debitAccountId, Amount: legs.amount, CurrencyCode: legs.currencyCode, AvailableBalance: newDebitBalance,
+			Reference: e.Reference, OriginalReference: originalReference, FxRate: &fxRate, SourceAmount: &sourceAmount, DestinationAmount: &destinationAmount, IsFee: legs.isFee},
+		models.Transaction{AccountId: legs.creditAccountId, Amount: legs.creditAmount, CurrencyCode: legs.creditCurrencyCode, AvailableBalance: newCreditBalance, IsCredit: true,
+			Reference: e.Reference, OriginalReference: originalReference, FxRate: &fxRate, SourceAmount: &sourceAmount, DestinationAmount: &destinationAmount, IsFee: legs.isFee},
+	)
+}
+
+// post writes entry within tx once its postings balance, followed by its transaction rows and its postings.
+// An entry that does not balance is refused with ErrUnbalancedJournalEntry; Postgres checks it again on commit
+func (r *JournalRepository) post(tx *sql.Tx, entry *journalEntry) error {
+	fName := "JournalRepository.post"
+	if err := entry.Validate(); err != nil {
+		log.Printf("[%s] refused unbalanced journal entry %s: %+v", fName, entry.Reference, entry.Postings)
+		return err
+	}
+
+	originalReference := sql.NullString{String: entry.OriginalReference, Valid: entry.OriginalReference != ""}
+	query := `INSERT INTO journal_entries (reference, original_reference) VALUES ($1, $2) RETURNING id, created_at`
+	if err := tx.QueryRow(query, entry.Reference, originalReference).Scan(&entry.Id, &entry.CreatedAt); err != nil {
+		log.Printf("[%s] failed to create journal entry: %v", fName, err)
+		return retryableOr(err, appErr.ErrTransactionFailed)
+	}
+
+	query = `INSERT INTO transactions (account_id, amount, currency_code, available_balance, is_credit, reference, original_reference, fx_rate, source_amount, destination_amount, is_fee, journal_entry_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	for _, t := range entry.transactions {
+		if _, err := tx.Exec(query, t.AccountId, t.Amount, t.CurrencyCode, t.AvailableBalance, t.IsCredit, t.Reference, t.OriginalReference, t.FxRate, t.SourceAmount, t.DestinationAmount, t.IsFee, entry.Id); err != nil {
+			log.Printf("[%s] failed to create transaction record of account %d: %v", fName, t.AccountId, err)
+			return appErr.ErrTransactionFailed
+		}
+	}
+
+	values := make([]string, len(entry.Postings))
+	args := make([]interface{}, 0, 5*len(entry.Postings))
+	for i, p := range entry.Postings {
+		values[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", 5*i+1, 5*i+2, 5*i+3, 5*i+4, 5*i+5)
+		args = append(args, entry.Id, p.AccountId, p.Kind, p.Amount, p.CurrencyCode)
+	}
+	query = `INSERT INTO postings (journal_entry_id, account_id, kind, amount, currency_code) VALUES ` + strings.Join(values, ", ")
+	if _, err := tx.Exec(query, args...); err != nil {
+		log.Printf("[%s] failed to create postings: %v", fName, err)
+		return appErr.ErrTransactionFailed
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournalRepository_Post(t *testing.T) {
+	crossCurrency := transferLegs{
+		debitAccountId:     1,
+		creditAccountId:    2,
+		amount:             models.NewMoney(100),
+		currencyCode:       "USD",
+		creditAmount:       models.MustParseMoney("92.35"),
+		creditCurrencyCode: "EUR",
+		fxRate:             models.MustParseExchangeRate("0.9235"),
+	}
+
+	t.Run("reversal posted through the FX position", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+		original := sql.NullString{String: "TXN-1", Valid: true}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO journal_entries \\(reference, original_reference\\) VALUES \\(\\$1, \\$2\\) RETURNING id, created_at").
+			WithArgs("TXN-2", original).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+		mock.ExpectExec("INSERT INTO transactions").
+			WithArgs(int64(1), models.NewMoney(100), "USD", models.NewMoney(400), false, "TXN-2", original, crossCurrency.fxRate, models.NewMoney(100), crossCurrency.creditAmount, false, int64(7)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO transactions").
+			WithArgs(int64(2), crossCurrency.creditAmount, "EUR", models.MustParseMoney("192.35"), true, "TXN-2", original, crossCurrency.fxRate, models.NewMoney(100), crossCurrency.creditAmount, false, int64(7)).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec("INSERT INTO postings \\(journal_entry_id, account_id, kind, amount, currency_code\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\), (.+), \\(\\$16, \\$17, \\$18, \\$19, \\$20\\)").
+			WithArgs(int64(7), int64(1), models.PostingKindTransfer, models.NewMoney(-100), "USD",
+				int64(7), int64(2), models.PostingKindTransfer, crossCurrency.creditAmount, "EUR",
+				int64(7), nil, models.PostingKindFx, models.NewMoney(100), "USD",
+				int64(7), nil, models.PostingKindFx, crossCurrency.creditAmount.Neg(), "EUR").
+			WillReturnResult(sqlmock.NewResult(4, 4))
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+		entry := newJournalEntry("TXN-2", "TXN-1")
+		entry.addLegs(crossCurrency, models.NewMoney(400), models.MustParseMoney("192.35"))
+
+		assert.NoError(t, (&JournalRepository{db: db}).post(tx, entry))
+		assert.Equal(t, int64(7), entry.Id)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unbalanced entry refused before anything is written", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+		mock.ExpectBegin()
+
+		tx, err := db.Begin()
+		assert.NoError(t, err)
+		entry := newJournalEntry("TXN-2", "")
+		entry.addLegs(crossCurrency, models.NewMoney(400), models.MustParseMoney("192.35"))
+		entry.Postings = entry.Postings[:2]
+
+		assert.Equal(t, appErr.ErrUnbalancedJournalEntry, (&JournalRepository{db: db}).post(tx, entry))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
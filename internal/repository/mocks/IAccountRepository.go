@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	sql "database/sql"
+
+	time "time"
 )
 
 // IAccountRepository is an autogenerated mock type for the IAccountRepository type
@@ -22,17 +24,73 @@ func (_m *IAccountRepository) EXPECT() *IAccountRepository_Expecter {
 	return &IAccountRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateAccount provides a mock function with given fields: account
-func (_m *IAccountRepository) CreateAccount(account models.Account) error {
-	ret := _m.Called(account)
+// CloseAccount provides a mock function with given fields: args
+func (_m *IAccountRepository) CloseAccount(args models.CloseAccountArgs) (models.CloseAccountResponse, error) {
+	ret := _m.Called(args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseAccount")
+	}
+
+	var r0 models.CloseAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.CloseAccountArgs) (models.CloseAccountResponse, error)); ok {
+		return rf(args)
+	}
+	if rf, ok := ret.Get(0).(func(models.CloseAccountArgs) models.CloseAccountResponse); ok {
+		r0 = rf(args)
+	} else {
+		r0 = ret.Get(0).(models.CloseAccountResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.CloseAccountArgs) error); ok {
+		r1 = rf(args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountRepository_CloseAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccount'
+type IAccountRepository_CloseAccount_Call struct {
+	*mock.Call
+}
+
+// CloseAccount is a helper method to define mock.On call
+//   - args models.CloseAccountArgs
+func (_e *IAccountRepository_Expecter) CloseAccount(args interface{}) *IAccountRepository_CloseAccount_Call {
+	return &IAccountRepository_CloseAccount_Call{Call: _e.mock.On("CloseAccount", args)}
+}
+
+func (_c *IAccountRepository_CloseAccount_Call) Run(run func(args models.CloseAccountArgs)) *IAccountRepository_CloseAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.CloseAccountArgs))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_CloseAccount_Call) Return(_a0 models.CloseAccountResponse, _a1 error) *IAccountRepository_CloseAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountRepository_CloseAccount_Call) RunAndReturn(run func(models.CloseAccountArgs) (models.CloseAccountResponse, error)) *IAccountRepository_CloseAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAccount provides a mock function with given fields: args
+func (_m *IAccountRepository) CreateAccount(args models.CreateAccountArgs) error {
+	ret := _m.Called(args)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAccount")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(models.Account) error); ok {
-		r0 = rf(account)
+	if rf, ok := ret.Get(0).(func(models.CreateAccountArgs) error); ok {
+		r0 = rf(args)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -46,14 +104,14 @@ type IAccountRepository_CreateAccount_Call struct {
 }
 
 // CreateAccount is a helper method to define mock.On call
-//   - account models.Account
-func (_e *IAccountRepository_Expecter) CreateAccount(account interface{}) *IAccountRepository_CreateAccount_Call {
-	return &IAccountRepository_CreateAccount_Call{Call: _e.mock.On("CreateAccount", account)}
+//   - args models.CreateAccountArgs
+func (_e *IAccountRepository_Expecter) CreateAccount(args interface{}) *IAccountRepository_CreateAccount_Call {
+	return &IAccountRepository_CreateAccount_Call{Call: _e.mock.On("CreateAccount", args)}
 }
 
-func (_c *IAccountRepository_CreateAccount_Call) Run(run func(account models.Account)) *IAccountRepository_CreateAccount_Call {
+func (_c *IAccountRepository_CreateAccount_Call) Run(run func(args models.CreateAccountArgs)) *IAccountRepository_CreateAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(models.Account))
+		run(args[0].(models.CreateAccountArgs))
 	})
 	return _c
 }
@@ -63,32 +121,32 @@ func (_c *IAccountRepository_CreateAccount_Call) Return(_a0 error) *IAccountRepo
 	return _c
 }
 
-func (_c *IAccountRepository_CreateAccount_Call) RunAndReturn(run func(models.Account) error) *IAccountRepository_CreateAccount_Call {
+func (_c *IAccountRepository_CreateAccount_Call) RunAndReturn(run func(models.CreateAccountArgs) error) *IAccountRepository_CreateAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetBalanceForUpdate provides a mock function with given fields: tx, accountId
-func (_m *IAccountRepository) GetBalanceForUpdate(tx *sql.Tx, accountId int64) (float64, error) {
-	ret := _m.Called(tx, accountId)
+// GetBalanceAsOf provides a mock function with given fields: accountId, asOf
+func (_m *IAccountRepository) GetBalanceAsOf(accountId int64, asOf time.Time) (models.Money, error) {
+	ret := _m.Called(accountId, asOf)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBalanceForUpdate")
+		panic("no return value specified for GetBalanceAsOf")
 	}
 
-	var r0 float64
+	var r0 models.Money
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*sql.Tx, int64) (float64, error)); ok {
-		return rf(tx, accountId)
+	if rf, ok := ret.Get(0).(func(int64, time.Time) (models.Money, error)); ok {
+		return rf(accountId, asOf)
 	}
-	if rf, ok := ret.Get(0).(func(*sql.Tx, int64) float64); ok {
-		r0 = rf(tx, accountId)
+	if rf, ok := ret.Get(0).(func(int64, time.Time) models.Money); ok {
+		r0 = rf(accountId, asOf)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(models.Money)
 	}
 
-	if rf, ok := ret.Get(1).(func(*sql.Tx, int64) error); ok {
-		r1 = rf(tx, accountId)
+	if rf, ok := ret.Get(1).(func(int64, time.Time) error); ok {
+		r1 = rf(accountId, asOf)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -96,31 +154,31 @@ func (_m *IAccountRepository) GetBalanceForUpdate(tx *sql.Tx, accountId int64) (
 	return r0, r1
 }
 
-// IAccountRepository_GetBalanceForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceForUpdate'
-type IAccountRepository_GetBalanceForUpdate_Call struct {
+// IAccountRepository_GetBalanceAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAsOf'
+type IAccountRepository_GetBalanceAsOf_Call struct {
 	*mock.Call
 }
 
-// GetBalanceForUpdate is a helper method to define mock.On call
-//   - tx *sql.Tx
+// GetBalanceAsOf is a helper method to define mock.On call
 //   - accountId int64
-func (_e *IAccountRepository_Expecter) GetBalanceForUpdate(tx interface{}, accountId interface{}) *IAccountRepository_GetBalanceForUpdate_Call {
-	return &IAccountRepository_GetBalanceForUpdate_Call{Call: _e.mock.On("GetBalanceForUpdate", tx, accountId)}
+//   - asOf time.Time
+func (_e *IAccountRepository_Expecter) GetBalanceAsOf(accountId interface{}, asOf interface{}) *IAccountRepository_GetBalanceAsOf_Call {
+	return &IAccountRepository_GetBalanceAsOf_Call{Call: _e.mock.On("GetBalanceAsOf", accountId, asOf)}
 }
 
-func (_c *IAccountRepository_GetBalanceForUpdate_Call) Run(run func(tx *sql.Tx, accountId int64)) *IAccountRepository_GetBalanceForUpdate_Call {
+func (_c *IAccountRepository_GetBalanceAsOf_Call) Run(run func(accountId int64, asOf time.Time)) *IAccountRepository_GetBalanceAsOf_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*sql.Tx), args[1].(int64))
+		run(args[0].(int64), args[1].(time.Time))
 	})
 	return _c
 }
 
-func (_c *IAccountRepository_GetBalanceForUpdate_Call) Return(_a0 float64, _a1 error) *IAccountRepository_GetBalanceForUpdate_Call {
+func (_c *IAccountRepository_GetBalanceAsOf_Call) Return(_a0 models.Money, _a1 error) *IAccountRepository_GetBalanceAsOf_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *IAccountRepository_GetBalanceForUpdate_Call) RunAndReturn(run func(*sql.Tx, int64) (float64, error)) *IAccountRepository_GetBalanceForUpdate_Call {
+func (_c *IAccountRepository_GetBalanceAsOf_Call) RunAndReturn(run func(int64, time.Time) (models.Money, error)) *IAccountRepository_GetBalanceAsOf_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -183,8 +241,209 @@ func (_c *IAccountRepository_GetByAccountId_Call) RunAndReturn(run func(int64) (
 	return _c
 }
 
+// GetForUpdate provides a mock function with given fields: tx, accountId
+func (_m *IAccountRepository) GetForUpdate(tx *sql.Tx, accountId int64) (*models.Account, error) {
+	ret := _m.Called(tx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetForUpdate")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sql.Tx, int64) (*models.Account, error)); ok {
+		return rf(tx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(*sql.Tx, int64) *models.Account); ok {
+		r0 = rf(tx, accountId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sql.Tx, int64) error); ok {
+		r1 = rf(tx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountRepository_GetForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUpdate'
+type IAccountRepository_GetForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetForUpdate is a helper method to define mock.On call
+//   - tx *sql.Tx
+//   - accountId int64
+func (_e *IAccountRepository_Expecter) GetForUpdate(tx interface{}, accountId interface{}) *IAccountRepository_GetForUpdate_Call {
+	return &IAccountRepository_GetForUpdate_Call{Call: _e.mock.On("GetForUpdate", tx, accountId)}
+}
+
+func (_c *IAccountRepository_GetForUpdate_Call) Run(run func(tx *sql.Tx, accountId int64)) *IAccountRepository_GetForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sql.Tx), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_GetForUpdate_Call) Return(_a0 *models.Account, _a1 error) *IAccountRepository_GetForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountRepository_GetForUpdate_Call) RunAndReturn(run func(*sql.Tx, int64) (*models.Account, error)) *IAccountRepository_GetForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOverdraftLimit provides a mock function with given fields: accountId, limit
+func (_m *IAccountRepository) SetOverdraftLimit(accountId int64, limit models.Money) error {
+	ret := _m.Called(accountId, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOverdraftLimit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, models.Money) error); ok {
+		r0 = rf(accountId, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccountRepository_SetOverdraftLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverdraftLimit'
+type IAccountRepository_SetOverdraftLimit_Call struct {
+	*mock.Call
+}
+
+// SetOverdraftLimit is a helper method to define mock.On call
+//   - accountId int64
+//   - limit models.Money
+func (_e *IAccountRepository_Expecter) SetOverdraftLimit(accountId interface{}, limit interface{}) *IAccountRepository_SetOverdraftLimit_Call {
+	return &IAccountRepository_SetOverdraftLimit_Call{Call: _e.mock.On("SetOverdraftLimit", accountId, limit)}
+}
+
+func (_c *IAccountRepository_SetOverdraftLimit_Call) Run(run func(accountId int64, limit models.Money)) *IAccountRepository_SetOverdraftLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(models.Money))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_SetOverdraftLimit_Call) Return(_a0 error) *IAccountRepository_SetOverdraftLimit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccountRepository_SetOverdraftLimit_Call) RunAndReturn(run func(int64, models.Money) error) *IAccountRepository_SetOverdraftLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOwner provides a mock function with given fields: accountId, ownerClientId
+func (_m *IAccountRepository) SetOwner(accountId int64, ownerClientId int64) error {
+	ret := _m.Called(accountId, ownerClientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOwner")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
+		r0 = rf(accountId, ownerClientId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccountRepository_SetOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOwner'
+type IAccountRepository_SetOwner_Call struct {
+	*mock.Call
+}
+
+// SetOwner is a helper method to define mock.On call
+//   - accountId int64
+//   - ownerClientId int64
+func (_e *IAccountRepository_Expecter) SetOwner(accountId interface{}, ownerClientId interface{}) *IAccountRepository_SetOwner_Call {
+	return &IAccountRepository_SetOwner_Call{Call: _e.mock.On("SetOwner", accountId, ownerClientId)}
+}
+
+func (_c *IAccountRepository_SetOwner_Call) Run(run func(accountId int64, ownerClientId int64)) *IAccountRepository_SetOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_SetOwner_Call) Return(_a0 error) *IAccountRepository_SetOwner_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccountRepository_SetOwner_Call) RunAndReturn(run func(int64, int64) error) *IAccountRepository_SetOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function with given fields: accountId, from, to
+func (_m *IAccountRepository) SetStatus(accountId int64, from models.AccountStatus, to models.AccountStatus) error {
+	ret := _m.Called(accountId, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, models.AccountStatus, models.AccountStatus) error); ok {
+		r0 = rf(accountId, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccountRepository_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type IAccountRepository_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - accountId int64
+//   - from models.AccountStatus
+//   - to models.AccountStatus
+func (_e *IAccountRepository_Expecter) SetStatus(accountId interface{}, from interface{}, to interface{}) *IAccountRepository_SetStatus_Call {
+	return &IAccountRepository_SetStatus_Call{Call: _e.mock.On("SetStatus", accountId, from, to)}
+}
+
+func (_c *IAccountRepository_SetStatus_Call) Run(run func(accountId int64, from models.AccountStatus, to models.AccountStatus)) *IAccountRepository_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(models.AccountStatus), args[2].(models.AccountStatus))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_SetStatus_Call) Return(_a0 error) *IAccountRepository_SetStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccountRepository_SetStatus_Call) RunAndReturn(run func(int64, models.AccountStatus, models.AccountStatus) error) *IAccountRepository_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateBalance provides a mock function with given fields: tx, accountId, newBalance
-func (_m *IAccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBalance float64) error {
+func (_m *IAccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBalance models.Money) error {
 	ret := _m.Called(tx, accountId, newBalance)
 
 	if len(ret) == 0 {
@@ -192,7 +451,7 @@ func (_m *IAccountRepository) UpdateBalance(tx *sql.Tx, accountId int64, newBala
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*sql.Tx, int64, float64) error); ok {
+	if rf, ok := ret.Get(0).(func(*sql.Tx, int64, models.Money) error); ok {
 		r0 = rf(tx, accountId, newBalance)
 	} else {
 		r0 = ret.Error(0)
@@ -209,14 +468,14 @@ type IAccountRepository_UpdateBalance_Call struct {
 // UpdateBalance is a helper method to define mock.On call
 //   - tx *sql.Tx
 //   - accountId int64
-//   - newBalance float64
+//   - newBalance models.Money
 func (_e *IAccountRepository_Expecter) UpdateBalance(tx interface{}, accountId interface{}, newBalance interface{}) *IAccountRepository_UpdateBalance_Call {
 	return &IAccountRepository_UpdateBalance_Call{Call: _e.mock.On("UpdateBalance", tx, accountId, newBalance)}
 }
 
-func (_c *IAccountRepository_UpdateBalance_Call) Run(run func(tx *sql.Tx, accountId int64, newBalance float64)) *IAccountRepository_UpdateBalance_Call {
+func (_c *IAccountRepository_UpdateBalance_Call) Run(run func(tx *sql.Tx, accountId int64, newBalance models.Money)) *IAccountRepository_UpdateBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*sql.Tx), args[1].(int64), args[2].(float64))
+		run(args[0].(*sql.Tx), args[1].(int64), args[2].(models.Money))
 	})
 	return _c
 }
@@ -226,7 +485,55 @@ func (_c *IAccountRepository_UpdateBalance_Call) Return(_a0 error) *IAccountRepo
 	return _c
 }
 
-func (_c *IAccountRepository_UpdateBalance_Call) RunAndReturn(run func(*sql.Tx, int64, float64) error) *IAccountRepository_UpdateBalance_Call {
+func (_c *IAccountRepository_UpdateBalance_Call) RunAndReturn(run func(*sql.Tx, int64, models.Money) error) *IAccountRepository_UpdateBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateHeldAmount provides a mock function with given fields: tx, accountId, heldAmount
+func (_m *IAccountRepository) UpdateHeldAmount(tx *sql.Tx, accountId int64, heldAmount models.Money) error {
+	ret := _m.Called(tx, accountId, heldAmount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateHeldAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sql.Tx, int64, models.Money) error); ok {
+		r0 = rf(tx, accountId, heldAmount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IAccountRepository_UpdateHeldAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateHeldAmount'
+type IAccountRepository_UpdateHeldAmount_Call struct {
+	*mock.Call
+}
+
+// UpdateHeldAmount is a helper method to define mock.On call
+//   - tx *sql.Tx
+//   - accountId int64
+//   - heldAmount models.Money
+func (_e *IAccountRepository_Expecter) UpdateHeldAmount(tx interface{}, accountId interface{}, heldAmount interface{}) *IAccountRepository_UpdateHeldAmount_Call {
+	return &IAccountRepository_UpdateHeldAmount_Call{Call: _e.mock.On("UpdateHeldAmount", tx, accountId, heldAmount)}
+}
+
+func (_c *IAccountRepository_UpdateHeldAmount_Call) Run(run func(tx *sql.Tx, accountId int64, heldAmount models.Money)) *IAccountRepository_UpdateHeldAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sql.Tx), args[1].(int64), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *IAccountRepository_UpdateHeldAmount_Call) Return(_a0 error) *IAccountRepository_UpdateHeldAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IAccountRepository_UpdateHeldAmount_Call) RunAndReturn(run func(*sql.Tx, int64, models.Money) error) *IAccountRepository_UpdateHeldAmount_Call {
 	_c.Call.Return(run)
 	return _c
 }
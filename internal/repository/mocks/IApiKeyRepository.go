@@ -0,0 +1,367 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IApiKeyRepository is an autogenerated mock type for the IApiKeyRepository type
+type IApiKeyRepository struct {
+	mock.Mock
+}
+
+type IApiKeyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IApiKeyRepository) EXPECT() *IApiKeyRepository_Expecter {
+	return &IApiKeyRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateApiKey provides a mock function with given fields: key, keyHash
+func (_m *IApiKeyRepository) CreateApiKey(key models.ApiKey, keyHash string) (*models.ApiKey, error) {
+	ret := _m.Called(key, keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApiKey")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ApiKey, string) (*models.ApiKey, error)); ok {
+		return rf(key, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(models.ApiKey, string) *models.ApiKey); ok {
+		r0 = rf(key, keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ApiKey, string) error); ok {
+		r1 = rf(key, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyRepository_CreateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApiKey'
+type IApiKeyRepository_CreateApiKey_Call struct {
+	*mock.Call
+}
+
+// CreateApiKey is a helper method to define mock.On call
+//   - key models.ApiKey
+//   - keyHash string
+func (_e *IApiKeyRepository_Expecter) CreateApiKey(key interface{}, keyHash interface{}) *IApiKeyRepository_CreateApiKey_Call {
+	return &IApiKeyRepository_CreateApiKey_Call{Call: _e.mock.On("CreateApiKey", key, keyHash)}
+}
+
+func (_c *IApiKeyRepository_CreateApiKey_Call) Run(run func(key models.ApiKey, keyHash string)) *IApiKeyRepository_CreateApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.ApiKey), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_CreateApiKey_Call) Return(_a0 *models.ApiKey, _a1 error) *IApiKeyRepository_CreateApiKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyRepository_CreateApiKey_Call) RunAndReturn(run func(models.ApiKey, string) (*models.ApiKey, error)) *IApiKeyRepository_CreateApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveApiKey provides a mock function with given fields: keyHash
+func (_m *IApiKeyRepository) GetActiveApiKey(keyHash string) (*models.ApiKey, error) {
+	ret := _m.Called(keyHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveApiKey")
+	}
+
+	var r0 *models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.ApiKey, error)); ok {
+		return rf(keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.ApiKey); ok {
+		r0 = rf(keyHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyRepository_GetActiveApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveApiKey'
+type IApiKeyRepository_GetActiveApiKey_Call struct {
+	*mock.Call
+}
+
+// GetActiveApiKey is a helper method to define mock.On call
+//   - keyHash string
+func (_e *IApiKeyRepository_Expecter) GetActiveApiKey(keyHash interface{}) *IApiKeyRepository_GetActiveApiKey_Call {
+	return &IApiKeyRepository_GetActiveApiKey_Call{Call: _e.mock.On("GetActiveApiKey", keyHash)}
+}
+
+func (_c *IApiKeyRepository_GetActiveApiKey_Call) Run(run func(keyHash string)) *IApiKeyRepository_GetActiveApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_GetActiveApiKey_Call) Return(_a0 *models.ApiKey, _a1 error) *IApiKeyRepository_GetActiveApiKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyRepository_GetActiveApiKey_Call) RunAndReturn(run func(string) (*models.ApiKey, error)) *IApiKeyRepository_GetActiveApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetActiveSigningKey provides a mock function with given fields: id
+func (_m *IApiKeyRepository) GetActiveSigningKey(id int64) (*models.ApiKey, string, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetActiveSigningKey")
+	}
+
+	var r0 *models.ApiKey
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int64) (*models.ApiKey, string, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *models.ApiKey); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) string); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(int64) error); ok {
+		r2 = rf(id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IApiKeyRepository_GetActiveSigningKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetActiveSigningKey'
+type IApiKeyRepository_GetActiveSigningKey_Call struct {
+	*mock.Call
+}
+
+// GetActiveSigningKey is a helper method to define mock.On call
+//   - id int64
+func (_e *IApiKeyRepository_Expecter) GetActiveSigningKey(id interface{}) *IApiKeyRepository_GetActiveSigningKey_Call {
+	return &IApiKeyRepository_GetActiveSigningKey_Call{Call: _e.mock.On("GetActiveSigningKey", id)}
+}
+
+func (_c *IApiKeyRepository_GetActiveSigningKey_Call) Run(run func(id int64)) *IApiKeyRepository_GetActiveSigningKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_GetActiveSigningKey_Call) Return(_a0 *models.ApiKey, _a1 string, _a2 error) *IApiKeyRepository_GetActiveSigningKey_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IApiKeyRepository_GetActiveSigningKey_Call) RunAndReturn(run func(int64) (*models.ApiKey, string, error)) *IApiKeyRepository_GetActiveSigningKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListApiKeys provides a mock function with no fields
+func (_m *IApiKeyRepository) ListApiKeys() ([]models.ApiKey, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListApiKeys")
+	}
+
+	var r0 []models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.ApiKey, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.ApiKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyRepository_ListApiKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListApiKeys'
+type IApiKeyRepository_ListApiKeys_Call struct {
+	*mock.Call
+}
+
+// ListApiKeys is a helper method to define mock.On call
+func (_e *IApiKeyRepository_Expecter) ListApiKeys() *IApiKeyRepository_ListApiKeys_Call {
+	return &IApiKeyRepository_ListApiKeys_Call{Call: _e.mock.On("ListApiKeys")}
+}
+
+func (_c *IApiKeyRepository_ListApiKeys_Call) Run(run func()) *IApiKeyRepository_ListApiKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_ListApiKeys_Call) Return(_a0 []models.ApiKey, _a1 error) *IApiKeyRepository_ListApiKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyRepository_ListApiKeys_Call) RunAndReturn(run func() ([]models.ApiKey, error)) *IApiKeyRepository_ListApiKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeApiKey provides a mock function with given fields: id
+func (_m *IApiKeyRepository) RevokeApiKey(id int64) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IApiKeyRepository_RevokeApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeApiKey'
+type IApiKeyRepository_RevokeApiKey_Call struct {
+	*mock.Call
+}
+
+// RevokeApiKey is a helper method to define mock.On call
+//   - id int64
+func (_e *IApiKeyRepository_Expecter) RevokeApiKey(id interface{}) *IApiKeyRepository_RevokeApiKey_Call {
+	return &IApiKeyRepository_RevokeApiKey_Call{Call: _e.mock.On("RevokeApiKey", id)}
+}
+
+func (_c *IApiKeyRepository_RevokeApiKey_Call) Run(run func(id int64)) *IApiKeyRepository_RevokeApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_RevokeApiKey_Call) Return(_a0 error) *IApiKeyRepository_RevokeApiKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IApiKeyRepository_RevokeApiKey_Call) RunAndReturn(run func(int64) error) *IApiKeyRepository_RevokeApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetSigningSecret provides a mock function with given fields: id, secret
+func (_m *IApiKeyRepository) SetSigningSecret(id int64, secret string) error {
+	ret := _m.Called(id, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSigningSecret")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(id, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IApiKeyRepository_SetSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetSigningSecret'
+type IApiKeyRepository_SetSigningSecret_Call struct {
+	*mock.Call
+}
+
+// SetSigningSecret is a helper method to define mock.On call
+//   - id int64
+//   - secret string
+func (_e *IApiKeyRepository_Expecter) SetSigningSecret(id interface{}, secret interface{}) *IApiKeyRepository_SetSigningSecret_Call {
+	return &IApiKeyRepository_SetSigningSecret_Call{Call: _e.mock.On("SetSigningSecret", id, secret)}
+}
+
+func (_c *IApiKeyRepository_SetSigningSecret_Call) Run(run func(id int64, secret string)) *IApiKeyRepository_SetSigningSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IApiKeyRepository_SetSigningSecret_Call) Return(_a0 error) *IApiKeyRepository_SetSigningSecret_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IApiKeyRepository_SetSigningSecret_Call) RunAndReturn(run func(int64, string) error) *IApiKeyRepository_SetSigningSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIApiKeyRepository creates a new instance of IApiKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIApiKeyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IApiKeyRepository {
+	mock := &IApiKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IBalanceSnapshotRepository is an autogenerated mock type for the IBalanceSnapshotRepository type
+type IBalanceSnapshotRepository struct {
+	mock.Mock
+}
+
+type IBalanceSnapshotRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IBalanceSnapshotRepository) EXPECT() *IBalanceSnapshotRepository_Expecter {
+	return &IBalanceSnapshotRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetLatestSnapshotDay provides a mock function with no fields
+func (_m *IBalanceSnapshotRepository) GetLatestSnapshotDay() (*time.Time, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestSnapshotDay")
+	}
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*time.Time, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *time.Time); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceSnapshotRepository_GetLatestSnapshotDay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestSnapshotDay'
+type IBalanceSnapshotRepository_GetLatestSnapshotDay_Call struct {
+	*mock.Call
+}
+
+// GetLatestSnapshotDay is a helper method to define mock.On call
+func (_e *IBalanceSnapshotRepository_Expecter) GetLatestSnapshotDay() *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call {
+	return &IBalanceSnapshotRepository_GetLatestSnapshotDay_Call{Call: _e.mock.On("GetLatestSnapshotDay")}
+}
+
+func (_c *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call) Run(run func()) *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call) Return(_a0 *time.Time, _a1 error) *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call) RunAndReturn(run func() (*time.Time, error)) *IBalanceSnapshotRepository_GetLatestSnapshotDay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveSnapshots provides a mock function with given fields: day
+func (_m *IBalanceSnapshotRepository) SaveSnapshots(day time.Time) (int64, error) {
+	ret := _m.Called(day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSnapshots")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(day)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(day)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IBalanceSnapshotRepository_SaveSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveSnapshots'
+type IBalanceSnapshotRepository_SaveSnapshots_Call struct {
+	*mock.Call
+}
+
+// SaveSnapshots is a helper method to define mock.On call
+//   - day time.Time
+func (_e *IBalanceSnapshotRepository_Expecter) SaveSnapshots(day interface{}) *IBalanceSnapshotRepository_SaveSnapshots_Call {
+	return &IBalanceSnapshotRepository_SaveSnapshots_Call{Call: _e.mock.On("SaveSnapshots", day)}
+}
+
+func (_c *IBalanceSnapshotRepository_SaveSnapshots_Call) Run(run func(day time.Time)) *IBalanceSnapshotRepository_SaveSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IBalanceSnapshotRepository_SaveSnapshots_Call) Return(_a0 int64, _a1 error) *IBalanceSnapshotRepository_SaveSnapshots_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IBalanceSnapshotRepository_SaveSnapshots_Call) RunAndReturn(run func(time.Time) (int64, error)) *IBalanceSnapshotRepository_SaveSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIBalanceSnapshotRepository creates a new instance of IBalanceSnapshotRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBalanceSnapshotRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBalanceSnapshotRepository {
+	mock := &IBalanceSnapshotRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
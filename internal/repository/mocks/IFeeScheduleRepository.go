@@ -0,0 +1,337 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IFeeScheduleRepository is an autogenerated mock type for the IFeeScheduleRepository type
+type IFeeScheduleRepository struct {
+	mock.Mock
+}
+
+type IFeeScheduleRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IFeeScheduleRepository) EXPECT() *IFeeScheduleRepository_Expecter {
+	return &IFeeScheduleRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: accountId
+func (_m *IFeeScheduleRepository) Delete(accountId int64) error {
+	ret := _m.Called(accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(accountId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IFeeScheduleRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type IFeeScheduleRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - accountId int64
+func (_e *IFeeScheduleRepository_Expecter) Delete(accountId interface{}) *IFeeScheduleRepository_Delete_Call {
+	return &IFeeScheduleRepository_Delete_Call{Call: _e.mock.On("Delete", accountId)}
+}
+
+func (_c *IFeeScheduleRepository_Delete_Call) Run(run func(accountId int64)) *IFeeScheduleRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_Delete_Call) Return(_a0 error) *IFeeScheduleRepository_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_Delete_Call) RunAndReturn(run func(int64) error) *IFeeScheduleRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCurrency provides a mock function with given fields: currencyCode
+func (_m *IFeeScheduleRepository) DeleteCurrency(currencyCode string) error {
+	ret := _m.Called(currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(currencyCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IFeeScheduleRepository_DeleteCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCurrency'
+type IFeeScheduleRepository_DeleteCurrency_Call struct {
+	*mock.Call
+}
+
+// DeleteCurrency is a helper method to define mock.On call
+//   - currencyCode string
+func (_e *IFeeScheduleRepository_Expecter) DeleteCurrency(currencyCode interface{}) *IFeeScheduleRepository_DeleteCurrency_Call {
+	return &IFeeScheduleRepository_DeleteCurrency_Call{Call: _e.mock.On("DeleteCurrency", currencyCode)}
+}
+
+func (_c *IFeeScheduleRepository_DeleteCurrency_Call) Run(run func(currencyCode string)) *IFeeScheduleRepository_DeleteCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_DeleteCurrency_Call) Return(_a0 error) *IFeeScheduleRepository_DeleteCurrency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_DeleteCurrency_Call) RunAndReturn(run func(string) error) *IFeeScheduleRepository_DeleteCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByAccountId provides a mock function with given fields: accountId
+func (_m *IFeeScheduleRepository) GetByAccountId(accountId int64) (*models.FeeSchedule, error) {
+	ret := _m.Called(accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByAccountId")
+	}
+
+	var r0 *models.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*models.FeeSchedule, error)); ok {
+		return rf(accountId)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *models.FeeSchedule); ok {
+		r0 = rf(accountId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FeeSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeScheduleRepository_GetByAccountId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByAccountId'
+type IFeeScheduleRepository_GetByAccountId_Call struct {
+	*mock.Call
+}
+
+// GetByAccountId is a helper method to define mock.On call
+//   - accountId int64
+func (_e *IFeeScheduleRepository_Expecter) GetByAccountId(accountId interface{}) *IFeeScheduleRepository_GetByAccountId_Call {
+	return &IFeeScheduleRepository_GetByAccountId_Call{Call: _e.mock.On("GetByAccountId", accountId)}
+}
+
+func (_c *IFeeScheduleRepository_GetByAccountId_Call) Run(run func(accountId int64)) *IFeeScheduleRepository_GetByAccountId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_GetByAccountId_Call) Return(_a0 *models.FeeSchedule, _a1 error) *IFeeScheduleRepository_GetByAccountId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_GetByAccountId_Call) RunAndReturn(run func(int64) (*models.FeeSchedule, error)) *IFeeScheduleRepository_GetByAccountId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByCurrency provides a mock function with given fields: currencyCode
+func (_m *IFeeScheduleRepository) GetByCurrency(currencyCode string) (*models.FeeSchedule, error) {
+	ret := _m.Called(currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCurrency")
+	}
+
+	var r0 *models.FeeSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.FeeSchedule, error)); ok {
+		return rf(currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.FeeSchedule); ok {
+		r0 = rf(currencyCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FeeSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeScheduleRepository_GetByCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByCurrency'
+type IFeeScheduleRepository_GetByCurrency_Call struct {
+	*mock.Call
+}
+
+// GetByCurrency is a helper method to define mock.On call
+//   - currencyCode string
+func (_e *IFeeScheduleRepository_Expecter) GetByCurrency(currencyCode interface{}) *IFeeScheduleRepository_GetByCurrency_Call {
+	return &IFeeScheduleRepository_GetByCurrency_Call{Call: _e.mock.On("GetByCurrency", currencyCode)}
+}
+
+func (_c *IFeeScheduleRepository_GetByCurrency_Call) Run(run func(currencyCode string)) *IFeeScheduleRepository_GetByCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_GetByCurrency_Call) Return(_a0 *models.FeeSchedule, _a1 error) *IFeeScheduleRepository_GetByCurrency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_GetByCurrency_Call) RunAndReturn(run func(string) (*models.FeeSchedule, error)) *IFeeScheduleRepository_GetByCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: accountId, schedule
+func (_m *IFeeScheduleRepository) Upsert(accountId int64, schedule models.FeeSchedule) error {
+	ret := _m.Called(accountId, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, models.FeeSchedule) error); ok {
+		r0 = rf(accountId, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IFeeScheduleRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type IFeeScheduleRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - accountId int64
+//   - schedule models.FeeSchedule
+func (_e *IFeeScheduleRepository_Expecter) Upsert(accountId interface{}, schedule interface{}) *IFeeScheduleRepository_Upsert_Call {
+	return &IFeeScheduleRepository_Upsert_Call{Call: _e.mock.On("Upsert", accountId, schedule)}
+}
+
+func (_c *IFeeScheduleRepository_Upsert_Call) Run(run func(accountId int64, schedule models.FeeSchedule)) *IFeeScheduleRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(models.FeeSchedule))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_Upsert_Call) Return(_a0 error) *IFeeScheduleRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_Upsert_Call) RunAndReturn(run func(int64, models.FeeSchedule) error) *IFeeScheduleRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCurrency provides a mock function with given fields: currencyCode, schedule
+func (_m *IFeeScheduleRepository) UpsertCurrency(currencyCode string, schedule models.FeeSchedule) error {
+	ret := _m.Called(currencyCode, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.FeeSchedule) error); ok {
+		r0 = rf(currencyCode, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IFeeScheduleRepository_UpsertCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCurrency'
+type IFeeScheduleRepository_UpsertCurrency_Call struct {
+	*mock.Call
+}
+
+// UpsertCurrency is a helper method to define mock.On call
+//   - currencyCode string
+//   - schedule models.FeeSchedule
+func (_e *IFeeScheduleRepository_Expecter) UpsertCurrency(currencyCode interface{}, schedule interface{}) *IFeeScheduleRepository_UpsertCurrency_Call {
+	return &IFeeScheduleRepository_UpsertCurrency_Call{Call: _e.mock.On("UpsertCurrency", currencyCode, schedule)}
+}
+
+func (_c *IFeeScheduleRepository_UpsertCurrency_Call) Run(run func(currencyCode string, schedule models.FeeSchedule)) *IFeeScheduleRepository_UpsertCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(models.FeeSchedule))
+	})
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_UpsertCurrency_Call) Return(_a0 error) *IFeeScheduleRepository_UpsertCurrency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IFeeScheduleRepository_UpsertCurrency_Call) RunAndReturn(run func(string, models.FeeSchedule) error) *IFeeScheduleRepository_UpsertCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIFeeScheduleRepository creates a new instance of IFeeScheduleRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIFeeScheduleRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IFeeScheduleRepository {
+	mock := &IFeeScheduleRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,204 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
+)
+
+// IFxQuoteRepository is an autogenerated mock type for the IFxQuoteRepository type
+type IFxQuoteRepository struct {
+	mock.Mock
+}
+
+type IFxQuoteRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IFxQuoteRepository) EXPECT() *IFxQuoteRepository_Expecter {
+	return &IFxQuoteRepository_Expecter{mock: &_m.Mock}
+}
+
+// Consume provides a mock function with given fields: tx, quoteId, reference, now
+func (_m *IFxQuoteRepository) Consume(tx *sql.Tx, quoteId string, reference string, now time.Time) (*models.FxQuote, error) {
+	ret := _m.Called(tx, quoteId, reference, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Consume")
+	}
+
+	var r0 *models.FxQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sql.Tx, string, string, time.Time) (*models.FxQuote, error)); ok {
+		return rf(tx, quoteId, reference, now)
+	}
+	if rf, ok := ret.Get(0).(func(*sql.Tx, string, string, time.Time) *models.FxQuote); ok {
+		r0 = rf(tx, quoteId, reference, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FxQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sql.Tx, string, string, time.Time) error); ok {
+		r1 = rf(tx, quoteId, reference, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFxQuoteRepository_Consume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Consume'
+type IFxQuoteRepository_Consume_Call struct {
+	*mock.Call
+}
+
+// Consume is a helper method to define mock.On call
+//   - tx *sql.Tx
+//   - quoteId string
+//   - reference string
+//   - now time.Time
+func (_e *IFxQuoteRepository_Expecter) Consume(tx interface{}, quoteId interface{}, reference interface{}, now interface{}) *IFxQuoteRepository_Consume_Call {
+	return &IFxQuoteRepository_Consume_Call{Call: _e.mock.On("Consume", tx, quoteId, reference, now)}
+}
+
+func (_c *IFxQuoteRepository_Consume_Call) Run(run func(tx *sql.Tx, quoteId string, reference string, now time.Time)) *IFxQuoteRepository_Consume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sql.Tx), args[1].(string), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IFxQuoteRepository_Consume_Call) Return(_a0 *models.FxQuote, _a1 error) *IFxQuoteRepository_Consume_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFxQuoteRepository_Consume_Call) RunAndReturn(run func(*sql.Tx, string, string, time.Time) (*models.FxQuote, error)) *IFxQuoteRepository_Consume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuote provides a mock function with given fields: quote
+func (_m *IFxQuoteRepository) CreateQuote(quote models.FxQuote) error {
+	ret := _m.Called(quote)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.FxQuote) error); ok {
+		r0 = rf(quote)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IFxQuoteRepository_CreateQuote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuote'
+type IFxQuoteRepository_CreateQuote_Call struct {
+	*mock.Call
+}
+
+// CreateQuote is a helper method to define mock.On call
+//   - quote models.FxQuote
+func (_e *IFxQuoteRepository_Expecter) CreateQuote(quote interface{}) *IFxQuoteRepository_CreateQuote_Call {
+	return &IFxQuoteRepository_CreateQuote_Call{Call: _e.mock.On("CreateQuote", quote)}
+}
+
+func (_c *IFxQuoteRepository_CreateQuote_Call) Run(run func(quote models.FxQuote)) *IFxQuoteRepository_CreateQuote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.FxQuote))
+	})
+	return _c
+}
+
+func (_c *IFxQuoteRepository_CreateQuote_Call) Return(_a0 error) *IFxQuoteRepository_CreateQuote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IFxQuoteRepository_CreateQuote_Call) RunAndReturn(run func(models.FxQuote) error) *IFxQuoteRepository_CreateQuote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuote provides a mock function with given fields: quoteId
+func (_m *IFxQuoteRepository) GetQuote(quoteId string) (*models.FxQuote, error) {
+	ret := _m.Called(quoteId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuote")
+	}
+
+	var r0 *models.FxQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.FxQuote, error)); ok {
+		return rf(quoteId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.FxQuote); ok {
+		r0 = rf(quoteId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FxQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(quoteId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFxQuoteRepository_GetQuote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuote'
+type IFxQuoteRepository_GetQuote_Call struct {
+	*mock.Call
+}
+
+// GetQuote is a helper method to define mock.On call
+//   - quoteId string
+func (_e *IFxQuoteRepository_Expecter) GetQuote(quoteId interface{}) *IFxQuoteRepository_GetQuote_Call {
+	return &IFxQuoteRepository_GetQuote_Call{Call: _e.mock.On("GetQuote", quoteId)}
+}
+
+func (_c *IFxQuoteRepository_GetQuote_Call) Run(run func(quoteId string)) *IFxQuoteRepository_GetQuote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IFxQuoteRepository_GetQuote_Call) Return(_a0 *models.FxQuote, _a1 error) *IFxQuoteRepository_GetQuote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFxQuoteRepository_GetQuote_Call) RunAndReturn(run func(string) (*models.FxQuote, error)) *IFxQuoteRepository_GetQuote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIFxQuoteRepository creates a new instance of IFxQuoteRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIFxQuoteRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IFxQuoteRepository {
+	mock := &IFxQuoteRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
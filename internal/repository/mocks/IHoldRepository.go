@@ -0,0 +1,339 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IHoldRepository is an autogenerated mock type for the IHoldRepository type
+type IHoldRepository struct {
+	mock.Mock
+}
+
+type IHoldRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IHoldRepository) EXPECT() *IHoldRepository_Expecter {
+	return &IHoldRepository_Expecter{mock: &_m.Mock}
+}
+
+// CaptureHold provides a mock function with given fields: args, now
+func (_m *IHoldRepository) CaptureHold(args models.CaptureHoldArgs, now time.Time) (*models.Hold, models.Money, error) {
+	ret := _m.Called(args, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureHold")
+	}
+
+	var r0 *models.Hold
+	var r1 models.Money
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.CaptureHoldArgs, time.Time) (*models.Hold, models.Money, error)); ok {
+		return rf(args, now)
+	}
+	if rf, ok := ret.Get(0).(func(models.CaptureHoldArgs, time.Time) *models.Hold); ok {
+		r0 = rf(args, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.CaptureHoldArgs, time.Time) models.Money); ok {
+		r1 = rf(args, now)
+	} else {
+		r1 = ret.Get(1).(models.Money)
+	}
+
+	if rf, ok := ret.Get(2).(func(models.CaptureHoldArgs, time.Time) error); ok {
+		r2 = rf(args, now)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IHoldRepository_CaptureHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureHold'
+type IHoldRepository_CaptureHold_Call struct {
+	*mock.Call
+}
+
+// CaptureHold is a helper method to define mock.On call
+//   - args models.CaptureHoldArgs
+//   - now time.Time
+func (_e *IHoldRepository_Expecter) CaptureHold(args interface{}, now interface{}) *IHoldRepository_CaptureHold_Call {
+	return &IHoldRepository_CaptureHold_Call{Call: _e.mock.On("CaptureHold", args, now)}
+}
+
+func (_c *IHoldRepository_CaptureHold_Call) Run(run func(args models.CaptureHoldArgs, now time.Time)) *IHoldRepository_CaptureHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.CaptureHoldArgs), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IHoldRepository_CaptureHold_Call) Return(_a0 *models.Hold, _a1 models.Money, _a2 error) *IHoldRepository_CaptureHold_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IHoldRepository_CaptureHold_Call) RunAndReturn(run func(models.CaptureHoldArgs, time.Time) (*models.Hold, models.Money, error)) *IHoldRepository_CaptureHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHold provides a mock function with given fields: hold
+func (_m *IHoldRepository) CreateHold(hold models.Hold) (models.Money, error) {
+	ret := _m.Called(hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHold")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Hold) (models.Money, error)); ok {
+		return rf(hold)
+	}
+	if rf, ok := ret.Get(0).(func(models.Hold) models.Money); ok {
+		r0 = rf(hold)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Hold) error); ok {
+		r1 = rf(hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldRepository_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type IHoldRepository_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//   - hold models.Hold
+func (_e *IHoldRepository_Expecter) CreateHold(hold interface{}) *IHoldRepository_CreateHold_Call {
+	return &IHoldRepository_CreateHold_Call{Call: _e.mock.On("CreateHold", hold)}
+}
+
+func (_c *IHoldRepository_CreateHold_Call) Run(run func(hold models.Hold)) *IHoldRepository_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.Hold))
+	})
+	return _c
+}
+
+func (_c *IHoldRepository_CreateHold_Call) Return(_a0 models.Money, _a1 error) *IHoldRepository_CreateHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldRepository_CreateHold_Call) RunAndReturn(run func(models.Hold) (models.Money, error)) *IHoldRepository_CreateHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireHolds provides a mock function with given fields: now, limit
+func (_m *IHoldRepository) ExpireHolds(now time.Time, limit int) (int, error) {
+	ret := _m.Called(now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int) (int, error)); ok {
+		return rf(now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int) int); ok {
+		r0 = rf(now, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldRepository_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type IHoldRepository_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//   - now time.Time
+//   - limit int
+func (_e *IHoldRepository_Expecter) ExpireHolds(now interface{}, limit interface{}) *IHoldRepository_ExpireHolds_Call {
+	return &IHoldRepository_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", now, limit)}
+}
+
+func (_c *IHoldRepository_ExpireHolds_Call) Run(run func(now time.Time, limit int)) *IHoldRepository_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *IHoldRepository_ExpireHolds_Call) Return(_a0 int, _a1 error) *IHoldRepository_ExpireHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldRepository_ExpireHolds_Call) RunAndReturn(run func(time.Time, int) (int, error)) *IHoldRepository_ExpireHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHold provides a mock function with given fields: holdId
+func (_m *IHoldRepository) GetHold(holdId string) (*models.Hold, error) {
+	ret := _m.Called(holdId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHold")
+	}
+
+	var r0 *models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Hold, error)); ok {
+		return rf(holdId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Hold); ok {
+		r0 = rf(holdId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(holdId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldRepository_GetHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHold'
+type IHoldRepository_GetHold_Call struct {
+	*mock.Call
+}
+
+// GetHold is a helper method to define mock.On call
+//   - holdId string
+func (_e *IHoldRepository_Expecter) GetHold(holdId interface{}) *IHoldRepository_GetHold_Call {
+	return &IHoldRepository_GetHold_Call{Call: _e.mock.On("GetHold", holdId)}
+}
+
+func (_c *IHoldRepository_GetHold_Call) Run(run func(holdId string)) *IHoldRepository_GetHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IHoldRepository_GetHold_Call) Return(_a0 *models.Hold, _a1 error) *IHoldRepository_GetHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldRepository_GetHold_Call) RunAndReturn(run func(string) (*models.Hold, error)) *IHoldRepository_GetHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VoidHold provides a mock function with given fields: holdId
+func (_m *IHoldRepository) VoidHold(holdId string) (*models.Hold, models.Money, error) {
+	ret := _m.Called(holdId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VoidHold")
+	}
+
+	var r0 *models.Hold
+	var r1 models.Money
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string) (*models.Hold, models.Money, error)); ok {
+		return rf(holdId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.Hold); ok {
+		r0 = rf(holdId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) models.Money); ok {
+		r1 = rf(holdId)
+	} else {
+		r1 = ret.Get(1).(models.Money)
+	}
+
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(holdId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IHoldRepository_VoidHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VoidHold'
+type IHoldRepository_VoidHold_Call struct {
+	*mock.Call
+}
+
+// VoidHold is a helper method to define mock.On call
+//   - holdId string
+func (_e *IHoldRepository_Expecter) VoidHold(holdId interface{}) *IHoldRepository_VoidHold_Call {
+	return &IHoldRepository_VoidHold_Call{Call: _e.mock.On("VoidHold", holdId)}
+}
+
+func (_c *IHoldRepository_VoidHold_Call) Run(run func(holdId string)) *IHoldRepository_VoidHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IHoldRepository_VoidHold_Call) Return(_a0 *models.Hold, _a1 models.Money, _a2 error) *IHoldRepository_VoidHold_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IHoldRepository_VoidHold_Call) RunAndReturn(run func(string) (*models.Hold, models.Money, error)) *IHoldRepository_VoidHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIHoldRepository creates a new instance of IHoldRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIHoldRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IHoldRepository {
+	mock := &IHoldRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
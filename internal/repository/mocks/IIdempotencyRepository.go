@@ -0,0 +1,206 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+)
+
+// IIdempotencyRepository is an autogenerated mock type for the IIdempotencyRepository type
+type IIdempotencyRepository struct {
+	mock.Mock
+}
+
+type IIdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IIdempotencyRepository) EXPECT() *IIdempotencyRepository_Expecter {
+	return &IIdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Acquire provides a mock function with given fields: tx, idem, endpoint
+func (_m *IIdempotencyRepository) Acquire(tx *sql.Tx, idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error) {
+	ret := _m.Called(tx, idem, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Acquire")
+	}
+
+	var r0 *models.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*sql.Tx, models.Idempotency, string) (*models.IdempotencyRecord, error)); ok {
+		return rf(tx, idem, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(*sql.Tx, models.Idempotency, string) *models.IdempotencyRecord); ok {
+		r0 = rf(tx, idem, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*sql.Tx, models.Idempotency, string) error); ok {
+		r1 = rf(tx, idem, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IIdempotencyRepository_Acquire_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Acquire'
+type IIdempotencyRepository_Acquire_Call struct {
+	*mock.Call
+}
+
+// Acquire is a helper method to define mock.On call
+//   - tx *sql.Tx
+//   - idem models.Idempotency
+//   - endpoint string
+func (_e *IIdempotencyRepository_Expecter) Acquire(tx interface{}, idem interface{}, endpoint interface{}) *IIdempotencyRepository_Acquire_Call {
+	return &IIdempotencyRepository_Acquire_Call{Call: _e.mock.On("Acquire", tx, idem, endpoint)}
+}
+
+func (_c *IIdempotencyRepository_Acquire_Call) Run(run func(tx *sql.Tx, idem models.Idempotency, endpoint string)) *IIdempotencyRepository_Acquire_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sql.Tx), args[1].(models.Idempotency), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Acquire_Call) Return(_a0 *models.IdempotencyRecord, _a1 error) *IIdempotencyRepository_Acquire_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Acquire_Call) RunAndReturn(run func(*sql.Tx, models.Idempotency, string) (*models.IdempotencyRecord, error)) *IIdempotencyRepository_Acquire_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Complete provides a mock function with given fields: tx, idem, endpoint, statusCode, body
+func (_m *IIdempotencyRepository) Complete(tx *sql.Tx, idem models.Idempotency, endpoint string, statusCode int, body []byte) error {
+	ret := _m.Called(tx, idem, endpoint, statusCode, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sql.Tx, models.Idempotency, string, int, []byte) error); ok {
+		r0 = rf(tx, idem, endpoint, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IIdempotencyRepository_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type IIdempotencyRepository_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - tx *sql.Tx
+//   - idem models.Idempotency
+//   - endpoint string
+//   - statusCode int
+//   - body []byte
+func (_e *IIdempotencyRepository_Expecter) Complete(tx interface{}, idem interface{}, endpoint interface{}, statusCode interface{}, body interface{}) *IIdempotencyRepository_Complete_Call {
+	return &IIdempotencyRepository_Complete_Call{Call: _e.mock.On("Complete", tx, idem, endpoint, statusCode, body)}
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) Run(run func(tx *sql.Tx, idem models.Idempotency, endpoint string, statusCode int, body []byte)) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*sql.Tx), args[1].(models.Idempotency), args[2].(string), args[3].(int), args[4].([]byte))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) Return(_a0 error) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Complete_Call) RunAndReturn(run func(*sql.Tx, models.Idempotency, string, int, []byte) error) *IIdempotencyRepository_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: idem, endpoint
+func (_m *IIdempotencyRepository) Get(idem models.Idempotency, endpoint string) (*models.IdempotencyRecord, error) {
+	ret := _m.Called(idem, endpoint)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.Idempotency, string) (*models.IdempotencyRecord, error)); ok {
+		return rf(idem, endpoint)
+	}
+	if rf, ok := ret.Get(0).(func(models.Idempotency, string) *models.IdempotencyRecord); ok {
+		r0 = rf(idem, endpoint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyRecord)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.Idempotency, string) error); ok {
+		r1 = rf(idem, endpoint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IIdempotencyRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type IIdempotencyRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - idem models.Idempotency
+//   - endpoint string
+func (_e *IIdempotencyRepository_Expecter) Get(idem interface{}, endpoint interface{}) *IIdempotencyRepository_Get_Call {
+	return &IIdempotencyRepository_Get_Call{Call: _e.mock.On("Get", idem, endpoint)}
+}
+
+func (_c *IIdempotencyRepository_Get_Call) Run(run func(idem models.Idempotency, endpoint string)) *IIdempotencyRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.Idempotency), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Get_Call) Return(_a0 *models.IdempotencyRecord, _a1 error) *IIdempotencyRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IIdempotencyRepository_Get_Call) RunAndReturn(run func(models.Idempotency, string) (*models.IdempotencyRecord, error)) *IIdempotencyRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIIdempotencyRepository creates a new instance of IIdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdempotencyRepository {
+	mock := &IIdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,358 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IInterestRepository is an autogenerated mock type for the IInterestRepository type
+type IInterestRepository struct {
+	mock.Mock
+}
+
+type IInterestRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IInterestRepository) EXPECT() *IInterestRepository_Expecter {
+	return &IInterestRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetEndOfDayBalance provides a mock function with given fields: accountId, day
+func (_m *IInterestRepository) GetEndOfDayBalance(accountId int64, day time.Time) (models.Money, error) {
+	ret := _m.Called(accountId, day)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEndOfDayBalance")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, time.Time) (models.Money, error)); ok {
+		return rf(accountId, day)
+	}
+	if rf, ok := ret.Get(0).(func(int64, time.Time) models.Money); ok {
+		r0 = rf(accountId, day)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, time.Time) error); ok {
+		r1 = rf(accountId, day)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestRepository_GetEndOfDayBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEndOfDayBalance'
+type IInterestRepository_GetEndOfDayBalance_Call struct {
+	*mock.Call
+}
+
+// GetEndOfDayBalance is a helper method to define mock.On call
+//   - accountId int64
+//   - day time.Time
+func (_e *IInterestRepository_Expecter) GetEndOfDayBalance(accountId interface{}, day interface{}) *IInterestRepository_GetEndOfDayBalance_Call {
+	return &IInterestRepository_GetEndOfDayBalance_Call{Call: _e.mock.On("GetEndOfDayBalance", accountId, day)}
+}
+
+func (_c *IInterestRepository_GetEndOfDayBalance_Call) Run(run func(accountId int64, day time.Time)) *IInterestRepository_GetEndOfDayBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_GetEndOfDayBalance_Call) Return(_a0 models.Money, _a1 error) *IInterestRepository_GetEndOfDayBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestRepository_GetEndOfDayBalance_Call) RunAndReturn(run func(int64, time.Time) (models.Money, error)) *IInterestRepository_GetEndOfDayBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListInterestAccounts provides a mock function with no fields
+func (_m *IInterestRepository) ListInterestAccounts() ([]models.InterestAccount, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListInterestAccounts")
+	}
+
+	var r0 []models.InterestAccount
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]models.InterestAccount, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []models.InterestAccount); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InterestAccount)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestRepository_ListInterestAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListInterestAccounts'
+type IInterestRepository_ListInterestAccounts_Call struct {
+	*mock.Call
+}
+
+// ListInterestAccounts is a helper method to define mock.On call
+func (_e *IInterestRepository_Expecter) ListInterestAccounts() *IInterestRepository_ListInterestAccounts_Call {
+	return &IInterestRepository_ListInterestAccounts_Call{Call: _e.mock.On("ListInterestAccounts")}
+}
+
+func (_c *IInterestRepository_ListInterestAccounts_Call) Run(run func()) *IInterestRepository_ListInterestAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_ListInterestAccounts_Call) Return(_a0 []models.InterestAccount, _a1 error) *IInterestRepository_ListInterestAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestRepository_ListInterestAccounts_Call) RunAndReturn(run func() ([]models.InterestAccount, error)) *IInterestRepository_ListInterestAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUnpostedAccruals provides a mock function with given fields: through
+func (_m *IInterestRepository) ListUnpostedAccruals(through time.Time) ([]models.InterestAccrual, error) {
+	ret := _m.Called(through)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnpostedAccruals")
+	}
+
+	var r0 []models.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]models.InterestAccrual, error)); ok {
+		return rf(through)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []models.InterestAccrual); ok {
+		r0 = rf(through)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.InterestAccrual)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(through)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestRepository_ListUnpostedAccruals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUnpostedAccruals'
+type IInterestRepository_ListUnpostedAccruals_Call struct {
+	*mock.Call
+}
+
+// ListUnpostedAccruals is a helper method to define mock.On call
+//   - through time.Time
+func (_e *IInterestRepository_Expecter) ListUnpostedAccruals(through interface{}) *IInterestRepository_ListUnpostedAccruals_Call {
+	return &IInterestRepository_ListUnpostedAccruals_Call{Call: _e.mock.On("ListUnpostedAccruals", through)}
+}
+
+func (_c *IInterestRepository_ListUnpostedAccruals_Call) Run(run func(through time.Time)) *IInterestRepository_ListUnpostedAccruals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_ListUnpostedAccruals_Call) Return(_a0 []models.InterestAccrual, _a1 error) *IInterestRepository_ListUnpostedAccruals_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestRepository_ListUnpostedAccruals_Call) RunAndReturn(run func(time.Time) ([]models.InterestAccrual, error)) *IInterestRepository_ListUnpostedAccruals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostInterest provides a mock function with given fields: args
+func (_m *IInterestRepository) PostInterest(args models.PostInterestArgs) (models.InterestPosting, error) {
+	ret := _m.Called(args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostInterest")
+	}
+
+	var r0 models.InterestPosting
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.PostInterestArgs) (models.InterestPosting, error)); ok {
+		return rf(args)
+	}
+	if rf, ok := ret.Get(0).(func(models.PostInterestArgs) models.InterestPosting); ok {
+		r0 = rf(args)
+	} else {
+		r0 = ret.Get(0).(models.InterestPosting)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.PostInterestArgs) error); ok {
+		r1 = rf(args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestRepository_PostInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostInterest'
+type IInterestRepository_PostInterest_Call struct {
+	*mock.Call
+}
+
+// PostInterest is a helper method to define mock.On call
+//   - args models.PostInterestArgs
+func (_e *IInterestRepository_Expecter) PostInterest(args interface{}) *IInterestRepository_PostInterest_Call {
+	return &IInterestRepository_PostInterest_Call{Call: _e.mock.On("PostInterest", args)}
+}
+
+func (_c *IInterestRepository_PostInterest_Call) Run(run func(args models.PostInterestArgs)) *IInterestRepository_PostInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.PostInterestArgs))
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_PostInterest_Call) Return(_a0 models.InterestPosting, _a1 error) *IInterestRepository_PostInterest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestRepository_PostInterest_Call) RunAndReturn(run func(models.PostInterestArgs) (models.InterestPosting, error)) *IInterestRepository_PostInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveAccruals provides a mock function with given fields: accruals
+func (_m *IInterestRepository) SaveAccruals(accruals []models.InterestAccrual) error {
+	ret := _m.Called(accruals)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveAccruals")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.InterestAccrual) error); ok {
+		r0 = rf(accruals)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IInterestRepository_SaveAccruals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveAccruals'
+type IInterestRepository_SaveAccruals_Call struct {
+	*mock.Call
+}
+
+// SaveAccruals is a helper method to define mock.On call
+//   - accruals []models.InterestAccrual
+func (_e *IInterestRepository_Expecter) SaveAccruals(accruals interface{}) *IInterestRepository_SaveAccruals_Call {
+	return &IInterestRepository_SaveAccruals_Call{Call: _e.mock.On("SaveAccruals", accruals)}
+}
+
+func (_c *IInterestRepository_SaveAccruals_Call) Run(run func(accruals []models.InterestAccrual)) *IInterestRepository_SaveAccruals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.InterestAccrual))
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_SaveAccruals_Call) Return(_a0 error) *IInterestRepository_SaveAccruals_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IInterestRepository_SaveAccruals_Call) RunAndReturn(run func([]models.InterestAccrual) error) *IInterestRepository_SaveAccruals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetRate provides a mock function with given fields: accountId, rate
+func (_m *IInterestRepository) SetRate(accountId int64, rate models.InterestRate) error {
+	ret := _m.Called(accountId, rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetRate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, models.InterestRate) error); ok {
+		r0 = rf(accountId, rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IInterestRepository_SetRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetRate'
+type IInterestRepository_SetRate_Call struct {
+	*mock.Call
+}
+
+// SetRate is a helper method to define mock.On call
+//   - accountId int64
+//   - rate models.InterestRate
+func (_e *IInterestRepository_Expecter) SetRate(accountId interface{}, rate interface{}) *IInterestRepository_SetRate_Call {
+	return &IInterestRepository_SetRate_Call{Call: _e.mock.On("SetRate", accountId, rate)}
+}
+
+func (_c *IInterestRepository_SetRate_Call) Run(run func(accountId int64, rate models.InterestRate)) *IInterestRepository_SetRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(models.InterestRate))
+	})
+	return _c
+}
+
+func (_c *IInterestRepository_SetRate_Call) Return(_a0 error) *IInterestRepository_SetRate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IInterestRepository_SetRate_Call) RunAndReturn(run func(int64, models.InterestRate) error) *IInterestRepository_SetRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIInterestRepository creates a new instance of IInterestRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIInterestRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IInterestRepository {
+	mock := &IInterestRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
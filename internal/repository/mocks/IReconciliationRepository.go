@@ -0,0 +1,139 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IReconciliationRepository is an autogenerated mock type for the IReconciliationRepository type
+type IReconciliationRepository struct {
+	mock.Mock
+}
+
+type IReconciliationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IReconciliationRepository) EXPECT() *IReconciliationRepository_Expecter {
+	return &IReconciliationRepository_Expecter{mock: &_m.Mock}
+}
+
+// ListAccountReconciliations provides a mock function with given fields: accountId
+func (_m *IReconciliationRepository) ListAccountReconciliations(accountId int64) ([]models.AccountReconciliation, error) {
+	ret := _m.Called(accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountReconciliations")
+	}
+
+	var r0 []models.AccountReconciliation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]models.AccountReconciliation, error)); ok {
+		return rf(accountId)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []models.AccountReconciliation); ok {
+		r0 = rf(accountId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountReconciliation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IReconciliationRepository_ListAccountReconciliations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountReconciliations'
+type IReconciliationRepository_ListAccountReconciliations_Call struct {
+	*mock.Call
+}
+
+// ListAccountReconciliations is a helper method to define mock.On call
+//   - accountId int64
+func (_e *IReconciliationRepository_Expecter) ListAccountReconciliations(accountId interface{}) *IReconciliationRepository_ListAccountReconciliations_Call {
+	return &IReconciliationRepository_ListAccountReconciliations_Call{Call: _e.mock.On("ListAccountReconciliations", accountId)}
+}
+
+func (_c *IReconciliationRepository_ListAccountReconciliations_Call) Run(run func(accountId int64)) *IReconciliationRepository_ListAccountReconciliations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IReconciliationRepository_ListAccountReconciliations_Call) Return(_a0 []models.AccountReconciliation, _a1 error) *IReconciliationRepository_ListAccountReconciliations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IReconciliationRepository_ListAccountReconciliations_Call) RunAndReturn(run func(int64) ([]models.AccountReconciliation, error)) *IReconciliationRepository_ListAccountReconciliations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveFlags provides a mock function with given fields: reconciliations
+func (_m *IReconciliationRepository) SaveFlags(reconciliations []models.AccountReconciliation) error {
+	ret := _m.Called(reconciliations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFlags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]models.AccountReconciliation) error); ok {
+		r0 = rf(reconciliations)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IReconciliationRepository_SaveFlags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveFlags'
+type IReconciliationRepository_SaveFlags_Call struct {
+	*mock.Call
+}
+
+// SaveFlags is a helper method to define mock.On call
+//   - reconciliations []models.AccountReconciliation
+func (_e *IReconciliationRepository_Expecter) SaveFlags(reconciliations interface{}) *IReconciliationRepository_SaveFlags_Call {
+	return &IReconciliationRepository_SaveFlags_Call{Call: _e.mock.On("SaveFlags", reconciliations)}
+}
+
+func (_c *IReconciliationRepository_SaveFlags_Call) Run(run func(reconciliations []models.AccountReconciliation)) *IReconciliationRepository_SaveFlags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.AccountReconciliation))
+	})
+	return _c
+}
+
+func (_c *IReconciliationRepository_SaveFlags_Call) Return(_a0 error) *IReconciliationRepository_SaveFlags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IReconciliationRepository_SaveFlags_Call) RunAndReturn(run func([]models.AccountReconciliation) error) *IReconciliationRepository_SaveFlags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIReconciliationRepository creates a new instance of IReconciliationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReconciliationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReconciliationRepository {
+	mock := &IReconciliationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
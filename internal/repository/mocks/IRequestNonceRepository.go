@@ -0,0 +1,140 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IRequestNonceRepository is an autogenerated mock type for the IRequestNonceRepository type
+type IRequestNonceRepository struct {
+	mock.Mock
+}
+
+type IRequestNonceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IRequestNonceRepository) EXPECT() *IRequestNonceRepository_Expecter {
+	return &IRequestNonceRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteExpiredNonces provides a mock function with given fields: now
+func (_m *IRequestNonceRepository) DeleteExpiredNonces(now time.Time) (int64, error) {
+	ret := _m.Called(now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteExpiredNonces")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IRequestNonceRepository_DeleteExpiredNonces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteExpiredNonces'
+type IRequestNonceRepository_DeleteExpiredNonces_Call struct {
+	*mock.Call
+}
+
+// DeleteExpiredNonces is a helper method to define mock.On call
+//   - now time.Time
+func (_e *IRequestNonceRepository_Expecter) DeleteExpiredNonces(now interface{}) *IRequestNonceRepository_DeleteExpiredNonces_Call {
+	return &IRequestNonceRepository_DeleteExpiredNonces_Call{Call: _e.mock.On("DeleteExpiredNonces", now)}
+}
+
+func (_c *IRequestNonceRepository_DeleteExpiredNonces_Call) Run(run func(now time.Time)) *IRequestNonceRepository_DeleteExpiredNonces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IRequestNonceRepository_DeleteExpiredNonces_Call) Return(_a0 int64, _a1 error) *IRequestNonceRepository_DeleteExpiredNonces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IRequestNonceRepository_DeleteExpiredNonces_Call) RunAndReturn(run func(time.Time) (int64, error)) *IRequestNonceRepository_DeleteExpiredNonces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseNonce provides a mock function with given fields: apiKeyId, nonce, expiresAt
+func (_m *IRequestNonceRepository) UseNonce(apiKeyId int64, nonce string, expiresAt time.Time) error {
+	ret := _m.Called(apiKeyId, nonce, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseNonce")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string, time.Time) error); ok {
+		r0 = rf(apiKeyId, nonce, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IRequestNonceRepository_UseNonce_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseNonce'
+type IRequestNonceRepository_UseNonce_Call struct {
+	*mock.Call
+}
+
+// UseNonce is a helper method to define mock.On call
+//   - apiKeyId int64
+//   - nonce string
+//   - expiresAt time.Time
+func (_e *IRequestNonceRepository_Expecter) UseNonce(apiKeyId interface{}, nonce interface{}, expiresAt interface{}) *IRequestNonceRepository_UseNonce_Call {
+	return &IRequestNonceRepository_UseNonce_Call{Call: _e.mock.On("UseNonce", apiKeyId, nonce, expiresAt)}
+}
+
+func (_c *IRequestNonceRepository_UseNonce_Call) Run(run func(apiKeyId int64, nonce string, expiresAt time.Time)) *IRequestNonceRepository_UseNonce_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IRequestNonceRepository_UseNonce_Call) Return(_a0 error) *IRequestNonceRepository_UseNonce_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IRequestNonceRepository_UseNonce_Call) RunAndReturn(run func(int64, string, time.Time) error) *IRequestNonceRepository_UseNonce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIRequestNonceRepository creates a new instance of IRequestNonceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRequestNonceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRequestNonceRepository {
+	mock := &IRequestNonceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	sql "database/sql"
+
+	time "time"
+)
+
+// IScheduledTransferRepository is an autogenerated mock type for the IScheduledTransferRepository type
+type IScheduledTransferRepository struct {
+	mock.Mock
+}
+
+type IScheduledTransferRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IScheduledTransferRepository) EXPECT() *IScheduledTransferRepository_Expecter {
+	return &IScheduledTransferRepository_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: st
+func (_m *IScheduledTransferRepository) Create(st models.ScheduledTransfer) error {
+	ret := _m.Called(st)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ScheduledTransfer) error); ok {
+		r0 = rf(st)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduledTransferRepository_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type IScheduledTransferRepository_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - st models.ScheduledTransfer
+func (_e *IScheduledTransferRepository_Expecter) Create(st interface{}) *IScheduledTransferRepository_Create_Call {
+	return &IScheduledTransferRepository_Create_Call{Call: _e.mock.On("Create", st)}
+}
+
+func (_c *IScheduledTransferRepository_Create_Call) Run(run func(st models.ScheduledTransfer)) *IScheduledTransferRepository_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.ScheduledTransfer))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_Create_Call) Return(_a0 error) *IScheduledTransferRepository_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_Create_Call) RunAndReturn(run func(models.ScheduledTransfer) error) *IScheduledTransferRepository_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetById provides a mock function with given fields: scheduledTransferId
+func (_m *IScheduledTransferRepository) GetById(scheduledTransferId string) (*models.ScheduledTransfer, error) {
+	ret := _m.Called(scheduledTransferId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *models.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.ScheduledTransfer, error)); ok {
+		return rf(scheduledTransferId)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.ScheduledTransfer); ok {
+		r0 = rf(scheduledTransferId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScheduledTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(scheduledTransferId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferRepository_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type IScheduledTransferRepository_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - scheduledTransferId string
+func (_e *IScheduledTransferRepository_Expecter) GetById(scheduledTransferId interface{}) *IScheduledTransferRepository_GetById_Call {
+	return &IScheduledTransferRepository_GetById_Call{Call: _e.mock.On("GetById", scheduledTransferId)}
+}
+
+func (_c *IScheduledTransferRepository_GetById_Call) Run(run func(scheduledTransferId string)) *IScheduledTransferRepository_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_GetById_Call) Return(_a0 *models.ScheduledTransfer, _a1 error) *IScheduledTransferRepository_GetById_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_GetById_Call) RunAndReturn(run func(string) (*models.ScheduledTransfer, error)) *IScheduledTransferRepository_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBySourceAccount provides a mock function with given fields: accountId
+func (_m *IScheduledTransferRepository) ListBySourceAccount(accountId int64) ([]models.ScheduledTransfer, error) {
+	ret := _m.Called(accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBySourceAccount")
+	}
+
+	var r0 []models.ScheduledTransfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) ([]models.ScheduledTransfer, error)); ok {
+		return rf(accountId)
+	}
+	if rf, ok := ret.Get(0).(func(int64) []models.ScheduledTransfer); ok {
+		r0 = rf(accountId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ScheduledTransfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferRepository_ListBySourceAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBySourceAccount'
+type IScheduledTransferRepository_ListBySourceAccount_Call struct {
+	*mock.Call
+}
+
+// ListBySourceAccount is a helper method to define mock.On call
+//   - accountId int64
+func (_e *IScheduledTransferRepository_Expecter) ListBySourceAccount(accountId interface{}) *IScheduledTransferRepository_ListBySourceAccount_Call {
+	return &IScheduledTransferRepository_ListBySourceAccount_Call{Call: _e.mock.On("ListBySourceAccount", accountId)}
+}
+
+func (_c *IScheduledTransferRepository_ListBySourceAccount_Call) Run(run func(accountId int64)) *IScheduledTransferRepository_ListBySourceAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_ListBySourceAccount_Call) Return(_a0 []models.ScheduledTransfer, _a1 error) *IScheduledTransferRepository_ListBySourceAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_ListBySourceAccount_Call) RunAndReturn(run func(int64) ([]models.ScheduledTransfer, error)) *IScheduledTransferRepository_ListBySourceAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunDue provides a mock function with given fields: now, limit, execute
+func (_m *IScheduledTransferRepository) RunDue(now time.Time, limit int, execute func(models.ScheduledTransfer) models.ScheduledTransferRun) (int, error) {
+	ret := _m.Called(now, limit, execute)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDue")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int, func(models.ScheduledTransfer) models.ScheduledTransferRun) (int, error)); ok {
+		return rf(now, limit, execute)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int, func(models.ScheduledTransfer) models.ScheduledTransferRun) int); ok {
+		r0 = rf(now, limit, execute)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int, func(models.ScheduledTransfer) models.ScheduledTransferRun) error); ok {
+		r1 = rf(now, limit, execute)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferRepository_RunDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunDue'
+type IScheduledTransferRepository_RunDue_Call struct {
+	*mock.Call
+}
+
+// RunDue is a helper method to define mock.On call
+//   - now time.Time
+//   - limit int
+//   - execute func(models.ScheduledTransfer) models.ScheduledTransferRun
+func (_e *IScheduledTransferRepository_Expecter) RunDue(now interface{}, limit interface{}, execute interface{}) *IScheduledTransferRepository_RunDue_Call {
+	return &IScheduledTransferRepository_RunDue_Call{Call: _e.mock.On("RunDue", now, limit, execute)}
+}
+
+func (_c *IScheduledTransferRepository_RunDue_Call) Run(run func(now time.Time, limit int, execute func(models.ScheduledTransfer) models.ScheduledTransferRun)) *IScheduledTransferRepository_RunDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(int), args[2].(func(models.ScheduledTransfer) models.ScheduledTransferRun))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_RunDue_Call) Return(_a0 int, _a1 error) *IScheduledTransferRepository_RunDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_RunDue_Call) RunAndReturn(run func(time.Time, int, func(models.ScheduledTransfer) models.ScheduledTransferRun) (int, error)) *IScheduledTransferRepository_RunDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateStatus provides a mock function with given fields: scheduledTransferId, from, to, nextRunAt
+func (_m *IScheduledTransferRepository) UpdateStatus(scheduledTransferId string, from []models.ScheduledTransferStatus, to models.ScheduledTransferStatus, nextRunAt sql.NullTime) error {
+	ret := _m.Called(scheduledTransferId, from, to, nextRunAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []models.ScheduledTransferStatus, models.ScheduledTransferStatus, sql.NullTime) error); ok {
+		r0 = rf(scheduledTransferId, from, to, nextRunAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IScheduledTransferRepository_UpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStatus'
+type IScheduledTransferRepository_UpdateStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateStatus is a helper method to define mock.On call
+//   - scheduledTransferId string
+//   - from []models.ScheduledTransferStatus
+//   - to models.ScheduledTransferStatus
+//   - nextRunAt sql.NullTime
+func (_e *IScheduledTransferRepository_Expecter) UpdateStatus(scheduledTransferId interface{}, from interface{}, to interface{}, nextRunAt interface{}) *IScheduledTransferRepository_UpdateStatus_Call {
+	return &IScheduledTransferRepository_UpdateStatus_Call{Call: _e.mock.On("UpdateStatus", scheduledTransferId, from, to, nextRunAt)}
+}
+
+func (_c *IScheduledTransferRepository_UpdateStatus_Call) Run(run func(scheduledTransferId string, from []models.ScheduledTransferStatus, to models.ScheduledTransferStatus, nextRunAt sql.NullTime)) *IScheduledTransferRepository_UpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].([]models.ScheduledTransferStatus), args[2].(models.ScheduledTransferStatus), args[3].(sql.NullTime))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_UpdateStatus_Call) Return(_a0 error) *IScheduledTransferRepository_UpdateStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IScheduledTransferRepository_UpdateStatus_Call) RunAndReturn(run func(string, []models.ScheduledTransferStatus, models.ScheduledTransferStatus, sql.NullTime) error) *IScheduledTransferRepository_UpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIScheduledTransferRepository creates a new instance of IScheduledTransferRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIScheduledTransferRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IScheduledTransferRepository {
+	mock := &IScheduledTransferRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
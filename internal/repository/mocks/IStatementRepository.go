@@ -0,0 +1,100 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IStatementRepository is an autogenerated mock type for the IStatementRepository type
+type IStatementRepository struct {
+	mock.Mock
+}
+
+type IStatementRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IStatementRepository) EXPECT() *IStatementRepository_Expecter {
+	return &IStatementRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetStatementEntries provides a mock function with given fields: args
+func (_m *IStatementRepository) GetStatementEntries(args models.StatementArgs) (models.Money, []models.Transaction, error) {
+	ret := _m.Called(args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatementEntries")
+	}
+
+	var r0 models.Money
+	var r1 []models.Transaction
+	var r2 error
+	if rf, ok := ret.Get(0).(func(models.StatementArgs) (models.Money, []models.Transaction, error)); ok {
+		return rf(args)
+	}
+	if rf, ok := ret.Get(0).(func(models.StatementArgs) models.Money); ok {
+		r0 = rf(args)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.StatementArgs) []models.Transaction); ok {
+		r1 = rf(args)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(models.StatementArgs) error); ok {
+		r2 = rf(args)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// IStatementRepository_GetStatementEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatementEntries'
+type IStatementRepository_GetStatementEntries_Call struct {
+	*mock.Call
+}
+
+// GetStatementEntries is a helper method to define mock.On call
+//   - args models.StatementArgs
+func (_e *IStatementRepository_Expecter) GetStatementEntries(args interface{}) *IStatementRepository_GetStatementEntries_Call {
+	return &IStatementRepository_GetStatementEntries_Call{Call: _e.mock.On("GetStatementEntries", args)}
+}
+
+func (_c *IStatementRepository_GetStatementEntries_Call) Run(run func(args models.StatementArgs)) *IStatementRepository_GetStatementEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.StatementArgs))
+	})
+	return _c
+}
+
+func (_c *IStatementRepository_GetStatementEntries_Call) Return(_a0 models.Money, _a1 []models.Transaction, _a2 error) *IStatementRepository_GetStatementEntries_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *IStatementRepository_GetStatementEntries_Call) RunAndReturn(run func(models.StatementArgs) (models.Money, []models.Transaction, error)) *IStatementRepository_GetStatementEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIStatementRepository creates a new instance of IStatementRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatementRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatementRepository {
+	mock := &IStatementRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
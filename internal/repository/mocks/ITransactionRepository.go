@@ -20,6 +20,71 @@ func (_m *ITransactionRepository) EXPECT() *ITransactionRepository_Expecter {
 	return &ITransactionRepository_Expecter{mock: &_m.Mock}
 }
 
+// CreateBatchTransaction provides a mock function with given fields: reqs
+func (_m *ITransactionRepository) CreateBatchTransaction(reqs []models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error) {
+	ret := _m.Called(reqs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatchTransaction")
+	}
+
+	var r0 []models.CreateTransactionResponse
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error)); ok {
+		return rf(reqs)
+	}
+	if rf, ok := ret.Get(0).(func([]models.CreateTransactionArgs) []models.CreateTransactionResponse); ok {
+		r0 = rf(reqs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CreateTransactionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]models.CreateTransactionArgs) int); ok {
+		r1 = rf(reqs)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func([]models.CreateTransactionArgs) error); ok {
+		r2 = rf(reqs)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ITransactionRepository_CreateBatchTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatchTransaction'
+type ITransactionRepository_CreateBatchTransaction_Call struct {
+	*mock.Call
+}
+
+// CreateBatchTransaction is a helper method to define mock.On call
+//   - reqs []models.CreateTransactionArgs
+func (_e *ITransactionRepository_Expecter) CreateBatchTransaction(reqs interface{}) *ITransactionRepository_CreateBatchTransaction_Call {
+	return &ITransactionRepository_CreateBatchTransaction_Call{Call: _e.mock.On("CreateBatchTransaction", reqs)}
+}
+
+func (_c *ITransactionRepository_CreateBatchTransaction_Call) Run(run func(reqs []models.CreateTransactionArgs)) *ITransactionRepository_CreateBatchTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]models.CreateTransactionArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_CreateBatchTransaction_Call) Return(_a0 []models.CreateTransactionResponse, _a1 int, _a2 error) *ITransactionRepository_CreateBatchTransaction_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *ITransactionRepository_CreateBatchTransaction_Call) RunAndReturn(run func([]models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error)) *ITransactionRepository_CreateBatchTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTransaction provides a mock function with given fields: req
 func (_m *ITransactionRepository) CreateTransaction(req models.CreateTransactionArgs) (models.CreateTransactionResponse, error) {
 	ret := _m.Called(req)
@@ -76,6 +141,178 @@ func (_c *ITransactionRepository_CreateTransaction_Call) RunAndReturn(run func(m
 	return _c
 }
 
+// GetByReference provides a mock function with given fields: reference
+func (_m *ITransactionRepository) GetByReference(reference string) ([]models.Transaction, error) {
+	ret := _m.Called(reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByReference")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]models.Transaction, error)); ok {
+		return rf(reference)
+	}
+	if rf, ok := ret.Get(0).(func(string) []models.Transaction); ok {
+		r0 = rf(reference)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_GetByReference_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByReference'
+type ITransactionRepository_GetByReference_Call struct {
+	*mock.Call
+}
+
+// GetByReference is a helper method to define mock.On call
+//   - reference string
+func (_e *ITransactionRepository_Expecter) GetByReference(reference interface{}) *ITransactionRepository_GetByReference_Call {
+	return &ITransactionRepository_GetByReference_Call{Call: _e.mock.On("GetByReference", reference)}
+}
+
+func (_c *ITransactionRepository_GetByReference_Call) Run(run func(reference string)) *ITransactionRepository_GetByReference_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_GetByReference_Call) Return(_a0 []models.Transaction, _a1 error) *ITransactionRepository_GetByReference_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_GetByReference_Call) RunAndReturn(run func(string) ([]models.Transaction, error)) *ITransactionRepository_GetByReference_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactions provides a mock function with given fields: filter
+func (_m *ITransactionRepository) ListTransactions(filter models.TransactionFilter) ([]models.Transaction, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.TransactionFilter) ([]models.Transaction, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(models.TransactionFilter) []models.Transaction); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(models.TransactionFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type ITransactionRepository_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - filter models.TransactionFilter
+func (_e *ITransactionRepository_Expecter) ListTransactions(filter interface{}) *ITransactionRepository_ListTransactions_Call {
+	return &ITransactionRepository_ListTransactions_Call{Call: _e.mock.On("ListTransactions", filter)}
+}
+
+func (_c *ITransactionRepository_ListTransactions_Call) Run(run func(filter models.TransactionFilter)) *ITransactionRepository_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.TransactionFilter))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_ListTransactions_Call) Return(_a0 []models.Transaction, _a1 error) *ITransactionRepository_ListTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_ListTransactions_Call) RunAndReturn(run func(models.TransactionFilter) ([]models.Transaction, error)) *ITransactionRepository_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReverseTransaction provides a mock function with given fields: req
+func (_m *ITransactionRepository) ReverseTransaction(req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReverseTransaction")
+	}
+
+	var r0 models.ReverseTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(models.ReverseTransactionArgs) models.ReverseTransactionResponse); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Get(0).(models.ReverseTransactionResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(models.ReverseTransactionArgs) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionRepository_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type ITransactionRepository_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//   - req models.ReverseTransactionArgs
+func (_e *ITransactionRepository_Expecter) ReverseTransaction(req interface{}) *ITransactionRepository_ReverseTransaction_Call {
+	return &ITransactionRepository_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", req)}
+}
+
+func (_c *ITransactionRepository_ReverseTransaction_Call) Run(run func(req models.ReverseTransactionArgs)) *ITransactionRepository_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(models.ReverseTransactionArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionRepository_ReverseTransaction_Call) Return(_a0 models.ReverseTransactionResponse, _a1 error) *ITransactionRepository_ReverseTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionRepository_ReverseTransaction_Call) RunAndReturn(run func(models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)) *ITransactionRepository_ReverseTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewITransactionRepository creates a new instance of ITransactionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewITransactionRepository(t interface {
@@ -0,0 +1,241 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITransferLimitRepository is an autogenerated mock type for the ITransferLimitRepository type
+type ITransferLimitRepository struct {
+	mock.Mock
+}
+
+type ITransferLimitRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ITransferLimitRepository) EXPECT() *ITransferLimitRepository_Expecter {
+	return &ITransferLimitRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetByAccountId provides a mock function with given fields: accountId
+func (_m *ITransferLimitRepository) GetByAccountId(accountId int64) (models.TransferLimits, error) {
+	ret := _m.Called(accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByAccountId")
+	}
+
+	var r0 models.TransferLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (models.TransferLimits, error)); ok {
+		return rf(accountId)
+	}
+	if rf, ok := ret.Get(0).(func(int64) models.TransferLimits); ok {
+		r0 = rf(accountId)
+	} else {
+		r0 = ret.Get(0).(models.TransferLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitRepository_GetByAccountId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByAccountId'
+type ITransferLimitRepository_GetByAccountId_Call struct {
+	*mock.Call
+}
+
+// GetByAccountId is a helper method to define mock.On call
+//   - accountId int64
+func (_e *ITransferLimitRepository_Expecter) GetByAccountId(accountId interface{}) *ITransferLimitRepository_GetByAccountId_Call {
+	return &ITransferLimitRepository_GetByAccountId_Call{Call: _e.mock.On("GetByAccountId", accountId)}
+}
+
+func (_c *ITransferLimitRepository_GetByAccountId_Call) Run(run func(accountId int64)) *ITransferLimitRepository_GetByAccountId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitRepository_GetByAccountId_Call) Return(_a0 models.TransferLimits, _a1 error) *ITransferLimitRepository_GetByAccountId_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitRepository_GetByAccountId_Call) RunAndReturn(run func(int64) (models.TransferLimits, error)) *ITransferLimitRepository_GetByAccountId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByCurrency provides a mock function with given fields: currencyCode
+func (_m *ITransferLimitRepository) GetByCurrency(currencyCode string) (models.TransferLimits, error) {
+	ret := _m.Called(currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCurrency")
+	}
+
+	var r0 models.TransferLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (models.TransferLimits, error)); ok {
+		return rf(currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(string) models.TransferLimits); ok {
+		r0 = rf(currencyCode)
+	} else {
+		r0 = ret.Get(0).(models.TransferLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitRepository_GetByCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByCurrency'
+type ITransferLimitRepository_GetByCurrency_Call struct {
+	*mock.Call
+}
+
+// GetByCurrency is a helper method to define mock.On call
+//   - currencyCode string
+func (_e *ITransferLimitRepository_Expecter) GetByCurrency(currencyCode interface{}) *ITransferLimitRepository_GetByCurrency_Call {
+	return &ITransferLimitRepository_GetByCurrency_Call{Call: _e.mock.On("GetByCurrency", currencyCode)}
+}
+
+func (_c *ITransferLimitRepository_GetByCurrency_Call) Run(run func(currencyCode string)) *ITransferLimitRepository_GetByCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitRepository_GetByCurrency_Call) Return(_a0 models.TransferLimits, _a1 error) *ITransferLimitRepository_GetByCurrency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitRepository_GetByCurrency_Call) RunAndReturn(run func(string) (models.TransferLimits, error)) *ITransferLimitRepository_GetByCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upsert provides a mock function with given fields: accountId, limits
+func (_m *ITransferLimitRepository) Upsert(accountId int64, limits models.TransferLimits) error {
+	ret := _m.Called(accountId, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upsert")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, models.TransferLimits) error); ok {
+		r0 = rf(accountId, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITransferLimitRepository_Upsert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upsert'
+type ITransferLimitRepository_Upsert_Call struct {
+	*mock.Call
+}
+
+// Upsert is a helper method to define mock.On call
+//   - accountId int64
+//   - limits models.TransferLimits
+func (_e *ITransferLimitRepository_Expecter) Upsert(accountId interface{}, limits interface{}) *ITransferLimitRepository_Upsert_Call {
+	return &ITransferLimitRepository_Upsert_Call{Call: _e.mock.On("Upsert", accountId, limits)}
+}
+
+func (_c *ITransferLimitRepository_Upsert_Call) Run(run func(accountId int64, limits models.TransferLimits)) *ITransferLimitRepository_Upsert_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(models.TransferLimits))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitRepository_Upsert_Call) Return(_a0 error) *ITransferLimitRepository_Upsert_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITransferLimitRepository_Upsert_Call) RunAndReturn(run func(int64, models.TransferLimits) error) *ITransferLimitRepository_Upsert_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertCurrency provides a mock function with given fields: currencyCode, limits
+func (_m *ITransferLimitRepository) UpsertCurrency(currencyCode string, limits models.TransferLimits) error {
+	ret := _m.Called(currencyCode, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertCurrency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, models.TransferLimits) error); ok {
+		r0 = rf(currencyCode, limits)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ITransferLimitRepository_UpsertCurrency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCurrency'
+type ITransferLimitRepository_UpsertCurrency_Call struct {
+	*mock.Call
+}
+
+// UpsertCurrency is a helper method to define mock.On call
+//   - currencyCode string
+//   - limits models.TransferLimits
+func (_e *ITransferLimitRepository_Expecter) UpsertCurrency(currencyCode interface{}, limits interface{}) *ITransferLimitRepository_UpsertCurrency_Call {
+	return &ITransferLimitRepository_UpsertCurrency_Call{Call: _e.mock.On("UpsertCurrency", currencyCode, limits)}
+}
+
+func (_c *ITransferLimitRepository_UpsertCurrency_Call) Run(run func(currencyCode string, limits models.TransferLimits)) *ITransferLimitRepository_UpsertCurrency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(models.TransferLimits))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitRepository_UpsertCurrency_Call) Return(_a0 error) *ITransferLimitRepository_UpsertCurrency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ITransferLimitRepository_UpsertCurrency_Call) RunAndReturn(run func(string, models.TransferLimits) error) *ITransferLimitRepository_UpsertCurrency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewITransferLimitRepository creates a new instance of ITransferLimitRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITransferLimitRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITransferLimitRepository {
+	mock := &ITransferLimitRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"log"
+)
+
+// ReconciliationRepository compares account balances with their ledger
+type ReconciliationRepository struct {
+	db *sql.DB
+}
+
+// NewReconciliationRepository creates a new reconciliation repository
+func NewReconciliationRepository(db *sql.DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+type IReconciliationRepository interface {
+	ListAccountReconciliations(accountId int64) ([]models.AccountReconciliation, error)
+	SaveFlags(reconciliations []models.AccountReconciliation) error
+}
+
+// ListAccountReconciliations returns the balance of every account, or of accountId only when it is not 0, next to
+// the balance its ledger implies and the one recorded on its latest entry. Everything is read from one snapshot so
+// that transfers committed meanwhile cannot show up as drift
+func (r *ReconciliationRepository) ListAccountReconciliations(accountId int64) ([]models.AccountReconciliation, error) {
+	fName := "ReconciliationRepository.ListAccountReconciliations"
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `SELECT a.account_id, a.currency_code, a.balance, a.initial_balance + COALESCE(t.total, 0), l.available_balance
+		FROM accounts a
+		LEFT JOIN (SELECT account_id, SUM(CASE WHEN is_credit THEN amount ELSE -amount END) AS total FROM transactions GROUP BY account_id) t
+			ON t.account_id = a.account_id
+		LEFT JOIN LATERAL (SELECT available_balance FROM transactions WHERE account_id = a.account_id ORDER BY id DESC LIMIT 1) l ON true
+		WHERE $1 = 0 OR a.account_id = $1
+		ORDER BY a.account_id`
+
+	rows, err := tx.Query(query, accountId)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	var reconciliations []models.AccountReconciliation
+	for rows.Next() {
+		var a models.AccountReconciliation
+		if err := rows.Scan(&a.AccountId, &a.CurrencyCode, &a.Balance, &a.LedgerBalance, &a.LastRecordedBalance); err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		a.Difference = a.Balance.Sub(a.LedgerBalance)
+		reconciliations = append(reconciliations, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return reconciliations, nil
+}
+
+// SaveFlags records in one DB transaction the accounts of reconciliations that do not reconcile, replacing their
+// previous flag, and clears the flag of the ones that do
+func (r *ReconciliationRepository) SaveFlags(reconciliations []models.AccountReconciliation) error {
+	fName := "ReconciliationRepository.SaveFlags"
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO reconciliation_flags (account_id, balance, ledger_balance, last_recorded_balance) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id) DO UPDATE SET balance = EXCLUDED.balance, ledger_balance = EXCLUDED.ledger_balance,
+			last_recorded_balance = EXCLUDED.last_recorded_balance, flagged_at = CURRENT_TIMESTAMP`
+	var reconciled []int64
+	for _, a := range reconciliations {
+		if a.IsReconciled() {
+			reconciled = append(reconciled, a.AccountId)
+			continue
+		}
+		if _, err := tx.Exec(query, a.AccountId, a.Balance, a.LedgerBalance, a.LastRecordedBalance); err != nil {
+			log.Printf("[%s] failed to flag account %d: %v", fName, a.AccountId, err)
+			return appErr.ErrInternal
+		}
+	}
+
+	if len(reconciled) > 0 {
+		if _, err := tx.Exec(`DELETE FROM reconciliation_flags WHERE account_id = ANY($1)`, pq.Array(reconciled)); err != nil {
+			log.Printf("[%s] failed to clear flags: %v", fName, err)
+			return appErr.ErrInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
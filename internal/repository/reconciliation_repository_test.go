@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationRepository_ListAccountReconciliations(t *testing.T) {
+	balance, lastRecorded := models.NewMoney(100), models.NewMoney(90)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    []models.AccountReconciliation
+		expectedErr error
+	}{
+		{
+			name: "balances next to their ledger",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.account_id, a.currency_code, a.balance, a.initial_balance \\+ COALESCE\\(t.total, 0\\), l.available_balance FROM accounts a").
+					WithArgs(int64(0)).
+					WillReturnRows(sqlmock.NewRows([]string{"account_id", "currency_code", "balance", "ledger_balance", "available_balance"}).
+						AddRow(1, "USD", "100.00000", "100.00000", "100.00000").
+						AddRow(2, "EUR", "100.00000", "90.00000", "90.00000").
+						AddRow(3, "USD", "50.00000", "50.00000", nil))
+				mock.ExpectRollback()
+			},
+			expected: []models.AccountReconciliation{
+				{AccountId: 1, CurrencyCode: "USD", Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(100), LastRecordedBalance: &balance},
+				{AccountId: 2, CurrencyCode: "EUR", Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(90), LastRecordedBalance: &lastRecorded, Difference: models.NewMoney(10)},
+				{AccountId: 3, CurrencyCode: "USD", Balance: models.NewMoney(50), LedgerBalance: models.NewMoney(50)},
+			},
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT a.account_id").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			reconciliations, err := NewReconciliationRepository(db).ListAccountReconciliations(0)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, reconciliations)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReconciliationRepository_SaveFlags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO reconciliation_flags (.+) ON CONFLICT \\(account_id\\) DO UPDATE").
+		WithArgs(int64(2), models.NewMoney(100), models.NewMoney(90), nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM reconciliation_flags WHERE account_id = ANY\\(\\$1\\)").
+		WithArgs(pq.Array([]int64{1, 3})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = NewReconciliationRepository(db).SaveFlags([]models.AccountReconciliation{
+		{AccountId: 1, Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(100)},
+		{AccountId: 2, Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(90), Difference: models.NewMoney(10)},
+		{AccountId: 3, Balance: models.NewMoney(50), LedgerBalance: models.NewMoney(50)},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
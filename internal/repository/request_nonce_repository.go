@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"log"
+	"time"
+)
+
+// RequestNonceRepository records the nonces of signed requests so that none of them can be replayed
+type RequestNonceRepository struct {
+	db *sql.DB
+}
+
+// NewRequestNonceRepository creates a new request nonce repository
+func NewRequestNonceRepository(db *sql.DB) *RequestNonceRepository {
+	return &RequestNonceRepository{db: db}
+}
+
+type IRequestNonceRepository interface {
+	UseNonce(apiKeyId int64, nonce string, expiresAt time.Time) error
+	DeleteExpiredNonces(now time.Time) (int64, error)
+}
+
+// UseNonce records that the API key with the given id used nonce, which is kept until expiresAt. It fails with
+// ErrReplayedRequest when the key already used it
+func (r *RequestNonceRepository) UseNonce(apiKeyId int64, nonce string, expiresAt time.Time) error {
+	fName := "RequestNonceRepository.UseNonce"
+	query := `INSERT INTO request_nonces (api_key_id, nonce, expires_at) VALUES ($1, $2, $3) ON CONFLICT (api_key_id, nonce) DO NOTHING`
+
+	result, err := r.db.Exec(query, apiKeyId, nonce, expiresAt)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to read the inserted count: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if inserted == 0 {
+		return appErr.ErrReplayedRequest
+	}
+	return nil
+}
+
+// DeleteExpiredNonces deletes the nonces that expired by now, returning how many there were
+func (r *RequestNonceRepository) DeleteExpiredNonces(now time.Time) (int64, error) {
+	fName := "RequestNonceRepository.DeleteExpiredNonces"
+	result, err := r.db.Exec(`DELETE FROM request_nonces WHERE expires_at <= $1`, now)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to read the deleted count: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	return deleted, nil
+}
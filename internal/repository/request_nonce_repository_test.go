@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestNonceRepository_UseNonce(t *testing.T) {
+	expiresAt := time.Date(2025, 4, 1, 9, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expectedErr error
+	}{
+		{
+			name: "new nonce",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`INSERT INTO request_nonces \(api_key_id, nonce, expires_at\) VALUES \(\$1, \$2, \$3\) ON CONFLICT \(api_key_id, nonce\) DO NOTHING`).
+					WithArgs(int64(1), "9f8c1e", expiresAt).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "nonce already used",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO request_nonces").WithArgs(int64(1), "9f8c1e", expiresAt).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			expectedErr: appErr.ErrReplayedRequest,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO request_nonces").WithArgs(int64(1), "9f8c1e", expiresAt).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			err = NewRequestNonceRepository(db).UseNonce(1, "9f8c1e", expiresAt)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRequestNonceRepository_DeleteExpiredNonces(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectExec(`DELETE FROM request_nonces WHERE expires_at <= \$1`).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 4))
+
+	deleted, err := NewRequestNonceRepository(db).DeleteExpiredNonces(now)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
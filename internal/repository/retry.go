@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/lib/pq"
+)
+
+// maxTxAttempts bounds how many times a money movement is run when Postgres aborts it to break a deadlock
+// or a serialization conflict
+const maxTxAttempts = 3
+
+// Postgres SQLSTATE codes after which the whole transaction can safely be run again
+const (
+	sqlStateSerializationFailure = "40001"
+	sqlStateDeadlockDetected     = "40P01"
+)
+
+// errRetryable is returned inside the repository when the transaction was aborted by a deadlock or a
+// serialization failure. withRetry runs the transaction again and never lets it reach the caller
+var errRetryable = errors.New("transaction aborted by a deadlock or serialization failure")
+
+// retryBackoff is the pause before the given retry attempt, with jitter so that the conflicting transactions
+// do not collide again
+var retryBackoff = func(attempt int) time.Duration {
+	base := time.Duration(attempt) * 10 * time.Millisecond
+	return base + time.Duration(rand.Int63n(int64(base)))
+}
+
+// isRetryable reports whether err is a Postgres deadlock or serialization failure
+func isRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == sqlStateDeadlockDetected || pqErr.Code == sqlStateSerializationFailure
+}
+
+// retryableOr returns errRetryable when err can be retried and fallback otherwise
+func retryableOr(err error, fallback error) error {
+	if isRetryable(err) {
+		return errRetryable
+	}
+	return fallback
+}
+
+// withRetry runs fn, which must perform a whole DB transaction, up to maxTxAttempts times while it fails
+// with errRetryable. Once the attempts are used up the failure is reported as ErrTransactionFailed
+func withRetry(fName string, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err != errRetryable {
+			return err
+		}
+		if attempt == maxTxAttempts {
+			log.Printf("[%s] giving up after %d attempts: %v", fName, attempt, err)
+			return appErr.ErrTransactionFailed
+		}
+		log.Printf("[%s] attempt %d failed, retrying: %v", fName, attempt, err)
+		time.Sleep(retryBackoff(attempt))
+	}
+}
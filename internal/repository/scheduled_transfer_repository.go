@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"log"
+	"time"
+)
+
+// scheduledTransferColumns is the column list read by scanScheduledTransfer
+const scheduledTransferColumns = `scheduled_transfer_id, source_account_id, destination_account_id, amount, schedule, status, next_run_at, last_run_at, last_run_status, last_run_reference, last_run_error, created_at, updated_at`
+
+// ScheduledTransferRepository handles scheduled transfer database operations
+type ScheduledTransferRepository struct {
+	db *sql.DB
+}
+
+// NewScheduledTransferRepository creates a new scheduled transfer repository
+func NewScheduledTransferRepository(db *sql.DB) *ScheduledTransferRepository {
+	return &ScheduledTransferRepository{db: db}
+}
+
+type IScheduledTransferRepository interface {
+	Create(st models.ScheduledTransfer) error
+	GetById(scheduledTransferId string) (*models.ScheduledTransfer, error)
+	ListBySourceAccount(accountId int64) ([]models.ScheduledTransfer, error)
+	UpdateStatus(scheduledTransferId string, from []models.ScheduledTransferStatus, to models.ScheduledTransferStatus, nextRunAt sql.NullTime) error
+	RunDue(now time.Time, limit int, execute func(models.ScheduledTransfer) models.ScheduledTransferRun) (int, error)
+}
+
+// Create stores a new scheduled transfer
+func (r *ScheduledTransferRepository) Create(st models.ScheduledTransfer) error {
+	fName := "ScheduledTransferRepository.Create"
+	query := `INSERT INTO scheduled_transfers (scheduled_transfer_id, source_account_id, destination_account_id, amount, schedule, status, next_run_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.db.Exec(query, st.ScheduledTransferId, st.SourceAccountId, st.DestinationAccountId, st.Amount, st.Schedule, st.Status,
+		st.NextRunAt, st.CreatedAt, st.UpdatedAt)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// GetById retrieves a scheduled transfer by its id
+func (r *ScheduledTransferRepository) GetById(scheduledTransferId string) (*models.ScheduledTransfer, error) {
+	fName := "ScheduledTransferRepository.GetById"
+	query := `SELECT ` + scheduledTransferColumns + ` FROM scheduled_transfers WHERE scheduled_transfer_id = $1`
+
+	st, err := scanScheduledTransfer(r.db.QueryRow(query, scheduledTransferId))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, appErr.ErrScheduledTransferNotFound
+		}
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return st, nil
+}
+
+// ListBySourceAccount returns the scheduled transfers paid from an account, newest first
+func (r *ScheduledTransferRepository) ListBySourceAccount(accountId int64) ([]models.ScheduledTransfer, error) {
+	fName := "ScheduledTransferRepository.ListBySourceAccount"
+	query := `SELECT ` + scheduledTransferColumns + ` FROM scheduled_transfers WHERE source_account_id = $1 ORDER BY created_at DESC, scheduled_transfer_id`
+
+	rows, err := r.db.Query(query, accountId)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	var scheduledTransfers []models.ScheduledTransfer
+	for rows.Next() {
+		st, err := scanScheduledTransfer(rows)
+		if err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		scheduledTransfers = append(scheduledTransfers, *st)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	return scheduledTransfers, nil
+}
+
+// UpdateStatus moves a scheduled transfer to the to status and sets its next run time. It fails with
+// ErrInvalidScheduleTransition when the transfer is not in one of the from statuses, eg because it was changed
+// concurrently
+func (r *ScheduledTransferRepository) UpdateStatus(scheduledTransferId string, from []models.ScheduledTransferStatus, to models.ScheduledTransferStatus, nextRunAt sql.NullTime) error {
+	fName := "ScheduledTransferRepository.UpdateStatus"
+	fromStatuses := make([]string, len(from))
+	for i, status := range from {
+		fromStatuses[i] = string(status)
+	}
+
+	query := `UPDATE scheduled_transfers SET status = $1, next_run_at = $2, updated_at = CURRENT_TIMESTAMP WHERE scheduled_transfer_id = $3 AND status = ANY($4)`
+	result, err := r.db.Exec(query, to, nextRunAt, scheduledTransferId, pq.Array(fromStatuses))
+	if err != nil {
+		log.Printf("[%s] failed to update status: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("[%s] failed to get affected rows: %v", fName, err)
+		return appErr.ErrInternal
+	}
+	if rowsAffected == 0 {
+		return appErr.ErrInvalidScheduleTransition
+	}
+	return nil
+}
+
+// RunDue locks up to limit active transfers due at now, skipping those locked by another scheduler, and calls
+// execute for each one. The outcome of each run is recorded and the transfer moves to its next run time, or is
+// completed when it has none, in the same DB transaction. Missed runs are not caught up: the next run is the first
+// one after now. It returns how many transfers were run
+func (r *ScheduledTransferRepository) RunDue(now time.Time, limit int, execute func(models.ScheduledTransfer) models.ScheduledTransferRun) (int, error) {
+	fName := "ScheduledTransferRepository.RunDue"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `SELECT ` + scheduledTransferColumns + ` FROM scheduled_transfers WHERE status = $1 AND next_run_at <= $2 ORDER BY next_run_at LIMIT $3 FOR UPDATE SKIP LOCKED`
+	rows, err := tx.Query(query, models.ScheduledTransferStatusActive, now, limit)
+	if err != nil {
+		log.Printf("[%s] failed to select due transfers: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	var due []models.ScheduledTransfer
+	for rows.Next() {
+		st, err := scanScheduledTransfer(rows)
+		if err != nil {
+			rows.Close()
+			log.Printf("[%s] failed to scan scheduled transfer: %v", fName, err)
+			return 0, appErr.ErrInternal
+		}
+		due = append(due, *st)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating scheduled transfers: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	runQuery := `INSERT INTO scheduled_transfer_runs (scheduled_transfer_id, scheduled_for, executed_at, status, reference, error_message) VALUES ($1, $2, $3, $4, $5, $6)`
+	updateQuery := `UPDATE scheduled_transfers SET status = $1, next_run_at = $2, last_run_at = $3, last_run_status = $4, last_run_reference = $5, last_run_error = $6, updated_at = $3 WHERE scheduled_transfer_id = $7`
+	for _, st := range due {
+		run := execute(st)
+		if _, err := tx.Exec(runQuery, st.ScheduledTransferId, run.ScheduledFor, run.ExecutedAt, run.Status, run.Reference, run.ErrorMessage); err != nil {
+			log.Printf("[%s] failed to record run of %s: %v", fName, st.ScheduledTransferId, err)
+			return 0, appErr.ErrInternal
+		}
+
+		status, nextRunAt := models.ScheduledTransferStatusActive, st.NextRunAfter(now)
+		if !nextRunAt.Valid {
+			status = models.ScheduledTransferStatusCompleted
+		}
+		if _, err := tx.Exec(updateQuery, status, nextRunAt, run.ExecutedAt, run.Status, run.Reference, run.ErrorMessage, st.ScheduledTransferId); err != nil {
+			log.Printf("[%s] failed to update %s: %v", fName, st.ScheduledTransferId, err)
+			return 0, appErr.ErrInternal
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return 0, appErr.ErrInternal
+	}
+	return len(due), nil
+}
+
+// scanScheduledTransfer reads a scheduled transfer row selected with scheduledTransferColumns
+func scanScheduledTransfer(row interface{ Scan(dest ...any) error }) (*models.ScheduledTransfer, error) {
+	var st models.ScheduledTransfer
+	err := row.Scan(&st.ScheduledTransferId, &st.SourceAccountId, &st.DestinationAccountId, &st.Amount, &st.Schedule, &st.Status,
+		&st.NextRunAt, &st.LastRunAt, &st.LastRunStatus, &st.LastRunReference, &st.LastRunError, &st.CreatedAt, &st.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
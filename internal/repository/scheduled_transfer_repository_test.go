@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduledTransferTestColumns = []string{"scheduled_transfer_id", "source_account_id", "destination_account_id", "amount", "schedule", "status",
+	"next_run_at", "last_run_at", "last_run_status", "last_run_reference", "last_run_error", "created_at", "updated_at"}
+
+const (
+	testScheduledTransferId = "SCH-3f2c1b0a-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+	testOneOffTransferId    = "SCH-8e7d6c5b-4a39-4281-9f0e-1d2c3b4a5968"
+)
+
+func TestScheduledTransferRepository_Create(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	st := models.ScheduledTransfer{
+		ScheduledTransferId:  testScheduledTransferId,
+		SourceAccountId:      1,
+		DestinationAccountId: 2,
+		Amount:               models.NewMoney(100),
+		Schedule:             sql.NullString{String: "0 9 1 * *", Valid: true},
+		Status:               models.ScheduledTransferStatusActive,
+		NextRunAt:            sql.NullTime{Time: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC), Valid: true},
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	mock.ExpectExec("INSERT INTO scheduled_transfers").
+		WithArgs(testScheduledTransferId, int64(1), int64(2), models.NewMoney(100), st.Schedule, models.ScheduledTransferStatusActive, st.NextRunAt, now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, NewScheduledTransferRepository(db).Create(st))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduledTransferRepository_UpdateStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		expectedErr  error
+	}{
+		{name: "moved", rowsAffected: 1},
+		{name: "not in a from status", rowsAffected: 0, expectedErr: appErr.ErrInvalidScheduleTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+
+			mock.ExpectExec("UPDATE scheduled_transfers SET status = \\$1, next_run_at = \\$2").
+				WithArgs(models.ScheduledTransferStatusCancelled, sql.NullTime{}, testScheduledTransferId, pq.Array([]string{"active", "paused"})).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err := NewScheduledTransferRepository(db).UpdateStatus(testScheduledTransferId,
+				[]models.ScheduledTransferStatus{models.ScheduledTransferStatusActive, models.ScheduledTransferStatusPaused},
+				models.ScheduledTransferStatusCancelled, sql.NullTime{})
+			assert.Equal(t, tt.expectedErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestScheduledTransferRepository_RunDue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 30, 0, time.UTC)
+	dueAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("records runs and moves to the next run", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM scheduled_transfers WHERE status = \\$1 AND next_run_at <= \\$2 ORDER BY next_run_at LIMIT \\$3 FOR UPDATE SKIP LOCKED").
+			WithArgs(models.ScheduledTransferStatusActive, now, 50).
+			WillReturnRows(sqlmock.NewRows(scheduledTransferTestColumns).
+				AddRow(testScheduledTransferId, 1, 2, "100.00000", "0 9 1 * *", "active", dueAt, nil, nil, nil, nil, now, now).
+				AddRow(testOneOffTransferId, 1, 3, "5.00000", nil, "active", dueAt, nil, nil, nil, nil, now, now))
+
+		reference := sql.NullString{String: "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11", Valid: true}
+		failure := sql.NullString{String: "insufficient funds in sender account", Valid: true}
+		mock.ExpectExec("INSERT INTO scheduled_transfer_runs").
+			WithArgs(testScheduledTransferId, dueAt, now, models.ScheduledRunStatusSucceeded, reference, sql.NullString{}).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE scheduled_transfers SET status = \\$1, next_run_at = \\$2, last_run_at = \\$3").
+			WithArgs(models.ScheduledTransferStatusActive, sql.NullTime{Time: time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC), Valid: true},
+				now, models.ScheduledRunStatusSucceeded, reference, sql.NullString{}, testScheduledTransferId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO scheduled_transfer_runs").
+			WithArgs(testOneOffTransferId, dueAt, now, models.ScheduledRunStatusFailed, sql.NullString{}, failure).
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec("UPDATE scheduled_transfers SET status = \\$1").
+			WithArgs(models.ScheduledTransferStatusCompleted, sql.NullTime{}, now, models.ScheduledRunStatusFailed, sql.NullString{}, failure, testOneOffTransferId).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		var executed []string
+		run, err := NewScheduledTransferRepository(db).RunDue(now, 50, func(st models.ScheduledTransfer) models.ScheduledTransferRun {
+			executed = append(executed, st.ScheduledTransferId)
+			run := models.ScheduledTransferRun{ScheduledTransferId: st.ScheduledTransferId, ScheduledFor: st.NextRunAt.Time, ExecutedAt: now}
+			if st.Schedule.Valid {
+				run.Status, run.Reference = models.ScheduledRunStatusSucceeded, reference
+			} else {
+				run.Status, run.ErrorMessage = models.ScheduledRunStatusFailed, failure
+			}
+			return run
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, run)
+		assert.Equal(t, []string{testScheduledTransferId, testOneOffTransferId}, executed)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing due", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT (.+) FROM scheduled_transfers").WillReturnRows(sqlmock.NewRows(scheduledTransferTestColumns))
+		mock.ExpectRollback()
+
+		run, err := NewScheduledTransferRepository(db).RunDue(now, 50, func(models.ScheduledTransfer) models.ScheduledTransferRun {
+			t.Fatal("nothing should run")
+			return models.ScheduledTransferRun{}
+		})
+		assert.NoError(t, err)
+		assert.Zero(t, run)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+)
+
+// StatementRepository reads the ledger entries that account statements are built from
+type StatementRepository struct {
+	db *sql.DB
+}
+
+// NewStatementRepository creates a new statement repository
+func NewStatementRepository(db *sql.DB) *StatementRepository {
+	return &StatementRepository{db: db}
+}
+
+type IStatementRepository interface {
+	GetStatementEntries(args models.StatementArgs) (models.Money, []models.Transaction, error)
+}
+
+// GetStatementEntries returns the balance of an account when the period of args starts, followed by the ledger
+// entries of the period oldest first. The opening balance is the one recorded on the latest entry before the period,
+// or the initial balance of the account when it has none. Both are read from one snapshot so that a transfer
+// committed meanwhile cannot leave a gap between them
+func (r *StatementRepository) GetStatementEntries(args models.StatementArgs) (models.Money, []models.Transaction, error) {
+	fName := "StatementRepository.GetStatementEntries"
+	var openingBalance models.Money
+	from, until := args.From, args.To.AddDate(0, 0, 1)
+
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return openingBalance, nil, appErr.ErrInternal
+	}
+	defer tx.Rollback()
+
+	query := `SELECT COALESCE((SELECT available_balance FROM transactions WHERE account_id = $1 AND created_at < $2 ORDER BY id DESC LIMIT 1), a.initial_balance)
+		FROM accounts a WHERE a.account_id = $1`
+	if err := tx.QueryRow(query, args.AccountId, from).Scan(&openingBalance); err != nil {
+		log.Printf("[%s] failed to read the opening balance: %v", fName, err)
+		if err == sql.ErrNoRows {
+			return openingBalance, nil, appErr.ErrAccountNotFound
+		}
+		return openingBalance, nil, appErr.ErrInternal
+	}
+
+	query = `SELECT ` + transactionColumns + ` FROM transactions WHERE account_id = $1 AND created_at >= $2 AND created_at < $3 ORDER BY id`
+	rows, err := tx.Query(query, args.AccountId, from, until)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return openingBalance, nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	transactions, err := scanTransactions(fName, rows)
+	if err != nil {
+		return openingBalance, nil, err
+	}
+	return openingBalance, transactions, nil
+}
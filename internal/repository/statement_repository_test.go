@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementRepository_GetStatementEntries(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+	args := models.StatementArgs{AccountId: 1, From: from, To: to}
+	columns := []string{"id", "account_id", "amount", "currency_code", "available_balance", "is_credit", "reference", "original_reference", "created_at", "updated_at", "deleted_at", "fx_rate", "source_amount", "destination_amount", "is_fee"}
+
+	tests := []struct {
+		name            string
+		setupMock       func(sqlmock.Sqlmock)
+		expectedOpening models.Money
+		expectedLen     int
+		expectedErr     error
+	}{
+		{
+			name: "opening balance and entries of the period",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT COALESCE\(\(SELECT available_balance FROM transactions WHERE account_id = \$1 AND created_at < \$2 ORDER BY id DESC LIMIT 1\), a.initial_balance\)`).
+					WithArgs(int64(1), from).
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("100.00000"))
+				mock.ExpectQuery(`SELECT (.+) FROM transactions WHERE account_id = \$1 AND created_at >= \$2 AND created_at < \$3 ORDER BY id`).
+					WithArgs(int64(1), from, until).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(5, 1, "25.00000", "USD", "75.00000", false, "TXN-5", nil, at, at, nil, nil, nil, nil, false).
+						AddRow(6, 1, "10.00000", "USD", "85.00000", true, "TXN-6", nil, at, at, nil, nil, nil, nil, false))
+				mock.ExpectRollback()
+			},
+			expectedOpening: models.NewMoney(100),
+			expectedLen:     2,
+		},
+		{
+			name: "account not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COALESCE").WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT COALESCE").
+					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("0.00000"))
+				mock.ExpectQuery("SELECT (.+) FROM transactions").WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			opening, transactions, err := NewStatementRepository(db).GetStatementEntries(args)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedOpening, opening)
+			assert.Len(t, transactions, tt.expectedLen)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,12 +2,19 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"log"
-	"strconv"
+	"net/http"
+	"sort"
+	"time"
 )
 
+// transactionColumns is the column list read by scanTransactions
+const transactionColumns = `id, account_id, amount, currency_code, available_balance, is_credit, reference, original_reference, created_at, updated_at, deleted_at, fx_rate, source_amount, destination_amount, is_fee`
+
 // TransactionRepository handles transaction database operations
 type TransactionRepository struct {
 	db *sql.DB
@@ -20,10 +27,28 @@ func NewTransactionRepository(db *sql.DB) *TransactionRepository {
 
 type ITransactionRepository interface {
 	CreateTransaction(req models.CreateTransactionArgs) (models.CreateTransactionResponse, error)
+	ListTransactions(filter models.TransactionFilter) ([]models.Transaction, error)
+	GetByReference(reference string) ([]models.Transaction, error)
+	ReverseTransaction(req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)
+	CreateBatchTransaction(reqs []models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error)
 }
 
-// CreateTransaction creates a two transaction entries in Transactions table and updates account balances in Accounts table
+// CreateTransaction creates a two transaction entries in Transactions table and updates account balances in Accounts table.
+// A transfer charged a fee gets two more entries under the same reference, moving the fee from the source account
+// to the revenue account. All of them are recorded as one balanced journal entry.
+// The transfer is run again when Postgres aborts it because of a deadlock or a serialization failure
 func (r *TransactionRepository) CreateTransaction(req models.CreateTransactionArgs) (models.CreateTransactionResponse, error) {
+	var resp models.CreateTransactionResponse
+	err := withRetry("TransactionRepository.CreateTransaction", func() error {
+		var err error
+		resp, err = r.createTransaction(req)
+		return err
+	})
+	return resp, err
+}
+
+// createTransaction runs a single attempt of CreateTransaction
+func (r *TransactionRepository) createTransaction(req models.CreateTransactionArgs) (models.CreateTransactionResponse, error) {
 	fName := "TransactionRepository.CreateTransaction"
 	var resp models.CreateTransactionResponse
 
@@ -34,66 +59,597 @@ func (r *TransactionRepository) CreateTransaction(req models.CreateTransactionAr
 	}
 	defer tx.Rollback()
 
-	// Get source account balance with row lock
-	sourceBalance, err := r.getAccountRepository().GetBalanceForUpdate(tx, req.SourceAccountId)
+	// A replayed request returns the response stored by the original one
+	if req.Idempotency.Key != "" {
+		record, err := r.getIdempotencyRepository().Acquire(tx, req.Idempotency, models.IdempotencyEndpointCreateTransaction)
+		if err != nil {
+			if err == appErr.ErrIdempotencyKeyReused {
+				return resp, err
+			}
+			return resp, appErr.ErrTransactionFailed
+		}
+		if record != nil {
+			if err := json.Unmarshal(record.ResponseBody, &resp); err != nil {
+				log.Printf("[%s] failed to decode stored response: %v", fName, err)
+				return models.CreateTransactionResponse{}, appErr.ErrTransactionFailed
+			}
+			return resp, nil
+		}
+	}
+
+	if err := r.consumeQuote(tx, req); err != nil {
+		return resp, err
+	}
+
+	// Lock every account in ascending account_id order, as postTransfer does, the revenue account included
+	accounts, err := r.lockAccounts(tx, transferAccountIds(req)...)
 	if err != nil {
-		log.Printf("[%s] failed to get source account balance: %v", fName, err)
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		if err == errRetryable {
+			return resp, err
+		}
 		return resp, appErr.ErrTransactionFailed
 	}
 
-	if sourceBalance < req.Amount {
-		return resp, appErr.ErrInsufficientBalance
+	newSourceBalance, err := r.applyCreateTransaction(tx, accounts, req)
+	if err != nil {
+		return resp, err
+	}
+	resp = newCreateTransactionResponse(req, newSourceBalance)
+
+	if req.Idempotency.Key != "" {
+		body, err := json.Marshal(resp)
+		if err != nil {
+			log.Printf("[%s] failed to encode response: %v", fName, err)
+			return models.CreateTransactionResponse{}, appErr.ErrTransactionFailed
+		}
+		if err := r.getIdempotencyRepository().Complete(tx, req.Idempotency, models.IdempotencyEndpointCreateTransaction, http.StatusOK, body); err != nil {
+			return models.CreateTransactionResponse{}, appErr.ErrTransactionFailed
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return models.CreateTransactionResponse{}, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+
+	return resp, nil
+}
+
+// CreateBatchTransaction posts every transfer of reqs in one DB transaction: either all of them are applied or
+// none is. All the accounts involved are locked up front in ascending account_id order and each transfer sees the
+// balances left by the ones before it. On failure the position of the failing transfer in reqs is returned along
+// with the error. The batch is run again when Postgres aborts it because of a deadlock or a serialization failure
+func (r *TransactionRepository) CreateBatchTransaction(reqs []models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error) {
+	var resps []models.CreateTransactionResponse
+	var failedIndex int
+	err := withRetry("TransactionRepository.CreateBatchTransaction", func() error {
+		var err error
+		resps, failedIndex, err = r.createBatchTransaction(reqs)
+		return err
+	})
+	return resps, failedIndex, err
+}
+
+// createBatchTransaction runs a single attempt of CreateBatchTransaction
+func (r *TransactionRepository) createBatchTransaction(reqs []models.CreateTransactionArgs) ([]models.CreateTransactionResponse, int, error) {
+	fName := "TransactionRepository.CreateBatchTransaction"
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("[%s] failed due to %v", fName, err)
+		return nil, 0, appErr.ErrTransactionFailed
 	}
+	defer tx.Rollback()
 
-	// Get destination account balance with row lock
-	destinationBalance, err := r.getAccountRepository().GetBalanceForUpdate(tx, req.DestinationAccountId)
+	// Quotes are consumed before the accounts are locked, as for a single transfer
+	accountIds := make([]int64, 0, 2*len(reqs))
+	for i, req := range reqs {
+		if err := r.consumeQuote(tx, req); err != nil {
+			return nil, i, err
+		}
+		accountIds = append(accountIds, transferAccountIds(req)...)
+	}
+	accounts, err := r.lockAccounts(tx, accountIds...)
 	if err != nil {
-		log.Printf("[%s] failed to get destination account balance: %v", fName, err)
-		return resp, appErr.ErrTransactionFailed
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		if err == errRetryable {
+			return nil, 0, err
+		}
+		return nil, 0, appErr.ErrTransactionFailed
+	}
+
+	resps := make([]models.CreateTransactionResponse, len(reqs))
+	for i, req := range reqs {
+		newSourceBalance, err := r.applyCreateTransaction(tx, accounts, req)
+		if err != nil {
+			return nil, i, err
+		}
+		resps[i] = newCreateTransactionResponse(req, newSourceBalance)
 	}
 
-	newSourceBalance := sourceBalance - req.Amount
-	newDestinationBalance := destinationBalance + req.Amount
+	if err := tx.Commit(); err != nil {
+		log.Printf("[%s] failed to commit transaction: %v", fName, err)
+		return nil, 0, retryableOr(err, appErr.ErrTransactionFailed)
+	}
 
-	// Update source account balance
-	if err := r.getAccountRepository().UpdateBalance(tx, req.SourceAccountId, newSourceBalance); err != nil {
-		log.Printf("[%s] failed to update source account balance: %v", fName, err)
-		return resp, appErr.ErrTransactionFailed
+	return resps, 0, nil
+}
+
+// consumeQuote marks the quote of req used within tx, so that it cannot be executed twice. It does nothing when
+// req has no quote
+func (r *TransactionRepository) consumeQuote(tx *sql.Tx, req models.CreateTransactionArgs) error {
+	if req.QuoteId == "" {
+		return nil
+	}
+	if _, err := r.getFxQuoteRepository().Consume(tx, req.QuoteId, req.Reference, time.Now().UTC()); err != nil {
+		if err == errRetryable || err == appErr.ErrQuoteNotFound || err == appErr.ErrQuoteAlreadyUsed || err == appErr.ErrQuoteExpired {
+			return err
+		}
+		return appErr.ErrTransactionFailed
 	}
+	return nil
+}
 
-	// Update destination account balance
-	if err := r.getAccountRepository().UpdateBalance(tx, req.DestinationAccountId, newDestinationBalance); err != nil {
-		log.Printf("[%s] failed to update destination account balance: %v", fName, err)
-		return resp, appErr.ErrTransactionFailed
+// transferAccountIds returns the accounts touched by the transfer described by req: its source and destination
+// accounts, and the revenue account when it is charged a fee
+func transferAccountIds(req models.CreateTransactionArgs) []int64 {
+	if req.Fee == nil {
+		return []int64{req.SourceAccountId, req.DestinationAccountId}
 	}
+	return []int64{req.SourceAccountId, req.DestinationAccountId, req.Fee.RevenueAccountId}
+}
+
+// applyCreateTransaction posts the transfer described by req on the locked accounts, followed by its fee when it
+// is charged one, as a single journal entry. The amount and the fee must both be covered by the source account.
+// It returns the new available balance of the source account
+func (r *TransactionRepository) applyCreateTransaction(tx *sql.Tx, accounts map[int64]*models.Account, req models.CreateTransactionArgs) (models.Money, error) {
+	entry := newJournalEntry(req.Reference, "")
+	newSourceBalance, _, err := r.applyTransfer(tx, accounts, entry, newTransferLegs(req))
+	if err != nil {
+		return models.Money{}, err
+	}
+
+	if req.Fee != nil {
+		newSourceBalance, _, err = r.applyTransfer(tx, accounts, entry, newFeeLegs(req))
+		if err == appErr.ErrDestinationAccountClosed {
+			return models.Money{}, appErr.ErrFeeAccountUnavailable
+		}
+		if err != nil {
+			return models.Money{}, err
+		}
+	}
+
+	if err := r.getJournalRepository().post(tx, entry); err != nil {
+		return models.Money{}, err
+	}
+	return newSourceBalance, nil
+}
+
+// newTransferLegs returns the ledger entries of the transfer described by req
+func newTransferLegs(req models.CreateTransactionArgs) transferLegs {
+	return transferLegs{
+		debitAccountId:     req.SourceAccountId,
+		creditAccountId:    req.DestinationAccountId,
+		amount:             req.Amount,
+		currencyCode:       req.CurrencyCode,
+		creditAmount:       req.DestinationAmount,
+		creditCurrencyCode: req.DestinationCurrencyCode,
+		fxRate:             req.FxRate,
+		limits:             req.Limits,
+	}
+}
+
+// newFeeLegs returns the ledger entries moving the fee of the transfer described by req to the revenue account.
+// req.Fee must be set
+func newFeeLegs(req models.CreateTransactionArgs) transferLegs {
+	return transferLegs{
+		debitAccountId:     req.SourceAccountId,
+		creditAccountId:    req.Fee.RevenueAccountId,
+		amount:             req.Fee.Breakdown.Amount,
+		currencyCode:       req.CurrencyCode,
+		creditAmount:       req.Fee.RevenueAmount,
+		creditCurrencyCode: req.Fee.RevenueCurrencyCode,
+		fxRate:             req.Fee.FxRate,
+		isFee:              true,
+	}
+}
+
+// newCreateTransactionResponse builds the response of the transfer described by req once it is posted
+func newCreateTransactionResponse(req models.CreateTransactionArgs, newSourceBalance models.Money) models.CreateTransactionResponse {
+	resp := models.CreateTransactionResponse{
+		SourceAccountId:  req.SourceAccountId,
+		AvailableBalance: newSourceBalance,
+		Reference:        req.Reference,
+	}
+	if req.DestinationCurrencyCode != req.CurrencyCode {
+		destinationAmount, fxRate := req.DestinationAmount, req.FxRate
+		resp.DestinationAmount = &destinationAmount
+		resp.DestinationCurrencyCode = req.DestinationCurrencyCode
+		resp.FxRate = &fxRate
+	}
+	if req.Fee != nil {
+		resp.Fee = &models.FeeResponse{
+			FeeBreakdown:     req.Fee.Breakdown,
+			CurrencyCode:     req.CurrencyCode,
+			RevenueAccountId: req.Fee.RevenueAccountId,
+			TotalDebited:     req.Amount.Add(req.Fee.Breakdown.Amount),
+		}
+	}
+	return resp
+}
 
-	// Create debit transaction record
-	query := `INSERT INTO transactions (account_id, amount, currency_code, available_balance, is_credit, reference) VALUES ($1, $2, $3, $4, $5, $6)`
-	_, err = tx.Exec(query, req.SourceAccountId, req.Amount, req.CurrencyCode, newSourceBalance, false, req.Reference)
+// ReverseTransaction moves money back from the receiver to the sender of the original transfer under a new reference.
+// The original entries stay locked for the whole transaction so concurrent reversals cannot exceed the original amount
+func (r *TransactionRepository) ReverseTransaction(req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error) {
+	var resp models.ReverseTransactionResponse
+	err := withRetry("TransactionRepository.ReverseTransaction", func() error {
+		var err error
+		resp, err = r.reverseTransaction(req)
+		return err
+	})
+	return resp, err
+}
+
+// reverseTransaction runs a single attempt of ReverseTransaction
+func (r *TransactionRepository) reverseTransaction(req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error) {
+	fName := "TransactionRepository.ReverseTransaction"
+	var resp models.ReverseTransactionResponse
+
+	tx, err := r.db.Begin()
 	if err != nil {
-		log.Printf("[%s] failed to create debit transaction record: %v", fName, err)
+		log.Printf("[%s] failed due to %v", fName, err)
 		return resp, appErr.ErrTransactionFailed
 	}
+	defer tx.Rollback()
 
-	// Create credit transaction record
-	_, err = tx.Exec(query, req.DestinationAccountId, req.Amount, req.CurrencyCode, newDestinationBalance, true, req.Reference)
+	rows, err := tx.Query(`SELECT `+transactionColumns+` FROM transactions WHERE reference = $1 ORDER BY id FOR UPDATE`, req.OriginalReference)
+	if err != nil {
+		log.Printf("[%s] failed to lock original transaction: %v", fName, err)
+		return resp, retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	legs, err := scanTransactions(fName, rows)
+	rows.Close()
 	if err != nil {
-		log.Printf("[%s] failed to create credit transaction record: %v", fName, err)
 		return resp, appErr.ErrTransactionFailed
 	}
 
+	var debit, credit *models.Transaction
+	for i := range legs {
+		if !legs[i].IsCredit && debit == nil {
+			debit = &legs[i]
+		}
+		if legs[i].IsCredit && credit == nil {
+			credit = &legs[i]
+		}
+	}
+	if debit == nil || credit == nil {
+		return resp, appErr.ErrTransactionNotFound
+	}
+	if debit.OriginalReference.Valid {
+		return resp, appErr.ErrCannotReverseReversal
+	}
+
+	// Previous reversals credited the original sender in its currency and debited the original receiver in theirs
+	var reversedToSender, reversedFromReceiver models.Money
+	query := `SELECT COALESCE(SUM(amount) FILTER (WHERE is_credit), 0), COALESCE(SUM(amount) FILTER (WHERE NOT is_credit), 0) FROM transactions WHERE original_reference = $1`
+	if err := tx.QueryRow(query, req.OriginalReference).Scan(&reversedToSender, &reversedFromReceiver); err != nil {
+		log.Printf("[%s] failed to sum previous reversals: %v", fName, err)
+		return resp, appErr.ErrTransactionFailed
+	}
+
+	remaining := debit.Amount.Sub(reversedToSender)
+	if !remaining.IsPositive() {
+		return resp, appErr.ErrTransactionAlreadyReversed
+	}
+
+	amount := remaining
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+	if amount.GreaterThan(remaining) {
+		return resp, appErr.ErrReversalAmountExceeded
+	}
+	if currency, err := models.LookupCurrency(debit.CurrencyCode); err == nil && !currency.Allows(amount) {
+		return resp, appErr.ErrInvalidCurrencyPrecision
+	}
+
+	// The receiver gives back at the original rate. The final reversal takes exactly what the receiver has left
+	// to give back so that rounding never leaves a residue
+	receiverAmount, fxRate := amount, models.OneExchangeRate
+	if debit.CurrencyCode != credit.CurrencyCode {
+		originalRate := models.OneExchangeRate
+		if credit.FxRate != nil {
+			originalRate = *credit.FxRate
+		}
+		fxRate = originalRate.Inverse()
+
+		receiverAmount = credit.Amount.Sub(reversedFromReceiver)
+		if amount.Cmp(remaining) != 0 {
+			receiverCurrency, err := models.LookupCurrency(credit.CurrencyCode)
+			if err != nil {
+				log.Printf("[%s] failed to look up currency %s: %v", fName, credit.CurrencyCode, err)
+				return resp, appErr.ErrTransactionFailed
+			}
+			receiverAmount = originalRate.Convert(amount, receiverCurrency)
+		}
+	}
+
+	newSourceBalance, _, err := r.postTransfer(tx, req.Reference, req.OriginalReference, transferLegs{
+		debitAccountId:     credit.AccountId,
+		creditAccountId:    debit.AccountId,
+		amount:             receiverAmount,
+		currencyCode:       credit.CurrencyCode,
+		creditAmount:       amount,
+		creditCurrencyCode: debit.CurrencyCode,
+		fxRate:             fxRate,
+	})
+	if err != nil {
+		if err == appErr.ErrInsufficientBalance {
+			return resp, appErr.ErrReversalInsufficientBalance
+		}
+		return resp, err
+	}
+
 	if err := tx.Commit(); err != nil {
 		log.Printf("[%s] failed to commit transaction: %v", fName, err)
-		return resp, appErr.ErrTransactionFailed
+		return resp, retryableOr(err, appErr.ErrTransactionFailed)
 	}
 
-	resp.AvailableBalance = strconv.FormatFloat(newSourceBalance, 'f', 5, 64)
-	resp.SourceAccountId = req.SourceAccountId
+	resp.Reference = req.Reference
+	resp.OriginalReference = req.OriginalReference
+	resp.SourceAccountId = credit.AccountId
+	resp.DestinationAccountId = debit.AccountId
+	resp.Amount = amount
+	resp.CurrencyCode = debit.CurrencyCode
+	resp.AvailableBalance = newSourceBalance
+	resp.DebitedAmount = receiverAmount
+	resp.DebitedCurrencyCode = credit.CurrencyCode
+	resp.RemainingReversible = remaining.Sub(amount)
 	return resp, nil
 }
 
+// ListTransactions returns the ledger entries of an account newest-first, applying the optional filters and cursor
+func (r *TransactionRepository) ListTransactions(filter models.TransactionFilter) ([]models.Transaction, error) {
+	fName := "TransactionRepository.ListTransactions"
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE account_id = $1`
+	args := []interface{}{filter.AccountId}
+	where := func(cond string, values ...interface{}) {
+		placeholders := make([]interface{}, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = len(args)
+		}
+		query += " AND " + fmt.Sprintf(cond, placeholders...)
+	}
+
+	if filter.From != nil {
+		where("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		where("created_at <= $%d", *filter.To)
+	}
+	if filter.IsCredit != nil {
+		where("is_credit = $%d", *filter.IsCredit)
+	}
+	if filter.MinAmount != nil {
+		where("amount >= $%d", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		where("amount <= $%d", *filter.MaxAmount)
+	}
+	if filter.CursorId > 0 {
+		where("(created_at, id) < ($%d, $%d)", filter.CursorCreatedAt, filter.CursorId)
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	return scanTransactions(fName, rows)
+}
+
+// GetByReference returns every ledger entry written under the given transfer reference, oldest first
+func (r *TransactionRepository) GetByReference(reference string) ([]models.Transaction, error) {
+	fName := "TransactionRepository.GetByReference"
+	query := `SELECT ` + transactionColumns + ` FROM transactions WHERE reference = $1 ORDER BY id`
+
+	rows, err := r.db.Query(query, reference)
+	if err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return nil, appErr.ErrInternal
+	}
+	defer rows.Close()
+
+	transactions, err := scanTransactions(fName, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) == 0 {
+		return nil, appErr.ErrTransactionNotFound
+	}
+
+	return transactions, nil
+}
+
+// scanTransactions reads all the transaction rows selected with transactionColumns
+func scanTransactions(fName string, rows *sql.Rows) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	for rows.Next() {
+		var t models.Transaction
+		if err := rows.Scan(&t.Id, &t.AccountId, &t.Amount, &t.CurrencyCode, &t.AvailableBalance, &t.IsCredit, &t.Reference, &t.OriginalReference, &t.CreatedAt, &t.UpdatedAt, &t.DeletedAt, &t.FxRate, &t.SourceAmount, &t.DestinationAmount, &t.IsFee); err != nil {
+			log.Printf("[%s] failed to scan row: %v", fName, err)
+			return nil, appErr.ErrInternal
+		}
+		transactions = append(transactions, t)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("[%s] failed while iterating rows: %v", fName, err)
+		return nil, appErr.ErrInternal
+	}
+
+	return transactions, nil
+}
+
+// transferLegs describes one debit and one credit ledger entry of a journal entry.
+// amount is debited in currencyCode and creditAmount is credited in creditCurrencyCode, fxRate being the rate
+// between the two. limits are the velocity caps of the debited account; they are left empty for reversals, sweeps
+// and fees. isFee marks the entries moving the fee of a transfer
+type transferLegs struct {
+	debitAccountId     int64
+	creditAccountId    int64
+	amount             models.Money
+	currencyCode       string
+	creditAmount       models.Money
+	creditCurrencyCode string
+	fxRate             models.ExchangeRate
+	limits             models.TransferLimits
+	isFee              bool
+}
+
+// postTransfer locks both accounts, moves the amount between their balances and writes the debit and credit
+// entries within tx as the journal entry of reference. It returns the new available balances of the debited and the credited account.
+// Only ErrInsufficientBalance, the account status and limit errors and errRetryable are returned as is, any other failure
+// is reported as ErrTransactionFailed
+func (r *TransactionRepository) postTransfer(tx *sql.Tx, reference, originalReference string, legs transferLegs) (models.Money, models.Money, error) {
+	fName := "TransactionRepository.postTransfer"
+	var zero models.Money
+
+	// Lock both accounts in ascending account_id order, whatever the direction of the transfer, so that
+	// concurrent A->B and B->A transfers queue up on the same row instead of deadlocking
+	accounts, err := r.lockAccounts(tx, legs.debitAccountId, legs.creditAccountId)
+	if err != nil {
+		log.Printf("[%s] failed to lock accounts: %v", fName, err)
+		if err == errRetryable {
+			return zero, zero, err
+		}
+		return zero, zero, appErr.ErrTransactionFailed
+	}
+	return r.applyEntry(tx, accounts, reference, originalReference, legs)
+}
+
+// applyEntry applies legs to the locked accounts and posts them as the journal entry of reference on their own
+func (r *TransactionRepository) applyEntry(tx *sql.Tx, accounts map[int64]*models.Account, reference, originalReference string, legs transferLegs) (models.Money, models.Money, error) {
+	var zero models.Money
+	entry := newJournalEntry(reference, originalReference)
+	debitBalance, creditBalance, err := r.applyTransfer(tx, accounts, entry, legs)
+	if err != nil {
+		return zero, zero, err
+	}
+	if err := r.getJournalRepository().post(tx, entry); err != nil {
+		return zero, zero, err
+	}
+	return debitBalance, creditBalance, nil
+}
+
+// applyTransfer does the work of postTransfer once both accounts are locked. The status of the accounts is
+// checked under the lock so that a transfer cannot race with a freeze or a close, and the debit must be covered by
+// the available balance plus the overdraft limit so that funds reserved by holds are not spent. The velocity limits of
+// legs are checked against the transfers already posted, including the ones made earlier in tx. The locked accounts are updated with
+// their new balances, so several transfers can be applied one after another. The debit and credit are added to entry,
+// which writes them once posted. It returns the new available balances
+func (r *TransactionRepository) applyTransfer(tx *sql.Tx, accounts map[int64]*models.Account, entry *journalEntry, legs transferLegs) (models.Money, models.Money, error) {
+	fName := "TransactionRepository.applyTransfer"
+	var zero models.Money
+
+	debitAccount := accounts[legs.debitAccountId]
+	creditAccount := accounts[legs.creditAccountId]
+
+	switch {
+	case debitAccount.Status == models.AccountStatusClosed:
+		return zero, zero, appErr.ErrSourceAccountClosed
+	case !debitAccount.Status.AllowsDebit():
+		return zero, zero, appErr.ErrSourceAccountFrozen
+	case !creditAccount.Status.AllowsCredit():
+		return zero, zero, appErr.ErrDestinationAccountClosed
+	}
+
+	if err := r.getTransferLimitRepository().checkVelocity(tx, legs.debitAccountId, legs.limits, legs.amount); err != nil {
+		return zero, zero, err
+	}
+
+	debitBalance := debitAccount.Balance
+	creditBalance := creditAccount.Balance
+
+	if debitAccount.AvailableToSpend().LessThan(legs.amount) {
+		return zero, zero, appErr.ErrInsufficientBalance
+	}
+
+	newDebitBalance := debitBalance.Sub(legs.amount)
+	newCreditBalance := creditBalance.Add(legs.creditAmount)
+
+	// Update debit account balance
+	if err := r.getAccountRepository().UpdateBalance(tx, legs.debitAccountId, newDebitBalance); err != nil {
+		log.Printf("[%s] failed to update debit account balance: %v", fName, err)
+		return zero, zero, appErr.ErrTransactionFailed
+	}
+
+	// Update credit account balance
+	if err := r.getAccountRepository().UpdateBalance(tx, legs.creditAccountId, newCreditBalance); err != nil {
+		log.Printf("[%s] failed to update credit account balance: %v", fName, err)
+		return zero, zero, appErr.ErrTransactionFailed
+	}
+
+	entry.addLegs(legs, newDebitBalance, newCreditBalance)
+
+	debitAccount.Balance = newDebitBalance
+	creditAccount.Balance = newCreditBalance
+	return debitAccount.AvailableBalance(), creditAccount.AvailableBalance(), nil
+}
+
+// lockAccounts takes the row lock of every given account in ascending account_id order and returns their
+// balance, currency and status
+func (r *TransactionRepository) lockAccounts(tx *sql.Tx, accountIds ...int64) (map[int64]*models.Account, error) {
+	ordered := append([]int64(nil), accountIds...)
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i] < ordered[j] })
+
+	accounts := make(map[int64]*models.Account, len(ordered))
+	for _, accountId := range ordered {
+		if _, ok := accounts[accountId]; ok {
+			continue
+		}
+		account, err := r.getAccountRepository().GetForUpdate(tx, accountId)
+		if err != nil {
+			return nil, err
+		}
+		accounts[accountId] = account
+	}
+	return accounts, nil
+}
+
 // getAccountRepository returns an account repository instance
 // This is a helper method to access account operations within transactions
 func (r *TransactionRepository) getAccountRepository() *AccountRepository {
 	return &AccountRepository{db: r.db}
 }
+
+// getIdempotencyRepository returns an idempotency repository instance
+// This is a helper method to reserve idempotency keys within transactions
+func (r *TransactionRepository) getIdempotencyRepository() *IdempotencyRepository {
+	return &IdempotencyRepository{db: r.db}
+}
+
+// getFxQuoteRepository returns an FX quote repository instance
+// This is a helper method to consume quotes within transactions
+func (r *TransactionRepository) getFxQuoteRepository() *FxQuoteRepository {
+	return &FxQuoteRepository{db: r.db}
+}
+
+// getJournalRepository returns a journal repository instance
+// This is a helper method to post journal entries within transactions
+func (r *TransactionRepository) getJournalRepository() *JournalRepository {
+	return &JournalRepository{db: r.db}
+}
+
+// getTransferLimitRepository returns a transfer limit repository instance
+// This is a helper method to count the transfers of a locked account within transactions
+func (r *TransactionRepository) getTransferLimitRepository() *TransferLimitRepository {
+	return &TransferLimitRepository{db: r.db}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/database/migrations"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTransactionRepository_OppositeTransfersConcurrently hammers A->B and B->A transfers at the same time against
+// a real Postgres, which used to deadlock. Set TEST_DATABASE_URL to a disposable database to run it
+func TestTransactionRepository_OppositeTransfersConcurrently(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(32)
+
+	migrator, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	require.NoError(t, migrator.Up(context.Background()))
+
+	accountA := time.Now().UnixNano() % 1_000_000_000
+	accountB := accountA + 1
+	initial := models.NewMoney(1000)
+	accountRepo := NewAccountRepository(db)
+	for _, id := range []int64{accountA, accountB} {
+		now := time.Now()
+		require.NoError(t, accountRepo.CreateAccount(models.CreateAccountArgs{Account: models.Account{AccountId: id, Balance: initial, CreatedAt: now, UpdatedAt: now}}))
+	}
+
+	repo := NewTransactionRepository(db)
+	const transfersPerDirection = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*transfersPerDirection)
+	transfer := func(i int, source, destination int64) {
+		defer wg.Done()
+		_, err := repo.CreateTransaction(models.CreateTransactionArgs{
+			SourceAccountId:      source,
+			DestinationAccountId: destination,
+			Amount:               models.NewMoney(1),
+			CurrencyCode:         "USD",
+			Reference:            fmt.Sprintf("TXN-concurrency-%d-%d-%d", source, destination, i),
+		})
+		errs <- err
+	}
+	for i := 0; i < transfersPerDirection; i++ {
+		wg.Add(2)
+		go transfer(i, accountA, accountB)
+		go transfer(i, accountB, accountA)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	for _, id := range []int64{accountA, accountB} {
+		account, err := accountRepo.GetByAccountId(id)
+		require.NoError(t, err)
+		assert.Equal(t, initial, account.Balance, "account %d", id)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,69 +19,192 @@ func TestTransactionRepository_CreateTransaction(t *testing.T) {
 	repo := NewTransactionRepository(db)
 
 	req := models.CreateTransactionArgs{
-		SourceAccountId:      1,
-		DestinationAccountId: 2,
-		Amount:               100.0,
-		CurrencyCode:         "INR",
-		Reference:            "TXN-123456",
+		SourceAccountId:         1,
+		DestinationAccountId:    2,
+		Amount:                  models.MustParseMoney("100"),
+		CurrencyCode:            "INR",
+		DestinationAmount:       models.MustParseMoney("100"),
+		DestinationCurrencyCode: "INR",
+		FxRate:                  models.OneExchangeRate,
+		Reference:               "TXN-123456",
 	}
+	maxDailyAmount := models.MustParseMoney("500")
 
 	tests := []struct {
-		name       string
-		setupMock  func()
-		expectErr  error
-		expectResp bool
+		name          string
+		setupMock     func()
+		expectErr     error
+		expectResp    bool
+		expectBalance string
+		limits        models.TransferLimits
 	}{
 		{
 			name: "success",
 			setupMock: func() {
 				mock.ExpectBegin()
 
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(req.SourceAccountId).
-					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(200.0))
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "USD", "active", "0.00000", "0.00000"))
 
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(req.DestinationAccountId).
-					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(50.0))
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "USD", "active", "0.00000", "0.00000"))
 
 				mock.ExpectExec("UPDATE accounts SET balance").
-					WithArgs(100.0, req.SourceAccountId).
+					WithArgs(models.MustParseMoney("100"), req.SourceAccountId).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("UPDATE accounts SET balance").
-					WithArgs(150.0, req.DestinationAccountId).
+					WithArgs(models.MustParseMoney("150"), req.DestinationAccountId).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
+				mock.ExpectQuery("INSERT INTO journal_entries").
+					WithArgs(req.Reference, sql.NullString{}).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+
 				mock.ExpectExec("INSERT INTO transactions").
-					WithArgs(req.SourceAccountId, req.Amount, req.CurrencyCode, false, req.Reference).
+					WithArgs(req.SourceAccountId, req.Amount, req.CurrencyCode, models.MustParseMoney("100"), false, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
 				mock.ExpectExec("INSERT INTO transactions").
-					WithArgs(req.DestinationAccountId, req.Amount, req.CurrencyCode, true, req.Reference).
+					WithArgs(req.DestinationAccountId, req.Amount, req.CurrencyCode, models.MustParseMoney("150"), true, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 
+				mock.ExpectExec("INSERT INTO postings").
+					WithArgs(int64(1), req.SourceAccountId, models.PostingKindTransfer, req.Amount.Neg(), req.CurrencyCode,
+						int64(1), req.DestinationAccountId, models.PostingKindTransfer, req.Amount, req.CurrencyCode).
+					WillReturnResult(sqlmock.NewResult(2, 2))
+
 				mock.ExpectCommit()
 			},
-			expectErr:  nil,
-			expectResp: true,
+			expectErr:     nil,
+			expectResp:    true,
+			expectBalance: "100.00000",
 		},
 		{
 			name: "insufficient balance",
 			setupMock: func() {
 				mock.ExpectBegin()
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "USD", "active", "0.00000", "0.00000")) // less than amount
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "USD", "active", "0.00000", "0.00000"))
+			},
+			expectErr:  appErr.ErrInsufficientBalance,
+			expectResp: false,
+		},
+		{
+			name: "overdraft limit covers the shortfall",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("30.00000", "INR", "active", "0.00000", "70.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("-70"), req.SourceAccountId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("150"), req.DestinationAccountId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(req.SourceAccountId, req.Amount, req.CurrencyCode, models.MustParseMoney("-70"), false, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(req.DestinationAccountId, req.Amount, req.CurrencyCode, models.MustParseMoney("150"), true, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectCommit()
+			},
+			expectErr:     nil,
+			expectResp:    true,
+			expectBalance: "-70.00000",
+		},
+		{
+			name: "beyond the overdraft limit",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(req.SourceAccountId).
-					WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow(50.0)) // less than amount
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("30.00000", "INR", "active", "0.00000", "69.99999"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "active", "0.00000", "0.00000"))
+			},
+			expectErr:  appErr.ErrInsufficientBalance,
+			expectResp: false,
+		},
+		{
+			name: "daily amount limit",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "INR", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\)(.+)WHERE account_id = \\$1 AND is_credit = false AND original_reference IS NULL").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"daily_amount", "hourly_count"}).AddRow("400.00001", 3))
+			},
+			limits:     models.TransferLimits{MaxDailyAmount: &maxDailyAmount},
+			expectErr:  appErr.ErrDailyAmountLimitExceeded,
+			expectResp: false,
+		},
+		{
+			name: "funds reserved by holds are not available",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "INR", "active", "150.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "active", "0.00000", "0.00000"))
 			},
 			expectErr:  appErr.ErrInsufficientBalance,
 			expectResp: false,
 		},
+		{
+			name: "source account frozen",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "INR", "frozen", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "active", "0.00000", "0.00000"))
+			},
+			expectErr:  appErr.ErrSourceAccountFrozen,
+			expectResp: false,
+		},
+		{
+			name: "destination account closed",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "INR", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "INR", "closed", "0.00000", "0.00000"))
+			},
+			expectErr:  appErr.ErrDestinationAccountClosed,
+			expectResp: false,
+		},
 		{
 			name: "get source balance fails",
 			setupMock: func() {
 				mock.ExpectBegin()
-				mock.ExpectQuery("SELECT balance FROM accounts").
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
 					WithArgs(req.SourceAccountId).
 					WillReturnError(sql.ErrConnDone)
 			},
@@ -100,15 +225,874 @@ func TestTransactionRepository_CreateTransaction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setupMock()
 
+			req := req
+			req.Limits = tt.limits
 			resp, err := repo.CreateTransaction(req)
 			assert.Equal(t, tt.expectErr, err)
 
 			if tt.expectResp {
 				assert.Equal(t, req.SourceAccountId, resp.SourceAccountId)
-				assert.Equal(t, "100.00000", resp.AvailableBalance)
+				assert.Equal(t, tt.expectBalance, resp.AvailableBalance.String())
+				assert.Equal(t, req.Reference, resp.Reference)
 			} else {
 				assert.Zero(t, resp.SourceAccountId)
 			}
 		})
 	}
 }
+
+func TestTransactionRepository_CreateTransaction_CrossCurrency(t *testing.T) {
+	req := models.CreateTransactionArgs{
+		SourceAccountId:         1,
+		DestinationAccountId:    3,
+		Amount:                  models.MustParseMoney("100"),
+		CurrencyCode:            "USD",
+		DestinationAmount:       models.MustParseMoney("92.35"),
+		DestinationCurrencyCode: "EUR",
+		FxRate:                  models.MustParseExchangeRate("0.9235"),
+		Reference:               "TXN-123456",
+		QuoteId:                 testQuoteId,
+	}
+	lockQuote := func(mock sqlmock.Sqlmock, usedAt interface{}) {
+		mock.ExpectQuery("SELECT (.+) FROM fx_quotes WHERE quote_id = \\$1 FOR UPDATE").
+			WithArgs(testQuoteId).
+			WillReturnRows(sqlmock.NewRows(fxQuoteTestColumns).AddRow(testQuoteId, 1, 3, "USD", "EUR", "100.00000", "92.35000",
+				"0.9235000000", time.Now().Add(time.Minute), usedAt, nil, time.Now()))
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		expectErr error
+	}{
+		{
+			name: "credits the converted amount in the destination currency",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockQuote(mock, nil)
+				mock.ExpectExec("UPDATE fx_quotes SET used_at").
+					WithArgs(sqlmock.AnyArg(), req.Reference, testQuoteId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("10.00000", "USD", "active", "0.00000", "0.00000"))
+
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("100"), req.SourceAccountId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.MustParseMoney("102.35"), req.DestinationAccountId).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(req.SourceAccountId, req.Amount, "USD", models.MustParseMoney("100"), false, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(req.DestinationAccountId, req.DestinationAmount, "EUR", models.MustParseMoney("102.35"), true, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.DestinationAmount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "quote already used",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockQuote(mock, time.Now())
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrQuoteAlreadyUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			resp, err := NewTransactionRepository(db).CreateTransaction(req)
+			assert.Equal(t, tt.expectErr, err)
+			if tt.expectErr == nil {
+				assert.Equal(t, "100.00000", resp.AvailableBalance.String())
+				assert.Equal(t, &req.DestinationAmount, resp.DestinationAmount)
+				assert.Equal(t, "EUR", resp.DestinationCurrencyCode)
+				assert.Equal(t, &req.FxRate, resp.FxRate)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_CreateTransaction_Fee(t *testing.T) {
+	fee := models.TransferFee{
+		Breakdown:           models.FeeBreakdown{Flat: models.MustParseMoney("0.25"), Percentage: models.MustParseMoney("1.5"), PercentageAmount: models.MustParseMoney("1.5"), Amount: models.MustParseMoney("1.75")},
+		CurrencyCode:        "USD",
+		RevenueAccountId:    9,
+		RevenueAmount:       models.MustParseMoney("1.62"),
+		RevenueCurrencyCode: "EUR",
+		FxRate:              models.MustParseExchangeRate("0.9235"),
+	}
+	req := models.CreateTransactionArgs{
+		SourceAccountId:         1,
+		DestinationAccountId:    2,
+		Amount:                  models.MustParseMoney("100"),
+		CurrencyCode:            "USD",
+		DestinationAmount:       models.MustParseMoney("100"),
+		DestinationCurrencyCode: "USD",
+		FxRate:                  models.OneExchangeRate,
+		Reference:               "TXN-123456",
+		Fee:                     &fee,
+	}
+	lockAccount := func(mock sqlmock.Sqlmock, accountId int64, balance, currencyCode, status string) {
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(accountId).
+			WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow(balance, currencyCode, status, "0.00000", "0.00000"))
+	}
+	postPrincipal := func(mock sqlmock.Sqlmock, sourceBalance string) {
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.MustParseMoney(sourceBalance), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(150), int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		expectErr error
+	}{
+		{
+			name: "fee moved to the revenue account under the same reference",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccount(mock, 1, "200.00000", "USD", "active")
+				lockAccount(mock, 2, "50.00000", "USD", "active")
+				lockAccount(mock, 9, "1000.00000", "EUR", "active")
+				postPrincipal(mock, "100")
+
+				mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.MustParseMoney("98.25"), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.MustParseMoney("1001.62"), int64(9)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				// The transfer and its fee make up one journal entry
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").WithArgs(int64(1), req.Amount, "USD", models.NewMoney(100), false, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.Amount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").WithArgs(int64(2), req.Amount, "USD", models.NewMoney(150), true, req.Reference, sql.NullString{}, req.FxRate, req.Amount, req.Amount, false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(1), fee.Breakdown.Amount, "USD", models.MustParseMoney("98.25"), false, req.Reference, sql.NullString{}, fee.FxRate, fee.Breakdown.Amount, fee.RevenueAmount, true, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(9), fee.RevenueAmount, "EUR", models.MustParseMoney("1001.62"), true, req.Reference, sql.NullString{}, fee.FxRate, fee.Breakdown.Amount, fee.RevenueAmount, true, int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				// The fee leaves in USD and reaches the revenue account in EUR through the FX position
+				mock.ExpectExec("INSERT INTO postings").
+					WithArgs(int64(1), int64(1), models.PostingKindTransfer, models.NewMoney(-100), "USD",
+						int64(1), int64(2), models.PostingKindTransfer, models.NewMoney(100), "USD",
+						int64(1), int64(1), models.PostingKindFee, models.MustParseMoney("-1.75"), "USD",
+						int64(1), int64(9), models.PostingKindFee, models.MustParseMoney("1.62"), "EUR",
+						int64(1), nil, models.PostingKindFx, models.MustParseMoney("1.75"), "USD",
+						int64(1), nil, models.PostingKindFx, models.MustParseMoney("-1.62"), "EUR").
+					WillReturnResult(sqlmock.NewResult(6, 6))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "balance covers the amount but not the fee",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccount(mock, 1, "101.00000", "USD", "active")
+				lockAccount(mock, 2, "50.00000", "USD", "active")
+				lockAccount(mock, 9, "1000.00000", "EUR", "active")
+				postPrincipal(mock, "1")
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrInsufficientBalance,
+		},
+		{
+			name: "revenue account closed",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockAccount(mock, 1, "200.00000", "USD", "active")
+				lockAccount(mock, 2, "50.00000", "USD", "active")
+				lockAccount(mock, 9, "1000.00000", "EUR", "closed")
+				postPrincipal(mock, "100")
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrFeeAccountUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			defer db.Close()
+			tt.setupMock(mock)
+
+			resp, err := NewTransactionRepository(db).CreateTransaction(req)
+			assert.Equal(t, tt.expectErr, err)
+			if tt.expectErr == nil {
+				assert.Equal(t, "98.25000", resp.AvailableBalance.String())
+				assert.Equal(t, &models.FeeResponse{
+					FeeBreakdown:     fee.Breakdown,
+					CurrencyCode:     "USD",
+					RevenueAccountId: 9,
+					TotalDebited:     models.MustParseMoney("101.75"),
+				}, resp.Fee)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_ListTransactions(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewTransactionRepository(db)
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cursorAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	isCredit := true
+	minAmount := models.NewMoney(10)
+	columns := []string{"id", "account_id", "amount", "currency_code", "available_balance", "is_credit", "reference", "original_reference", "created_at", "updated_at", "deleted_at", "fx_rate", "source_amount", "destination_amount", "is_fee"}
+
+	tests := []struct {
+		name        string
+		filter      models.TransactionFilter
+		setupMock   func()
+		expectedErr error
+		expectedLen int
+	}{
+		{
+			name:   "success without filters",
+			filter: models.TransactionFilter{AccountId: 1, Limit: 3},
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM transactions WHERE account_id = \$1 ORDER BY created_at DESC, id DESC LIMIT \$2`).
+					WithArgs(int64(1), 3).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(2, 1, "25.00000", "USD", "75.00000", false, "TXN-2", nil, cursorAt, cursorAt, nil, nil, nil, nil, false).
+						AddRow(1, 1, "100.00000", "USD", "100.00000", true, "TXN-1", nil, from, from, nil, nil, nil, nil, false))
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "success with filters and cursor",
+			filter: models.TransactionFilter{
+				AccountId:       1,
+				From:            &from,
+				IsCredit:        &isCredit,
+				MinAmount:       &minAmount,
+				CursorCreatedAt: cursorAt,
+				CursorId:        7,
+				Limit:           5,
+			},
+			setupMock: func() {
+				mock.ExpectQuery(`WHERE account_id = \$1 AND created_at >= \$2 AND is_credit = \$3 AND amount >= \$4 AND \(created_at, id\) < \(\$5, \$6\) ORDER BY created_at DESC, id DESC LIMIT \$7`).
+					WithArgs(int64(1), from, true, minAmount, cursorAt, int64(7), 5).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, 1, "100.00000", "USD", "100.00000", true, "TXN-1", nil, from, from, nil, nil, nil, nil, false))
+			},
+			expectedLen: 1,
+		},
+		{
+			name:   "db error",
+			filter: models.TransactionFilter{AccountId: 1, Limit: 3},
+			setupMock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM transactions").
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			transactions, err := repo.ListTransactions(tt.filter)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Len(t, transactions, tt.expectedLen)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_GetByReference(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewTransactionRepository(db)
+
+	reference := "TXN-123456"
+	createdAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "account_id", "amount", "currency_code", "available_balance", "is_credit", "reference", "original_reference", "created_at", "updated_at", "deleted_at", "fx_rate", "source_amount", "destination_amount", "is_fee"}
+
+	tests := []struct {
+		name        string
+		setupMock   func()
+		expectedErr error
+		expectedLen int
+	}{
+		{
+			name: "success",
+			setupMock: func() {
+				mock.ExpectQuery(`SELECT (.+) FROM transactions WHERE reference = \$1 ORDER BY id`).
+					WithArgs(reference).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, 1, "100.00000", "USD", "900.00000", false, reference, nil, createdAt, createdAt, nil, nil, nil, nil, false).
+						AddRow(2, 2, "100.00000", "USD", "600.00000", true, reference, nil, createdAt, createdAt, nil, nil, nil, nil, false))
+			},
+			expectedLen: 2,
+		},
+		{
+			name: "not found",
+			setupMock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM transactions").
+					WithArgs(reference).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expectedErr: appErr.ErrTransactionNotFound,
+		},
+		{
+			name: "db error",
+			setupMock: func() {
+				mock.ExpectQuery("SELECT (.+) FROM transactions").
+					WithArgs(reference).
+					WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			legs, err := repo.GetByReference(reference)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Len(t, legs, tt.expectedLen)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_CreateTransaction_Idempotency(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewTransactionRepository(db)
+
+	req := models.CreateTransactionArgs{
+		SourceAccountId:         1,
+		DestinationAccountId:    2,
+		Amount:                  models.MustParseMoney("100"),
+		CurrencyCode:            "USD",
+		DestinationAmount:       models.MustParseMoney("100"),
+		DestinationCurrencyCode: "USD",
+		FxRate:                  models.OneExchangeRate,
+		Reference:               "TXN-123456",
+		Idempotency:             models.Idempotency{ClientId: 3, Key: "key-1", RequestHash: "hash-1"},
+	}
+	endpoint := models.IdempotencyEndpointCreateTransaction
+	idemColumns := []string{"idempotency_key", "endpoint", "request_hash", "status_code", "response_body", "created_at"}
+	storedBody := []byte(`{"source_account_id":1,"available_balance":"100.00000","reference":"TXN-original"}`)
+
+	tests := []struct {
+		name         string
+		setupMock    func()
+		expectErr    error
+		expectedResp models.CreateTransactionResponse
+	}{
+		{
+			name: "first request stores its response",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WithArgs(req.Idempotency.ClientId, req.Idempotency.Key, endpoint, req.Idempotency.RequestHash).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys (.+) FOR UPDATE").
+					WithArgs(req.Idempotency.ClientId, req.Idempotency.Key, endpoint).
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(req.Idempotency.Key, endpoint, req.Idempotency.RequestHash, 0, nil, time.Now()))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.SourceAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("200.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(req.DestinationAccountId).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET balance").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+				expectPostings(mock)
+				mock.ExpectExec("UPDATE idempotency_keys SET status_code").
+					WithArgs(200, []byte(`{"source_account_id":1,"available_balance":"100.00000","reference":"TXN-123456"}`), req.Idempotency.ClientId, req.Idempotency.Key, endpoint).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedResp: models.CreateTransactionResponse{
+				SourceAccountId:  1,
+				AvailableBalance: models.NewMoney(100),
+				Reference:        "TXN-123456",
+			},
+		},
+		{
+			name: "replay returns the stored response",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(req.Idempotency.Key, endpoint, req.Idempotency.RequestHash, 200, storedBody, time.Now()))
+				mock.ExpectRollback()
+			},
+			expectedResp: models.CreateTransactionResponse{
+				SourceAccountId:  1,
+				AvailableBalance: models.NewMoney(100),
+				Reference:        "TXN-original",
+			},
+		},
+		{
+			name: "key reused with a different request",
+			setupMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO idempotency_keys").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+					WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(req.Idempotency.Key, endpoint, "other-hash", 200, storedBody, time.Now()))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrIdempotencyKeyReused,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMock()
+
+			resp, err := repo.CreateTransaction(req)
+			assert.Equal(t, tt.expectErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_CreateTransaction_QuotedReplay(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+
+	req := models.CreateTransactionArgs{
+		SourceAccountId:         1,
+		DestinationAccountId:    2,
+		Amount:                  models.MustParseMoney("10.01"),
+		CurrencyCode:            "USD",
+		DestinationAmount:       models.NewMoney(1500),
+		DestinationCurrencyCode: "JPY",
+		FxRate:                  models.MustParseExchangeRate("149.85"),
+		QuoteId:                 "QTE-0f8fad5b-d9cb-469f-a165-70867728950e",
+		Reference:               "TXN-123456",
+		Idempotency:             models.Idempotency{ClientId: 3, Key: "key-1", RequestHash: "hash-1"},
+	}
+	endpoint := models.IdempotencyEndpointCreateTransaction
+	idemColumns := []string{"idempotency_key", "endpoint", "request_hash", "status_code", "response_body", "created_at"}
+	storedBody := []byte(`{"source_account_id":1,"available_balance":"89.99000","reference":"TXN-original","destination_amount":"1500.00000","destination_currency_code":"JPY","fx_rate":"149.8500000000"}`)
+
+	// The stored response is returned before the quote, already used by the original request, is looked at
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT (.+) FROM idempotency_keys").
+		WillReturnRows(sqlmock.NewRows(idemColumns).AddRow(req.Idempotency.Key, endpoint, req.Idempotency.RequestHash, 200, storedBody, time.Now()))
+	mock.ExpectRollback()
+
+	resp, err := NewTransactionRepository(db).CreateTransaction(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "TXN-original", resp.Reference)
+	assert.Equal(t, models.MustParseMoney("89.99"), resp.AvailableBalance)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_ReverseTransaction(t *testing.T) {
+	original := "TXN-original"
+	reversal := "TXN-reversal"
+	createdAt := time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "account_id", "amount", "currency_code", "available_balance", "is_credit", "reference", "original_reference", "created_at", "updated_at", "deleted_at", "fx_rate", "source_amount", "destination_amount", "is_fee"}
+	originalLegs := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).
+			AddRow(1, 1, "100.00000", "USD", "900.00000", false, original, nil, createdAt, createdAt, nil, nil, nil, nil, false).
+			AddRow(2, 2, "100.00000", "USD", "600.00000", true, original, nil, createdAt, createdAt, nil, nil, nil, nil, false)
+	}
+	partial := models.NewMoney(40)
+	tooMuch := models.NewMoney(70)
+
+	tests := []struct {
+		name         string
+		amount       *models.Money
+		setupMock    func(mock sqlmock.Sqlmock)
+		expectErr    error
+		expectedResp models.ReverseTransactionResponse
+	}{
+		{
+			name: "full reversal of what is left",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference = \\$1 ORDER BY id FOR UPDATE").
+					WithArgs(original).
+					WillReturnRows(originalLegs())
+				mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\) FILTER (.+) FROM transactions WHERE original_reference").
+					WithArgs(original).
+					WillReturnRows(sqlmock.NewRows([]string{"to_sender", "from_receiver"}).AddRow("40.00000", "40.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("900.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("600.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(540), int64(2)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE accounts SET balance").
+					WithArgs(models.NewMoney(960), int64(1)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				expectJournalEntry(mock)
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(2), models.NewMoney(60), "USD", models.NewMoney(540), false, reversal, sql.NullString{String: original, Valid: true}, models.OneExchangeRate, models.NewMoney(60), models.NewMoney(60), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(3, 1))
+				mock.ExpectExec("INSERT INTO transactions").
+					WithArgs(int64(1), models.NewMoney(60), "USD", models.NewMoney(960), true, reversal, sql.NullString{String: original, Valid: true}, models.OneExchangeRate, models.NewMoney(60), models.NewMoney(60), false, int64(1)).
+					WillReturnResult(sqlmock.NewResult(4, 1))
+				expectPostings(mock)
+				mock.ExpectCommit()
+			},
+			expectedResp: models.ReverseTransactionResponse{
+				Reference:            reversal,
+				OriginalReference:    original,
+				SourceAccountId:      2,
+				DestinationAccountId: 1,
+				Amount:               models.NewMoney(60),
+				CurrencyCode:         "USD",
+				AvailableBalance:     models.NewMoney(540),
+				DebitedAmount:        models.NewMoney(60),
+				DebitedCurrencyCode:  "USD",
+				RemainingReversible:  models.Money{},
+			},
+		},
+		{
+			name:   "partial amount above what is left",
+			amount: &tooMuch,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference").WillReturnRows(originalLegs())
+				mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"to_sender", "from_receiver"}).AddRow("40.00000", "40.00000"))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrReversalAmountExceeded,
+		},
+		{
+			name:   "already fully reversed",
+			amount: &partial,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference").WillReturnRows(originalLegs())
+				mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"to_sender", "from_receiver"}).AddRow("100.00000", "100.00000"))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrTransactionAlreadyReversed,
+		},
+		{
+			name:   "receiver no longer has the funds",
+			amount: &partial,
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference").WillReturnRows(originalLegs())
+				mock.ExpectQuery("SELECT COALESCE").WillReturnRows(sqlmock.NewRows([]string{"to_sender", "from_receiver"}).AddRow("0", "0"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("900.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("10.00000", "USD", "active", "0.00000", "0.00000"))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrReversalInsufficientBalance,
+		},
+		{
+			name: "reversal of a reversal",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference").
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(3, 2, "60.00000", "USD", "540.00000", false, original, "TXN-older", createdAt, createdAt, nil, nil, nil, nil, false).
+						AddRow(4, 1, "60.00000", "USD", "960.00000", true, original, "TXN-older", createdAt, createdAt, nil, nil, nil, nil, false))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrCannotReverseReversal,
+		},
+		{
+			name: "original not found",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM transactions WHERE reference").WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrTransactionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(mock)
+
+			repo := NewTransactionRepository(db)
+			resp, err := repo.ReverseTransaction(models.ReverseTransactionArgs{
+				OriginalReference: original,
+				Amount:            tt.amount,
+				Reference:         reversal,
+			})
+
+			assert.Equal(t, tt.expectErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_CreateTransaction_LockOrderAndRetry(t *testing.T) {
+	retryBackoff = func(int) time.Duration { return 0 }
+
+	// The transfer goes from the higher account id to the lower one, the locks must still be taken lowest first
+	req := models.CreateTransactionArgs{
+		SourceAccountId:         2,
+		DestinationAccountId:    1,
+		Amount:                  models.NewMoney(10),
+		CurrencyCode:            "USD",
+		DestinationAmount:       models.NewMoney(10),
+		DestinationCurrencyCode: "USD",
+		FxRate:                  models.OneExchangeRate,
+		Reference:               "TXN-123456",
+	}
+	deadlock := &pq.Error{Code: "40P01", Message: "deadlock detected"}
+	serialization := &pq.Error{Code: "40001", Message: "could not serialize access"}
+
+	expectTransfer := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("50.00000", "USD", "active", "0.00000", "0.00000"))
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"balance", "currency_code", "status", "held_amount", "overdraft_limit"}).AddRow("100.00000", "USD", "active", "0.00000", "0.00000"))
+		mock.ExpectExec("UPDATE accounts SET balance").
+			WithArgs(models.NewMoney(90), int64(2)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").
+			WithArgs(models.NewMoney(60), int64(1)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectJournalEntry(mock)
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(2, 1))
+		expectPostings(mock)
+	}
+	expectDeadlock := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").WithArgs(int64(1)).WillReturnError(deadlock)
+		mock.ExpectRollback()
+	}
+
+	tests := []struct {
+		name      string
+		setupMock func(mock sqlmock.Sqlmock)
+		expectErr error
+	}{
+		{
+			name: "locks lowest account id first",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				expectTransfer(mock)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "retries after a deadlock",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				expectDeadlock(mock)
+				expectTransfer(mock)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "retries after a serialization failure on commit",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				expectTransfer(mock)
+				mock.ExpectCommit().WillReturnError(serialization)
+				expectTransfer(mock)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "gives up after the last attempt",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				for i := 0; i < maxTxAttempts; i++ {
+					expectDeadlock(mock)
+				}
+			},
+			expectErr: appErr.ErrTransactionFailed,
+		},
+		{
+			name: "other errors are not retried",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts").WithArgs(int64(1)).WillReturnError(&pq.Error{Code: "57014"})
+				mock.ExpectRollback()
+			},
+			expectErr: appErr.ErrTransactionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+			tt.setupMock(mock)
+
+			resp, err := NewTransactionRepository(db).CreateTransaction(req)
+
+			assert.Equal(t, tt.expectErr, err)
+			if tt.expectErr == nil {
+				assert.Equal(t, models.NewMoney(90), resp.AvailableBalance)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransactionRepository_CreateBatchTransaction(t *testing.T) {
+	transfer := func(source, destination int64, amount int64, reference string) models.CreateTransactionArgs {
+		return models.CreateTransactionArgs{
+			SourceAccountId:         source,
+			DestinationAccountId:    destination,
+			Amount:                  models.NewMoney(amount),
+			CurrencyCode:            "USD",
+			DestinationAmount:       models.NewMoney(amount),
+			DestinationCurrencyCode: "USD",
+			FxRate:                  models.OneExchangeRate,
+			Reference:               reference,
+		}
+	}
+	reqs := []models.CreateTransactionArgs{
+		transfer(3, 1, 60, "TXN-1"),
+		transfer(3, 2, 30, "TXN-2"),
+	}
+	expectLocks := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		for _, row := range []struct {
+			accountId int64
+			balance   string
+		}{{1, "0.00000"}, {2, "0.00000"}, {3, "100.00000"}} {
+			mock.ExpectQuery("SELECT balance, currency_code, status, held_amount, overdraft_limit FROM accounts WHERE account_id = \\$1 FOR UPDATE").
+				WithArgs(row.accountId).
+				WillReturnRows(sqlmock.NewRows(accountLockColumns).AddRow(row.balance, "USD", "active", "0.00000", "0.00000"))
+		}
+	}
+
+	t.Run("every transfer is posted in one transaction", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		expectLocks(mock)
+		// the second transfer starts from the balance left by the first one
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(40), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(60), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJournalEntry(mock)
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(2, 1))
+		expectPostings(mock)
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(10), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(30), int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJournalEntry(mock)
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(4, 1))
+		expectPostings(mock)
+		mock.ExpectCommit()
+
+		resps, _, err := NewTransactionRepository(db).CreateBatchTransaction(reqs)
+		assert.NoError(t, err)
+		assert.Equal(t, []models.CreateTransactionResponse{
+			{SourceAccountId: 3, AvailableBalance: models.NewMoney(40), Reference: "TXN-1"},
+			{SourceAccountId: 3, AvailableBalance: models.NewMoney(10), Reference: "TXN-2"},
+		}, resps)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("a failing transfer rolls back the whole batch", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		expectLocks(mock)
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(40), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(60), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJournalEntry(mock)
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(2, 1))
+		expectPostings(mock)
+		mock.ExpectRollback()
+
+		overdrawn := append([]models.CreateTransactionArgs{}, reqs...)
+		overdrawn[1] = transfer(3, 2, 41, "TXN-2")
+		resps, failedIndex, err := NewTransactionRepository(db).CreateBatchTransaction(overdrawn)
+		assert.Equal(t, appErr.ErrInsufficientBalance, err)
+		assert.Equal(t, 1, failedIndex)
+		assert.Nil(t, resps)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("velocity limits count the transfers made earlier in the batch", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		defer db.Close()
+
+		maxHourlyCount := 2
+		limited := append([]models.CreateTransactionArgs{}, reqs...)
+		for i := range limited {
+			limited[i].Limits = models.TransferLimits{MaxHourlyCount: &maxHourlyCount}
+		}
+		velocityColumns := []string{"daily_amount", "hourly_count"}
+
+		expectLocks(mock)
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\), COUNT\\(\\*\\) (.+) FROM transactions").
+			WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows(velocityColumns).AddRow("10.00000", 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(40), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("UPDATE accounts SET balance").WithArgs(models.NewMoney(60), int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+		expectJournalEntry(mock)
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO transactions").WillReturnResult(sqlmock.NewResult(2, 1))
+		expectPostings(mock)
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(amount\\), 0\\)").
+			WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows(velocityColumns).AddRow("70.00000", 2))
+		mock.ExpectRollback()
+
+		resps, failedIndex, err := NewTransactionRepository(db).CreateBatchTransaction(limited)
+		assert.Equal(t, appErr.ErrHourlyCountLimitExceeded, err)
+		assert.Equal(t, 1, failedIndex)
+		assert.Nil(t, resps)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// expectJournalEntry expects the header of a journal entry to be written, getting id 1
+func expectJournalEntry(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("INSERT INTO journal_entries").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(1, time.Now()))
+}
+
+// expectPostings expects the postings of a journal entry to be written
+func expectPostings(mock sqlmock.Sqlmock) {
+	mock.ExpectExec("INSERT INTO postings").WillReturnResult(sqlmock.NewResult(2, 2))
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"database/sql"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"log"
+)
+
+// TransferLimitRepository handles the global transfer limits of each currency and the ones set for single accounts
+type TransferLimitRepository struct {
+	db *sql.DB
+}
+
+// NewTransferLimitRepository creates a new transfer limit repository
+func NewTransferLimitRepository(db *sql.DB) *TransferLimitRepository {
+	return &TransferLimitRepository{db: db}
+}
+
+type ITransferLimitRepository interface {
+	GetByAccountId(accountId int64) (models.TransferLimits, error)
+	Upsert(accountId int64, limits models.TransferLimits) error
+	GetByCurrency(currencyCode string) (models.TransferLimits, error)
+	UpsertCurrency(currencyCode string, limits models.TransferLimits) error
+}
+
+// GetByAccountId returns the limits set for an account. An account without limits of its own gets no caps
+func (r *TransferLimitRepository) GetByAccountId(accountId int64) (models.TransferLimits, error) {
+	fName := "TransferLimitRepository.GetByAccountId"
+	query := `SELECT max_amount, max_daily_amount, max_hourly_count FROM account_transfer_limits WHERE account_id = $1`
+
+	var limits models.TransferLimits
+	err := r.db.QueryRow(query, accountId).Scan(&limits.MaxAmount, &limits.MaxDailyAmount, &limits.MaxHourlyCount)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return models.TransferLimits{}, appErr.ErrInternal
+	}
+	return limits, nil
+}
+
+// Upsert replaces the limits set for an account. Caps left nil are removed
+func (r *TransferLimitRepository) Upsert(accountId int64, limits models.TransferLimits) error {
+	fName := "TransferLimitRepository.Upsert"
+	query := `INSERT INTO account_transfer_limits (account_id, max_amount, max_daily_amount, max_hourly_count) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_id) DO UPDATE SET max_amount = EXCLUDED.max_amount, max_daily_amount = EXCLUDED.max_daily_amount,
+		max_hourly_count = EXCLUDED.max_hourly_count, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Exec(query, accountId, limits.MaxAmount, limits.MaxDailyAmount, limits.MaxHourlyCount); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// GetByCurrency returns the global limits of a currency. A currency without limits gets no caps
+func (r *TransferLimitRepository) GetByCurrency(currencyCode string) (models.TransferLimits, error) {
+	fName := "TransferLimitRepository.GetByCurrency"
+	query := `SELECT max_amount, max_daily_amount, max_hourly_count FROM currency_transfer_limits WHERE currency_code = $1`
+
+	var limits models.TransferLimits
+	err := r.db.QueryRow(query, currencyCode).Scan(&limits.MaxAmount, &limits.MaxDailyAmount, &limits.MaxHourlyCount)
+	if err != nil && err != sql.ErrNoRows {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return models.TransferLimits{}, appErr.ErrInternal
+	}
+	return limits, nil
+}
+
+// UpsertCurrency replaces the global limits of a currency. Caps left nil are removed
+func (r *TransferLimitRepository) UpsertCurrency(currencyCode string, limits models.TransferLimits) error {
+	fName := "TransferLimitRepository.UpsertCurrency"
+	query := `INSERT INTO currency_transfer_limits (currency_code, max_amount, max_daily_amount, max_hourly_count) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (currency_code) DO UPDATE SET max_amount = EXCLUDED.max_amount, max_daily_amount = EXCLUDED.max_daily_amount,
+		max_hourly_count = EXCLUDED.max_hourly_count, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.db.Exec(query, currencyCode, limits.MaxAmount, limits.MaxDailyAmount, limits.MaxHourlyCount); err != nil {
+		log.Printf("[%s] failed due to error %+v. ", fName, err)
+		return appErr.ErrInternal
+	}
+	return nil
+}
+
+// checkVelocity returns the limit error of limits that a debit of amount from accountId would break, counting the
+// transfers that left the account in the last 24 hours and the last hour, captured holds included since a capture
+// posts its debit like a transfer. Reversals and fees are not counted.
+// It must run within tx after the account is locked, so that concurrent transfers are counted one after another
+func (r *TransferLimitRepository) checkVelocity(tx *sql.Tx, accountId int64, limits models.TransferLimits, amount models.Money) error {
+	fName := "TransferLimitRepository.checkVelocity"
+	if !limits.HasVelocity() {
+		return nil
+	}
+
+	query := `SELECT COALESCE(SUM(amount), 0), COUNT(*) FILTER (WHERE created_at > CURRENT_TIMESTAMP - INTERVAL '1 hour') FROM transactions
+		WHERE account_id = $1 AND is_credit = false AND original_reference IS NULL AND is_fee = false AND created_at > CURRENT_TIMESTAMP - INTERVAL '1 day'`
+	var dailyAmount models.Money
+	var hourlyCount int
+	if err := tx.QueryRow(query, accountId).Scan(&dailyAmount, &hourlyCount); err != nil {
+		log.Printf("[%s] failed to count transfers of account %d: %v", fName, accountId, err)
+		return retryableOr(err, appErr.ErrTransactionFailed)
+	}
+	return limits.CheckVelocity(amount, dailyAmount, hourlyCount)
+}
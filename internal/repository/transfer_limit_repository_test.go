@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferLimitRepository_GetByAccountId(t *testing.T) {
+	maxAmount := models.MustParseMoney("25000")
+	hourlyCount := 5
+
+	tests := []struct {
+		name        string
+		setupMock   func(sqlmock.Sqlmock)
+		expected    models.TransferLimits
+		expectedErr error
+	}{
+		{
+			name: "limits set",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT max_amount, max_daily_amount, max_hourly_count FROM account_transfer_limits WHERE account_id = \\$1").
+					WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows([]string{"max_amount", "max_daily_amount", "max_hourly_count"}).AddRow("25000.00000", nil, 5))
+			},
+			expected: models.TransferLimits{MaxAmount: &maxAmount, MaxHourlyCount: &hourlyCount},
+		},
+		{
+			name: "no limits of its own",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT max_amount").WithArgs(int64(7)).WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "db error",
+			setupMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT max_amount").WithArgs(int64(7)).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			defer db.Close()
+
+			tt.setupMock(mock)
+			limits, err := NewTransferLimitRepository(db).GetByAccountId(7)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, limits)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTransferLimitRepository_Upsert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	maxDailyAmount := models.MustParseMoney("50000")
+	mock.ExpectExec("INSERT INTO account_transfer_limits (.+) ON CONFLICT \\(account_id\\) DO UPDATE").
+		WithArgs(int64(7), nil, "50000.00000", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewTransferLimitRepository(db).Upsert(7, models.TransferLimits{MaxDailyAmount: &maxDailyAmount})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransferLimitRepository_GetByCurrency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	maxAmount := models.MustParseMoney("1500000")
+	mock.ExpectQuery("SELECT max_amount, max_daily_amount, max_hourly_count FROM currency_transfer_limits WHERE currency_code = \\$1").
+		WithArgs("JPY").
+		WillReturnRows(sqlmock.NewRows([]string{"max_amount", "max_daily_amount", "max_hourly_count"}).AddRow("1500000.00000", nil, nil))
+	mock.ExpectQuery("FROM currency_transfer_limits").WithArgs("EUR").WillReturnError(sql.ErrNoRows)
+
+	repo := NewTransferLimitRepository(db)
+	limits, err := repo.GetByCurrency("JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferLimits{MaxAmount: &maxAmount}, limits)
+
+	limits, err = repo.GetByCurrency("EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TransferLimits{}, limits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransferLimitRepository_UpsertCurrency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	hourlyCount := 5
+	mock.ExpectExec("INSERT INTO currency_transfer_limits (.+) ON CONFLICT \\(currency_code\\) DO UPDATE").
+		WithArgs("JPY", nil, nil, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = NewTransferLimitRepository(db).UpsertCurrency("JPY", models.TransferLimits{MaxHourlyCount: &hourlyCount})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,173 @@
+package schedule
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds how far ahead Next looks for a matching time, so that an expression that can never
+// match, eg "0 0 30 2 *", does not loop forever
+const maxSearchYears = 5
+
+// field is one of the five fields of a cron expression, with its allowed range
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = [5]field{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+// Cron is a parsed five-field cron expression: minute, hour, day of month, month and day of week.
+// Each field accepts *, a value, a range a-b, a step */n or a-b/n, and comma separated lists of those.
+// Sunday is 0, and 7 is accepted as Sunday too. As in standard cron, when both the day of month and the
+// day of week are restricted a day matching either one is a match
+type Cron struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// domStar and dowStar record whether the day fields were given as *
+	domStar, dowStar bool
+}
+
+// Parse parses a five-field cron expression such as "0 9 1 * *", 09:00 on the first of every month
+func Parse(expr string) (*Cron, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("schedule: expected 5 fields in %q, got %d", expr, len(parts))
+	}
+
+	var sets [5]uint64
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+	// 7 is another name for Sunday
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return &Cron{
+		minute:     sets[0],
+		hour:       sets[1],
+		dayOfMonth: sets[2],
+		month:      sets[3],
+		dayOfWeek:  sets[4],
+		domStar:    strings.HasPrefix(parts[2], "*"),
+		dowStar:    strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField returns the set of values matched by one field as a bit set
+func parseField(expr string, f field) (uint64, error) {
+	max := f.max
+	if f.name == "day of week" {
+		max = 7
+	}
+
+	var set uint64
+	for _, item := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepExpr)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("schedule: invalid step %q in %s field", stepExpr, f.name)
+			}
+			step = n
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangeExpr == "*":
+		case strings.Contains(rangeExpr, "-"):
+			lowExpr, highExpr, _ := strings.Cut(rangeExpr, "-")
+			var err error
+			if low, err = parseValue(lowExpr, f, max); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(highExpr, f, max); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("schedule: invalid range %q in %s field", rangeExpr, f.name)
+			}
+		default:
+			value, err := parseValue(rangeExpr, f, max)
+			if err != nil {
+				return 0, err
+			}
+			low, high = value, value
+			// a step after a single value runs to the end of the field, eg 5/15 in minutes is 5,20,35,50
+			if hasStep {
+				high = f.max
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// parseValue parses a single number of a field and checks it is within range
+func parseValue(expr string, f field, max int) (int, error) {
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < f.min || value > max {
+		return 0, fmt.Errorf("schedule: invalid value %q in %s field, expected %d-%d", expr, f.name, f.min, f.max)
+	}
+	return value, nil
+}
+
+// Next returns the first time strictly after after that matches the expression, at minute precision and in the
+// location of after. The zero time is returned when there is no match within the next few years
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			// jump straight to the next matching minute of this hour, or to the next hour
+			if next := c.minute >> uint(t.Minute()); next != 0 {
+				t = t.Add(time.Duration(bits.TrailingZeros64(next)) * time.Minute)
+			} else {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			}
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay applies the cron rule for the day of month and day of week fields
+func (c *Cron) matchesDay(t time.Time) bool {
+	dom := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dow := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
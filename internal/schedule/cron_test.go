@@ -0,0 +1,67 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "first of the month", expr: "0 9 1 * *"},
+		{name: "lists ranges and steps", expr: "0,30 9-17/2 * 1-6 1-5"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "too few fields", expr: "0 9 1 *", wantErr: true},
+		{name: "out of range", expr: "60 * * * *", wantErr: true},
+		{name: "inverted range", expr: "* 17-9 * * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "not a number", expr: "* * * JAN *", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			assert.Equal(t, tt.wantErr, err != nil, "Parse(%q) error = %v", tt.expr, err)
+		})
+	}
+}
+
+func TestCron_Next(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		assert.NoError(t, err)
+		return parsed
+	}
+
+	tests := []struct {
+		name  string
+		expr  string
+		after string
+		want  string
+	}{
+		{name: "next minute", expr: "* * * * *", after: "2025-03-01T10:15:30Z", want: "2025-03-01T10:16:00Z"},
+		{name: "strictly after", expr: "0 9 1 * *", after: "2025-03-01T09:00:00Z", want: "2025-04-01T09:00:00Z"},
+		{name: "later today", expr: "0 9 1 * *", after: "2025-03-01T08:59:59Z", want: "2025-03-01T09:00:00Z"},
+		{name: "year rollover", expr: "0 0 1 1 *", after: "2025-06-15T00:00:00Z", want: "2026-01-01T00:00:00Z"},
+		{name: "step", expr: "*/15 * * * *", after: "2025-03-01T10:16:00Z", want: "2025-03-01T10:30:00Z"},
+		{name: "step from a value", expr: "5/20 * * * *", after: "2025-03-01T10:46:00Z", want: "2025-03-01T11:05:00Z"},
+		{name: "weekdays only", expr: "30 8 * * 1-5", after: "2025-03-01T09:00:00Z", want: "2025-03-03T08:30:00Z"},
+		{name: "day of month or day of week", expr: "0 0 13 * 5", after: "2025-03-01T00:00:00Z", want: "2025-03-07T00:00:00Z"},
+		{name: "leap day", expr: "0 0 29 2 *", after: "2025-03-01T00:00:00Z", want: "2028-02-29T00:00:00Z"},
+		{name: "never", expr: "0 0 30 2 *", after: "2025-03-01T00:00:00Z", want: "0001-01-01T00:00:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cron, err := Parse(tt.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, at(tt.want), cron.Next(at(tt.after)))
+		})
+	}
+}
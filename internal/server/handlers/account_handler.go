@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/service"
 	"github.com/gorilla/mux"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type AccountHandler struct {
@@ -27,7 +30,14 @@ func (ah *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err := ah.service.CreateAccount(r.Context(), req)
+	idem, err := readIdempotency(r, req)
+	if err != nil {
+		ah.sendErrorResponse(w, err)
+		return
+	}
+	req.Idempotency = idem
+
+	err = ah.service.CreateAccount(r.Context(), req)
 	if err != nil {
 		ah.sendErrorResponse(w, err)
 		return
@@ -36,7 +46,8 @@ func (ah *AccountHandler) CreateAccount(w http.ResponseWriter, r *http.Request)
 	ah.sendSuccessResponse(w)
 }
 
-// GetAccount handles GET /accounts/{account_id}
+// GetAccount handles GET /accounts/{account_id}. With an as_of query parameter it responds with the balance the
+// account had at that time instead
 func (ah *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	accountIDStr := vars["account_id"]
@@ -47,18 +58,142 @@ func (ah *AccountHandler) GetAccount(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if v := r.URL.Query().Get("as_of"); v != "" {
+		ah.getBalanceAsOf(w, r, accountID, v)
+		return
+	}
+
 	account, err := ah.service.GetAccount(r.Context(), accountID)
 	if err != nil {
-		ah.sendErrorResponse(w, appErr.ErrAccountNotFound)
+		ah.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.NewGetAccountResponse(*account))
+}
+
+// FreezeAccount handles POST /accounts/{account_id}/freeze
+func (ah *AccountHandler) FreezeAccount(w http.ResponseWriter, r *http.Request) {
+	ah.changeStatus(w, r, ah.service.FreezeAccount)
+}
+
+// UnfreezeAccount handles POST /accounts/{account_id}/unfreeze
+func (ah *AccountHandler) UnfreezeAccount(w http.ResponseWriter, r *http.Request) {
+	ah.changeStatus(w, r, ah.service.UnfreezeAccount)
+}
+
+// CloseAccount handles POST /accounts/{account_id}/close
+func (ah *AccountHandler) CloseAccount(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.CloseAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		ah.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := ah.service.CloseAccount(r.Context(), models.CloseAccountArgs{AccountId: accountID, SweepAccountId: req.SweepAccountId})
+	if err != nil {
+		ah.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// getBalanceAsOf responds with the balance of the account at the RFC3339 time asOf
+func (ah *AccountHandler) getBalanceAsOf(w http.ResponseWriter, r *http.Request, accountID int64, asOf string) {
+	at, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidAsOf)
+		return
+	}
+
+	resp, err := ah.service.GetBalanceAsOf(r.Context(), accountID, at)
+	if err != nil {
+		ah.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SetOverdraftLimit handles PUT /admin/accounts/{account_id}/overdraft-limit
+func (ah *AccountHandler) SetOverdraftLimit(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.SetOverdraftLimitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+	limit, err := models.ParseMoney(req.OverdraftLimit)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidOverdraftLimit)
+		return
+	}
+
+	account, err := ah.service.SetOverdraftLimit(r.Context(), accountID, limit)
+	if err != nil {
+		ah.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.NewGetAccountResponse(*account))
+}
+
+// SetAccountOwner handles PUT /admin/accounts/{account_id}/owner
+func (ah *AccountHandler) SetAccountOwner(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.SetAccountOwnerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	account, err := ah.service.SetAccountOwner(r.Context(), accountID, req.OwnerClientId)
+	if err != nil {
+		ah.sendErrorResponse(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(models.NewGetAccountResponse(*account))
+}
+
+// changeStatus runs a freeze or unfreeze of the account in the path and responds with the updated account
+func (ah *AccountHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id int64) (*models.Account, error)) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	account, err := change(r.Context(), accountID)
+	if err != nil {
+		ah.sendErrorResponse(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(models.GetAccountResponse{
-		AccountId: account.AccountId,
-		Balance:   strconv.FormatFloat(account.Balance, 'f', 5, 64),
-		IsDeleted: account.DeletedAt.Valid,
-	})
+	json.NewEncoder(w).Encode(models.NewGetAccountResponse(*account))
 }
 
 // sendErrorResponse to build an error response
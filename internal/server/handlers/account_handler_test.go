@@ -2,13 +2,19 @@ package handlers
 
 import (
 	"bytes"
+	"database/sql"
 	"errors"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestAccountHandler_CreateAccount(t *testing.T) {
@@ -67,3 +73,349 @@ func TestAccountHandler_CreateAccount(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountHandler_GetAccount(t *testing.T) {
+	asOf := time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(mockService *mocks.IAccountService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "current account details",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetAccount", mock.Anything, int64(7)).Return(&models.Account{
+					AccountId:    7,
+					Balance:      models.NewMoney(10),
+					CurrencyCode: "USD",
+					Status:       models.AccountStatusActive,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"balance":"10.00000","available_balance":"10.00000","held_amount":"0.00000","overdraft_limit":"0.00000","available_to_spend":"10.00000","currency_code":"USD","status":"active"}`,
+		},
+		{
+			name: "account not found",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetAccount", mock.Anything, int64(7)).Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"` + appErr.ErrAccountNotFound.Error() + `"}`,
+		},
+		{
+			name: "account owned by another client",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetAccount", mock.Anything, int64(7)).Return(nil, appErr.ErrAccountForbidden).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error_message":"` + appErr.ErrAccountForbidden.Error() + `"}`,
+		},
+		{
+			name: "internal error",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetAccount", mock.Anything, int64(7)).Return(nil, appErr.ErrInternal).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error_message":"` + appErr.ErrInternal.Error() + `"}`,
+		},
+		{
+			name:  "balance as of a past time",
+			query: "?as_of=2025-03-31T23:59:00Z",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetBalanceAsOf", mock.Anything, int64(7), asOf).Return(models.BalanceAsOfResponse{
+					AccountId:    7,
+					CurrencyCode: "USD",
+					AsOf:         asOf,
+					Balance:      models.MustParseMoney("1250.5"),
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"currency_code":"USD","as_of":"2025-03-31T23:59:00Z","balance":"1250.50000"}`,
+		},
+		{
+			name:           "invalid as_of",
+			query:          "?as_of=2025-03-31",
+			mockSetup:      func(*mocks.IAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidAsOf.Error() + `"}`,
+		},
+		{
+			name:  "account created after as_of",
+			query: "?as_of=2025-03-31T23:59:00Z",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("GetBalanceAsOf", mock.Anything, int64(7), asOf).Return(models.BalanceAsOfResponse{}, appErr.ErrAccountNotCreatedAsOf).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"` + appErr.ErrAccountNotCreatedAsOf.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIAccountService(t)
+			tt.mockSetup(mockService)
+			handler := NewAccountHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/7"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			rr := httptest.NewRecorder()
+
+			handler.GetAccount(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_FreezeAccount(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountId      string
+		mockSetup      func(mockService *mocks.IAccountService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "success",
+			accountId: "7",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("FreezeAccount", mock.Anything, int64(7)).Return(&models.Account{
+					AccountId:    7,
+					Balance:      models.NewMoney(10),
+					HeldAmount:   models.NewMoney(4),
+					CurrencyCode: "USD",
+					Status:       models.AccountStatusFrozen,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"balance":"10.00000","available_balance":"6.00000","held_amount":"4.00000","overdraft_limit":"0.00000","available_to_spend":"6.00000","currency_code":"USD","status":"frozen"}`,
+		},
+		{
+			name:           "invalid account id",
+			accountId:      "abc",
+			mockSetup:      func(*mocks.IAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid account id"}`,
+		},
+		{
+			name:      "already frozen",
+			accountId: "7",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("FreezeAccount", mock.Anything, int64(7)).Return(nil, appErr.ErrInvalidStatusTransition).Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error_message":"account status does not allow this change"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIAccountService(t)
+			tt.mockSetup(mockService)
+			handler := NewAccountHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts/"+tt.accountId+"/freeze", nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountId})
+			rr := httptest.NewRecorder()
+
+			handler.FreezeAccount(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_SetOverdraftLimit(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IAccountService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			body: `{"overdraft_limit":"500"}`,
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("SetOverdraftLimit", mock.Anything, int64(7), models.NewMoney(500)).Return(&models.Account{
+					AccountId:      7,
+					Balance:        models.NewMoney(-20),
+					OverdraftLimit: models.NewMoney(500),
+					CurrencyCode:   "USD",
+					Status:         models.AccountStatusActive,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"balance":"-20.00000","available_balance":"-20.00000","held_amount":"0.00000","overdraft_limit":"500.00000","available_to_spend":"480.00000","currency_code":"USD","status":"active"}`,
+		},
+		{
+			name:           "malformed limit",
+			body:           `{"overdraft_limit":"lots"}`,
+			mockSetup:      func(*mocks.IAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"overdraft limit must be a non-negative amount"}`,
+		},
+		{
+			name: "closed account",
+			body: `{"overdraft_limit":"500"}`,
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("SetOverdraftLimit", mock.Anything, int64(7), models.NewMoney(500)).Return(nil, appErr.ErrAccountClosed).Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error_message":"account is closed"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIAccountService(t)
+			tt.mockSetup(mockService)
+			handler := NewAccountHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/accounts/7/overdraft-limit", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			rr := httptest.NewRecorder()
+
+			handler.SetOverdraftLimit(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_SetAccountOwner(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IAccountService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			body: `{"owner_client_id":3}`,
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("SetAccountOwner", mock.Anything, int64(7), int64(3)).Return(&models.Account{
+					AccountId:     7,
+					Balance:       models.NewMoney(20),
+					CurrencyCode:  "USD",
+					Status:        models.AccountStatusActive,
+					OwnerClientId: sql.NullInt64{Int64: 3, Valid: true},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"balance":"20.00000","available_balance":"20.00000","held_amount":"0.00000","overdraft_limit":"0.00000","available_to_spend":"20.00000","currency_code":"USD","status":"active","owner_client_id":3}`,
+		},
+		{
+			name:           "malformed body",
+			body:           `{"owner_client_id":"three"}`,
+			mockSetup:      func(*mocks.IAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid JSON format"}`,
+		},
+		{
+			name: "unknown client",
+			body: `{"owner_client_id":3}`,
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("SetAccountOwner", mock.Anything, int64(7), int64(3)).Return(nil, appErr.ErrClientNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"client not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIAccountService(t)
+			tt.mockSetup(mockService)
+			handler := NewAccountHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/accounts/7/owner", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			rr := httptest.NewRecorder()
+
+			handler.SetAccountOwner(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_CloseAccount(t *testing.T) {
+	swept := models.MustParseMoney("25.5")
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IAccountService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "zero balance without body",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("CloseAccount", mock.Anything, models.CloseAccountArgs{AccountId: 7}).
+					Return(models.CloseAccountResponse{AccountId: 7, Status: models.AccountStatusClosed}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"status":"closed"}`,
+		},
+		{
+			name: "balance swept",
+			body: `{"sweep_account_id":3}`,
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("CloseAccount", mock.Anything, models.CloseAccountArgs{AccountId: 7, SweepAccountId: 3}).
+					Return(models.CloseAccountResponse{
+						AccountId:      7,
+						Status:         models.AccountStatusClosed,
+						SweepAccountId: 3,
+						SweptAmount:    &swept,
+						Reference:      "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11",
+					}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"account_id":7,"status":"closed","sweep_account_id":3,"swept_amount":"25.50000",
+				"reference":"TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"}`,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"sweep_account_id":`,
+			mockSetup:      func(*mocks.IAccountService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid JSON format"}`,
+		},
+		{
+			name: "balance left",
+			mockSetup: func(mockService *mocks.IAccountService) {
+				mockService.On("CloseAccount", mock.Anything, mock.Anything).
+					Return(models.CloseAccountResponse{}, appErr.ErrAccountBalanceNotZero).Once()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error_message":"account balance must be zero to close it, or a sweep account must be given"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIAccountService(t)
+			tt.mockSetup(mockService)
+			handler := NewAccountHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/accounts/7/close", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			rr := httptest.NewRecorder()
+
+			handler.CloseAccount(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"net/http"
+	"strings"
+)
+
+// bearerPrefix precedes the API key in the Authorization header
+const bearerPrefix = "Bearer "
+
+// AuthMiddleware authenticates requests by their API key and checks the scope each route requires
+type AuthMiddleware struct {
+	service service.IApiKeyService
+}
+
+func NewAuthMiddleware(service service.IApiKeyService) *AuthMiddleware {
+	return &AuthMiddleware{service: service}
+}
+
+// Authenticate is a mux middleware that reads the API key of the `Authorization: Bearer <key>` header and attaches
+// it to the request context, rejecting requests without a valid key with a 401. Requests already authenticated by
+// their signature go through as they are
+func (am *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.ApiKeyFromContext(r.Context()); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := r.Header.Get("Authorization")
+		if len(header) < len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			am.sendErrorResponse(w, appErr.ErrUnauthenticated)
+			return
+		}
+
+		apiKey, err := am.service.Authenticate(r.Context(), strings.TrimSpace(header[len(bearerPrefix):]))
+		if err != nil {
+			am.sendErrorResponse(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithApiKey(r.Context(), apiKey)))
+	})
+}
+
+// Require wraps the handler of a route so that it only runs for requests authenticated with an API key holding
+// scope, answering 403 to the others
+func (am *AuthMiddleware) Require(scope models.ApiKeyScope, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		apiKey, ok := auth.ApiKeyFromContext(r.Context())
+		if !ok {
+			am.sendErrorResponse(w, appErr.ErrUnauthenticated)
+			return
+		}
+		if !apiKey.HasScope(scope) {
+			am.sendErrorResponse(w, appErr.ErrInsufficientScope)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// sendErrorResponse to build an error response
+func (am *AuthMiddleware) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	if statusCode == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
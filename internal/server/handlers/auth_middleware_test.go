@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	apiKey := models.ApiKey{Id: 1, Name: "payments", Scopes: []models.ApiKeyScope{models.ScopeAccountsRead}}
+
+	tests := []struct {
+		name           string
+		authorization  string
+		scope          models.ApiKeyScope
+		mockSetup      func(mockService *mocks.IApiKeyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:          "key with the scope",
+			authorization: "Bearer tak_secret",
+			scope:         models.ScopeAccountsRead,
+			mockSetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"client":"payments"}`,
+		},
+		{
+			name:          "bearer scheme is case insensitive",
+			authorization: "bearer tak_secret",
+			scope:         models.ScopeAccountsRead,
+			mockSetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"client":"payments"}`,
+		},
+		{
+			name:          "key without the scope",
+			authorization: "Bearer tak_secret",
+			scope:         models.ScopeTransactionsWrite,
+			mockSetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error_message":"API key is not allowed to make this request"}`,
+		},
+		{
+			name:           "missing header",
+			scope:          models.ScopeAccountsRead,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"missing or invalid API key"}`,
+		},
+		{
+			name:           "other scheme",
+			authorization:  "Basic dXNlcjpwYXNz",
+			scope:          models.ScopeAccountsRead,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"missing or invalid API key"}`,
+		},
+		{
+			name:          "unknown or revoked key",
+			authorization: "Bearer tak_secret",
+			scope:         models.ScopeAccountsRead,
+			mockSetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(models.ApiKey{}, appErr.ErrUnauthenticated).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"missing or invalid API key"}`,
+		},
+		{
+			name:          "internal error",
+			authorization: "Bearer tak_secret",
+			scope:         models.ScopeAccountsRead,
+			mockSetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(models.ApiKey{}, appErr.ErrInternal).Once()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error_message":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIApiKeyService(t)
+			if tt.mockSetup != nil {
+				tt.mockSetup(mockService)
+			}
+			am := NewAuthMiddleware(mockService)
+			handler := am.Authenticate(am.Require(tt.scope, func(w http.ResponseWriter, r *http.Request) {
+				key, _ := auth.ApiKeyFromContext(r.Context())
+				w.Write([]byte(`{"client":"` + key.Name + `"}`))
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/1001", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_RequireWithoutAuthenticate(t *testing.T) {
+	am := NewAuthMiddleware(mocks.NewIApiKeyService(t))
+	handler := am.Require(models.ScopeAccountsRead, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not run for an unauthenticated request")
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/accounts/1001", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type FeeHandler struct {
+	service service.IFeeService
+}
+
+func NewFeeHandler(service service.IFeeService) *FeeHandler {
+	return &FeeHandler{service: service}
+}
+
+// GetFeeSchedule handles GET /admin/accounts/{account_id}/fee-schedule
+func (fh *FeeHandler) GetFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		fh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	resp, err := fh.service.GetFeeSchedule(r.Context(), accountID)
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// SetFeeSchedule handles PUT /admin/accounts/{account_id}/fee-schedule
+func (fh *FeeHandler) SetFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		fh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.FeeSchedule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := fh.service.SetFeeSchedule(r.Context(), accountID, req)
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// DeleteFeeSchedule handles DELETE /admin/accounts/{account_id}/fee-schedule
+func (fh *FeeHandler) DeleteFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		fh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	resp, err := fh.service.DeleteFeeSchedule(r.Context(), accountID)
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// GetCurrencyFeeSchedule handles GET /admin/fee-schedules/{currency_code}
+func (fh *FeeHandler) GetCurrencyFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	resp, err := fh.service.GetCurrencyFeeSchedule(r.Context(), mux.Vars(r)["currency_code"])
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// SetCurrencyFeeSchedule handles PUT /admin/fee-schedules/{currency_code}
+func (fh *FeeHandler) SetCurrencyFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	var req models.FeeSchedule
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		fh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := fh.service.SetCurrencyFeeSchedule(r.Context(), mux.Vars(r)["currency_code"], req)
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// DeleteCurrencyFeeSchedule handles DELETE /admin/fee-schedules/{currency_code}
+func (fh *FeeHandler) DeleteCurrencyFeeSchedule(w http.ResponseWriter, r *http.Request) {
+	resp, err := fh.service.DeleteCurrencyFeeSchedule(r.Context(), mux.Vars(r)["currency_code"])
+	if err != nil {
+		fh.sendErrorResponse(w, err)
+		return
+	}
+
+	fh.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (fh *FeeHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (fh *FeeHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeeHandler_GetFeeSchedule(t *testing.T) {
+	mockService := mocks.NewIFeeService(t)
+	handler := NewFeeHandler(mockService)
+	mockService.On("GetFeeSchedule", mock.Anything, int64(7)).Return(models.FeeScheduleResponse{
+		AccountId: 7,
+		Schedule:  &models.FeeSchedule{Flat: models.MustParseMoney("0.25"), Percentage: models.MustParseMoney("1.5")},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/accounts/7/fee-schedule", nil)
+	req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+	rr := httptest.NewRecorder()
+
+	handler.GetFeeSchedule(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"account_id":7,"schedule":{"flat":"0.25000","percentage":"1.50000"},"is_override":false}`, rr.Body.String())
+}
+
+func TestFeeHandler_SetFeeSchedule(t *testing.T) {
+	maxFee := models.NewMoney(20)
+	schedule := models.FeeSchedule{Percentage: models.MustParseMoney("1.5"), Max: &maxFee}
+
+	tests := []struct {
+		name           string
+		accountID      string
+		body           string
+		mockSetup      func(mockService *mocks.IFeeService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "schedule set",
+			accountID: "7",
+			body:      `{"percentage":"1.5","max":"20"}`,
+			mockSetup: func(mockService *mocks.IFeeService) {
+				mockService.On("SetFeeSchedule", mock.Anything, int64(7), schedule).
+					Return(models.FeeScheduleResponse{AccountId: 7, Schedule: &schedule, IsOverride: true}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"schedule":{"flat":"0.00000","percentage":"1.50000","max":"20.00000"},"is_override":true}`,
+		},
+		{
+			name:      "invalid schedule",
+			accountID: "7",
+			body:      `{"min":"5","max":"1"}`,
+			mockSetup: func(mockService *mocks.IFeeService) {
+				mockService.On("SetFeeSchedule", mock.Anything, int64(7), mock.Anything).
+					Return(models.FeeScheduleResponse{}, appErr.ErrInvalidFeeSchedule).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"fee schedule amounts must be non-negative, tiers ascending and min not above max"}`,
+		},
+		{
+			name:           "invalid account id",
+			accountID:      "abc",
+			body:           `{}`,
+			mockSetup:      func(*mocks.IFeeService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid account id"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIFeeService(t)
+			tt.mockSetup(mockService)
+			handler := NewFeeHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/accounts/"+tt.accountID+"/fee-schedule", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountID})
+			rr := httptest.NewRecorder()
+
+			handler.SetFeeSchedule(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestFeeHandler_DeleteFeeSchedule(t *testing.T) {
+	mockService := mocks.NewIFeeService(t)
+	handler := NewFeeHandler(mockService)
+	mockService.On("DeleteFeeSchedule", mock.Anything, int64(7)).Return(models.FeeScheduleResponse{AccountId: 7}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/accounts/7/fee-schedule", nil)
+	req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+	rr := httptest.NewRecorder()
+
+	handler.DeleteFeeSchedule(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"account_id":7,"schedule":null,"is_override":false}`, rr.Body.String())
+}
+
+func TestFeeHandler_CurrencyFeeSchedule(t *testing.T) {
+	schedule := models.FeeSchedule{Flat: models.NewMoney(30)}
+
+	t.Run("set", func(t *testing.T) {
+		mockService := mocks.NewIFeeService(t)
+		mockService.On("SetCurrencyFeeSchedule", mock.Anything, "JPY", schedule).
+			Return(models.CurrencyFeeScheduleResponse{CurrencyCode: "JPY", Schedule: &schedule}, nil).Once()
+		handler := NewFeeHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/fee-schedules/JPY", strings.NewReader(`{"flat":"30"}`))
+		req = mux.SetURLVars(req, map[string]string{"currency_code": "JPY"})
+		rr := httptest.NewRecorder()
+
+		handler.SetCurrencyFeeSchedule(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"currency_code":"JPY","schedule":{"flat":"30.00000","percentage":"0.00000"}}`, rr.Body.String())
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		mockService := mocks.NewIFeeService(t)
+		mockService.On("GetCurrencyFeeSchedule", mock.Anything, "XYZ").
+			Return(models.CurrencyFeeScheduleResponse{}, appErr.ErrUnsupportedCurrency).Once()
+		handler := NewFeeHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/fee-schedules/XYZ", nil)
+		req = mux.SetURLVars(req, map[string]string{"currency_code": "XYZ"})
+		rr := httptest.NewRecorder()
+
+		handler.GetCurrencyFeeSchedule(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error_message":"unsupported currency code"}`, rr.Body.String())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockService := mocks.NewIFeeService(t)
+		mockService.On("DeleteCurrencyFeeSchedule", mock.Anything, "JPY").
+			Return(models.CurrencyFeeScheduleResponse{CurrencyCode: "JPY"}, nil).Once()
+		handler := NewFeeHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodDelete, "/admin/fee-schedules/JPY", nil)
+		req = mux.SetURLVars(req, map[string]string{"currency_code": "JPY"})
+		rr := httptest.NewRecorder()
+
+		handler.DeleteCurrencyFeeSchedule(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"currency_code":"JPY","schedule":null}`, rr.Body.String())
+	})
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/gorilla/mux"
+	"io"
+	"net/http"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+)
+
+type HoldHandler struct {
+	service service.IHoldService
+}
+
+func NewHoldHandler(service service.IHoldService) *HoldHandler {
+	return &HoldHandler{service: service}
+}
+
+// CreateHold handles POST /holds
+func (hh *HoldHandler) CreateHold(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		hh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	amount, err := models.ParseMoney(req.Amount)
+	if err != nil {
+		hh.sendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := hh.service.CreateHold(r.Context(), models.CreateHoldArgs{
+		SourceAccountId:      req.SourceAccountId,
+		DestinationAccountId: req.DestinationAccountId,
+		Amount:               amount,
+	})
+	if err != nil {
+		hh.sendErrorResponse(w, err)
+		return
+	}
+
+	hh.sendSuccessResponse(w, resp)
+}
+
+// CaptureHold handles POST /holds/{hold_id}/capture
+func (hh *HoldHandler) CaptureHold(w http.ResponseWriter, r *http.Request) {
+	var req models.CaptureHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		hh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	args := models.CaptureHoldArgs{HoldId: mux.Vars(r)["hold_id"]}
+	if req.Amount != "" {
+		amount, err := models.ParseMoney(req.Amount)
+		if err != nil {
+			hh.sendErrorResponse(w, err)
+			return
+		}
+		args.Amount = &amount
+	}
+
+	resp, err := hh.service.CaptureHold(r.Context(), args)
+	if err != nil {
+		hh.sendErrorResponse(w, err)
+		return
+	}
+
+	hh.sendSuccessResponse(w, resp)
+}
+
+// VoidHold handles POST /holds/{hold_id}/void
+func (hh *HoldHandler) VoidHold(w http.ResponseWriter, r *http.Request) {
+	resp, err := hh.service.VoidHold(r.Context(), mux.Vars(r)["hold_id"])
+	if err != nil {
+		hh.sendErrorResponse(w, err)
+		return
+	}
+
+	hh.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (hh *HoldHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, ok := appErr.HTTPStatusMap[err]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+		err = appErr.ErrInternal
+	}
+
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (hh *HoldHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
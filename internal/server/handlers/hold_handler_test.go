@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testHoldId = "HLD-7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestHoldHandler_CreateHold(t *testing.T) {
+	expiresAt := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IHoldService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			body: `{"source_account_id":1,"destination_account_id":2,"amount":"40"}`,
+			mockSetup: func(mockService *mocks.IHoldService) {
+				mockService.On("CreateHold", mock.Anything, models.CreateHoldArgs{
+					SourceAccountId:      1,
+					DestinationAccountId: 2,
+					Amount:               models.NewMoney(40),
+				}).Return(models.HoldResponse{
+					HoldId:               testHoldId,
+					SourceAccountId:      1,
+					DestinationAccountId: 2,
+					Amount:               models.NewMoney(40),
+					CurrencyCode:         "USD",
+					Status:               models.HoldStatusPending,
+					AvailableBalance:     models.NewMoney(60),
+					ExpiresAt:            expiresAt,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"hold_id":"` + testHoldId + `","source_account_id":1,"destination_account_id":2,"amount":"40.00000",` +
+				`"currency_code":"USD","status":"pending","available_balance":"60.00000","expires_at":"2025-03-08T12:00:00Z"}`,
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"source_account_id":`,
+			mockSetup:      func(*mocks.IHoldService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid JSON format"}`,
+		},
+		{
+			name: "insufficient balance",
+			body: `{"source_account_id":1,"destination_account_id":2,"amount":"40"}`,
+			mockSetup: func(mockService *mocks.IHoldService) {
+				mockService.On("CreateHold", mock.Anything, mock.Anything).
+					Return(models.HoldResponse{}, appErr.ErrInsufficientBalance).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInsufficientBalance.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIHoldService(t)
+			tt.mockSetup(mockService)
+			handler := NewHoldHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/holds", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.CreateHold(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestHoldHandler_CaptureHold(t *testing.T) {
+	partial := models.NewMoney(25)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IHoldService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "full capture without body",
+			mockSetup: func(mockService *mocks.IHoldService) {
+				mockService.On("CaptureHold", mock.Anything, models.CaptureHoldArgs{HoldId: testHoldId}).
+					Return(models.HoldResponse{HoldId: testHoldId, Status: models.HoldStatusCaptured}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"hold_id":"` + testHoldId + `","source_account_id":0,"destination_account_id":0,"amount":"0.00000",` +
+				`"currency_code":"","status":"captured","available_balance":"0.00000","expires_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "partial capture",
+			body: `{"amount":"25"}`,
+			mockSetup: func(mockService *mocks.IHoldService) {
+				mockService.On("CaptureHold", mock.Anything, models.CaptureHoldArgs{HoldId: testHoldId, Amount: &partial}).
+					Return(models.HoldResponse{}, appErr.ErrHoldExpired).Once()
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   `{"error_message":"hold has expired"}`,
+		},
+		{
+			name:           "invalid amount",
+			body:           `{"amount":"abc"}`,
+			mockSetup:      func(*mocks.IHoldService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidAmount.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIHoldService(t)
+			tt.mockSetup(mockService)
+			handler := NewHoldHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/holds/"+testHoldId+"/capture", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"hold_id": testHoldId})
+			rr := httptest.NewRecorder()
+
+			handler.CaptureHold(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestHoldHandler_VoidHold(t *testing.T) {
+	mockService := mocks.NewIHoldService(t)
+	handler := NewHoldHandler(mockService)
+	mockService.On("VoidHold", mock.Anything, testHoldId).
+		Return(models.HoldResponse{}, appErr.ErrHoldNotFound).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/holds/"+testHoldId+"/void", nil)
+	req = mux.SetURLVars(req, map[string]string{"hold_id": testHoldId})
+	rr := httptest.NewRecorder()
+
+	handler.VoidHold(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.JSONEq(t, `{"error_message":"hold not found"}`, rr.Body.String())
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"net/http"
+	"strings"
+)
+
+const (
+	// idempotencyKeyHeader is the request header carrying the client supplied idempotency key
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
+// readIdempotency reads the Idempotency-Key header and fingerprints the decoded request body,
+// so that a replay with a different payload can be told apart from a genuine retry. The key is scoped to the
+// client of the request, so that clients picking the same key do not see each other's responses
+func readIdempotency(r *http.Request, req interface{}) (models.Idempotency, error) {
+	key := strings.TrimSpace(r.Header.Get(idempotencyKeyHeader))
+	if key == "" {
+		return models.Idempotency{}, nil
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return models.Idempotency{}, appErr.ErrInvalidIdempotencyKey
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return models.Idempotency{}, appErr.ErrInternal
+	}
+	sum := sha256.Sum256(body)
+
+	idem := models.Idempotency{Key: key, RequestHash: hex.EncodeToString(sum[:])}
+	if apiKey, ok := auth.ApiKeyFromContext(r.Context()); ok {
+		idem.ClientId = apiKey.ClientId
+	}
+	return idem, nil
+}
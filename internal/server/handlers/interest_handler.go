@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type InterestHandler struct {
+	service service.IInterestService
+}
+
+func NewInterestHandler(service service.IInterestService) *InterestHandler {
+	return &InterestHandler{service: service}
+}
+
+// SetInterestRate handles PUT /admin/accounts/{account_id}/interest-rate
+func (ih *InterestHandler) SetInterestRate(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		ih.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.InterestRate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ih.sendErrorResponse(w, appErr.ErrInvalidInterestRate)
+		return
+	}
+
+	resp, err := ih.service.SetInterestRate(r.Context(), accountID, req)
+	if err != nil {
+		ih.sendErrorResponse(w, err)
+		return
+	}
+
+	ih.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (ih *InterestHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (ih *InterestHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInterestHandler_SetInterestRate(t *testing.T) {
+	rate := models.InterestRate{AnnualRate: models.MustParseMoney("2.5"), DayCount: models.DayCountActual365}
+
+	tests := []struct {
+		name           string
+		accountID      string
+		body           string
+		mockSetup      func(mockService *mocks.IInterestService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "rate set",
+			accountID: "7",
+			body:      `{"annual_rate":"2.5","day_count":"ACT/365"}`,
+			mockSetup: func(mockService *mocks.IInterestService) {
+				mockService.On("SetInterestRate", mock.Anything, int64(7), rate).
+					Return(models.InterestRateResponse{AccountId: 7, InterestRate: rate}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"account_id":7,"annual_rate":"2.50000","day_count":"ACT/365"}`,
+		},
+		{
+			name:      "invalid rate",
+			accountID: "7",
+			body:      `{"annual_rate":"2.5","day_count":"30/360"}`,
+			mockSetup: func(mockService *mocks.IInterestService) {
+				mockService.On("SetInterestRate", mock.Anything, int64(7), mock.Anything).
+					Return(models.InterestRateResponse{}, appErr.ErrInvalidInterestRate).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"interest rate must be a non-negative percent with a day count of ACT/365, ACT/360 or ACT/ACT"}`,
+		},
+		{
+			name:           "malformed body",
+			accountID:      "7",
+			body:           `{"annual_rate":"abc"}`,
+			mockSetup:      func(*mocks.IInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"interest rate must be a non-negative percent with a day count of ACT/365, ACT/360 or ACT/ACT"}`,
+		},
+		{
+			name:           "invalid account id",
+			accountID:      "abc",
+			body:           `{}`,
+			mockSetup:      func(*mocks.IInterestService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid account id"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIInterestService(t)
+			tt.mockSetup(mockService)
+			handler := NewInterestHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/accounts/"+tt.accountID+"/interest-rate", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountID})
+			rr := httptest.NewRecorder()
+
+			handler.SetInterestRate(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
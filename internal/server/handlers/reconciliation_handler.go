@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"io"
+	"net/http"
+)
+
+type ReconciliationHandler struct {
+	service service.IReconciliationService
+}
+
+func NewReconciliationHandler(service service.IReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{service: service}
+}
+
+// Reconcile handles POST /admin/reconciliation
+func (rh *ReconciliationHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	var req models.ReconcileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		rh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := rh.service.Reconcile(r.Context(), req)
+	if err != nil {
+		rh.sendErrorResponse(w, err)
+		return
+	}
+
+	rh.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (rh *ReconciliationHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (rh *ReconciliationHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
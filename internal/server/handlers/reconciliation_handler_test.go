@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconciliationHandler_Reconcile(t *testing.T) {
+	checkedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	lastRecorded := models.NewMoney(90)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IReconciliationService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "every account checked without a body",
+			mockSetup: func(mockService *mocks.IReconciliationService) {
+				mockService.On("Reconcile", mock.Anything, models.ReconcileRequest{}).Return(models.ReconciliationReport{
+					CheckedAt:       checkedAt,
+					AccountsChecked: 2,
+					Mismatches: []models.AccountReconciliation{
+						{AccountId: 2, CurrencyCode: "USD", Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(90), LastRecordedBalance: &lastRecorded, Difference: models.NewMoney(10)},
+					},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"checked_at":"2024-03-01T12:00:00Z","accounts_checked":2,"flagged":false,"mismatches":[
+				{"account_id":2,"currency_code":"USD","balance":"100.00000","ledger_balance":"90.00000","last_recorded_balance":"90.00000","difference":"10.00000"}]}`,
+		},
+		{
+			name: "one account flagged",
+			body: `{"account_id":7,"flag":true}`,
+			mockSetup: func(mockService *mocks.IReconciliationService) {
+				mockService.On("Reconcile", mock.Anything, models.ReconcileRequest{AccountId: 7, Flag: true}).
+					Return(models.ReconciliationReport{}, appErr.ErrAccountNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"` + appErr.ErrAccountNotFound.Error() + `"}`,
+		},
+		{
+			name:           "malformed body",
+			body:           `{"account_id":`,
+			mockSetup:      func(*mocks.IReconciliationService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidJsonFormat.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIReconciliationService(t)
+			tt.mockSetup(mockService)
+			handler := NewReconciliationHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/reconciliation", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.Reconcile(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
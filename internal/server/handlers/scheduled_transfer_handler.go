@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+)
+
+type ScheduledTransferHandler struct {
+	service service.IScheduledTransferService
+}
+
+func NewScheduledTransferHandler(service service.IScheduledTransferService) *ScheduledTransferHandler {
+	return &ScheduledTransferHandler{service: service}
+}
+
+// CreateScheduledTransfer handles POST /scheduled-transfers
+func (sh *ScheduledTransferHandler) CreateScheduledTransfer(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateScheduledTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	amount, err := models.ParseMoney(req.Amount)
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	args := models.CreateScheduledTransferArgs{
+		SourceAccountId:      req.SourceAccountId,
+		DestinationAccountId: req.DestinationAccountId,
+		Amount:               amount,
+		Schedule:             req.Schedule,
+	}
+	if req.RunAt != "" {
+		runAt, err := time.Parse(time.RFC3339, req.RunAt)
+		if err != nil {
+			sh.sendErrorResponse(w, appErr.ErrInvalidRunAt)
+			return
+		}
+		args.RunAt = &runAt
+	}
+
+	resp, err := sh.service.CreateScheduledTransfer(r.Context(), args)
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	sh.sendSuccessResponse(w, resp)
+}
+
+// ListScheduledTransfers handles GET /accounts/{account_id}/scheduled-transfers
+func (sh *ScheduledTransferHandler) ListScheduledTransfers(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		sh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	resp, err := sh.service.ListScheduledTransfers(r.Context(), accountID)
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	sh.sendSuccessResponse(w, resp)
+}
+
+// PauseScheduledTransfer handles POST /scheduled-transfers/{scheduled_transfer_id}/pause
+func (sh *ScheduledTransferHandler) PauseScheduledTransfer(w http.ResponseWriter, r *http.Request) {
+	sh.changeStatus(w, r, sh.service.PauseScheduledTransfer)
+}
+
+// ResumeScheduledTransfer handles POST /scheduled-transfers/{scheduled_transfer_id}/resume
+func (sh *ScheduledTransferHandler) ResumeScheduledTransfer(w http.ResponseWriter, r *http.Request) {
+	sh.changeStatus(w, r, sh.service.ResumeScheduledTransfer)
+}
+
+// CancelScheduledTransfer handles POST /scheduled-transfers/{scheduled_transfer_id}/cancel
+func (sh *ScheduledTransferHandler) CancelScheduledTransfer(w http.ResponseWriter, r *http.Request) {
+	sh.changeStatus(w, r, sh.service.CancelScheduledTransfer)
+}
+
+// changeStatus runs a status change on the scheduled transfer of the request path
+func (sh *ScheduledTransferHandler) changeStatus(w http.ResponseWriter, r *http.Request,
+	change func(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error)) {
+	resp, err := change(r.Context(), mux.Vars(r)["scheduled_transfer_id"])
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	sh.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (sh *ScheduledTransferHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, ok := appErr.HTTPStatusMap[err]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+		err = appErr.ErrInternal
+	}
+
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (sh *ScheduledTransferHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testScheduledTransferId = "SCH-3f2c1b0a-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+
+func TestScheduledTransferHandler_CreateScheduledTransfer(t *testing.T) {
+	runAt := time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.IScheduledTransferService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "one-off",
+			body: `{"source_account_id":1,"destination_account_id":2,"amount":"100","run_at":"2025-04-01T09:00:00Z"}`,
+			mockSetup: func(mockService *mocks.IScheduledTransferService) {
+				mockService.On("CreateScheduledTransfer", mock.Anything, models.CreateScheduledTransferArgs{
+					SourceAccountId:      1,
+					DestinationAccountId: 2,
+					Amount:               models.NewMoney(100),
+					RunAt:                &runAt,
+				}).Return(models.ScheduledTransferResponse{
+					ScheduledTransferId:  testScheduledTransferId,
+					SourceAccountId:      1,
+					DestinationAccountId: 2,
+					Amount:               models.NewMoney(100),
+					Status:               models.ScheduledTransferStatusActive,
+					NextRunAt:            &runAt,
+					CreatedAt:            createdAt,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"scheduled_transfer_id":"` + testScheduledTransferId + `","source_account_id":1,"destination_account_id":2,
+				"amount":"100.00000","status":"active","next_run_at":"2025-04-01T09:00:00Z","created_at":"2025-03-01T12:00:00Z"}`,
+		},
+		{
+			name:           "run_at not RFC3339",
+			body:           `{"source_account_id":1,"destination_account_id":2,"amount":"100","run_at":"01/04/2025"}`,
+			mockSetup:      func(*mocks.IScheduledTransferService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"run_at must be a future RFC3339 time"}`,
+		},
+		{
+			name: "invalid schedule",
+			body: `{"source_account_id":1,"destination_account_id":2,"amount":"100","schedule":"monthly"}`,
+			mockSetup: func(mockService *mocks.IScheduledTransferService) {
+				mockService.On("CreateScheduledTransfer", mock.Anything, mock.Anything).
+					Return(models.ScheduledTransferResponse{}, appErr.ErrInvalidSchedule).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"schedule must be a 5-field cron expression"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIScheduledTransferService(t)
+			tt.mockSetup(mockService)
+			handler := NewScheduledTransferHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPost, "/scheduled-transfers", strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+
+			handler.CreateScheduledTransfer(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestScheduledTransferHandler_ListScheduledTransfers(t *testing.T) {
+	mockService := mocks.NewIScheduledTransferService(t)
+	handler := NewScheduledTransferHandler(mockService)
+	executedAt := time.Date(2025, 3, 1, 9, 0, 2, 0, time.UTC)
+	createdAt := time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService.On("ListScheduledTransfers", mock.Anything, int64(1)).Return(models.ListScheduledTransfersResponse{
+		ScheduledTransfers: []models.ScheduledTransferResponse{{
+			ScheduledTransferId:  testScheduledTransferId,
+			SourceAccountId:      1,
+			DestinationAccountId: 2,
+			Amount:               models.NewMoney(100),
+			Schedule:             "0 9 1 * *",
+			Status:               models.ScheduledTransferStatusPaused,
+			LastRun: &models.ScheduledTransferRunResponse{
+				ExecutedAt:   executedAt,
+				Status:       models.ScheduledRunStatusFailed,
+				ErrorMessage: "insufficient funds in sender account",
+			},
+			CreatedAt: createdAt,
+		}},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/accounts/1/scheduled-transfers", nil)
+	req = mux.SetURLVars(req, map[string]string{"account_id": "1"})
+	rr := httptest.NewRecorder()
+
+	handler.ListScheduledTransfers(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"scheduled_transfers":[{"scheduled_transfer_id":"`+testScheduledTransferId+`","source_account_id":1,
+		"destination_account_id":2,"amount":"100.00000","schedule":"0 9 1 * *","status":"paused",
+		"last_run":{"executed_at":"2025-03-01T09:00:02Z","status":"failed","error_message":"insufficient funds in sender account"},
+		"created_at":"2025-02-01T12:00:00Z"}]}`, rr.Body.String())
+}
+
+func TestScheduledTransferHandler_CancelScheduledTransfer(t *testing.T) {
+	mockService := mocks.NewIScheduledTransferService(t)
+	handler := NewScheduledTransferHandler(mockService)
+	mockService.On("CancelScheduledTransfer", mock.Anything, testScheduledTransferId).
+		Return(models.ScheduledTransferResponse{}, appErr.ErrInvalidScheduleTransition).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/scheduled-transfers/"+testScheduledTransferId+"/cancel", nil)
+	req = mux.SetURLVars(req, map[string]string{"scheduled_transfer_id": testScheduledTransferId})
+	rr := httptest.NewRecorder()
+
+	handler.CancelScheduledTransfer(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.JSONEq(t, `{"error_message":"scheduled transfer status does not allow this change"}`, rr.Body.String())
+}
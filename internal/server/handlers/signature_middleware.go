@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// Headers of a signed request, see models.SignedRequest
+const (
+	apiKeyIdHeader  = "X-Api-Key-Id"
+	timestampHeader = "X-Timestamp"
+	nonceHeader     = "X-Nonce"
+	signatureHeader = "X-Signature"
+)
+
+// maxSignedBodyBytes caps the body of a signed request, which is read whole to be checked. A batch of 1000
+// transfers is well below it
+const maxSignedBodyBytes = 1 << 20
+
+// SignatureMiddleware authenticates requests signed with the signing secret of an API key, for callers that
+// would rather not send the key itself
+type SignatureMiddleware struct {
+	service service.IRequestSigningService
+}
+
+func NewSignatureMiddleware(service service.IRequestSigningService) *SignatureMiddleware {
+	return &SignatureMiddleware{service: service}
+}
+
+// Verify is a mux middleware that checks the signature of requests carrying an X-Signature header and attaches
+// the API key they were signed for to the request context, rejecting bad signatures with a 401. Other requests
+// are left to the Authenticate middleware
+func (sm *SignatureMiddleware) Verify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(signatureHeader) == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		apiKeyId, err := strconv.ParseInt(r.Header.Get(apiKeyIdHeader), 10, 64)
+		if err != nil {
+			sm.sendErrorResponse(w, appErr.ErrMalformedSignature)
+			return
+		}
+		timestamp, err := strconv.ParseInt(r.Header.Get(timestampHeader), 10, 64)
+		if err != nil {
+			sm.sendErrorResponse(w, appErr.ErrMalformedSignature)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				sm.sendErrorResponse(w, appErr.ErrRequestTooLarge)
+				return
+			}
+			log.Printf("[SignatureMiddleware.Verify] failed to read the request body: %v", err)
+			sm.sendErrorResponse(w, appErr.ErrInvalidInput)
+			return
+		}
+		// The handler reads the body again
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		apiKey, err := sm.service.VerifySignature(r.Context(), models.SignedRequest{
+			ApiKeyId:  apiKeyId,
+			Method:    r.Method,
+			Path:      r.URL.RequestURI(),
+			Timestamp: timestamp,
+			Nonce:     r.Header.Get(nonceHeader),
+			Body:      body,
+			Signature: r.Header.Get(signatureHeader),
+		})
+		if err != nil {
+			sm.sendErrorResponse(w, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.WithApiKey(r.Context(), apiKey)))
+	})
+}
+
+// sendErrorResponse to build an error response
+func (sm *SignatureMiddleware) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
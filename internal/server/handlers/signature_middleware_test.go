@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSignatureMiddleware(t *testing.T) {
+	apiKey := models.ApiKey{Id: 3, Name: "payroll", Scopes: []models.ApiKeyScope{models.ScopeTransactionsWrite}}
+	body := `{"source_account_id":1,"destination_account_id":2,"amount":"10"}`
+	signedHeaders := map[string]string{
+		"X-Api-Key-Id": "3",
+		"X-Timestamp":  "1743498000",
+		"X-Nonce":      "9f8c1e",
+		"X-Signature":  "5d41402abc4b2a76",
+	}
+	expectedRequest := models.SignedRequest{
+		ApiKeyId:  3,
+		Method:    http.MethodPost,
+		Path:      "/transactions?source=payroll",
+		Timestamp: 1743498000,
+		Nonce:     "9f8c1e",
+		Body:      []byte(body),
+		Signature: "5d41402abc4b2a76",
+	}
+
+	tests := []struct {
+		name           string
+		body           string
+		headers        map[string]string
+		signingSetup   func(*mocks.IRequestSigningService)
+		apiKeySetup    func(*mocks.IApiKeyService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:    "valid signature",
+			headers: signedHeaders,
+			signingSetup: func(mockService *mocks.IRequestSigningService) {
+				mockService.On("VerifySignature", mock.Anything, expectedRequest).Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"client":"payroll","body":` + body + `}`,
+		},
+		{
+			name:    "bearer key without signature",
+			headers: map[string]string{"Authorization": "Bearer tak_secret"},
+			apiKeySetup: func(mockService *mocks.IApiKeyService) {
+				mockService.On("Authenticate", mock.Anything, "tak_secret").Return(apiKey, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"client":"payroll","body":` + body + `}`,
+		},
+		{
+			name:           "neither signature nor bearer key",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"missing or invalid API key"}`,
+		},
+		{
+			name:           "malformed key id",
+			headers:        map[string]string{"X-Api-Key-Id": "payroll", "X-Timestamp": "1743498000", "X-Nonce": "9f8c1e", "X-Signature": "5d41402abc4b2a76"},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"signed requests need X-Api-Key-Id, X-Timestamp, X-Nonce and X-Signature headers"}`,
+		},
+		{
+			name:           "missing timestamp",
+			headers:        map[string]string{"X-Api-Key-Id": "3", "X-Nonce": "9f8c1e", "X-Signature": "5d41402abc4b2a76"},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"signed requests need X-Api-Key-Id, X-Timestamp, X-Nonce and X-Signature headers"}`,
+		},
+		{
+			name:           "body too large",
+			body:           `{"note":"` + strings.Repeat("x", maxSignedBodyBytes) + `"}`,
+			headers:        signedHeaders,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error_message":"signed request body must be at most 1 MiB"}`,
+		},
+		{
+			name:    "signature does not match",
+			headers: signedHeaders,
+			signingSetup: func(mockService *mocks.IRequestSigningService) {
+				mockService.On("VerifySignature", mock.Anything, expectedRequest).Return(models.ApiKey{}, appErr.ErrInvalidSignature).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"invalid request signature"}`,
+		},
+		{
+			name:    "stale timestamp",
+			headers: signedHeaders,
+			signingSetup: func(mockService *mocks.IRequestSigningService) {
+				mockService.On("VerifySignature", mock.Anything, expectedRequest).Return(models.ApiKey{}, appErr.ErrStaleRequest).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"request timestamp is too far from the current time"}`,
+		},
+		{
+			name:    "replayed nonce",
+			headers: signedHeaders,
+			signingSetup: func(mockService *mocks.IRequestSigningService) {
+				mockService.On("VerifySignature", mock.Anything, expectedRequest).Return(models.ApiKey{}, appErr.ErrReplayedRequest).Once()
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error_message":"request nonce has already been used"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signingService := mocks.NewIRequestSigningService(t)
+			if tt.signingSetup != nil {
+				tt.signingSetup(signingService)
+			}
+			apiKeyService := mocks.NewIApiKeyService(t)
+			if tt.apiKeySetup != nil {
+				tt.apiKeySetup(apiKeyService)
+			}
+			am := NewAuthMiddleware(apiKeyService)
+			handler := NewSignatureMiddleware(signingService).Verify(am.Authenticate(am.Require(models.ScopeTransactionsWrite, func(w http.ResponseWriter, r *http.Request) {
+				key, _ := auth.ApiKeyFromContext(r.Context())
+				received, _ := io.ReadAll(r.Body)
+				w.Write([]byte(`{"client":"` + key.Name + `","body":` + string(received) + `}`))
+			})))
+
+			if tt.body == "" {
+				tt.body = body
+			}
+			req := httptest.NewRequest(http.MethodPost, "/transactions?source=payroll", strings.NewReader(tt.body))
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"github.com/bhuvi1021/TripleA/internal/statement"
+	"github.com/gorilla/mux"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type StatementHandler struct {
+	service service.IStatementService
+}
+
+func NewStatementHandler(service service.IStatementService) *StatementHandler {
+	return &StatementHandler{service: service}
+}
+
+// GetStatement handles GET /accounts/{account_id}/statement
+func (sh *StatementHandler) GetStatement(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		sh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	q := r.URL.Query()
+	format := models.StatementFormat(strings.ToLower(q.Get("format")))
+	if format == "" {
+		format = models.StatementFormatJSON
+	}
+	if !format.IsValid() {
+		sh.sendErrorResponse(w, appErr.ErrInvalidStatementFormat)
+		return
+	}
+
+	from, to, err := models.ParseStatementPeriod(q.Get("from"), q.Get("to"))
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := sh.service.GetStatement(r.Context(), models.StatementArgs{AccountId: accountID, From: from, To: to})
+	if err != nil {
+		sh.sendErrorResponse(w, err)
+		return
+	}
+
+	switch format {
+	case models.StatementFormatCSV:
+		sh.sendFileResponse(w, resp, format, "text/csv", statement.WriteCSV)
+	case models.StatementFormatPDF:
+		sh.sendFileResponse(w, resp, format, "application/pdf", statement.WritePDF)
+	default:
+		sh.sendSuccessResponse(w, resp)
+	}
+}
+
+// sendFileResponse to send a statement rendered by write as a file download
+func (sh *StatementHandler) sendFileResponse(w http.ResponseWriter, resp models.Statement, format models.StatementFormat, contentType string, write func(io.Writer, models.Statement) error) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="statement-%d-%s-%s.%s"`, resp.AccountId, resp.From, resp.To, format))
+	w.WriteHeader(http.StatusOK)
+	if err := write(w, resp); err != nil {
+		log.Printf("[StatementHandler.sendFileResponse] failed to write the statement of account %d: %v", resp.AccountId, err)
+	}
+}
+
+// sendErrorResponse to build an error response
+func (sh *StatementHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (sh *StatementHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStatementHandler_GetStatement(t *testing.T) {
+	args := models.StatementArgs{AccountId: 1001, From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+	debit := models.NewMoney(25)
+	statement := models.Statement{
+		AccountId:      1001,
+		CurrencyCode:   "USD",
+		From:           "2025-03-01",
+		To:             "2025-03-31",
+		OpeningBalance: models.NewMoney(100),
+		TotalDebits:    debit,
+		ClosingBalance: models.NewMoney(75),
+		Lines: []models.StatementLine{
+			{Id: 5, CreatedAt: time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC), Reference: "TXN-5", Debit: &debit, Balance: models.NewMoney(75)},
+		},
+		GeneratedAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name                string
+		accountId           string
+		query               string
+		mockSetup           func(mockService *mocks.IStatementService)
+		expectedStatus      int
+		expectedContentType string
+		expectedDisposition string
+		expectedBody        string
+	}{
+		{
+			name:      "json by default",
+			accountId: "1001",
+			query:     "from=2025-03-01&to=2025-03-31",
+			mockSetup: func(mockService *mocks.IStatementService) {
+				mockService.On("GetStatement", mock.Anything, args).Return(statement, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"account_id":1001,"currency_code":"USD","from":"2025-03-01","to":"2025-03-31","opening_balance":"100.00000",
+				"total_debits":"25.00000","total_credits":"0.00000","closing_balance":"75.00000","generated_at":"2025-04-01T00:00:00Z",
+				"lines":[{"id":5,"created_at":"2025-03-10T09:30:00Z","reference":"TXN-5","debit":"25.00000","balance":"75.00000"}]}`,
+		},
+		{
+			name:      "csv",
+			accountId: "1001",
+			query:     "from=2025-03-01&to=2025-03-31&format=CSV",
+			mockSetup: func(mockService *mocks.IStatementService) {
+				mockService.On("GetStatement", mock.Anything, args).Return(statement, nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv",
+			expectedDisposition: `attachment; filename="statement-1001-2025-03-01-2025-03-31.csv"`,
+			expectedBody:        "5,2025-03-10T09:30:00Z,TXN-5,,false,25.00000,,75.00000\n",
+		},
+		{
+			name:      "pdf",
+			accountId: "1001",
+			query:     "from=2025-03-01&to=2025-03-31&format=pdf",
+			mockSetup: func(mockService *mocks.IStatementService) {
+				mockService.On("GetStatement", mock.Anything, args).Return(statement, nil).Once()
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/pdf",
+			expectedDisposition: `attachment; filename="statement-1001-2025-03-01-2025-03-31.pdf"`,
+			expectedBody:        "%PDF-1.4",
+		},
+		{
+			name:           "unsupported format",
+			accountId:      "1001",
+			query:          "from=2025-03-01&to=2025-03-31&format=xlsx",
+			mockSetup:      func(*mocks.IStatementService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidStatementFormat.Error() + `"}`,
+		},
+		{
+			name:           "missing period",
+			accountId:      "1001",
+			query:          "from=2025-03-01",
+			mockSetup:      func(*mocks.IStatementService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidStatementPeriod.Error() + `"}`,
+		},
+		{
+			name:           "invalid account id",
+			accountId:      "abc",
+			query:          "from=2025-03-01&to=2025-03-31",
+			mockSetup:      func(*mocks.IStatementService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"` + appErr.ErrInvalidAccountId.Error() + `"}`,
+		},
+		{
+			name:      "account not found",
+			accountId: "1001",
+			query:     "from=2025-03-01&to=2025-03-31",
+			mockSetup: func(mockService *mocks.IStatementService) {
+				mockService.On("GetStatement", mock.Anything, args).Return(models.Statement{}, appErr.ErrAccountNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"` + appErr.ErrAccountNotFound.Error() + `"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewIStatementService(t)
+			tt.mockSetup(mockService)
+			handler := NewStatementHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/"+tt.accountId+"/statement?"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountId})
+			rr := httptest.NewRecorder()
+
+			handler.GetStatement(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedContentType == "" {
+				assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+				return
+			}
+			assert.Equal(t, tt.expectedContentType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedDisposition, rr.Header().Get("Content-Disposition"))
+			assert.Contains(t, rr.Body.String(), tt.expectedBody)
+		})
+	}
+}
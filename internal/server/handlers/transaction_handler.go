@@ -3,8 +3,12 @@ package handlers
 import (
 	"encoding/json"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/gorilla/mux"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/service"
@@ -26,9 +30,15 @@ func (th *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	amount, err := strconv.ParseFloat(req.Amount, 64)
+	amount, err := models.ParseMoney(req.Amount)
 	if err != nil {
-		th.sendErrorResponse(w, appErr.ErrInvalidAmount)
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	idem, err := readIdempotency(r, req)
+	if err != nil {
+		th.sendErrorResponse(w, err)
 		return
 	}
 
@@ -36,6 +46,79 @@ func (th *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.R
 		SourceAccountId:      req.SourceAccountId,
 		DestinationAccountId: req.DestinationAccountId,
 		Amount:               amount,
+		QuoteId:              req.QuoteId,
+		Idempotency:          idem,
+	})
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	th.sendSuccessResponse(w, resp)
+}
+
+// CreateBatchTransaction handles POST /transactions/batch.
+// A transfer with a malformed amount rejects the whole batch in atomic mode, and only fails that transfer in
+// best-effort mode
+func (th *TransactionHandler) CreateBatchTransaction(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateBatchTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		th.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	args := models.CreateBatchTransactionArgs{Mode: req.Mode, Transfers: make([]models.CreateTransactionArgs, len(req.Transfers))}
+	for i, transfer := range req.Transfers {
+		amount, err := models.ParseMoney(transfer.Amount)
+		if err != nil {
+			if req.Mode != models.BatchModeBestEffort {
+				th.sendBatchErrorResponse(w, err, i)
+				return
+			}
+			if args.AmountErrors == nil {
+				args.AmountErrors = make([]error, len(req.Transfers))
+			}
+			args.AmountErrors[i] = err
+		}
+		args.Transfers[i] = models.CreateTransactionArgs{
+			SourceAccountId:      transfer.SourceAccountId,
+			DestinationAccountId: transfer.DestinationAccountId,
+			Amount:               amount,
+			QuoteId:              transfer.QuoteId,
+		}
+	}
+
+	resp, err := th.service.CreateBatchTransaction(r.Context(), args)
+	if err != nil {
+		if resp.FailedIndex != nil {
+			th.sendBatchErrorResponse(w, err, *resp.FailedIndex)
+			return
+		}
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	th.sendSuccessResponse(w, resp)
+}
+
+// CreateQuote handles POST /fx/quotes
+func (th *TransactionHandler) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		th.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	amount, err := models.ParseMoney(req.Amount)
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	resp, err := th.service.CreateQuote(r.Context(), models.CreateQuoteArgs{
+		SourceAccountId:      req.SourceAccountId,
+		DestinationAccountId: req.DestinationAccountId,
+		Amount:               amount,
 	})
 	if err != nil {
 		th.sendErrorResponse(w, err)
@@ -45,6 +128,115 @@ func (th *TransactionHandler) CreateTransaction(w http.ResponseWriter, r *http.R
 	th.sendSuccessResponse(w, resp)
 }
 
+// ListAccountTransactions handles GET /accounts/{account_id}/transactions
+func (th *TransactionHandler) ListAccountTransactions(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		th.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	args, err := parseListTransactionsQuery(r.URL.Query())
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+	args.AccountId = accountID
+
+	resp, err := th.service.ListAccountTransactions(r.Context(), args)
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	th.sendSuccessResponse(w, resp)
+}
+
+// GetTransfer handles GET /transactions/{reference}
+func (th *TransactionHandler) GetTransfer(w http.ResponseWriter, r *http.Request) {
+	resp, err := th.service.GetTransfer(r.Context(), mux.Vars(r)["reference"])
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	th.sendSuccessResponse(w, resp)
+}
+
+// ReverseTransaction handles POST /transactions/{reference}/reverse
+func (th *TransactionHandler) ReverseTransaction(w http.ResponseWriter, r *http.Request) {
+	var req models.ReverseTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		th.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	args := models.ReverseTransactionArgs{OriginalReference: mux.Vars(r)["reference"]}
+	if req.Amount != "" {
+		amount, err := models.ParseMoney(req.Amount)
+		if err != nil {
+			th.sendErrorResponse(w, err)
+			return
+		}
+		args.Amount = &amount
+	}
+
+	resp, err := th.service.ReverseTransaction(r.Context(), args)
+	if err != nil {
+		th.sendErrorResponse(w, err)
+		return
+	}
+
+	th.sendSuccessResponse(w, resp)
+}
+
+// parseListTransactionsQuery reads the pagination and filter query parameters of the transaction history endpoint
+func parseListTransactionsQuery(q url.Values) (models.ListTransactionsArgs, error) {
+	args := models.ListTransactionsArgs{Cursor: q.Get("cursor")}
+
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return args, appErr.ErrInvalidLimit
+		}
+		args.Limit = limit
+	}
+
+	for param, dst := range map[string]**time.Time{"from": &args.From, "to": &args.To} {
+		if v := q.Get(param); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return args, appErr.ErrInvalidDateFormat
+			}
+			*dst = &t
+		}
+	}
+
+	switch q.Get("direction") {
+	case "":
+	case "credit":
+		isCredit := true
+		args.IsCredit = &isCredit
+	case "debit":
+		isCredit := false
+		args.IsCredit = &isCredit
+	default:
+		return args, appErr.ErrInvalidDirection
+	}
+
+	for param, dst := range map[string]**models.Money{"min_amount": &args.MinAmount, "max_amount": &args.MaxAmount} {
+		if v := q.Get(param); v != "" {
+			amount, err := models.ParseMoney(v)
+			if err != nil {
+				return args, err
+			}
+			*dst = &amount
+		}
+	}
+
+	return args, nil
+}
+
 // sendErrorResponse to build an error response
 func (th *TransactionHandler) sendErrorResponse(w http.ResponseWriter, err error) {
 	statusCode, ok := appErr.HTTPStatusMap[err]
@@ -57,8 +249,15 @@ func (th *TransactionHandler) sendErrorResponse(w http.ResponseWriter, err error
 	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
 }
 
+// sendBatchErrorResponse to build the error response of a batch rejected because of the transfer at index
+func (th *TransactionHandler) sendBatchErrorResponse(w http.ResponseWriter, err error, index int) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.BatchErrorResponse{ErrorMessage: err.Error(), FailedIndex: index})
+}
+
 // sendErrorResponse to build success response
-func (th *TransactionHandler) sendSuccessResponse(w http.ResponseWriter, resp models.CreateTransactionResponse) {
+func (th *TransactionHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(resp)
 }
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/bhuvi1021/TripleA/internal/auth"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"github.com/bhuvi1021/TripleA/internal/models"
+	repoMocks "github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/bhuvi1021/TripleA/internal/service"
 	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,14 +37,49 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 				mockSvc.On("CreateTransaction", mock.Anything, models.CreateTransactionArgs{
 					SourceAccountId:      1,
 					DestinationAccountId: 2,
-					Amount:               100.00,
+					Amount:               models.MustParseMoney("100.00"),
 				}).Return(models.CreateTransactionResponse{
 					SourceAccountId:  1,
-					AvailableBalance: "900.00000",
+					AvailableBalance: models.MustParseMoney("900"),
+					Reference:        "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11",
 				}, nil).Once()
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `{"source_account_id":1,"available_balance":"900.00000"}`,
+			expectedBody:   `{"source_account_id":1,"available_balance":"900.00000","reference":"TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"}`,
+		},
+		{
+			name:        "Cross-Currency With Quote",
+			requestBody: `{"source_account_id":1,"destination_account_id":3,"amount":"100.00","quote_id":"QTE-0f8fad5b-d9cb-469f-a165-70867728950e"}`,
+			mockSetup: func() {
+				destinationAmount := models.MustParseMoney("92.35")
+				rate := models.MustParseExchangeRate("0.9235")
+				mockSvc.On("CreateTransaction", mock.Anything, models.CreateTransactionArgs{
+					SourceAccountId:      1,
+					DestinationAccountId: 3,
+					Amount:               models.MustParseMoney("100.00"),
+					QuoteId:              "QTE-0f8fad5b-d9cb-469f-a165-70867728950e",
+				}).Return(models.CreateTransactionResponse{
+					SourceAccountId:         1,
+					AvailableBalance:        models.MustParseMoney("900"),
+					Reference:               "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11",
+					DestinationAmount:       &destinationAmount,
+					DestinationCurrencyCode: "EUR",
+					FxRate:                  &rate,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"source_account_id":1,"available_balance":"900.00000","reference":"TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11",
+				"destination_amount":"92.35000","destination_currency_code":"EUR","fx_rate":"0.9235000000"}`,
+		},
+		{
+			name:        "Expired Quote",
+			requestBody: `{"source_account_id":1,"destination_account_id":3,"amount":"100.00","quote_id":"QTE-0f8fad5b-d9cb-469f-a165-70867728950e"}`,
+			mockSetup: func() {
+				mockSvc.On("CreateTransaction", mock.Anything, mock.Anything).
+					Return(models.CreateTransactionResponse{}, appErr.ErrQuoteExpired).Once()
+			},
+			expectedStatus: http.StatusGone,
+			expectedBody:   `{"error_message":"quote has expired"}`,
 		},
 		{
 			name:           "Invalid JSON",
@@ -54,6 +95,13 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error_message":"invalid amount"}`,
 		},
+		{
+			name:           "Too Many Decimal Places",
+			requestBody:    `{"source_account_id":1,"destination_account_id":2,"amount":"0.000001"}`,
+			mockSetup:      func() {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"amount cannot have more than 5 decimal places"}`,
+		},
 		{
 			name:        "Insufficient Balance",
 			requestBody: `{"source_account_id":1,"destination_account_id":2,"amount":"100.00"}`,
@@ -93,3 +141,585 @@ func TestTransactionHandler_CreateTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionHandler_ListAccountTransactions(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	isCredit := false
+	minAmount := models.NewMoney(5)
+
+	tests := []struct {
+		name           string
+		accountId      string
+		query          string
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:      "Success",
+			accountId: "1",
+			query:     "?limit=1&direction=debit&from=2025-01-01T00:00:00Z&min_amount=5&cursor=abc",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("ListAccountTransactions", mock.Anything, models.ListTransactionsArgs{
+					AccountId: 1,
+					From:      &from,
+					IsCredit:  &isCredit,
+					MinAmount: &minAmount,
+					Cursor:    "abc",
+					Limit:     1,
+				}).Return(models.ListTransactionsResponse{
+					Transactions: []models.TransactionResponse{{
+						Id:               5,
+						AccountId:        1,
+						Amount:           models.NewMoney(10),
+						CurrencyCode:     "USD",
+						AvailableBalance: models.NewMoney(90),
+						Reference:        "TXN-1",
+						CreatedAt:        createdAt,
+					}},
+					NextCursor: "next",
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"transactions":[{"id":5,"account_id":1,"amount":"10.00000","currency_code":"USD","is_credit":false,
+				"available_balance":"90.00000","reference":"TXN-1","created_at":"2025-03-01T12:00:00Z"}],"next_cursor":"next"}`,
+		},
+		{
+			name:           "Invalid Account ID",
+			accountId:      "abc",
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid account id"}`,
+		},
+		{
+			name:           "Invalid Direction",
+			accountId:      "1",
+			query:          "?direction=sideways",
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"direction must be either credit or debit"}`,
+		},
+		{
+			name:           "Invalid Date",
+			accountId:      "1",
+			query:          "?to=yesterday",
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid date format, expected RFC3339"}`,
+		},
+		{
+			name:           "Invalid Limit",
+			accountId:      "1",
+			query:          "?limit=ten",
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"limit must be between 1 and 100"}`,
+		},
+		{
+			name:           "Invalid Amount",
+			accountId:      "1",
+			query:          "?max_amount=lots",
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid amount"}`,
+		},
+		{
+			name:      "Account Not Found",
+			accountId: "9",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("ListAccountTransactions", mock.Anything, mock.Anything).
+					Return(models.ListTransactionsResponse{}, appErr.ErrAccountNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"account not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/accounts/"+tt.accountId+"/transactions"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"account_id": tt.accountId})
+			rec := httptest.NewRecorder()
+
+			handler.ListAccountTransactions(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestTransactionHandler_GetTransfer(t *testing.T) {
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("GetTransfer", mock.Anything, reference).Return(models.TransferResponse{
+					Reference:                   reference,
+					SourceAccountId:             1,
+					DestinationAccountId:        2,
+					Amount:                      models.NewMoney(100),
+					CurrencyCode:                "USD",
+					DestinationAmount:           models.NewMoney(100),
+					DestinationCurrencyCode:     "USD",
+					FxRate:                      models.OneExchangeRate,
+					SourceAvailableBalance:      models.NewMoney(900),
+					DestinationAvailableBalance: models.NewMoney(600),
+					CreatedAt:                   createdAt,
+					UpdatedAt:                   createdAt,
+					Legs:                        []models.TransactionResponse{},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"reference":"` + reference + `","source_account_id":1,"destination_account_id":2,"amount":"100.00000",
+				"currency_code":"USD","destination_amount":"100.00000","destination_currency_code":"USD","fx_rate":"1.0000000000",
+				"source_available_balance":"900.00000","destination_available_balance":"600.00000",
+				"created_at":"2025-03-01T12:00:00Z","updated_at":"2025-03-01T12:00:00Z","legs":[]}`,
+		},
+		{
+			name: "Not Found",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("GetTransfer", mock.Anything, reference).
+					Return(models.TransferResponse{}, appErr.ErrTransactionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error_message":"transaction not found"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodGet, "/transactions/"+reference, nil)
+			req = mux.SetURLVars(req, map[string]string{"reference": reference})
+			rec := httptest.NewRecorder()
+
+			handler.GetTransfer(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestTransactionHandler_CreateTransaction_IdempotencyKey(t *testing.T) {
+	body := `{"source_account_id":1,"destination_account_id":2,"amount":"100.00"}`
+	sameRequest := `{ "amount": "100.00", "destination_account_id": 2, "source_account_id": 1 }`
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		key            string
+		apiKey         *models.ApiKey
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Key And Request Hash Passed To Service",
+			requestBody: sameRequest,
+			key:         "retry-1",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(args models.CreateTransactionArgs) bool {
+					expected, _ := readIdempotency(idempotencyRequest("retry-1"), models.CreateTransactionRequest{
+						SourceAccountId:      1,
+						DestinationAccountId: 2,
+						Amount:               "100.00",
+					})
+					return args.Idempotency == expected && len(expected.RequestHash) == 64
+				})).Return(models.CreateTransactionResponse{SourceAccountId: 1, AvailableBalance: models.NewMoney(900), Reference: "TXN-1"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"source_account_id":1,"available_balance":"900.00000","reference":"TXN-1"}`,
+		},
+		{
+			name:        "Key Scoped To The Client Of The Request",
+			requestBody: body,
+			key:         "retry-1",
+			apiKey:      &models.ApiKey{Id: 12, ClientId: 7, Scopes: []models.ApiKeyScope{models.ScopeTransactionsWrite}},
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(args models.CreateTransactionArgs) bool {
+					return args.Idempotency.ClientId == 7 && args.Idempotency.Key == "retry-1"
+				})).Return(models.CreateTransactionResponse{SourceAccountId: 1, AvailableBalance: models.NewMoney(900), Reference: "TXN-1"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"source_account_id":1,"available_balance":"900.00000","reference":"TXN-1"}`,
+		},
+		{
+			name:           "Key Too Long",
+			requestBody:    body,
+			key:            strings.Repeat("k", 256),
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"idempotency key must be at most 255 characters"}`,
+		},
+		{
+			name:        "Key Reused With Different Body",
+			requestBody: body,
+			key:         "retry-1",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateTransaction", mock.Anything, mock.Anything).
+					Return(models.CreateTransactionResponse{}, appErr.ErrIdempotencyKeyReused).Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error_message":"idempotency key was already used with a different request"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/transactions", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set(idempotencyKeyHeader, tt.key)
+			if tt.apiKey != nil {
+				req = req.WithContext(auth.WithApiKey(req.Context(), *tt.apiKey))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.CreateTransaction(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+// idempotencyRequest builds a request carrying only the Idempotency-Key header
+func idempotencyRequest(key string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(idempotencyKeyHeader, key)
+	return req
+}
+
+func TestTransactionHandler_ReverseTransaction(t *testing.T) {
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+	partial := models.MustParseMoney("25.5")
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Full Reversal Without Body",
+			requestBody: "",
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("ReverseTransaction", mock.Anything, models.ReverseTransactionArgs{OriginalReference: reference}).
+					Return(models.ReverseTransactionResponse{
+						Reference:            "TXN-new",
+						OriginalReference:    reference,
+						SourceAccountId:      2,
+						DestinationAccountId: 1,
+						Amount:               models.NewMoney(100),
+						CurrencyCode:         "USD",
+						AvailableBalance:     models.NewMoney(500),
+						DebitedAmount:        models.NewMoney(100),
+						DebitedCurrencyCode:  "USD",
+					}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"reference":"TXN-new","original_reference":"` + reference + `","source_account_id":2,"destination_account_id":1,
+				"amount":"100.00000","currency_code":"USD","available_balance":"500.00000","debited_amount":"100.00000",
+				"debited_currency_code":"USD","remaining_reversible_amount":"0.00000"}`,
+		},
+		{
+			name:        "Partial Reversal",
+			requestBody: `{"amount":"25.5"}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("ReverseTransaction", mock.Anything, models.ReverseTransactionArgs{OriginalReference: reference, Amount: &partial}).
+					Return(models.ReverseTransactionResponse{}, appErr.ErrReversalAmountExceeded).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"reversal amount exceeds the amount left to reverse"}`,
+		},
+		{
+			name:           "Invalid Amount",
+			requestBody:    `{"amount":"abc"}`,
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid amount"}`,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"amount":`,
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid JSON format"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/transactions/"+reference+"/reverse", bytes.NewBufferString(tt.requestBody))
+			req = mux.SetURLVars(req, map[string]string{"reference": reference})
+			rec := httptest.NewRecorder()
+
+			handler.ReverseTransaction(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestTransactionHandler_CreateQuote(t *testing.T) {
+	expiresAt := time.Date(2025, 3, 1, 12, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Success",
+			requestBody: `{"source_account_id":1,"destination_account_id":3,"amount":"100"}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateQuote", mock.Anything, models.CreateQuoteArgs{
+					SourceAccountId:      1,
+					DestinationAccountId: 3,
+					Amount:               models.NewMoney(100),
+				}).Return(models.QuoteResponse{
+					QuoteId:                 "QTE-0f8fad5b-d9cb-469f-a165-70867728950e",
+					SourceAccountId:         1,
+					DestinationAccountId:    3,
+					SourceCurrencyCode:      "USD",
+					DestinationCurrencyCode: "EUR",
+					SourceAmount:            models.NewMoney(100),
+					DestinationAmount:       models.MustParseMoney("92.35"),
+					Rate:                    models.MustParseExchangeRate("0.9235"),
+					ExpiresAt:               expiresAt,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"quote_id":"QTE-0f8fad5b-d9cb-469f-a165-70867728950e","source_account_id":1,"destination_account_id":3,
+				"source_currency_code":"USD","destination_currency_code":"EUR","source_amount":"100.00000","destination_amount":"92.35000",
+				"rate":"0.9235000000","expires_at":"2025-03-01T12:01:00Z"}`,
+		},
+		{
+			name:           "Invalid Amount Format",
+			requestBody:    `{"source_account_id":1,"destination_account_id":3,"amount":"abc"}`,
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid amount"}`,
+		},
+		{
+			name:        "No Rate For The Pair",
+			requestBody: `{"source_account_id":1,"destination_account_id":3,"amount":"100"}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateQuote", mock.Anything, mock.Anything).
+					Return(models.QuoteResponse{}, appErr.ErrExchangeRateUnavailable).Once()
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedBody:   `{"error_message":"no exchange rate available for the currency pair"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/fx/quotes", strings.NewReader(tt.requestBody))
+			rec := httptest.NewRecorder()
+			handler.CreateQuote(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestTransactionHandler_CreateBatchTransaction(t *testing.T) {
+	failedIndex := 1
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockSetup      func(mockSvc *mocks.ITransactionService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "Best Effort",
+			requestBody: `{"mode":"best_effort","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"10"},{"source_account_id":1,"destination_account_id":3,"amount":"5"}]}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateBatchTransaction", mock.Anything, models.CreateBatchTransactionArgs{
+					Mode: models.BatchModeBestEffort,
+					Transfers: []models.CreateTransactionArgs{
+						{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(10)},
+						{SourceAccountId: 1, DestinationAccountId: 3, Amount: models.NewMoney(5)},
+					},
+				}).Return(models.CreateBatchTransactionResponse{
+					Mode:      models.BatchModeBestEffort,
+					Succeeded: 1,
+					Failed:    1,
+					Results: []models.BatchTransactionResult{
+						{Index: 0, StatusCode: http.StatusOK, Transaction: &models.CreateTransactionResponse{
+							SourceAccountId: 1, AvailableBalance: models.NewMoney(90), Reference: "TXN-1",
+						}},
+						{Index: 1, StatusCode: http.StatusNotFound, ErrorMessage: "receiver account not found"},
+					},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"mode":"best_effort","succeeded":1,"failed":1,"results":[
+				{"index":0,"status_code":200,"transaction":{"source_account_id":1,"available_balance":"90.00000","reference":"TXN-1"}},
+				{"index":1,"status_code":404,"error_message":"receiver account not found"}]}`,
+		},
+		{
+			name:        "Atomic Batch Rolled Back",
+			requestBody: `{"mode":"atomic","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"10"},{"source_account_id":1,"destination_account_id":2,"amount":"95"}]}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateBatchTransaction", mock.Anything, mock.Anything).
+					Return(models.CreateBatchTransactionResponse{FailedIndex: &failedIndex}, appErr.ErrInsufficientBalance).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"insufficient funds in sender account","failed_index":1}`,
+		},
+		{
+			name:        "Invalid Mode",
+			requestBody: `{"mode":"partial","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"10"}]}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateBatchTransaction", mock.Anything, mock.Anything).
+					Return(models.CreateBatchTransactionResponse{}, appErr.ErrInvalidBatchMode).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"batch mode must be either atomic or best_effort"}`,
+		},
+		{
+			name:           "Invalid Amount Format In An Atomic Batch",
+			requestBody:    `{"mode":"atomic","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"10"},{"source_account_id":1,"destination_account_id":2,"amount":"abc"}]}`,
+			mockSetup:      func(*mocks.ITransactionService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid amount","failed_index":1}`,
+		},
+		{
+			name:        "Invalid Amount Format In A Best Effort Batch",
+			requestBody: `{"mode":"best_effort","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"abc"},{"source_account_id":1,"destination_account_id":3,"amount":"5"}]}`,
+			mockSetup: func(mockSvc *mocks.ITransactionService) {
+				mockSvc.On("CreateBatchTransaction", mock.Anything, models.CreateBatchTransactionArgs{
+					Mode: models.BatchModeBestEffort,
+					Transfers: []models.CreateTransactionArgs{
+						{SourceAccountId: 1, DestinationAccountId: 2},
+						{SourceAccountId: 1, DestinationAccountId: 3, Amount: models.NewMoney(5)},
+					},
+					AmountErrors: []error{appErr.ErrInvalidAmount, nil},
+				}).Return(models.CreateBatchTransactionResponse{
+					Mode:      models.BatchModeBestEffort,
+					Succeeded: 1,
+					Failed:    1,
+					Results: []models.BatchTransactionResult{
+						{Index: 0, StatusCode: http.StatusBadRequest, ErrorMessage: "invalid amount"},
+						{Index: 1, StatusCode: http.StatusOK, Transaction: &models.CreateTransactionResponse{
+							SourceAccountId: 1, AvailableBalance: models.NewMoney(95), Reference: "TXN-2",
+						}},
+					},
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"mode":"best_effort","succeeded":1,"failed":1,"results":[
+				{"index":0,"status_code":400,"error_message":"invalid amount"},
+				{"index":1,"status_code":200,"transaction":{"source_account_id":1,"available_balance":"95.00000","reference":"TXN-2"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := mocks.NewITransactionService(t)
+			tt.mockSetup(mockSvc)
+			handler := NewTransactionHandler(mockSvc)
+
+			req := httptest.NewRequest(http.MethodPost, "/transactions/batch", strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			handler.CreateBatchTransaction(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+// TestTransactionHandler_ZeroAmount runs the handlers against the real service, which must turn a zero amount away
+// before any account is read
+func TestTransactionHandler_ZeroAmount(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		requestBody  string
+		serve        func(*TransactionHandler, http.ResponseWriter, *http.Request)
+		expectedBody string
+	}{
+		{
+			name:         "transfer",
+			path:         "/transactions",
+			requestBody:  `{"source_account_id":1,"destination_account_id":2,"amount":"0"}`,
+			serve:        (*TransactionHandler).CreateTransaction,
+			expectedBody: `{"error_message":"invalid amount"}`,
+		},
+		{
+			name:         "atomic batch",
+			path:         "/transactions/batch",
+			requestBody:  `{"mode":"atomic","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"0"}]}`,
+			serve:        (*TransactionHandler).CreateBatchTransaction,
+			expectedBody: `{"error_message":"invalid amount","failed_index":0}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := service.NewTransactionService(repoMocks.NewITransactionRepository(t), repoMocks.NewIAccountRepository(t), nil, nil, time.Minute, nil, nil)
+			handler := NewTransactionHandler(svc)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.requestBody))
+			rr := httptest.NewRecorder()
+
+			tt.serve(handler, rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	t.Run("best effort batch", func(t *testing.T) {
+		svc := service.NewTransactionService(repoMocks.NewITransactionRepository(t), repoMocks.NewIAccountRepository(t), nil, nil, time.Minute, nil, nil)
+		handler := NewTransactionHandler(svc)
+
+		req := httptest.NewRequest(http.MethodPost, "/transactions/batch",
+			strings.NewReader(`{"mode":"best_effort","transfers":[{"source_account_id":1,"destination_account_id":2,"amount":"0"}]}`))
+		rr := httptest.NewRecorder()
+
+		handler.CreateBatchTransaction(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"mode":"best_effort","succeeded":0,"failed":1,"results":[{"index":0,"status_code":400,"error_message":"invalid amount"}]}`, rr.Body.String())
+	})
+}
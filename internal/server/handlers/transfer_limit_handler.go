@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type TransferLimitHandler struct {
+	service service.ITransferLimitService
+}
+
+func NewTransferLimitHandler(service service.ITransferLimitService) *TransferLimitHandler {
+	return &TransferLimitHandler{service: service}
+}
+
+// GetTransferLimits handles GET /admin/accounts/{account_id}/transfer-limits
+func (lh *TransferLimitHandler) GetTransferLimits(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		lh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	resp, err := lh.service.GetTransferLimits(r.Context(), accountID)
+	if err != nil {
+		lh.sendErrorResponse(w, err)
+		return
+	}
+
+	lh.sendSuccessResponse(w, resp)
+}
+
+// SetTransferLimits handles PUT /admin/accounts/{account_id}/transfer-limits
+func (lh *TransferLimitHandler) SetTransferLimits(w http.ResponseWriter, r *http.Request) {
+	accountID, err := strconv.ParseInt(mux.Vars(r)["account_id"], 10, 64)
+	if err != nil {
+		lh.sendErrorResponse(w, appErr.ErrInvalidAccountId)
+		return
+	}
+
+	var req models.TransferLimits
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		lh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := lh.service.SetTransferLimits(r.Context(), accountID, req)
+	if err != nil {
+		lh.sendErrorResponse(w, err)
+		return
+	}
+
+	lh.sendSuccessResponse(w, resp)
+}
+
+// GetCurrencyTransferLimits handles GET /admin/transfer-limits/{currency_code}
+func (lh *TransferLimitHandler) GetCurrencyTransferLimits(w http.ResponseWriter, r *http.Request) {
+	resp, err := lh.service.GetCurrencyTransferLimits(r.Context(), mux.Vars(r)["currency_code"])
+	if err != nil {
+		lh.sendErrorResponse(w, err)
+		return
+	}
+
+	lh.sendSuccessResponse(w, resp)
+}
+
+// SetCurrencyTransferLimits handles PUT /admin/transfer-limits/{currency_code}
+func (lh *TransferLimitHandler) SetCurrencyTransferLimits(w http.ResponseWriter, r *http.Request) {
+	var req models.TransferLimits
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		lh.sendErrorResponse(w, appErr.ErrInvalidJsonFormat)
+		return
+	}
+
+	resp, err := lh.service.SetCurrencyTransferLimits(r.Context(), mux.Vars(r)["currency_code"], req)
+	if err != nil {
+		lh.sendErrorResponse(w, err)
+		return
+	}
+
+	lh.sendSuccessResponse(w, resp)
+}
+
+// sendErrorResponse to build an error response
+func (lh *TransferLimitHandler) sendErrorResponse(w http.ResponseWriter, err error) {
+	statusCode, err := appErr.HTTPStatus(err)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{ErrorMessage: err.Error()})
+}
+
+// sendSuccessResponse to build success response
+func (lh *TransferLimitHandler) sendSuccessResponse(w http.ResponseWriter, resp interface{}) {
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
+}
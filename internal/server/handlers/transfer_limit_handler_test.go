@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransferLimitHandler_SetTransferLimits(t *testing.T) {
+	maxAmount, overrideAmount := models.NewMoney(10000), models.NewMoney(25000)
+	maxHourlyCount := 5
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(mockService *mocks.ITransferLimitService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "success",
+			body: `{"max_amount":"25000","max_hourly_count":5}`,
+			mockSetup: func(mockService *mocks.ITransferLimitService) {
+				overrides := models.TransferLimits{MaxAmount: &overrideAmount, MaxHourlyCount: &maxHourlyCount}
+				mockService.On("SetTransferLimits", mock.Anything, int64(7), overrides).Return(models.TransferLimitsResponse{
+					AccountId: 7,
+					Limits:    overrides,
+					Overrides: overrides,
+				}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"account_id":7,
+				"limits":{"max_amount":"25000.00000","max_daily_amount":null,"max_hourly_count":5},
+				"overrides":{"max_amount":"25000.00000","max_daily_amount":null,"max_hourly_count":5}}`,
+		},
+		{
+			name:           "malformed amount",
+			body:           `{"max_amount":"lots"}`,
+			mockSetup:      func(*mocks.ITransferLimitService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"invalid JSON format"}`,
+		},
+		{
+			name: "invalid limits",
+			body: `{"max_hourly_count":0}`,
+			mockSetup: func(mockService *mocks.ITransferLimitService) {
+				mockService.On("SetTransferLimits", mock.Anything, int64(7), mock.Anything).
+					Return(models.TransferLimitsResponse{}, appErr.ErrInvalidTransferLimits).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error_message":"transfer limits must be positive"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mocks.NewITransferLimitService(t)
+			tt.mockSetup(mockService)
+			handler := NewTransferLimitHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/accounts/7/transfer-limits", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+			rr := httptest.NewRecorder()
+
+			handler.SetTransferLimits(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+
+	t.Run("get", func(t *testing.T) {
+		mockService := mocks.NewITransferLimitService(t)
+		mockService.On("GetTransferLimits", mock.Anything, int64(7)).Return(models.TransferLimitsResponse{
+			AccountId: 7,
+			Limits:    models.TransferLimits{MaxAmount: &maxAmount},
+		}, nil).Once()
+		handler := NewTransferLimitHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/accounts/7/transfer-limits", nil)
+		req = mux.SetURLVars(req, map[string]string{"account_id": "7"})
+		rr := httptest.NewRecorder()
+
+		handler.GetTransferLimits(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"account_id":7,"limits":{"max_amount":"10000.00000","max_daily_amount":null,"max_hourly_count":null},
+			"overrides":{"max_amount":null,"max_daily_amount":null,"max_hourly_count":null}}`, rr.Body.String())
+	})
+}
+
+func TestTransferLimitHandler_CurrencyTransferLimits(t *testing.T) {
+	maxAmount := models.NewMoney(1500000)
+	limits := models.TransferLimits{MaxAmount: &maxAmount}
+
+	t.Run("set", func(t *testing.T) {
+		mockService := mocks.NewITransferLimitService(t)
+		mockService.On("SetCurrencyTransferLimits", mock.Anything, "JPY", limits).
+			Return(models.CurrencyTransferLimitsResponse{CurrencyCode: "JPY", Limits: limits}, nil).Once()
+		handler := NewTransferLimitHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/admin/transfer-limits/JPY", strings.NewReader(`{"max_amount":"1500000"}`))
+		req = mux.SetURLVars(req, map[string]string{"currency_code": "JPY"})
+		rr := httptest.NewRecorder()
+
+		handler.SetCurrencyTransferLimits(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"currency_code":"JPY","limits":{"max_amount":"1500000.00000","max_daily_amount":null,"max_hourly_count":null}}`, rr.Body.String())
+	})
+
+	t.Run("unknown currency", func(t *testing.T) {
+		mockService := mocks.NewITransferLimitService(t)
+		mockService.On("GetCurrencyTransferLimits", mock.Anything, "XYZ").
+			Return(models.CurrencyTransferLimitsResponse{}, appErr.ErrUnsupportedCurrency).Once()
+		handler := NewTransferLimitHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/admin/transfer-limits/XYZ", nil)
+		req = mux.SetURLVars(req, map[string]string{"currency_code": "XYZ"})
+		rr := httptest.NewRecorder()
+
+		handler.GetCurrencyTransferLimits(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.JSONEq(t, `{"error_message":"unsupported currency code"}`, rr.Body.String())
+	})
+}
@@ -2,28 +2,41 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"github.com/bhuvi1021/TripleA/internal/auth"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/repository"
 	"log"
-	"strconv"
 	"time"
 )
 
 type AccountService struct {
-	accountRepo repository.IAccountRepository
+	accountRepo     repository.IAccountRepository
+	idempotencyRepo repository.IIdempotencyRepository
+	// fundingAccountIds are the accounts that opening balances are paid from, by currency
+	fundingAccountIds map[string]int64
 }
 
-func NewAccountService(repo repository.IAccountRepository) *AccountService {
-	return &AccountService{accountRepo: repo}
+func NewAccountService(repo repository.IAccountRepository, idempotencyRepo repository.IIdempotencyRepository, fundingAccountIds map[string]int64) *AccountService {
+	return &AccountService{accountRepo: repo, idempotencyRepo: idempotencyRepo, fundingAccountIds: fundingAccountIds}
 }
 
 type IAccountService interface {
 	CreateAccount(ctx context.Context, req models.CreateAccountRequest) error
 	GetAccount(ctx context.Context, id int64) (*models.Account, error)
+	GetBalanceAsOf(ctx context.Context, id int64, asOf time.Time) (models.BalanceAsOfResponse, error)
+	FreezeAccount(ctx context.Context, id int64) (*models.Account, error)
+	UnfreezeAccount(ctx context.Context, id int64) (*models.Account, error)
+	CloseAccount(ctx context.Context, args models.CloseAccountArgs) (models.CloseAccountResponse, error)
+	SetOverdraftLimit(ctx context.Context, id int64, limit models.Money) (*models.Account, error)
+	SetAccountOwner(ctx context.Context, id int64, ownerClientId int64) (*models.Account, error)
 }
 
-// CreateAccount is a service method that creates the account with initial balance
+// CreateAccount is a service method that creates the account with initial balance.
+// The account is held in USD unless another supported currency is given. Once funding accounts are configured,
+// the initial balance is paid from the funding account of the currency under its own transaction reference.
+// The account belongs to the client of the API key creating it
 func (s *AccountService) CreateAccount(ctx context.Context, req models.CreateAccountRequest) error {
 	fName := "AccountService.CreateAccount"
 	if req.AccountId <= 0 {
@@ -31,16 +44,47 @@ func (s *AccountService) CreateAccount(ctx context.Context, req models.CreateAcc
 		return appErr.ErrInvalidAccountId
 	}
 
-	initialBalance, err := parseBalance(req.InitialBalance)
+	initialBalance, err := models.ParseMoney(req.InitialBalance)
 	if err != nil {
 		log.Printf("[%s] failed to parse balance: %v", fName, err)
-		return appErr.ErrInvalidAmount
+		return err
 	}
 
-	if initialBalance < 0 {
+	if initialBalance.IsNegative() {
 		return appErr.ErrNegativeBalance
 	}
 
+	if req.CurrencyCode == "" {
+		req.CurrencyCode = models.DefaultCurrencyCode
+	}
+	currency, err := models.LookupCurrency(req.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to validate currency %q: %v", fName, req.CurrencyCode, err)
+		return err
+	}
+	if !currency.Allows(initialBalance) {
+		return appErr.ErrInvalidCurrencyPrecision
+	}
+	// An opening balance is money the client could move out right away, which only admin clients may hand out
+	if _, ok := restrictedApiKey(ctx); ok && initialBalance.IsPositive() {
+		return appErr.ErrInsufficientScope
+	}
+
+	// a retried request must not fail with account already exists
+	if req.Idempotency.Key != "" {
+		record, err := s.idempotencyRepo.Get(req.Idempotency, models.IdempotencyEndpointCreateAccount)
+		if err != nil {
+			log.Printf("[%s] failed due to: %v", fName, err)
+			return appErr.ErrInternal
+		}
+		if record != nil && record.IsCompleted() {
+			if record.RequestHash != req.Idempotency.RequestHash {
+				return appErr.ErrIdempotencyKeyReused
+			}
+			return nil
+		}
+	}
+
 	account, err := s.accountRepo.GetByAccountId(req.AccountId)
 	if err != nil && err != appErr.ErrAccountNotFound {
 		log.Printf("[%s] failed due to: %v", fName, appErr.ErrInternal)
@@ -52,21 +96,213 @@ func (s *AccountService) CreateAccount(ctx context.Context, req models.CreateAcc
 		return appErr.ErrAccountExists
 	}
 
+	args := models.CreateAccountArgs{Idempotency: req.Idempotency}
+	if len(s.fundingAccountIds) > 0 && initialBalance.IsPositive() {
+		fundingAccountId, ok := s.fundingAccountIds[currency.Code]
+		if !ok {
+			log.Printf("[%s] no funding account for %s", fName, currency.Code)
+			return appErr.ErrFundingAccountUnavailable
+		}
+		args.FundingAccountId = fundingAccountId
+		args.Reference = generateTransactionRef()
+	}
+
 	timeNow := time.Now().UTC()
-	return s.accountRepo.CreateAccount(models.Account{
-		AccountId: req.AccountId,
-		Balance:   initialBalance,
-		CreatedAt: timeNow,
-		UpdatedAt: timeNow,
-	})
+	args.Account = models.Account{
+		AccountId:    req.AccountId,
+		Balance:      initialBalance,
+		CurrencyCode: currency.Code,
+		CreatedAt:    timeNow,
+		UpdatedAt:    timeNow,
+	}
+	if apiKey, ok := auth.ApiKeyFromContext(ctx); ok {
+		args.Account.OwnerClientId = sql.NullInt64{Int64: apiKey.ClientId, Valid: true}
+	}
+	return s.accountRepo.CreateAccount(args)
 }
 
 // GetAccount a service method that gets the details of the account
 func (s *AccountService) GetAccount(ctx context.Context, accountID int64) (*models.Account, error) {
-	return s.accountRepo.GetByAccountId(accountID)
+	account, err := s.accountRepo.GetByAccountId(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
 }
 
-// parseBalance used for parsing the balance passed in payload
-func parseBalance(balanceStr string) (float64, error) {
-	return strconv.ParseFloat(balanceStr, 64)
+// GetBalanceAsOf is a service method that returns the balance the account had at asOf, reconstructed from its ledger.
+// asOf may not be in the future nor before the account was created
+func (s *AccountService) GetBalanceAsOf(ctx context.Context, accountID int64, asOf time.Time) (models.BalanceAsOfResponse, error) {
+	fName := "AccountService.GetBalanceAsOf"
+	asOf = asOf.UTC()
+	if asOf.After(time.Now()) {
+		return models.BalanceAsOfResponse{}, appErr.ErrInvalidAsOf
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountID)
+	if err != nil {
+		return models.BalanceAsOfResponse{}, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return models.BalanceAsOfResponse{}, err
+	}
+	if asOf.Before(account.CreatedAt) {
+		return models.BalanceAsOfResponse{}, appErr.ErrAccountNotCreatedAsOf
+	}
+
+	balance, err := s.accountRepo.GetBalanceAsOf(accountID, asOf)
+	if err != nil {
+		log.Printf("[%s] failed to read the balance of account %d at %s: %v", fName, accountID, asOf, err)
+		return models.BalanceAsOfResponse{}, err
+	}
+	return models.BalanceAsOfResponse{AccountId: accountID, CurrencyCode: account.CurrencyCode, AsOf: asOf, Balance: balance}, nil
+}
+
+// FreezeAccount is a service method that stops money from leaving an active account. The account can still be credited
+func (s *AccountService) FreezeAccount(ctx context.Context, accountID int64) (*models.Account, error) {
+	return s.changeStatus(ctx, accountID, models.AccountStatusActive, models.AccountStatusFrozen)
+}
+
+// UnfreezeAccount is a service method that makes a frozen account active again. Only admin clients may unfreeze, so
+// that a client cannot lift a freeze imposed by an operator
+func (s *AccountService) UnfreezeAccount(ctx context.Context, accountID int64) (*models.Account, error) {
+	if _, ok := restrictedApiKey(ctx); ok {
+		return nil, appErr.ErrInsufficientScope
+	}
+	return s.changeStatus(ctx, accountID, models.AccountStatusFrozen, models.AccountStatusActive)
+}
+
+// CloseAccount is a service method that closes an account for good. An account holding money can only be closed
+// when a sweep account in the same currency is given to receive the remaining balance
+func (s *AccountService) CloseAccount(ctx context.Context, args models.CloseAccountArgs) (models.CloseAccountResponse, error) {
+	fName := "AccountService.CloseAccount"
+	if args.AccountId <= 0 {
+		return models.CloseAccountResponse{}, appErr.ErrInvalidAccountId
+	}
+
+	account, err := s.accountRepo.GetByAccountId(args.AccountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, args.AccountId, err)
+		return models.CloseAccountResponse{}, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return models.CloseAccountResponse{}, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return models.CloseAccountResponse{}, appErr.ErrAccountClosed
+	}
+
+	if args.SweepAccountId != 0 {
+		if args.SweepAccountId < 0 || args.SweepAccountId == args.AccountId {
+			return models.CloseAccountResponse{}, appErr.ErrInvalidSweepAccount
+		}
+		sweepAccount, err := s.accountRepo.GetByAccountId(args.SweepAccountId)
+		if err != nil {
+			if err == appErr.ErrAccountNotFound {
+				return models.CloseAccountResponse{}, appErr.ErrInvalidSweepAccount
+			}
+			log.Printf("[%s] failed to get sweep account %d: %v", fName, args.SweepAccountId, err)
+			return models.CloseAccountResponse{}, err
+		}
+		if sweepAccount.CurrencyCode != account.CurrencyCode || !sweepAccount.Status.AllowsCredit() {
+			return models.CloseAccountResponse{}, appErr.ErrInvalidSweepAccount
+		}
+		args.Reference = generateTransactionRef()
+	}
+
+	return s.accountRepo.CloseAccount(args)
+}
+
+// SetOverdraftLimit is a service method that lets the balance of an account go below zero by up to limit.
+// Lowering the limit under what is already overdrawn is allowed; it only stops further debits
+func (s *AccountService) SetOverdraftLimit(ctx context.Context, accountID int64, limit models.Money) (*models.Account, error) {
+	fName := "AccountService.SetOverdraftLimit"
+	if accountID <= 0 {
+		return nil, appErr.ErrInvalidAccountId
+	}
+	if limit.IsNegative() {
+		return nil, appErr.ErrInvalidOverdraftLimit
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountID)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountID, err)
+		return nil, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return nil, appErr.ErrAccountClosed
+	}
+	currency, err := models.LookupCurrency(account.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to look up currency %q: %v", fName, account.CurrencyCode, err)
+		return nil, appErr.ErrInternal
+	}
+	if !currency.Allows(limit) {
+		return nil, appErr.ErrInvalidCurrencyPrecision
+	}
+
+	if err := s.accountRepo.SetOverdraftLimit(accountID, limit); err != nil {
+		log.Printf("[%s] failed to set overdraft limit of account %d: %v", fName, accountID, err)
+		return nil, err
+	}
+	account.OverdraftLimit = limit
+	return account, nil
+}
+
+// SetAccountOwner is a service method that hands an account over to the client ownerClientId, which must have a key
+// that is not revoked. Accounts opened before API keys existed are given their owner this way
+func (s *AccountService) SetAccountOwner(ctx context.Context, accountID int64, ownerClientId int64) (*models.Account, error) {
+	fName := "AccountService.SetAccountOwner"
+	if accountID <= 0 {
+		return nil, appErr.ErrInvalidAccountId
+	}
+	if ownerClientId <= 0 {
+		return nil, appErr.ErrInvalidOwnerClientId
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountID)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountID, err)
+		return nil, err
+	}
+
+	if err := s.accountRepo.SetOwner(accountID, ownerClientId); err != nil {
+		log.Printf("[%s] failed to set owner of account %d to client %d: %v", fName, accountID, ownerClientId, err)
+		return nil, err
+	}
+	account.OwnerClientId = sql.NullInt64{Int64: ownerClientId, Valid: true}
+	return account, nil
+}
+
+// changeStatus moves an account from one status to another and returns the updated account
+func (s *AccountService) changeStatus(ctx context.Context, accountID int64, from, to models.AccountStatus) (*models.Account, error) {
+	fName := "AccountService.changeStatus"
+	if accountID <= 0 {
+		return nil, appErr.ErrInvalidAccountId
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountID)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountID, err)
+		return nil, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return nil, appErr.ErrAccountClosed
+	}
+	if account.Status != from {
+		return nil, appErr.ErrInvalidStatusTransition
+	}
+
+	if err := s.accountRepo.SetStatus(accountID, from, to); err != nil {
+		log.Printf("[%s] failed to set status of account %d to %s: %v", fName, accountID, to, err)
+		return nil, err
+	}
+	account.Status = to
+	return account, nil
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,7 +16,9 @@ import (
 
 func TestAccountService_CreateAccount(t *testing.T) {
 	mockRepo := new(mocks.IAccountRepository)
-	service := NewAccountService(mockRepo)
+	mockIdemRepo := new(mocks.IIdempotencyRepository)
+	service := NewAccountService(mockRepo, mockIdemRepo, nil)
+	idem := models.Idempotency{Key: "key-1", RequestHash: "hash-1"}
 
 	ctx := context.Background()
 
@@ -34,11 +37,92 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			setupMocks: func() {
 				mockRepo.On("GetByAccountId", int64(101)).
 					Return(nil, appErr.ErrAccountNotFound).Once()
-				mockRepo.On("CreateAccount", mock.AnythingOfType("models.Account")).
-					Return(nil).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.Account.CurrencyCode == models.DefaultCurrencyCode && args.Idempotency == models.Idempotency{} && args.FundingAccountId == 0
+				})).Return(nil).Once()
 			},
 			expectedErr: nil,
 		},
+		{
+			name: "Success With Idempotency Key",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+				Idempotency:    idem,
+			},
+			setupMocks: func() {
+				mockIdemRepo.On("Get", idem, models.IdempotencyEndpointCreateAccount).
+					Return(nil, nil).Once()
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.Idempotency == idem
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Replayed Idempotency Key",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+				Idempotency:    idem,
+			},
+			setupMocks: func() {
+				mockIdemRepo.On("Get", idem, models.IdempotencyEndpointCreateAccount).
+					Return(&models.IdempotencyRecord{RequestHash: idem.RequestHash, StatusCode: 200}, nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Idempotency Key Reused With Different Body",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+				Idempotency:    idem,
+			},
+			setupMocks: func() {
+				mockIdemRepo.On("Get", idem, models.IdempotencyEndpointCreateAccount).
+					Return(&models.IdempotencyRecord{RequestHash: "other-hash", StatusCode: 200}, nil).Once()
+			},
+			expectedErr: appErr.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "Success With Currency",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500",
+				CurrencyCode:   "jpy",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.Account.CurrencyCode == "JPY"
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Unsupported Currency",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500",
+				CurrencyCode:   "XYZ",
+			},
+			setupMocks:  func() {},
+			expectedErr: appErr.ErrUnsupportedCurrency,
+		},
+		{
+			name: "Balance Finer Than Currency Minor Unit",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.5",
+				CurrencyCode:   "JPY",
+			},
+			setupMocks:  func() {},
+			expectedErr: appErr.ErrInvalidCurrencyPrecision,
+		},
 		{
 			name: "Invalid Account ID",
 			req: models.CreateAccountRequest{
@@ -46,7 +130,7 @@ func TestAccountService_CreateAccount(t *testing.T) {
 				InitialBalance: "100.00",
 			},
 			setupMocks:  func() {},
-			expectedErr: appErr.ErrAccountNotFound,
+			expectedErr: appErr.ErrInvalidAccountId,
 		},
 		{
 			name: "Invalid Balance Format",
@@ -57,6 +141,15 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			setupMocks:  func() {},
 			expectedErr: appErr.ErrInvalidAmount,
 		},
+		{
+			name: "Too Many Decimal Places",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "10.123456",
+			},
+			setupMocks:  func() {},
+			expectedErr: appErr.ErrInvalidAmountScale,
+		},
 		{
 			name: "Negative Balance",
 			req: models.CreateAccountRequest{
@@ -98,19 +191,231 @@ func TestAccountService_CreateAccount(t *testing.T) {
 			err := service.CreateAccount(ctx, tt.req)
 			assert.Equal(t, tt.expectedErr, err)
 			mockRepo.AssertExpectations(t)
+			mockIdemRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_CreateAccount_Funded(t *testing.T) {
+	mockRepo := new(mocks.IAccountRepository)
+	service := NewAccountService(mockRepo, nil, map[string]int64{"USD": 1})
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		req         models.CreateAccountRequest
+		service     *AccountService
+		setupMocks  func()
+		expectedErr error
+	}{
+		{
+			name: "Opening Balance Paid From Funding Account",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.FundingAccountId == 1 && strings.HasPrefix(args.Reference, transactionRefPrefix) &&
+						args.Account.Balance.Cmp(models.MustParseMoney("500")) == 0
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Empty Account Is Not Funded",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "0",
+				CurrencyCode:   "EUR",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.FundingAccountId == 0 && args.Reference == ""
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "No Funding Account For Currency",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+				CurrencyCode:   "EUR",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+		{
+			name: "Opening Balance Paid By An Admin Client",
+			ctx:  clientContext(8, models.ScopeAdmin),
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.FundingAccountId == 1 && args.Account.IsOwnedBy(8)
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Opening Balance Asked By A Client That Is Not Admin",
+			ctx:  clientContext(7, models.ScopeAccountsWrite),
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+			},
+			setupMocks:  func() {},
+			expectedErr: appErr.ErrInsufficientScope,
+		},
+		{
+			name: "Opening Balance Asked By A Client That Is Not Admin Without Funding Accounts",
+			ctx:  clientContext(7, models.ScopeAccountsWrite),
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+			},
+			service:     NewAccountService(mockRepo, nil, nil),
+			setupMocks:  func() {},
+			expectedErr: appErr.ErrInsufficientScope,
+		},
+		{
+			name: "Empty Account Opened By A Client That Is Not Admin",
+			ctx:  clientContext(7, models.ScopeAccountsWrite),
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "0",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+					return args.FundingAccountId == 0 && args.Account.IsOwnedBy(7)
+				})).Return(nil).Once()
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Funding Account Cannot Pay",
+			req: models.CreateAccountRequest{
+				AccountId:      101,
+				InitialBalance: "500.00",
+			},
+			setupMocks: func() {
+				mockRepo.On("GetByAccountId", int64(101)).
+					Return(nil, appErr.ErrAccountNotFound).Once()
+				mockRepo.On("CreateAccount", mock.AnythingOfType("models.CreateAccountArgs")).
+					Return(appErr.ErrFundingAccountUnavailable).Once()
+			},
+			expectedErr: appErr.ErrFundingAccountUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+			if tt.ctx == nil {
+				tt.ctx = ctx
+			}
+			if tt.service == nil {
+				tt.service = service
+			}
+			err := tt.service.CreateAccount(tt.ctx, tt.req)
+			assert.Equal(t, tt.expectedErr, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAccountService_GetBalanceAsOf(t *testing.T) {
+	createdAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	asOf := time.Date(2025, 3, 31, 23, 59, 0, 0, time.UTC)
+	account := &models.Account{AccountId: 7, CurrencyCode: "EUR", CreatedAt: createdAt}
+
+	tests := []struct {
+		name        string
+		asOf        time.Time
+		setupMocks  func(*mocks.IAccountRepository)
+		expected    models.BalanceAsOfResponse
+		expectedErr error
+	}{
+		{
+			name: "balance reconstructed from the ledger",
+			asOf: asOf,
+			setupMocks: func(mockRepo *mocks.IAccountRepository) {
+				mockRepo.On("GetByAccountId", int64(7)).Return(account, nil).Once()
+				mockRepo.On("GetBalanceAsOf", int64(7), asOf).Return(models.NewMoney(40), nil).Once()
+			},
+			expected: models.BalanceAsOfResponse{AccountId: 7, CurrencyCode: "EUR", AsOf: asOf, Balance: models.NewMoney(40)},
+		},
+		{
+			name: "as_of in another time zone",
+			asOf: asOf.In(time.FixedZone("CEST", 2*60*60)),
+			setupMocks: func(mockRepo *mocks.IAccountRepository) {
+				mockRepo.On("GetByAccountId", int64(7)).Return(account, nil).Once()
+				mockRepo.On("GetBalanceAsOf", int64(7), asOf).Return(models.NewMoney(40), nil).Once()
+			},
+			expected: models.BalanceAsOfResponse{AccountId: 7, CurrencyCode: "EUR", AsOf: asOf, Balance: models.NewMoney(40)},
+		},
+		{
+			name:        "as_of in the future",
+			asOf:        time.Now().Add(time.Hour),
+			setupMocks:  func(*mocks.IAccountRepository) {},
+			expectedErr: appErr.ErrInvalidAsOf,
+		},
+		{
+			name: "account created after as_of",
+			asOf: createdAt.Add(-time.Second),
+			setupMocks: func(mockRepo *mocks.IAccountRepository) {
+				mockRepo.On("GetByAccountId", int64(7)).Return(account, nil).Once()
+			},
+			expectedErr: appErr.ErrAccountNotCreatedAsOf,
+		},
+		{
+			name: "account not found",
+			asOf: asOf,
+			setupMocks: func(mockRepo *mocks.IAccountRepository) {
+				mockRepo.On("GetByAccountId", int64(7)).Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.IAccountRepository)
+			tt.setupMocks(mockRepo)
+
+			resp, err := NewAccountService(mockRepo, nil, nil).GetBalanceAsOf(context.Background(), 7, tt.asOf)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, resp)
+			mockRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func TestAccountService_GetAccount(t *testing.T) {
 	mockRepo := new(mocks.IAccountRepository)
-	service := NewAccountService(mockRepo)
+	service := NewAccountService(mockRepo, new(mocks.IIdempotencyRepository), nil)
 
 	ctx := context.Background()
 
 	account := &models.Account{
 		AccountId: 123,
-		Balance:   1000.0,
+		Balance:   models.NewMoney(1000),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -121,4 +426,331 @@ func TestAccountService_GetAccount(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, account, result)
 	mockRepo.AssertExpectations(t)
+
+	t.Run("owned by another client", func(t *testing.T) {
+		result, err := service.GetAccount(clientContext(7, models.ScopeAccountsRead), 123)
+		assert.Equal(t, appErr.ErrAccountForbidden, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestAccountService_CreateAccount_Owner(t *testing.T) {
+	mockRepo := mocks.NewIAccountRepository(t)
+	service := NewAccountService(mockRepo, mocks.NewIIdempotencyRepository(t), nil)
+
+	mockRepo.On("GetByAccountId", int64(123)).Return(nil, appErr.ErrAccountNotFound).Once()
+	mockRepo.On("CreateAccount", mock.MatchedBy(func(args models.CreateAccountArgs) bool {
+		return args.Account.IsOwnedBy(7)
+	})).Return(nil).Once()
+
+	err := service.CreateAccount(clientContext(7, models.ScopeAccountsWrite), models.CreateAccountRequest{AccountId: 123, InitialBalance: "0"})
+	assert.NoError(t, err)
+}
+
+func TestAccountService_SetAccountOwner(t *testing.T) {
+	tests := []struct {
+		name          string
+		accountId     int64
+		ownerClientId int64
+		setupMocks    func(*mocks.IAccountRepository)
+		expectedErr   error
+	}{
+		{
+			name:          "owner set",
+			accountId:     123,
+			ownerClientId: 7,
+			setupMocks: func(repo *mocks.IAccountRepository) {
+				repo.On("GetByAccountId", int64(123)).Return(&models.Account{AccountId: 123}, nil).Once()
+				repo.On("SetOwner", int64(123), int64(7)).Return(nil).Once()
+			},
+		},
+		{
+			name:          "invalid account id",
+			accountId:     0,
+			ownerClientId: 7,
+			expectedErr:   appErr.ErrInvalidAccountId,
+		},
+		{
+			name:          "invalid owner client id",
+			accountId:     123,
+			ownerClientId: 0,
+			expectedErr:   appErr.ErrInvalidOwnerClientId,
+		},
+		{
+			name:          "account not found",
+			accountId:     123,
+			ownerClientId: 7,
+			setupMocks: func(repo *mocks.IAccountRepository) {
+				repo.On("GetByAccountId", int64(123)).Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+		{
+			name:          "unknown client",
+			accountId:     123,
+			ownerClientId: 7,
+			setupMocks: func(repo *mocks.IAccountRepository) {
+				repo.On("GetByAccountId", int64(123)).Return(&models.Account{AccountId: 123}, nil).Once()
+				repo.On("SetOwner", int64(123), int64(7)).Return(appErr.ErrClientNotFound).Once()
+			},
+			expectedErr: appErr.ErrClientNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewIAccountRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+
+			account, err := NewAccountService(repo, mocks.NewIIdempotencyRepository(t), nil).SetAccountOwner(context.Background(), tt.accountId, tt.ownerClientId)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.True(t, account.IsOwnedBy(tt.ownerClientId))
+			}
+		})
+	}
+}
+
+func TestAccountService_FreezeAndUnfreezeAccount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		freeze        bool
+		status        models.AccountStatus
+		getErr        error
+		setErr        error
+		expectedError error
+		expectSet     bool
+	}{
+		{name: "freeze active account", freeze: true, status: models.AccountStatusActive, expectSet: true},
+		{name: "unfreeze frozen account", freeze: false, status: models.AccountStatusFrozen, expectSet: true},
+		{name: "freeze frozen account", freeze: true, status: models.AccountStatusFrozen, expectedError: appErr.ErrInvalidStatusTransition},
+		{name: "unfreeze active account", freeze: false, status: models.AccountStatusActive, expectedError: appErr.ErrInvalidStatusTransition},
+		{name: "freeze closed account", freeze: true, status: models.AccountStatusClosed, expectedError: appErr.ErrAccountClosed},
+		{name: "account not found", freeze: true, getErr: appErr.ErrAccountNotFound, expectedError: appErr.ErrAccountNotFound},
+		{
+			name:          "status changed concurrently",
+			freeze:        true,
+			status:        models.AccountStatusActive,
+			setErr:        appErr.ErrInvalidStatusTransition,
+			expectedError: appErr.ErrInvalidStatusTransition,
+			expectSet:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewIAccountRepository(t)
+			service := NewAccountService(mockRepo, nil, nil)
+
+			var account *models.Account
+			if tt.getErr == nil {
+				account = &models.Account{AccountId: 7, Status: tt.status}
+			}
+			mockRepo.On("GetByAccountId", int64(7)).Return(account, tt.getErr).Once()
+
+			from, to := models.AccountStatusFrozen, models.AccountStatusActive
+			if tt.freeze {
+				from, to = models.AccountStatusActive, models.AccountStatusFrozen
+			}
+			if tt.expectSet {
+				mockRepo.On("SetStatus", int64(7), from, to).Return(tt.setErr).Once()
+			}
+
+			var result *models.Account
+			var err error
+			if tt.freeze {
+				result, err = service.FreezeAccount(ctx, 7)
+			} else {
+				result, err = service.UnfreezeAccount(ctx, 7)
+			}
+
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, to, result.Status)
+			}
+		})
+	}
+}
+
+func TestAccountService_UnfreezeAccount_AdminOnly(t *testing.T) {
+	t.Run("owner cannot lift a freeze", func(t *testing.T) {
+		service := NewAccountService(mocks.NewIAccountRepository(t), nil, nil)
+		result, err := service.UnfreezeAccount(clientContext(7, models.ScopeAccountsWrite), 7)
+		assert.Equal(t, appErr.ErrInsufficientScope, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("admin unfreezes", func(t *testing.T) {
+		mockRepo := mocks.NewIAccountRepository(t)
+		mockRepo.On("GetByAccountId", int64(7)).Return(&models.Account{AccountId: 7, Status: models.AccountStatusFrozen}, nil).Once()
+		mockRepo.On("SetStatus", int64(7), models.AccountStatusFrozen, models.AccountStatusActive).Return(nil).Once()
+
+		result, err := NewAccountService(mockRepo, nil, nil).UnfreezeAccount(clientContext(8, models.ScopeAdmin), 7)
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountStatusActive, result.Status)
+	})
+}
+
+func TestAccountService_SetOverdraftLimit(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		limit         models.Money
+		account       *models.Account
+		setErr        error
+		expectedError error
+		expectSet     bool
+	}{
+		{
+			name:      "limit set",
+			limit:     models.MustParseMoney("500"),
+			account:   &models.Account{AccountId: 7, Balance: models.MustParseMoney("-20"), CurrencyCode: "USD", Status: models.AccountStatusActive},
+			expectSet: true,
+		},
+		{
+			name:          "negative limit",
+			limit:         models.MustParseMoney("-1"),
+			expectedError: appErr.ErrInvalidOverdraftLimit,
+		},
+		{
+			name:          "more decimals than the currency allows",
+			limit:         models.MustParseMoney("10.5"),
+			account:       &models.Account{AccountId: 7, CurrencyCode: "JPY", Status: models.AccountStatusActive},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+		{
+			name:          "closed account",
+			limit:         models.MustParseMoney("500"),
+			account:       &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			expectedError: appErr.ErrAccountClosed,
+		},
+		{
+			name:          "closed concurrently",
+			limit:         models.MustParseMoney("500"),
+			account:       &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive},
+			setErr:        appErr.ErrAccountClosed,
+			expectedError: appErr.ErrAccountClosed,
+			expectSet:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewIAccountRepository(t)
+			service := NewAccountService(mockRepo, nil, nil)
+			if tt.account != nil {
+				mockRepo.On("GetByAccountId", int64(7)).Return(tt.account, nil).Once()
+			}
+			if tt.expectSet {
+				mockRepo.On("SetOverdraftLimit", int64(7), tt.limit).Return(tt.setErr).Once()
+			}
+
+			result, err := service.SetOverdraftLimit(ctx, 7, tt.limit)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, tt.limit, result.OverdraftLimit)
+				assert.Equal(t, models.MustParseMoney("480"), result.AvailableToSpend())
+			}
+		})
+	}
+}
+
+func TestAccountService_CloseAccount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		args          models.CloseAccountArgs
+		accounts      map[int64]*models.Account
+		expectedError error
+		expectClose   bool
+	}{
+		{
+			name:        "close without sweep account",
+			args:        models.CloseAccountArgs{AccountId: 7},
+			accounts:    map[int64]*models.Account{7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive}},
+			expectClose: true,
+		},
+		{
+			name: "close with sweep account",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8},
+			accounts: map[int64]*models.Account{
+				7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusFrozen},
+				8: {AccountId: 8, CurrencyCode: "USD", Status: models.AccountStatusActive},
+			},
+			expectClose: true,
+		},
+		{
+			name:          "already closed",
+			args:          models.CloseAccountArgs{AccountId: 7},
+			accounts:      map[int64]*models.Account{7: {AccountId: 7, Status: models.AccountStatusClosed}},
+			expectedError: appErr.ErrAccountClosed,
+		},
+		{
+			name:          "sweep into the same account",
+			args:          models.CloseAccountArgs{AccountId: 7, SweepAccountId: 7},
+			accounts:      map[int64]*models.Account{7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive}},
+			expectedError: appErr.ErrInvalidSweepAccount,
+		},
+		{
+			name:          "sweep account not found",
+			args:          models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8},
+			accounts:      map[int64]*models.Account{7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive}},
+			expectedError: appErr.ErrInvalidSweepAccount,
+		},
+		{
+			name: "sweep account in another currency",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8},
+			accounts: map[int64]*models.Account{
+				7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive},
+				8: {AccountId: 8, CurrencyCode: "EUR", Status: models.AccountStatusActive},
+			},
+			expectedError: appErr.ErrInvalidSweepAccount,
+		},
+		{
+			name: "sweep account closed",
+			args: models.CloseAccountArgs{AccountId: 7, SweepAccountId: 8},
+			accounts: map[int64]*models.Account{
+				7: {AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive},
+				8: {AccountId: 8, CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			},
+			expectedError: appErr.ErrInvalidSweepAccount,
+		},
+		{
+			name:          "invalid account id",
+			args:          models.CloseAccountArgs{AccountId: 0},
+			expectedError: appErr.ErrInvalidAccountId,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewIAccountRepository(t)
+			service := NewAccountService(mockRepo, nil, nil)
+
+			for id, account := range tt.accounts {
+				mockRepo.On("GetByAccountId", id).Return(account, nil).Maybe()
+			}
+			mockRepo.On("GetByAccountId", mock.Anything).Return(nil, appErr.ErrAccountNotFound).Maybe()
+
+			if tt.expectClose {
+				mockRepo.On("CloseAccount", mock.MatchedBy(func(args models.CloseAccountArgs) bool {
+					// a reference is only needed to post the sweep transfer
+					return args.AccountId == 7 && args.SweepAccountId == tt.args.SweepAccountId &&
+						(args.SweepAccountId == 0) == (args.Reference == "")
+				})).Return(models.CloseAccountResponse{AccountId: 7, Status: models.AccountStatusClosed}, nil).Once()
+			}
+
+			resp, err := service.CloseAccount(ctx, tt.args)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.AccountStatusClosed, resp.Status)
+			}
+		})
+	}
 }
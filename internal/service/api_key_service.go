@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	apiKeyPrefix = "tak_"
+	// apiKeySecretBytes is the number of random bytes in a key, so that a key cannot be guessed and a plain
+	// SHA-256 of it is enough to store it
+	apiKeySecretBytes = 32
+	// apiKeyDisplayLength is the number of leading characters of a key kept to tell it apart in listings
+	apiKeyDisplayLength = 12
+	maxApiKeyNameLength = 100
+)
+
+type ApiKeyService struct {
+	apiKeyRepo repository.IApiKeyRepository
+}
+
+// NewApiKeyService creates the service managing the API keys that clients authenticate with
+func NewApiKeyService(apiKeyRepo repository.IApiKeyRepository) *ApiKeyService {
+	return &ApiKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+type IApiKeyService interface {
+	CreateApiKey(ctx context.Context, args models.CreateApiKeyArgs) (models.CreatedApiKey, error)
+	ListApiKeys(ctx context.Context) ([]models.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (models.ApiKey, error)
+}
+
+// CreateApiKey is a service method that issues a new API key with the given scopes, to a new client or, to rotate
+// its keys, to an existing one. The key is returned only here: what is stored is its hash
+func (s *ApiKeyService) CreateApiKey(ctx context.Context, args models.CreateApiKeyArgs) (models.CreatedApiKey, error) {
+	fName := "ApiKeyService.CreateApiKey"
+	name := strings.TrimSpace(args.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxApiKeyNameLength {
+		return models.CreatedApiKey{}, appErr.ErrInvalidApiKeyName
+	}
+	if len(args.Scopes) == 0 {
+		return models.CreatedApiKey{}, appErr.ErrInvalidApiKeyScope
+	}
+	for _, scope := range args.Scopes {
+		if !scope.IsValid() {
+			return models.CreatedApiKey{}, appErr.ErrInvalidApiKeyScope
+		}
+	}
+
+	key, err := generateApiKey()
+	if err != nil {
+		log.Printf("[%s] failed to generate a key: %v", fName, err)
+		return models.CreatedApiKey{}, appErr.ErrInternal
+	}
+	created, err := s.apiKeyRepo.CreateApiKey(models.ApiKey{ClientId: args.ClientId, Name: name, Prefix: key[:apiKeyDisplayLength], Scopes: args.Scopes}, hashApiKey(key))
+	if err != nil {
+		return models.CreatedApiKey{}, err
+	}
+	return models.CreatedApiKey{ApiKey: *created, Key: key}, nil
+}
+
+// ListApiKeys is a service method that lists every API key, revoked ones included
+func (s *ApiKeyService) ListApiKeys(ctx context.Context) ([]models.ApiKey, error) {
+	return s.apiKeyRepo.ListApiKeys()
+}
+
+// RevokeApiKey is a service method that revokes an API key, which stops authenticating from then on
+func (s *ApiKeyService) RevokeApiKey(ctx context.Context, id int64) error {
+	return s.apiKeyRepo.RevokeApiKey(id)
+}
+
+// Authenticate is a service method that returns the API key matching key, failing with ErrUnauthenticated when
+// there is none or it was revoked
+func (s *ApiKeyService) Authenticate(ctx context.Context, key string) (models.ApiKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return models.ApiKey{}, appErr.ErrUnauthenticated
+	}
+	apiKey, err := s.apiKeyRepo.GetActiveApiKey(hashApiKey(key))
+	if err != nil {
+		if err == appErr.ErrApiKeyNotFound {
+			return models.ApiKey{}, appErr.ErrUnauthenticated
+		}
+		return models.ApiKey{}, err
+	}
+	return *apiKey, nil
+}
+
+// generateApiKey is a method that creates a new random API key
+func generateApiKey() (string, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashApiKey is a method that computes the hash an API key is stored and looked up by
+func hashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
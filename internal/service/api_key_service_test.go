@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestApiKeyService_CreateApiKey(t *testing.T) {
+	scopes := []models.ApiKeyScope{models.ScopeAccountsRead}
+
+	tests := []struct {
+		name        string
+		args        models.CreateApiKeyArgs
+		setupMocks  func(*mocks.IApiKeyRepository)
+		expectedErr error
+	}{
+		{
+			name: "created",
+			args: models.CreateApiKeyArgs{Name: " payments ", Scopes: scopes},
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("CreateApiKey", mock.MatchedBy(func(k models.ApiKey) bool {
+					return k.Name == "payments" && strings.HasPrefix(k.Prefix, apiKeyPrefix) && len(k.Prefix) == apiKeyDisplayLength
+				}), mock.AnythingOfType("string")).
+					Return(func(k models.ApiKey, _ string) *models.ApiKey { k.Id = 1; return &k }, nil).Once()
+			},
+		},
+		{
+			name: "rotated key of an existing client",
+			args: models.CreateApiKeyArgs{Name: "payments", Scopes: scopes, ClientId: 3},
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("CreateApiKey", mock.MatchedBy(func(k models.ApiKey) bool { return k.ClientId == 3 }), mock.AnythingOfType("string")).
+					Return(func(k models.ApiKey, _ string) *models.ApiKey { k.Id = 1; return &k }, nil).Once()
+			},
+		},
+		{
+			name: "unknown client",
+			args: models.CreateApiKeyArgs{Name: "payments", Scopes: scopes, ClientId: 9},
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("CreateApiKey", mock.Anything, mock.Anything).Return(nil, appErr.ErrClientNotFound).Once()
+			},
+			expectedErr: appErr.ErrClientNotFound,
+		},
+		{
+			name:        "missing name",
+			args:        models.CreateApiKeyArgs{Name: " ", Scopes: scopes},
+			expectedErr: appErr.ErrInvalidApiKeyName,
+		},
+		{
+			name:        "name too long",
+			args:        models.CreateApiKeyArgs{Name: strings.Repeat("a", maxApiKeyNameLength+1), Scopes: scopes},
+			expectedErr: appErr.ErrInvalidApiKeyName,
+		},
+		{
+			name:        "no scopes",
+			args:        models.CreateApiKeyArgs{Name: "payments"},
+			expectedErr: appErr.ErrInvalidApiKeyScope,
+		},
+		{
+			name:        "unknown scope",
+			args:        models.CreateApiKeyArgs{Name: "payments", Scopes: []models.ApiKeyScope{"accounts:delete"}},
+			expectedErr: appErr.ErrInvalidApiKeyScope,
+		},
+		{
+			name: "db error",
+			args: models.CreateApiKeyArgs{Name: "payments", Scopes: scopes},
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("CreateApiKey", mock.Anything, mock.Anything).Return(nil, appErr.ErrInternal).Once()
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IApiKeyRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+
+			created, err := NewApiKeyService(repo).CreateApiKey(context.Background(), tt.args)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, int64(1), created.Id)
+				assert.True(t, strings.HasPrefix(created.Key, created.Prefix))
+				repo.AssertCalled(t, "CreateApiKey", mock.Anything, hashApiKey(created.Key))
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApiKeyService_Authenticate(t *testing.T) {
+	key := apiKeyPrefix + "secret"
+	apiKey := &models.ApiKey{Id: 1, Name: "payments", Scopes: []models.ApiKeyScope{models.ScopeAccountsRead}}
+
+	tests := []struct {
+		name        string
+		key         string
+		setupMocks  func(*mocks.IApiKeyRepository)
+		expected    models.ApiKey
+		expectedErr error
+	}{
+		{
+			name: "active key",
+			key:  key,
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("GetActiveApiKey", hashApiKey(key)).Return(apiKey, nil).Once()
+			},
+			expected: *apiKey,
+		},
+		{
+			name: "unknown or revoked key",
+			key:  key,
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("GetActiveApiKey", hashApiKey(key)).Return(nil, appErr.ErrApiKeyNotFound).Once()
+			},
+			expectedErr: appErr.ErrUnauthenticated,
+		},
+		{
+			name:        "not an API key",
+			key:         "secret",
+			expectedErr: appErr.ErrUnauthenticated,
+		},
+		{
+			name: "db error",
+			key:  key,
+			setupMocks: func(repo *mocks.IApiKeyRepository) {
+				repo.On("GetActiveApiKey", hashApiKey(key)).Return(nil, appErr.ErrInternal).Once()
+			},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IApiKeyRepository)
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+
+			apiKey, err := NewApiKeyService(repo).Authenticate(context.Background(), tt.key)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, apiKey)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGenerateApiKey(t *testing.T) {
+	first, err := generateApiKey()
+	assert.NoError(t, err)
+	second, err := generateApiKey()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(first, apiKeyPrefix))
+	assert.Len(t, first, len(apiKeyPrefix)+43)
+	assert.NotEqual(t, first, second)
+	assert.Len(t, hashApiKey(first), 64)
+}
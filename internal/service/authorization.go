@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+)
+
+// restrictedApiKey returns the API key of the request of ctx when its client may only act on the accounts it owns,
+// that is when it is not an admin. Calls without an API key come from the background jobs and the command line,
+// which act on every account
+func restrictedApiKey(ctx context.Context) (models.ApiKey, bool) {
+	apiKey, ok := auth.ApiKeyFromContext(ctx)
+	if !ok || apiKey.HasScope(models.ScopeAdmin) {
+		return models.ApiKey{}, false
+	}
+	return apiKey, true
+}
+
+// authorizeAccount checks that the client of ctx may act on account, failing with ErrAccountForbidden when the
+// account belongs to another client or to none
+func authorizeAccount(ctx context.Context, account *models.Account) error {
+	if apiKey, ok := restrictedApiKey(ctx); ok && !account.IsOwnedBy(apiKey.ClientId) {
+		return appErr.ErrAccountForbidden
+	}
+	return nil
+}
+
+// authorizeAccountId is authorizeAccount for an account that was not read yet. The account is only read when the
+// client of ctx is restricted to its own accounts
+func authorizeAccountId(ctx context.Context, accountRepo repository.IAccountRepository, accountId int64) error {
+	if _, ok := restrictedApiKey(ctx); !ok {
+		return nil
+	}
+	account, err := accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		return err
+	}
+	return authorizeAccount(ctx, account)
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/bhuvi1021/TripleA/internal/auth"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientContext returns a context authenticated with an API key of the client clientId holding scopes
+func clientContext(clientId int64, scopes ...models.ApiKeyScope) context.Context {
+	return auth.WithApiKey(context.Background(), models.ApiKey{Id: clientId + 100, ClientId: clientId, Name: "client", Scopes: scopes})
+}
+
+func TestAuthorizeAccount(t *testing.T) {
+	owned := &models.Account{AccountId: 1, OwnerClientId: sql.NullInt64{Int64: 7, Valid: true}}
+	unowned := &models.Account{AccountId: 2}
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		account     *models.Account
+		expectedErr error
+	}{
+		{name: "owner", ctx: clientContext(7, models.ScopeAccountsRead), account: owned},
+		{name: "rotated key of the owner", ctx: auth.WithApiKey(context.Background(), models.ApiKey{Id: 12, ClientId: 7}), account: owned},
+		{name: "key numbered like the owner of another client", ctx: auth.WithApiKey(context.Background(), models.ApiKey{Id: 7, ClientId: 8}), account: owned, expectedErr: appErr.ErrAccountForbidden},
+		{name: "another client", ctx: clientContext(8, models.ScopeAccountsRead), account: owned, expectedErr: appErr.ErrAccountForbidden},
+		{name: "account without owner", ctx: clientContext(7, models.ScopeAccountsRead), account: unowned, expectedErr: appErr.ErrAccountForbidden},
+		{name: "admin acts on any account", ctx: clientContext(8, models.ScopeAdmin), account: owned},
+		{name: "admin acts on accounts without owner", ctx: clientContext(8, models.ScopeAdmin), account: unowned},
+		{name: "background jobs act on any account", ctx: context.Background(), account: unowned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErr, authorizeAccount(tt.ctx, tt.account))
+		})
+	}
+}
+
+func TestAuthorizeAccountId(t *testing.T) {
+	t.Run("account not read for unrestricted clients", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		assert.NoError(t, authorizeAccountId(clientContext(8, models.ScopeAdmin), accountRepo, 1))
+		assert.NoError(t, authorizeAccountId(context.Background(), accountRepo, 1))
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, OwnerClientId: sql.NullInt64{Int64: 7, Valid: true}}, nil).Once()
+		assert.NoError(t, authorizeAccountId(clientContext(7), accountRepo, 1))
+	})
+
+	t.Run("another client", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, OwnerClientId: sql.NullInt64{Int64: 7, Valid: true}}, nil).Once()
+		assert.Equal(t, appErr.ErrAccountForbidden, authorizeAccountId(clientContext(8), accountRepo, 1))
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(nil, appErr.ErrAccountNotFound).Once()
+		assert.Equal(t, appErr.ErrAccountNotFound, authorizeAccountId(clientContext(7), accountRepo, 1))
+	})
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"time"
+)
+
+// snapshotSettleDelay is how long after the end of a day its snapshots are taken, so that transfers started before
+// midnight, which are dated when they started, are committed by then
+const snapshotSettleDelay = 5 * time.Minute
+
+type BalanceSnapshotService struct {
+	snapshotRepo repository.IBalanceSnapshotRepository
+}
+
+// NewBalanceSnapshotService creates the service taking the end-of-day balance snapshots of every account
+func NewBalanceSnapshotService(snapshotRepo repository.IBalanceSnapshotRepository) *BalanceSnapshotService {
+	return &BalanceSnapshotService{snapshotRepo: snapshotRepo}
+}
+
+type IBalanceSnapshotService interface {
+	RunSnapshots(ctx context.Context, now time.Time) ([]models.BalanceSnapshotDay, error)
+}
+
+// RunSnapshots is a service method that takes the end-of-day balance snapshots of every UTC day that is over as of
+// now and has none yet, oldest first. The first run only takes the snapshots of the day before; older balances are
+// still reconstructed from the ledger
+func (s *BalanceSnapshotService) RunSnapshots(ctx context.Context, now time.Time) ([]models.BalanceSnapshotDay, error) {
+	fName := "BalanceSnapshotService.RunSnapshots"
+	lastDay := now.UTC().Add(-snapshotSettleDelay).Truncate(24*time.Hour).AddDate(0, 0, -1)
+
+	latest, err := s.snapshotRepo.GetLatestSnapshotDay()
+	if err != nil {
+		return nil, err
+	}
+	day := lastDay
+	if latest != nil {
+		day = latest.AddDate(0, 0, 1)
+	}
+
+	var days []models.BalanceSnapshotDay
+	for ; !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		if err := ctx.Err(); err != nil {
+			return days, err
+		}
+		saved, err := s.snapshotRepo.SaveSnapshots(day)
+		if err != nil {
+			log.Printf("[%s] failed to take the snapshots of %s: %v", fName, day.Format(time.DateOnly), err)
+			return days, err
+		}
+		days = append(days, models.BalanceSnapshotDay{Day: day, Accounts: saved})
+	}
+	return days, nil
+}
+
+// RunSnapshotJob takes the snapshots of the days that are over every interval until ctx is done
+func (s *BalanceSnapshotService) RunSnapshotJob(ctx context.Context, interval time.Duration) {
+	fName := "BalanceSnapshotService.RunSnapshotJob"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			days, err := s.RunSnapshots(ctx, time.Now().UTC())
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[%s] failed to take balance snapshots: %v", fName, err)
+			}
+			for _, day := range days {
+				log.Printf("[%s] took %d balance snapshots for %s", fName, day.Accounts, day.Day.Format(time.DateOnly))
+			}
+		}
+	}
+}
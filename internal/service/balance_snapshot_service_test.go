@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBalanceSnapshotService_RunSnapshots(t *testing.T) {
+	now := time.Date(2025, 4, 3, 8, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 4, d, 0, 0, 0, 0, time.UTC) }
+	latest := func(d int) *time.Time { t := day(d); return &t }
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		setupMocks  func(*mocks.IBalanceSnapshotRepository)
+		expected    []models.BalanceSnapshotDay
+		expectedErr error
+	}{
+		{
+			name: "first run takes the day before",
+			now:  now,
+			setupMocks: func(repo *mocks.IBalanceSnapshotRepository) {
+				repo.On("GetLatestSnapshotDay").Return(nil, nil).Once()
+				repo.On("SaveSnapshots", day(2)).Return(int64(3), nil).Once()
+			},
+			expected: []models.BalanceSnapshotDay{{Day: day(2), Accounts: 3}},
+		},
+		{
+			name: "missed days caught up oldest first",
+			now:  now,
+			setupMocks: func(repo *mocks.IBalanceSnapshotRepository) {
+				repo.On("GetLatestSnapshotDay").Return(latest(0), nil).Once()
+				repo.On("SaveSnapshots", day(1)).Return(int64(3), nil).Once()
+				repo.On("SaveSnapshots", day(2)).Return(int64(4), nil).Once()
+			},
+			expected: []models.BalanceSnapshotDay{{Day: day(1), Accounts: 3}, {Day: day(2), Accounts: 4}},
+		},
+		{
+			name: "nothing to take once the day before is done",
+			now:  now,
+			setupMocks: func(repo *mocks.IBalanceSnapshotRepository) {
+				repo.On("GetLatestSnapshotDay").Return(latest(2), nil).Once()
+			},
+		},
+		{
+			name: "day not settled yet right after midnight",
+			now:  day(3).Add(time.Minute),
+			setupMocks: func(repo *mocks.IBalanceSnapshotRepository) {
+				repo.On("GetLatestSnapshotDay").Return(latest(1), nil).Once()
+			},
+		},
+		{
+			name: "failure stops the run",
+			now:  now,
+			setupMocks: func(repo *mocks.IBalanceSnapshotRepository) {
+				repo.On("GetLatestSnapshotDay").Return(latest(0), nil).Once()
+				repo.On("SaveSnapshots", day(1)).Return(int64(3), nil).Once()
+				repo.On("SaveSnapshots", day(2)).Return(int64(0), appErr.ErrInternal).Once()
+			},
+			expected:    []models.BalanceSnapshotDay{{Day: day(1), Accounts: 3}},
+			expectedErr: appErr.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.IBalanceSnapshotRepository)
+			tt.setupMocks(repo)
+
+			days, err := NewBalanceSnapshotService(repo).RunSnapshots(context.Background(), tt.now)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expected, days)
+			repo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+)
+
+type FeeService struct {
+	scheduleRepo     repository.IFeeScheduleRepository
+	accountRepo      repository.IAccountRepository
+	revenueAccountId int64
+}
+
+// NewFeeService creates the fee service. Fees are paid into revenueAccountId, and the global schedule of a currency
+// prices the transfers of every account in that currency without a schedule of its own
+func NewFeeService(scheduleRepo repository.IFeeScheduleRepository, accountRepo repository.IAccountRepository,
+	revenueAccountId int64) *FeeService {
+	return &FeeService{
+		scheduleRepo:     scheduleRepo,
+		accountRepo:      accountRepo,
+		revenueAccountId: revenueAccountId,
+	}
+}
+
+type IFeeService interface {
+	GetFee(ctx context.Context, sourceAccount *models.Account, amount models.Money) (*models.TransferFee, error)
+	GetFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error)
+	SetFeeSchedule(ctx context.Context, accountId int64, schedule models.FeeSchedule) (models.FeeScheduleResponse, error)
+	DeleteFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error)
+	GetCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error)
+	SetCurrencyFeeSchedule(ctx context.Context, currencyCode string, schedule models.FeeSchedule) (models.CurrencyFeeScheduleResponse, error)
+	DeleteCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error)
+}
+
+// GetFee is a service method that prices a transfer of amount leaving sourceAccount. It returns nil when the
+// transfer is free, including the transfers leaving the revenue account itself. The revenue amount and rate of the
+// fee are left for the caller to fill in
+func (s *FeeService) GetFee(ctx context.Context, sourceAccount *models.Account, amount models.Money) (*models.TransferFee, error) {
+	fName := "FeeService.GetFee"
+	if sourceAccount.AccountId == s.revenueAccountId {
+		return nil, nil
+	}
+
+	schedule, err := s.scheduleRepo.GetByAccountId(sourceAccount.AccountId)
+	if err != nil {
+		log.Printf("[%s] failed to get fee schedule of account %d: %v", fName, sourceAccount.AccountId, err)
+		return nil, err
+	}
+	if schedule == nil {
+		schedule, err = s.scheduleRepo.GetByCurrency(sourceAccount.CurrencyCode)
+		if err != nil {
+			log.Printf("[%s] failed to get fee schedule of %s: %v", fName, sourceAccount.CurrencyCode, err)
+			return nil, err
+		}
+	}
+	if schedule == nil {
+		return nil, nil
+	}
+
+	currency, err := models.LookupCurrency(sourceAccount.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to look up currency %q: %v", fName, sourceAccount.CurrencyCode, err)
+		return nil, appErr.ErrInternal
+	}
+	breakdown := schedule.Fee(amount, currency)
+	if !breakdown.Amount.IsPositive() {
+		return nil, nil
+	}
+
+	revenueAccount, err := s.accountRepo.GetByAccountId(s.revenueAccountId)
+	if err != nil {
+		log.Printf("[%s] failed to get revenue account %d: %v", fName, s.revenueAccountId, err)
+		if err == appErr.ErrAccountNotFound {
+			return nil, appErr.ErrFeeAccountUnavailable
+		}
+		return nil, err
+	}
+	if !revenueAccount.Status.AllowsCredit() || revenueAccount.DeletedAt.Valid {
+		return nil, appErr.ErrFeeAccountUnavailable
+	}
+
+	return &models.TransferFee{
+		Breakdown:           breakdown,
+		CurrencyCode:        sourceAccount.CurrencyCode,
+		RevenueAccountId:    s.revenueAccountId,
+		RevenueCurrencyCode: revenueAccount.CurrencyCode,
+	}, nil
+}
+
+// GetFeeSchedule is a service method that returns the fee schedule in force for an account
+func (s *FeeService) GetFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error) {
+	fName := "FeeService.GetFeeSchedule"
+	if accountId <= 0 {
+		return models.FeeScheduleResponse{}, appErr.ErrInvalidAccountId
+	}
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+
+	schedule, err := s.scheduleRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get fee schedule of account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+	return s.newFeeScheduleResponse(account, schedule)
+}
+
+// SetFeeSchedule is a service method that replaces the fee schedule of an account, in place of the global one.
+// Amounts are in the currency of the account
+func (s *FeeService) SetFeeSchedule(ctx context.Context, accountId int64, schedule models.FeeSchedule) (models.FeeScheduleResponse, error) {
+	fName := "FeeService.SetFeeSchedule"
+	if accountId <= 0 {
+		return models.FeeScheduleResponse{}, appErr.ErrInvalidAccountId
+	}
+	if err := schedule.Validate(); err != nil {
+		return models.FeeScheduleResponse{}, err
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return models.FeeScheduleResponse{}, appErr.ErrAccountClosed
+	}
+	currency, err := models.LookupCurrency(account.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to look up currency %q: %v", fName, account.CurrencyCode, err)
+		return models.FeeScheduleResponse{}, appErr.ErrInternal
+	}
+	for _, amount := range scheduleAmounts(schedule) {
+		if !currency.Allows(amount) {
+			return models.FeeScheduleResponse{}, appErr.ErrInvalidCurrencyPrecision
+		}
+	}
+
+	if err := s.scheduleRepo.Upsert(accountId, schedule); err != nil {
+		log.Printf("[%s] failed to set fee schedule of account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+	return s.newFeeScheduleResponse(account, &schedule)
+}
+
+// DeleteFeeSchedule is a service method that removes the fee schedule of an account, so that the global one
+// applies again
+func (s *FeeService) DeleteFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error) {
+	fName := "FeeService.DeleteFeeSchedule"
+	if accountId <= 0 {
+		return models.FeeScheduleResponse{}, appErr.ErrInvalidAccountId
+	}
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+
+	if err := s.scheduleRepo.Delete(accountId); err != nil {
+		log.Printf("[%s] failed to delete fee schedule of account %d: %v", fName, accountId, err)
+		return models.FeeScheduleResponse{}, err
+	}
+	return s.newFeeScheduleResponse(account, nil)
+}
+
+// GetCurrencyFeeSchedule is a service method that returns the global fee schedule of a currency
+func (s *FeeService) GetCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error) {
+	fName := "FeeService.GetCurrencyFeeSchedule"
+	currency, err := models.LookupCurrency(currencyCode)
+	if err != nil {
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+
+	schedule, err := s.scheduleRepo.GetByCurrency(currency.Code)
+	if err != nil {
+		log.Printf("[%s] failed to get fee schedule of %s: %v", fName, currency.Code, err)
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+	return models.CurrencyFeeScheduleResponse{CurrencyCode: currency.Code, Schedule: schedule}, nil
+}
+
+// SetCurrencyFeeSchedule is a service method that replaces the global fee schedule of a currency, which prices the
+// transfers of the accounts in it that have no schedule of their own. Amounts are in the currency
+func (s *FeeService) SetCurrencyFeeSchedule(ctx context.Context, currencyCode string, schedule models.FeeSchedule) (models.CurrencyFeeScheduleResponse, error) {
+	fName := "FeeService.SetCurrencyFeeSchedule"
+	if err := schedule.Validate(); err != nil {
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+	currency, err := models.LookupCurrency(currencyCode)
+	if err != nil {
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+	for _, amount := range scheduleAmounts(schedule) {
+		if !currency.Allows(amount) {
+			return models.CurrencyFeeScheduleResponse{}, appErr.ErrInvalidCurrencyPrecision
+		}
+	}
+
+	if err := s.scheduleRepo.UpsertCurrency(currency.Code, schedule); err != nil {
+		log.Printf("[%s] failed to set fee schedule of %s: %v", fName, currency.Code, err)
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+	return models.CurrencyFeeScheduleResponse{CurrencyCode: currency.Code, Schedule: &schedule}, nil
+}
+
+// DeleteCurrencyFeeSchedule is a service method that removes the global fee schedule of a currency, so that the
+// transfers of the accounts in it without a schedule of their own are free
+func (s *FeeService) DeleteCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error) {
+	fName := "FeeService.DeleteCurrencyFeeSchedule"
+	currency, err := models.LookupCurrency(currencyCode)
+	if err != nil {
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+
+	if err := s.scheduleRepo.DeleteCurrency(currency.Code); err != nil {
+		log.Printf("[%s] failed to delete fee schedule of %s: %v", fName, currency.Code, err)
+		return models.CurrencyFeeScheduleResponse{}, err
+	}
+	return models.CurrencyFeeScheduleResponse{CurrencyCode: currency.Code}, nil
+}
+
+// newFeeScheduleResponse builds the API view of the fee schedule of an account, override being the schedule set
+// for the account or nil
+func (s *FeeService) newFeeScheduleResponse(account *models.Account, override *models.FeeSchedule) (models.FeeScheduleResponse, error) {
+	if override != nil {
+		return models.FeeScheduleResponse{AccountId: account.AccountId, Schedule: override, IsOverride: true}, nil
+	}
+	global, err := s.scheduleRepo.GetByCurrency(account.CurrencyCode)
+	if err != nil {
+		return models.FeeScheduleResponse{}, err
+	}
+	return models.FeeScheduleResponse{AccountId: account.AccountId, Schedule: global}, nil
+}
+
+// scheduleAmounts returns every fixed amount of schedule, the ones that must fit the minor units of the currency
+func scheduleAmounts(schedule models.FeeSchedule) []models.Money {
+	amounts := []models.Money{schedule.Flat}
+	for _, tier := range schedule.Tiers {
+		amounts = append(amounts, tier.Flat)
+		if tier.UpTo != nil {
+			amounts = append(amounts, *tier.UpTo)
+		}
+	}
+	if schedule.Min != nil {
+		amounts = append(amounts, *schedule.Min)
+	}
+	if schedule.Max != nil {
+		amounts = append(amounts, *schedule.Max)
+	}
+	return amounts
+}
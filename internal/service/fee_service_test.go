@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/fx"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	serviceMocks "github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFeeService_GetFee(t *testing.T) {
+	global := &models.FeeSchedule{Flat: models.MustParseMoney("0.25"), Percentage: models.MustParseMoney("1.5")}
+	override := &models.FeeSchedule{Flat: models.MustParseMoney("5")}
+	source := &models.Account{AccountId: 7, CurrencyCode: "USD"}
+
+	tests := []struct {
+		name           string
+		source         *models.Account
+		override       *models.FeeSchedule
+		global         *models.FeeSchedule
+		revenueAccount *models.Account
+		expectedFee    models.Money
+		expectedErr    error
+	}{
+		{
+			name:           "global schedule",
+			source:         source,
+			global:         global,
+			revenueAccount: &models.Account{AccountId: 9, CurrencyCode: "EUR", Status: models.AccountStatusActive},
+			expectedFee:    models.MustParseMoney("1.75"),
+		},
+		{
+			name:           "account schedule in place of the global one",
+			source:         source,
+			override:       override,
+			global:         global,
+			revenueAccount: &models.Account{AccountId: 9, CurrencyCode: "EUR", Status: models.AccountStatusActive},
+			expectedFee:    models.NewMoney(5),
+		},
+		{
+			name:   "no schedule",
+			source: source,
+		},
+		{
+			name:   "zero fee",
+			source: source,
+			global: &models.FeeSchedule{Percentage: models.MustParseMoney("0.001")},
+		},
+		{
+			name:   "transfers leaving the revenue account are free",
+			source: &models.Account{AccountId: 9, CurrencyCode: "EUR"},
+			global: global,
+		},
+		{
+			name:           "revenue account closed",
+			source:         source,
+			global:         global,
+			revenueAccount: &models.Account{AccountId: 9, CurrencyCode: "EUR", Status: models.AccountStatusClosed},
+			expectedErr:    appErr.ErrFeeAccountUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleRepo := mocks.NewIFeeScheduleRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			if tt.source.AccountId != 9 {
+				scheduleRepo.On("GetByAccountId", tt.source.AccountId).Return(tt.override, nil).Once()
+				if tt.override == nil {
+					scheduleRepo.On("GetByCurrency", tt.source.CurrencyCode).Return(tt.global, nil).Once()
+				}
+			}
+			if tt.revenueAccount != nil {
+				accountRepo.On("GetByAccountId", int64(9)).Return(tt.revenueAccount, nil).Once()
+			}
+			svc := NewFeeService(scheduleRepo, accountRepo, 9)
+
+			fee, err := svc.GetFee(context.Background(), tt.source, models.NewMoney(100))
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedFee.IsZero() {
+				assert.Nil(t, fee)
+				return
+			}
+			assert.Equal(t, tt.expectedFee, fee.Breakdown.Amount)
+			assert.Equal(t, "USD", fee.CurrencyCode)
+			assert.Equal(t, int64(9), fee.RevenueAccountId)
+			assert.Equal(t, "EUR", fee.RevenueCurrencyCode)
+		})
+	}
+}
+
+func TestFeeService_SetFeeSchedule(t *testing.T) {
+	schedule := models.FeeSchedule{Percentage: models.MustParseMoney("1.5"), Min: moneyPtr("1")}
+
+	tests := []struct {
+		name          string
+		schedule      models.FeeSchedule
+		account       *models.Account
+		expectUpsert  bool
+		expectedError error
+	}{
+		{
+			name:         "schedule set",
+			schedule:     schedule,
+			account:      &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive},
+			expectUpsert: true,
+		},
+		{
+			name:          "min above max",
+			schedule:      models.FeeSchedule{Min: moneyPtr("5"), Max: moneyPtr("1")},
+			expectedError: appErr.ErrInvalidFeeSchedule,
+		},
+		{
+			name:          "more decimals than the currency allows",
+			schedule:      models.FeeSchedule{Flat: models.MustParseMoney("0.5")},
+			account:       &models.Account{AccountId: 7, CurrencyCode: "JPY", Status: models.AccountStatusActive},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+		{
+			name:          "closed account",
+			schedule:      schedule,
+			account:       &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			expectedError: appErr.ErrAccountClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleRepo := mocks.NewIFeeScheduleRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			if tt.account != nil {
+				accountRepo.On("GetByAccountId", int64(7)).Return(tt.account, nil).Once()
+			}
+			if tt.expectUpsert {
+				scheduleRepo.On("Upsert", int64(7), tt.schedule).Return(nil).Once()
+			}
+			svc := NewFeeService(scheduleRepo, accountRepo, 9)
+
+			resp, err := svc.SetFeeSchedule(context.Background(), 7, tt.schedule)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.FeeScheduleResponse{AccountId: 7, Schedule: &tt.schedule, IsOverride: true}, resp)
+			}
+		})
+	}
+}
+
+func TestFeeService_DeleteFeeSchedule(t *testing.T) {
+	global := &models.FeeSchedule{Flat: models.MustParseMoney("0.25")}
+	scheduleRepo := mocks.NewIFeeScheduleRepository(t)
+	accountRepo := mocks.NewIAccountRepository(t)
+	accountRepo.On("GetByAccountId", int64(7)).Return(&models.Account{AccountId: 7, CurrencyCode: "JPY"}, nil).Once()
+	scheduleRepo.On("Delete", int64(7)).Return(nil).Once()
+	scheduleRepo.On("GetByCurrency", "JPY").Return(global, nil).Once()
+
+	resp, err := NewFeeService(scheduleRepo, accountRepo, 9).DeleteFeeSchedule(context.Background(), 7)
+	assert.NoError(t, err)
+	assert.Equal(t, models.FeeScheduleResponse{AccountId: 7, Schedule: global}, resp)
+}
+
+func TestFeeService_SetCurrencyFeeSchedule(t *testing.T) {
+	schedule := models.FeeSchedule{Flat: models.NewMoney(30), Max: moneyPtr("2000")}
+
+	tests := []struct {
+		name          string
+		currencyCode  string
+		schedule      models.FeeSchedule
+		expectUpsert  bool
+		expectedError error
+	}{
+		{
+			name:         "schedule set",
+			currencyCode: "jpy",
+			schedule:     schedule,
+			expectUpsert: true,
+		},
+		{
+			name:          "min above max",
+			currencyCode:  "JPY",
+			schedule:      models.FeeSchedule{Min: moneyPtr("5"), Max: moneyPtr("1")},
+			expectedError: appErr.ErrInvalidFeeSchedule,
+		},
+		{
+			name:          "unknown currency",
+			currencyCode:  "XYZ",
+			schedule:      schedule,
+			expectedError: appErr.ErrUnsupportedCurrency,
+		},
+		{
+			name:          "more decimals than the currency allows",
+			currencyCode:  "JPY",
+			schedule:      models.FeeSchedule{Flat: models.MustParseMoney("0.25")},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleRepo := mocks.NewIFeeScheduleRepository(t)
+			if tt.expectUpsert {
+				scheduleRepo.On("UpsertCurrency", "JPY", tt.schedule).Return(nil).Once()
+			}
+			svc := NewFeeService(scheduleRepo, mocks.NewIAccountRepository(t), 9)
+
+			resp, err := svc.SetCurrencyFeeSchedule(context.Background(), tt.currencyCode, tt.schedule)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.CurrencyFeeScheduleResponse{CurrencyCode: "JPY", Schedule: &tt.schedule}, resp)
+			}
+		})
+	}
+}
+
+func TestFeeService_GetAndDeleteCurrencyFeeSchedule(t *testing.T) {
+	schedule := &models.FeeSchedule{Flat: models.NewMoney(30)}
+	scheduleRepo := mocks.NewIFeeScheduleRepository(t)
+	scheduleRepo.On("GetByCurrency", "JPY").Return(schedule, nil).Once()
+	scheduleRepo.On("DeleteCurrency", "JPY").Return(nil).Once()
+	svc := NewFeeService(scheduleRepo, mocks.NewIAccountRepository(t), 9)
+
+	resp, err := svc.GetCurrencyFeeSchedule(context.Background(), "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, models.CurrencyFeeScheduleResponse{CurrencyCode: "JPY", Schedule: schedule}, resp)
+
+	resp, err = svc.DeleteCurrencyFeeSchedule(context.Background(), "JPY")
+	assert.NoError(t, err)
+	assert.Equal(t, models.CurrencyFeeScheduleResponse{CurrencyCode: "JPY"}, resp)
+
+	_, err = svc.GetCurrencyFeeSchedule(context.Background(), "XYZ")
+	assert.Equal(t, appErr.ErrUnsupportedCurrency, err)
+}
+
+func TestTransactionService_CreateTransaction_Fee(t *testing.T) {
+	rates, err := fx.NewStaticRateProvider(map[string]models.ExchangeRate{
+		"USD/EUR": models.MustParseExchangeRate("0.9235"),
+	})
+	assert.NoError(t, err)
+
+	txnRepo := mocks.NewITransactionRepository(t)
+	acctRepo := mocks.NewIAccountRepository(t)
+	source := &models.Account{AccountId: 1, CurrencyCode: "USD"}
+	acctRepo.On("GetByAccountId", int64(1)).Return(source, nil)
+	acctRepo.On("GetByAccountId", int64(2)).Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil)
+	feeService := serviceMocks.NewIFeeService(t)
+	feeService.On("GetFee", mock.Anything, source, models.NewMoney(100)).Return(&models.TransferFee{
+		Breakdown:           models.FeeBreakdown{Flat: models.MustParseMoney("1.75"), Amount: models.MustParseMoney("1.75")},
+		CurrencyCode:        "USD",
+		RevenueAccountId:    9,
+		RevenueCurrencyCode: "EUR",
+	}, nil).Once()
+	txnRepo.On("CreateTransaction", mock.MatchedBy(func(req models.CreateTransactionArgs) bool {
+		// the fee is converted into the currency of the revenue account
+		return req.Fee != nil && req.Fee.RevenueAmount == models.MustParseMoney("1.62") &&
+			req.Fee.FxRate == models.MustParseExchangeRate("0.9235") && req.DestinationAmount == models.NewMoney(100)
+	})).Return(models.CreateTransactionResponse{SourceAccountId: 1}, nil).Once()
+
+	svc := NewTransactionService(txnRepo, acctRepo, nil, rates, time.Minute, nil, feeService)
+	_, err = svc.CreateTransaction(context.Background(), models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)})
+	assert.NoError(t, err)
+}
+
+func moneyPtr(s string) *models.Money {
+	m := models.MustParseMoney(s)
+	return &m
+}
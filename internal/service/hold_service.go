@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	uuid "github.com/google/uuid"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	holdIdPrefix = "HLD-"
+	// expireBatchSize is the number of expired holds released per DB transaction by the sweeper
+	expireBatchSize = 100
+)
+
+type HoldService struct {
+	holdRepo     repository.IHoldRepository
+	accountRepo  repository.IAccountRepository
+	limitService ITransferLimitService
+	holdTTL      time.Duration
+}
+
+// NewHoldService creates the hold service. Holds expire holdTTL after they are created. They are capped by the
+// transfer limits of their source account like transfers, unless limitService is nil
+func NewHoldService(holdRepo repository.IHoldRepository, accountRepo repository.IAccountRepository, limitService ITransferLimitService,
+	holdTTL time.Duration) *HoldService {
+	return &HoldService{holdRepo: holdRepo, accountRepo: accountRepo, limitService: limitService, holdTTL: holdTTL}
+}
+
+type IHoldService interface {
+	CreateHold(ctx context.Context, args models.CreateHoldArgs) (models.HoldResponse, error)
+	CaptureHold(ctx context.Context, args models.CaptureHoldArgs) (models.HoldResponse, error)
+	VoidHold(ctx context.Context, holdId string) (models.HoldResponse, error)
+	ExpireHolds(ctx context.Context) (int, error)
+}
+
+// CreateHold is a service method that reserves funds on the source account for a later transfer to the
+// destination account. Both accounts must hold the same currency, and the amount must be within the per-transfer
+// cap of the source account
+func (s *HoldService) CreateHold(ctx context.Context, args models.CreateHoldArgs) (models.HoldResponse, error) {
+	fName := "HoldService.CreateHold"
+	if !args.Amount.IsPositive() {
+		return models.HoldResponse{}, appErr.ErrInvalidAmount
+	}
+
+	sourceAccount, destAccount, err := validateTransfer(ctx, s.accountRepo, models.CreateTransactionArgs{
+		SourceAccountId:      args.SourceAccountId,
+		DestinationAccountId: args.DestinationAccountId,
+		Amount:               args.Amount,
+	})
+	if err != nil {
+		return models.HoldResponse{}, err
+	}
+	if sourceAccount.CurrencyCode != destAccount.CurrencyCode {
+		return models.HoldResponse{}, appErr.ErrCurrencyMismatch
+	}
+	if s.limitService != nil {
+		limits, err := s.limitService.GetLimits(ctx, args.SourceAccountId)
+		if err != nil {
+			log.Printf("[%s] failed to get limits of account %d: %v", fName, args.SourceAccountId, err)
+			return models.HoldResponse{}, err
+		}
+		if err := limits.CheckAmount(args.Amount); err != nil {
+			return models.HoldResponse{}, err
+		}
+	}
+
+	timeNow := time.Now().UTC()
+	hold := models.Hold{
+		HoldId:               generateHoldId(),
+		SourceAccountId:      args.SourceAccountId,
+		DestinationAccountId: args.DestinationAccountId,
+		Amount:               args.Amount,
+		CurrencyCode:         sourceAccount.CurrencyCode,
+		Status:               models.HoldStatusPending,
+		ExpiresAt:            timeNow.Add(s.holdTTL),
+		CreatedAt:            timeNow,
+		UpdatedAt:            timeNow,
+	}
+	availableBalance, err := s.holdRepo.CreateHold(hold)
+	if err != nil {
+		log.Printf("[%s] failed to create hold: %v", fName, err)
+		return models.HoldResponse{}, err
+	}
+	return models.NewHoldResponse(hold, availableBalance), nil
+}
+
+// CaptureHold is a service method that turns a pending hold into a transfer with its own TXN reference. The
+// capture counts against the transfer limits of the source account as a transfer of the captured amount would
+func (s *HoldService) CaptureHold(ctx context.Context, args models.CaptureHoldArgs) (models.HoldResponse, error) {
+	fName := "HoldService.CaptureHold"
+	if !isValidHoldId(args.HoldId) {
+		return models.HoldResponse{}, appErr.ErrInvalidHoldId
+	}
+	if args.Amount != nil && !args.Amount.IsPositive() {
+		return models.HoldResponse{}, appErr.ErrInvalidAmount
+	}
+
+	hold, err := s.holdRepo.GetHold(args.HoldId)
+	if err != nil {
+		return models.HoldResponse{}, err
+	}
+	if err := authorizeAccountId(ctx, s.accountRepo, hold.SourceAccountId); err != nil {
+		return models.HoldResponse{}, err
+	}
+	if s.limitService != nil {
+		if args.Limits, err = s.limitService.GetLimits(ctx, hold.SourceAccountId); err != nil {
+			log.Printf("[%s] failed to get limits of account %d: %v", fName, hold.SourceAccountId, err)
+			return models.HoldResponse{}, err
+		}
+		amount := hold.Amount
+		if args.Amount != nil {
+			amount = *args.Amount
+		}
+		if err := args.Limits.CheckAmount(amount); err != nil {
+			return models.HoldResponse{}, err
+		}
+	}
+
+	args.Reference = generateTransactionRef()
+	captured, availableBalance, err := s.holdRepo.CaptureHold(args, time.Now().UTC())
+	if err != nil {
+		log.Printf("[%s] failed to capture hold %s: %v", fName, args.HoldId, err)
+		return models.HoldResponse{}, err
+	}
+	return models.NewHoldResponse(*captured, availableBalance), nil
+}
+
+// VoidHold is a service method that releases a pending hold without moving money
+func (s *HoldService) VoidHold(ctx context.Context, holdId string) (models.HoldResponse, error) {
+	fName := "HoldService.VoidHold"
+	if !isValidHoldId(holdId) {
+		return models.HoldResponse{}, appErr.ErrInvalidHoldId
+	}
+	if err := s.authorizeHold(ctx, holdId); err != nil {
+		return models.HoldResponse{}, err
+	}
+
+	hold, availableBalance, err := s.holdRepo.VoidHold(holdId)
+	if err != nil {
+		log.Printf("[%s] failed to void hold %s: %v", fName, holdId, err)
+		return models.HoldResponse{}, err
+	}
+	return models.NewHoldResponse(*hold, availableBalance), nil
+}
+
+// authorizeHold checks that the client of ctx owns the account a hold reserves funds on
+func (s *HoldService) authorizeHold(ctx context.Context, holdId string) error {
+	if _, ok := restrictedApiKey(ctx); !ok {
+		return nil
+	}
+	hold, err := s.holdRepo.GetHold(holdId)
+	if err != nil {
+		return err
+	}
+	return authorizeAccountId(ctx, s.accountRepo, hold.SourceAccountId)
+}
+
+// ExpireHolds is a service method that releases every pending hold past its expiry and returns how many were released
+func (s *HoldService) ExpireHolds(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		expired, err := s.holdRepo.ExpireHolds(time.Now().UTC(), expireBatchSize)
+		total += expired
+		if err != nil || expired < expireBatchSize {
+			return total, err
+		}
+	}
+}
+
+// RunExpirySweeper releases expired holds every interval until ctx is done
+func (s *HoldService) RunExpirySweeper(ctx context.Context, interval time.Duration) {
+	fName := "HoldService.RunExpirySweeper"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expired, err := s.ExpireHolds(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[%s] failed to expire holds: %v", fName, err)
+			}
+			if expired > 0 {
+				log.Printf("[%s] released %d expired holds", fName, expired)
+			}
+		}
+	}
+}
+
+// generateHoldId is a method that creates a unique hold id
+func generateHoldId() string {
+	return fmt.Sprintf("%s%s", holdIdPrefix, uuid.New().String())
+}
+
+// isValidHoldId is a method that checks the id was created by generateHoldId
+func isValidHoldId(holdId string) bool {
+	id, ok := strings.CutPrefix(holdId, holdIdPrefix)
+	if !ok {
+		return false
+	}
+	_, err := uuid.Parse(id)
+	return err == nil
+}
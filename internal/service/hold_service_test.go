@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	serviceMocks "github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testHoldId = "HLD-7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestHoldService_CreateHold(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        models.CreateHoldArgs
+		setupMocks  func(holdRepo *mocks.IHoldRepository, accountRepo *mocks.IAccountRepository)
+		expectedErr error
+	}{
+		{
+			name: "Success",
+			args: models.CreateHoldArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(40)},
+			setupMocks: func(holdRepo *mocks.IHoldRepository, accountRepo *mocks.IAccountRepository) {
+				accountRepo.On("GetByAccountId", int64(1)).
+					Return(&models.Account{AccountId: 1, Balance: models.NewMoney(100), CurrencyCode: "USD"}, nil).Once()
+				accountRepo.On("GetByAccountId", int64(2)).
+					Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil).Once()
+				holdRepo.On("CreateHold", mock.MatchedBy(func(h models.Hold) bool {
+					return strings.HasPrefix(h.HoldId, holdIdPrefix) && h.Status == models.HoldStatusPending &&
+						h.CurrencyCode == "USD" && h.ExpiresAt.Sub(h.CreatedAt) == time.Hour
+				})).Return(models.NewMoney(60), nil).Once()
+			},
+		},
+		{
+			name: "Currency Mismatch",
+			args: models.CreateHoldArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(40)},
+			setupMocks: func(holdRepo *mocks.IHoldRepository, accountRepo *mocks.IAccountRepository) {
+				accountRepo.On("GetByAccountId", int64(1)).
+					Return(&models.Account{AccountId: 1, Balance: models.NewMoney(100), CurrencyCode: "USD"}, nil).Once()
+				accountRepo.On("GetByAccountId", int64(2)).
+					Return(&models.Account{AccountId: 2, CurrencyCode: "EUR"}, nil).Once()
+			},
+			expectedErr: appErr.ErrCurrencyMismatch,
+		},
+		{
+			name:        "Invalid Amount",
+			args:        models.CreateHoldArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(0)},
+			setupMocks:  func(*mocks.IHoldRepository, *mocks.IAccountRepository) {},
+			expectedErr: appErr.ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holdRepo := mocks.NewIHoldRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			tt.setupMocks(holdRepo, accountRepo)
+			service := NewHoldService(holdRepo, accountRepo, nil, time.Hour)
+
+			resp, err := service.CreateHold(context.Background(), tt.args)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, models.HoldStatusPending, resp.Status)
+				assert.Equal(t, models.NewMoney(60), resp.AvailableBalance)
+			}
+		})
+	}
+}
+
+func TestHoldService_CaptureHold(t *testing.T) {
+	t.Run("Invalid Hold Id", func(t *testing.T) {
+		service := NewHoldService(mocks.NewIHoldRepository(t), mocks.NewIAccountRepository(t), nil, time.Hour)
+
+		_, err := service.CaptureHold(context.Background(), models.CaptureHoldArgs{HoldId: "TXN-7c9e6679-7425-40de-944b-e07fc1f90ae7"})
+		assert.Equal(t, appErr.ErrInvalidHoldId, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		holdRepo := mocks.NewIHoldRepository(t)
+		service := NewHoldService(holdRepo, mocks.NewIAccountRepository(t), nil, time.Hour)
+
+		captured := models.NewMoney(25)
+		holdRepo.On("GetHold", testHoldId).Return(&models.Hold{HoldId: testHoldId, SourceAccountId: 1, Amount: models.NewMoney(40)}, nil).Once()
+		holdRepo.On("CaptureHold", mock.MatchedBy(func(args models.CaptureHoldArgs) bool {
+			return args.HoldId == testHoldId && isValidTransactionRef(args.Reference)
+		}), mock.AnythingOfType("time.Time")).Return(&models.Hold{
+			HoldId:         testHoldId,
+			Amount:         models.NewMoney(40),
+			Status:         models.HoldStatusCaptured,
+			CapturedAmount: &captured,
+			Reference:      sql.NullString{String: "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11", Valid: true},
+		}, models.NewMoney(75), nil).Once()
+
+		resp, err := service.CaptureHold(context.Background(), models.CaptureHoldArgs{HoldId: testHoldId, Amount: &captured})
+		assert.NoError(t, err)
+		assert.Equal(t, models.HoldStatusCaptured, resp.Status)
+		assert.Equal(t, &captured, resp.CapturedAmount)
+		assert.Equal(t, models.NewMoney(75), resp.AvailableBalance)
+	})
+}
+
+func TestHoldService_Limits(t *testing.T) {
+	maxAmount := models.MustParseMoney("100")
+	maxDailyAmount := models.MustParseMoney("500")
+	limits := models.TransferLimits{MaxAmount: &maxAmount, MaxDailyAmount: &maxDailyAmount}
+
+	newService := func(t *testing.T) (*HoldService, *mocks.IHoldRepository, *mocks.IAccountRepository) {
+		holdRepo := mocks.NewIHoldRepository(t)
+		accountRepo := mocks.NewIAccountRepository(t)
+		limitService := serviceMocks.NewITransferLimitService(t)
+		limitService.On("GetLimits", mock.Anything, int64(1)).Return(limits, nil).Once()
+		return NewHoldService(holdRepo, accountRepo, limitService, time.Hour), holdRepo, accountRepo
+	}
+
+	t.Run("hold over the per-transfer cap is rejected", func(t *testing.T) {
+		service, _, accountRepo := newService(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, Balance: models.NewMoney(1000), CurrencyCode: "USD"}, nil).Once()
+		accountRepo.On("GetByAccountId", int64(2)).Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil).Once()
+
+		_, err := service.CreateHold(context.Background(), models.CreateHoldArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("100.01")})
+		assert.Equal(t, appErr.ErrTransferAmountLimitExceeded, err)
+	})
+
+	t.Run("capture over the per-transfer cap is rejected", func(t *testing.T) {
+		service, holdRepo, _ := newService(t)
+		// The hold was created before the cap was lowered
+		holdRepo.On("GetHold", testHoldId).Return(&models.Hold{HoldId: testHoldId, SourceAccountId: 1, Amount: models.NewMoney(150)}, nil).Once()
+
+		_, err := service.CaptureHold(context.Background(), models.CaptureHoldArgs{HoldId: testHoldId})
+		assert.Equal(t, appErr.ErrTransferAmountLimitExceeded, err)
+	})
+
+	t.Run("limits are passed on to the capture to be checked under the lock", func(t *testing.T) {
+		service, holdRepo, _ := newService(t)
+		holdRepo.On("GetHold", testHoldId).Return(&models.Hold{HoldId: testHoldId, SourceAccountId: 1, Amount: models.NewMoney(80)}, nil).Once()
+		holdRepo.On("CaptureHold", mock.MatchedBy(func(args models.CaptureHoldArgs) bool {
+			return args.Limits.MaxDailyAmount == &maxDailyAmount
+		}), mock.AnythingOfType("time.Time")).Return(nil, models.Money{}, appErr.ErrDailyAmountLimitExceeded).Once()
+
+		_, err := service.CaptureHold(context.Background(), models.CaptureHoldArgs{HoldId: testHoldId})
+		assert.Equal(t, appErr.ErrDailyAmountLimitExceeded, err)
+	})
+}
+
+func TestHoldService_CaptureHold_Ownership(t *testing.T) {
+	holdRepo := mocks.NewIHoldRepository(t)
+	accountRepo := mocks.NewIAccountRepository(t)
+	service := NewHoldService(holdRepo, accountRepo, nil, time.Hour)
+
+	holdRepo.On("GetHold", testHoldId).Return(&models.Hold{HoldId: testHoldId, SourceAccountId: 1}, nil).Once()
+	accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, OwnerClientId: sql.NullInt64{Int64: 7, Valid: true}}, nil).Once()
+
+	_, err := service.CaptureHold(clientContext(8, models.ScopeTransactionsWrite), models.CaptureHoldArgs{HoldId: testHoldId})
+	assert.Equal(t, appErr.ErrAccountForbidden, err)
+}
+
+func TestHoldService_VoidHold(t *testing.T) {
+	holdRepo := mocks.NewIHoldRepository(t)
+	service := NewHoldService(holdRepo, mocks.NewIAccountRepository(t), nil, time.Hour)
+
+	holdRepo.On("VoidHold", testHoldId).
+		Return(nil, models.Money{}, appErr.ErrHoldNotPending).Once()
+
+	_, err := service.VoidHold(context.Background(), testHoldId)
+	assert.Equal(t, appErr.ErrHoldNotPending, err)
+}
+
+func TestHoldService_ExpireHolds(t *testing.T) {
+	holdRepo := mocks.NewIHoldRepository(t)
+	service := NewHoldService(holdRepo, mocks.NewIAccountRepository(t), nil, time.Hour)
+
+	holdRepo.On("ExpireHolds", mock.AnythingOfType("time.Time"), expireBatchSize).Return(expireBatchSize, nil).Twice()
+	holdRepo.On("ExpireHolds", mock.AnythingOfType("time.Time"), expireBatchSize).Return(3, nil).Once()
+
+	expired, err := service.ExpireHolds(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2*expireBatchSize+3, expired)
+}
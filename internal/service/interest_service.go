@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"time"
+)
+
+type InterestService struct {
+	interestRepo     repository.IInterestRepository
+	accountRepo      repository.IAccountRepository
+	expenseAccountId int64
+}
+
+// NewInterestService creates the interest service. Interest is paid from expenseAccountId, which must hold the
+// currency of the accounts it pays
+func NewInterestService(interestRepo repository.IInterestRepository, accountRepo repository.IAccountRepository,
+	expenseAccountId int64) *InterestService {
+	return &InterestService{interestRepo: interestRepo, accountRepo: accountRepo, expenseAccountId: expenseAccountId}
+}
+
+type IInterestService interface {
+	SetInterestRate(ctx context.Context, accountId int64, rate models.InterestRate) (models.InterestRateResponse, error)
+	RunInterest(ctx context.Context, asOf time.Time, dryRun bool) (models.InterestRunResult, error)
+}
+
+// SetInterestRate is a service method that sets the annual rate an account earns from the first day it has not
+// accrued interest for yet. A rate of 0 stops the account from earning interest
+func (s *InterestService) SetInterestRate(ctx context.Context, accountId int64, rate models.InterestRate) (models.InterestRateResponse, error) {
+	fName := "InterestService.SetInterestRate"
+	if accountId <= 0 {
+		return models.InterestRateResponse{}, appErr.ErrInvalidAccountId
+	}
+	if !rate.IsValid() {
+		return models.InterestRateResponse{}, appErr.ErrInvalidInterestRate
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.InterestRateResponse{}, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return models.InterestRateResponse{}, appErr.ErrAccountClosed
+	}
+
+	if err := s.interestRepo.SetRate(accountId, rate); err != nil {
+		log.Printf("[%s] failed to set interest rate of account %d: %v", fName, accountId, err)
+		return models.InterestRateResponse{}, err
+	}
+	return models.InterestRateResponse{AccountId: accountId, InterestRate: rate}, nil
+}
+
+// RunInterest is a service method that accrues the interest of every UTC day before asOf that has not been accrued
+// yet, then pays the accruals of every month before the month of asOf. A failed payment is reported in its posting
+// and retried by the next run. With dryRun nothing is written and the result shows what a run would do
+func (s *InterestService) RunInterest(ctx context.Context, asOf time.Time, dryRun bool) (models.InterestRunResult, error) {
+	fName := "InterestService.RunInterest"
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+	result := models.InterestRunResult{AsOf: today, DryRun: dryRun, Accruals: []models.InterestAccrual{}, Postings: []models.InterestPosting{}}
+
+	accounts, err := s.interestRepo.ListInterestAccounts()
+	if err != nil {
+		log.Printf("[%s] failed to list interest accounts: %v", fName, err)
+		return result, err
+	}
+	for _, account := range accounts {
+		for day := account.AccrueFrom; day.Before(today); day = day.AddDate(0, 0, 1) {
+			if ctx.Err() != nil {
+				return result, ctx.Err()
+			}
+			balance, err := s.interestRepo.GetEndOfDayBalance(account.AccountId, day)
+			if err != nil {
+				return result, err
+			}
+			result.Accruals = append(result.Accruals, models.InterestAccrual{
+				AccountId:    account.AccountId,
+				AccrualDate:  day,
+				Balance:      balance,
+				AnnualRate:   account.Rate.AnnualRate,
+				DayCount:     account.Rate.DayCount,
+				Amount:       account.Rate.DailyInterest(balance, day),
+				CurrencyCode: account.CurrencyCode,
+			})
+		}
+	}
+	if !dryRun && len(result.Accruals) > 0 {
+		if err := s.interestRepo.SaveAccruals(result.Accruals); err != nil {
+			log.Printf("[%s] failed to save accruals: %v", fName, err)
+			return result, err
+		}
+	}
+
+	// Months are paid once they are over, up to the last day of the previous month
+	through := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	unposted, err := s.interestRepo.ListUnpostedAccruals(through)
+	if err != nil {
+		log.Printf("[%s] failed to list unposted accruals: %v", fName, err)
+		return result, err
+	}
+	if dryRun {
+		for _, accrual := range result.Accruals {
+			if !accrual.AccrualDate.After(through) {
+				unposted = append(unposted, accrual)
+			}
+		}
+	}
+
+	for _, posting := range sumAccruals(unposted, through) {
+		if !dryRun {
+			posting = s.postInterest(posting)
+		}
+		if posting.Amount.IsPositive() || posting.ErrorMessage != "" {
+			result.Postings = append(result.Postings, posting)
+		}
+	}
+	return result, nil
+}
+
+// postInterest pays the accruals summed in posting and returns the outcome
+func (s *InterestService) postInterest(posting models.InterestPosting) models.InterestPosting {
+	fName := "InterestService.postInterest"
+	paid, err := s.interestRepo.PostInterest(models.PostInterestArgs{
+		AccountId:        posting.AccountId,
+		ExpenseAccountId: s.expenseAccountId,
+		Through:          posting.Through,
+		Reference:        generateTransactionRef(),
+	})
+	if err != nil {
+		log.Printf("[%s] failed to pay interest of account %d: %v", fName, posting.AccountId, err)
+		_, err = appErr.HTTPStatus(err)
+		posting.ErrorMessage = err.Error()
+		return posting
+	}
+	return paid
+}
+
+// sumAccruals returns one posting per account of accruals, in the order the accounts first appear, with the sum
+// of their amounts rounded to the minor units of the currency
+func sumAccruals(accruals []models.InterestAccrual, through time.Time) []models.InterestPosting {
+	var postings []models.InterestPosting
+	byAccount := map[int64]int{}
+	sums := map[int64]models.Money{}
+	for _, accrual := range accruals {
+		if _, ok := byAccount[accrual.AccountId]; !ok {
+			byAccount[accrual.AccountId] = len(postings)
+			postings = append(postings, models.InterestPosting{AccountId: accrual.AccountId, Through: through, CurrencyCode: accrual.CurrencyCode})
+		}
+		sums[accrual.AccountId] = sums[accrual.AccountId].Add(accrual.Amount)
+	}
+	for i := range postings {
+		posting := &postings[i]
+		posting.Amount = sums[posting.AccountId]
+		if currency, err := models.LookupCurrency(posting.CurrencyCode); err == nil {
+			posting.Amount = currency.Round(posting.Amount)
+		}
+	}
+	return postings
+}
+
+// RunInterestJob accrues and pays interest every interval until ctx is done
+func (s *InterestService) RunInterestJob(ctx context.Context, interval time.Duration) {
+	fName := "InterestService.RunInterestJob"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			result, err := s.RunInterest(ctx, time.Now().UTC(), false)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[%s] failed to run interest: %v", fName, err)
+			}
+			if len(result.Accruals) > 0 || len(result.Postings) > 0 {
+				log.Printf("[%s] accrued %d days and made %d interest postings", fName, len(result.Accruals), len(result.Postings))
+			}
+		}
+	}
+}
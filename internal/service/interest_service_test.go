@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInterestService_SetInterestRate(t *testing.T) {
+	rate := models.InterestRate{AnnualRate: models.MustParseMoney("2.5"), DayCount: models.DayCountActual365}
+
+	tests := []struct {
+		name          string
+		rate          models.InterestRate
+		account       *models.Account
+		expectSet     bool
+		expectedError error
+	}{
+		{
+			name:      "rate set",
+			rate:      rate,
+			account:   &models.Account{AccountId: 7, Status: models.AccountStatusActive},
+			expectSet: true,
+		},
+		{
+			name:          "unknown day count",
+			rate:          models.InterestRate{AnnualRate: models.MustParseMoney("2.5"), DayCount: "30/360"},
+			expectedError: appErr.ErrInvalidInterestRate,
+		},
+		{
+			name:          "closed account",
+			rate:          rate,
+			account:       &models.Account{AccountId: 7, Status: models.AccountStatusClosed},
+			expectedError: appErr.ErrAccountClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interestRepo := mocks.NewIInterestRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			if tt.account != nil {
+				accountRepo.On("GetByAccountId", int64(7)).Return(tt.account, nil).Once()
+			}
+			if tt.expectSet {
+				interestRepo.On("SetRate", int64(7), tt.rate).Return(nil).Once()
+			}
+
+			resp, err := NewInterestService(interestRepo, accountRepo, 9).SetInterestRate(context.Background(), 7, tt.rate)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.InterestRateResponse{AccountId: 7, InterestRate: tt.rate}, resp)
+			}
+		})
+	}
+}
+
+func TestInterestService_RunInterest(t *testing.T) {
+	rate := models.InterestRate{AnnualRate: models.MustParseMoney("3.65"), DayCount: models.DayCountActual365}
+	asOf := time.Date(2024, 3, 2, 15, 0, 0, 0, time.UTC)
+	feb29 := time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+	mar1 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	stored := models.InterestAccrual{AccountId: 7, AccrualDate: time.Date(2024, 2, 28, 0, 0, 0, 0, time.UTC), Amount: models.MustParseMoney("0.1"), CurrencyCode: "USD"}
+
+	setup := func(t *testing.T) *mocks.IInterestRepository {
+		interestRepo := mocks.NewIInterestRepository(t)
+		interestRepo.On("ListInterestAccounts").Return([]models.InterestAccount{
+			{AccountId: 7, CurrencyCode: "USD", Rate: rate, AccrueFrom: feb29},
+		}, nil).Once()
+		interestRepo.On("GetEndOfDayBalance", int64(7), feb29).Return(models.NewMoney(1000), nil).Once()
+		interestRepo.On("GetEndOfDayBalance", int64(7), mar1).Return(models.NewMoney(2000), nil).Once()
+		return interestRepo
+	}
+	expectedAccruals := []models.InterestAccrual{
+		{AccountId: 7, AccrualDate: feb29, Balance: models.NewMoney(1000), AnnualRate: rate.AnnualRate, DayCount: rate.DayCount, Amount: models.MustParseMoney("0.1"), CurrencyCode: "USD"},
+		{AccountId: 7, AccrualDate: mar1, Balance: models.NewMoney(2000), AnnualRate: rate.AnnualRate, DayCount: rate.DayCount, Amount: models.MustParseMoney("0.2"), CurrencyCode: "USD"},
+	}
+
+	t.Run("dry run writes nothing and pays the accruals of February", func(t *testing.T) {
+		interestRepo := setup(t)
+		interestRepo.On("ListUnpostedAccruals", feb29).Return([]models.InterestAccrual{stored}, nil).Once()
+
+		result, err := NewInterestService(interestRepo, mocks.NewIAccountRepository(t), 9).RunInterest(context.Background(), asOf, true)
+		assert.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, expectedAccruals, result.Accruals)
+		assert.Equal(t, []models.InterestPosting{
+			{AccountId: 7, Through: feb29, Amount: models.MustParseMoney("0.2"), CurrencyCode: "USD"},
+		}, result.Postings)
+	})
+
+	paid := models.InterestPosting{AccountId: 7, Through: feb29, Amount: models.MustParseMoney("0.2"), CurrencyCode: "USD", Reference: "TXN-123456"}
+	tests := []struct {
+		name     string
+		postErr  error
+		expected models.InterestPosting
+	}{
+		{
+			name:     "accruals saved and paid",
+			expected: paid,
+		},
+		{
+			name:     "failed payment reported",
+			postErr:  appErr.ErrInsufficientBalance,
+			expected: models.InterestPosting{AccountId: 7, Through: feb29, Amount: models.MustParseMoney("0.2"), CurrencyCode: "USD", ErrorMessage: appErr.ErrInsufficientBalance.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interestRepo := setup(t)
+			interestRepo.On("SaveAccruals", expectedAccruals).Return(nil).Once()
+			interestRepo.On("ListUnpostedAccruals", feb29).Return([]models.InterestAccrual{stored, expectedAccruals[0]}, nil).Once()
+			interestRepo.On("PostInterest", mock.MatchedBy(func(args models.PostInterestArgs) bool {
+				return args.AccountId == 7 && args.ExpenseAccountId == 9 && args.Through.Equal(feb29) && args.Reference != ""
+			})).Return(paid, tt.postErr).Once()
+
+			result, err := NewInterestService(interestRepo, mocks.NewIAccountRepository(t), 9).RunInterest(context.Background(), asOf, false)
+			assert.NoError(t, err)
+			assert.False(t, result.DryRun)
+			assert.Equal(t, []models.InterestPosting{tt.expected}, result.Postings)
+		})
+	}
+}
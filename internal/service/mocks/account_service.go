@@ -7,6 +7,8 @@ import (
 
 	models "github.com/bhuvi1021/TripleA/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IAccountService is an autogenerated mock type for the IAccountService type
@@ -22,6 +24,63 @@ func (_m *IAccountService) EXPECT() *IAccountService_Expecter {
 	return &IAccountService_Expecter{mock: &_m.Mock}
 }
 
+// CloseAccount provides a mock function with given fields: ctx, args
+func (_m *IAccountService) CloseAccount(ctx context.Context, args models.CloseAccountArgs) (models.CloseAccountResponse, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseAccount")
+	}
+
+	var r0 models.CloseAccountResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CloseAccountArgs) (models.CloseAccountResponse, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CloseAccountArgs) models.CloseAccountResponse); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.CloseAccountResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CloseAccountArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_CloseAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseAccount'
+type IAccountService_CloseAccount_Call struct {
+	*mock.Call
+}
+
+// CloseAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.CloseAccountArgs
+func (_e *IAccountService_Expecter) CloseAccount(ctx interface{}, args interface{}) *IAccountService_CloseAccount_Call {
+	return &IAccountService_CloseAccount_Call{Call: _e.mock.On("CloseAccount", ctx, args)}
+}
+
+func (_c *IAccountService_CloseAccount_Call) Run(run func(ctx context.Context, args models.CloseAccountArgs)) *IAccountService_CloseAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CloseAccountArgs))
+	})
+	return _c
+}
+
+func (_c *IAccountService_CloseAccount_Call) Return(_a0 models.CloseAccountResponse, _a1 error) *IAccountService_CloseAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_CloseAccount_Call) RunAndReturn(run func(context.Context, models.CloseAccountArgs) (models.CloseAccountResponse, error)) *IAccountService_CloseAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAccount provides a mock function with given fields: ctx, req
 func (_m *IAccountService) CreateAccount(ctx context.Context, req models.CreateAccountRequest) error {
 	ret := _m.Called(ctx, req)
@@ -69,6 +128,65 @@ func (_c *IAccountService_CreateAccount_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// FreezeAccount provides a mock function with given fields: ctx, id
+func (_m *IAccountService) FreezeAccount(ctx context.Context, id int64) (*models.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeAccount")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_FreezeAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeAccount'
+type IAccountService_FreezeAccount_Call struct {
+	*mock.Call
+}
+
+// FreezeAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *IAccountService_Expecter) FreezeAccount(ctx interface{}, id interface{}) *IAccountService_FreezeAccount_Call {
+	return &IAccountService_FreezeAccount_Call{Call: _e.mock.On("FreezeAccount", ctx, id)}
+}
+
+func (_c *IAccountService_FreezeAccount_Call) Run(run func(ctx context.Context, id int64)) *IAccountService_FreezeAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IAccountService_FreezeAccount_Call) Return(_a0 *models.Account, _a1 error) *IAccountService_FreezeAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_FreezeAccount_Call) RunAndReturn(run func(context.Context, int64) (*models.Account, error)) *IAccountService_FreezeAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetAccount provides a mock function with given fields: ctx, id
 func (_m *IAccountService) GetAccount(ctx context.Context, id int64) (*models.Account, error) {
 	ret := _m.Called(ctx, id)
@@ -128,6 +246,243 @@ func (_c *IAccountService_GetAccount_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// GetBalanceAsOf provides a mock function with given fields: ctx, id, asOf
+func (_m *IAccountService) GetBalanceAsOf(ctx context.Context, id int64, asOf time.Time) (models.BalanceAsOfResponse, error) {
+	ret := _m.Called(ctx, id, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAsOf")
+	}
+
+	var r0 models.BalanceAsOfResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) (models.BalanceAsOfResponse, error)); ok {
+		return rf(ctx, id, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) models.BalanceAsOfResponse); ok {
+		r0 = rf(ctx, id, asOf)
+	} else {
+		r0 = ret.Get(0).(models.BalanceAsOfResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, time.Time) error); ok {
+		r1 = rf(ctx, id, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_GetBalanceAsOf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAsOf'
+type IAccountService_GetBalanceAsOf_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAsOf is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - asOf time.Time
+func (_e *IAccountService_Expecter) GetBalanceAsOf(ctx interface{}, id interface{}, asOf interface{}) *IAccountService_GetBalanceAsOf_Call {
+	return &IAccountService_GetBalanceAsOf_Call{Call: _e.mock.On("GetBalanceAsOf", ctx, id, asOf)}
+}
+
+func (_c *IAccountService_GetBalanceAsOf_Call) Run(run func(ctx context.Context, id int64, asOf time.Time)) *IAccountService_GetBalanceAsOf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *IAccountService_GetBalanceAsOf_Call) Return(_a0 models.BalanceAsOfResponse, _a1 error) *IAccountService_GetBalanceAsOf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_GetBalanceAsOf_Call) RunAndReturn(run func(context.Context, int64, time.Time) (models.BalanceAsOfResponse, error)) *IAccountService_GetBalanceAsOf_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAccountOwner provides a mock function with given fields: ctx, id, ownerClientId
+func (_m *IAccountService) SetAccountOwner(ctx context.Context, id int64, ownerClientId int64) (*models.Account, error) {
+	ret := _m.Called(ctx, id, ownerClientId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAccountOwner")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) (*models.Account, error)); ok {
+		return rf(ctx, id, ownerClientId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Account); ok {
+		r0 = rf(ctx, id, ownerClientId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, id, ownerClientId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_SetAccountOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAccountOwner'
+type IAccountService_SetAccountOwner_Call struct {
+	*mock.Call
+}
+
+// SetAccountOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - ownerClientId int64
+func (_e *IAccountService_Expecter) SetAccountOwner(ctx interface{}, id interface{}, ownerClientId interface{}) *IAccountService_SetAccountOwner_Call {
+	return &IAccountService_SetAccountOwner_Call{Call: _e.mock.On("SetAccountOwner", ctx, id, ownerClientId)}
+}
+
+func (_c *IAccountService_SetAccountOwner_Call) Run(run func(ctx context.Context, id int64, ownerClientId int64)) *IAccountService_SetAccountOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *IAccountService_SetAccountOwner_Call) Return(_a0 *models.Account, _a1 error) *IAccountService_SetAccountOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_SetAccountOwner_Call) RunAndReturn(run func(context.Context, int64, int64) (*models.Account, error)) *IAccountService_SetAccountOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOverdraftLimit provides a mock function with given fields: ctx, id, limit
+func (_m *IAccountService) SetOverdraftLimit(ctx context.Context, id int64, limit models.Money) (*models.Account, error) {
+	ret := _m.Called(ctx, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOverdraftLimit")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Money) (*models.Account, error)); ok {
+		return rf(ctx, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.Money) *models.Account); ok {
+		r0 = rf(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.Money) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_SetOverdraftLimit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOverdraftLimit'
+type IAccountService_SetOverdraftLimit_Call struct {
+	*mock.Call
+}
+
+// SetOverdraftLimit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - limit models.Money
+func (_e *IAccountService_Expecter) SetOverdraftLimit(ctx interface{}, id interface{}, limit interface{}) *IAccountService_SetOverdraftLimit_Call {
+	return &IAccountService_SetOverdraftLimit_Call{Call: _e.mock.On("SetOverdraftLimit", ctx, id, limit)}
+}
+
+func (_c *IAccountService_SetOverdraftLimit_Call) Run(run func(ctx context.Context, id int64, limit models.Money)) *IAccountService_SetOverdraftLimit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *IAccountService_SetOverdraftLimit_Call) Return(_a0 *models.Account, _a1 error) *IAccountService_SetOverdraftLimit_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_SetOverdraftLimit_Call) RunAndReturn(run func(context.Context, int64, models.Money) (*models.Account, error)) *IAccountService_SetOverdraftLimit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfreezeAccount provides a mock function with given fields: ctx, id
+func (_m *IAccountService) UnfreezeAccount(ctx context.Context, id int64) (*models.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfreezeAccount")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*models.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IAccountService_UnfreezeAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfreezeAccount'
+type IAccountService_UnfreezeAccount_Call struct {
+	*mock.Call
+}
+
+// UnfreezeAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *IAccountService_Expecter) UnfreezeAccount(ctx interface{}, id interface{}) *IAccountService_UnfreezeAccount_Call {
+	return &IAccountService_UnfreezeAccount_Call{Call: _e.mock.On("UnfreezeAccount", ctx, id)}
+}
+
+func (_c *IAccountService_UnfreezeAccount_Call) Run(run func(ctx context.Context, id int64)) *IAccountService_UnfreezeAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IAccountService_UnfreezeAccount_Call) Return(_a0 *models.Account, _a1 error) *IAccountService_UnfreezeAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IAccountService_UnfreezeAccount_Call) RunAndReturn(run func(context.Context, int64) (*models.Account, error)) *IAccountService_UnfreezeAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewIAccountService creates a new instance of IAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIAccountService(t interface {
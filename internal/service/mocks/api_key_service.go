@@ -0,0 +1,256 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IApiKeyService is an autogenerated mock type for the IApiKeyService type
+type IApiKeyService struct {
+	mock.Mock
+}
+
+type IApiKeyService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IApiKeyService) EXPECT() *IApiKeyService_Expecter {
+	return &IApiKeyService_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function with given fields: ctx, key
+func (_m *IApiKeyService) Authenticate(ctx context.Context, key string) (models.ApiKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ApiKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ApiKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(models.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyService_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type IApiKeyService_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *IApiKeyService_Expecter) Authenticate(ctx interface{}, key interface{}) *IApiKeyService_Authenticate_Call {
+	return &IApiKeyService_Authenticate_Call{Call: _e.mock.On("Authenticate", ctx, key)}
+}
+
+func (_c *IApiKeyService_Authenticate_Call) Run(run func(ctx context.Context, key string)) *IApiKeyService_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IApiKeyService_Authenticate_Call) Return(_a0 models.ApiKey, _a1 error) *IApiKeyService_Authenticate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyService_Authenticate_Call) RunAndReturn(run func(context.Context, string) (models.ApiKey, error)) *IApiKeyService_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateApiKey provides a mock function with given fields: ctx, args
+func (_m *IApiKeyService) CreateApiKey(ctx context.Context, args models.CreateApiKeyArgs) (models.CreatedApiKey, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateApiKey")
+	}
+
+	var r0 models.CreatedApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateApiKeyArgs) (models.CreatedApiKey, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateApiKeyArgs) models.CreatedApiKey); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.CreatedApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CreateApiKeyArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyService_CreateApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateApiKey'
+type IApiKeyService_CreateApiKey_Call struct {
+	*mock.Call
+}
+
+// CreateApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.CreateApiKeyArgs
+func (_e *IApiKeyService_Expecter) CreateApiKey(ctx interface{}, args interface{}) *IApiKeyService_CreateApiKey_Call {
+	return &IApiKeyService_CreateApiKey_Call{Call: _e.mock.On("CreateApiKey", ctx, args)}
+}
+
+func (_c *IApiKeyService_CreateApiKey_Call) Run(run func(ctx context.Context, args models.CreateApiKeyArgs)) *IApiKeyService_CreateApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CreateApiKeyArgs))
+	})
+	return _c
+}
+
+func (_c *IApiKeyService_CreateApiKey_Call) Return(_a0 models.CreatedApiKey, _a1 error) *IApiKeyService_CreateApiKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyService_CreateApiKey_Call) RunAndReturn(run func(context.Context, models.CreateApiKeyArgs) (models.CreatedApiKey, error)) *IApiKeyService_CreateApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListApiKeys provides a mock function with given fields: ctx
+func (_m *IApiKeyService) ListApiKeys(ctx context.Context) ([]models.ApiKey, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListApiKeys")
+	}
+
+	var r0 []models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.ApiKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.ApiKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IApiKeyService_ListApiKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListApiKeys'
+type IApiKeyService_ListApiKeys_Call struct {
+	*mock.Call
+}
+
+// ListApiKeys is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IApiKeyService_Expecter) ListApiKeys(ctx interface{}) *IApiKeyService_ListApiKeys_Call {
+	return &IApiKeyService_ListApiKeys_Call{Call: _e.mock.On("ListApiKeys", ctx)}
+}
+
+func (_c *IApiKeyService_ListApiKeys_Call) Run(run func(ctx context.Context)) *IApiKeyService_ListApiKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IApiKeyService_ListApiKeys_Call) Return(_a0 []models.ApiKey, _a1 error) *IApiKeyService_ListApiKeys_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IApiKeyService_ListApiKeys_Call) RunAndReturn(run func(context.Context) ([]models.ApiKey, error)) *IApiKeyService_ListApiKeys_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, id
+func (_m *IApiKeyService) RevokeApiKey(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeApiKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// IApiKeyService_RevokeApiKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeApiKey'
+type IApiKeyService_RevokeApiKey_Call struct {
+	*mock.Call
+}
+
+// RevokeApiKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *IApiKeyService_Expecter) RevokeApiKey(ctx interface{}, id interface{}) *IApiKeyService_RevokeApiKey_Call {
+	return &IApiKeyService_RevokeApiKey_Call{Call: _e.mock.On("RevokeApiKey", ctx, id)}
+}
+
+func (_c *IApiKeyService_RevokeApiKey_Call) Run(run func(ctx context.Context, id int64)) *IApiKeyService_RevokeApiKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IApiKeyService_RevokeApiKey_Call) Return(_a0 error) *IApiKeyService_RevokeApiKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *IApiKeyService_RevokeApiKey_Call) RunAndReturn(run func(context.Context, int64) error) *IApiKeyService_RevokeApiKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIApiKeyService creates a new instance of IApiKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIApiKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IApiKeyService {
+	mock := &IApiKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
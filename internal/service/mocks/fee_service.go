@@ -0,0 +1,441 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IFeeService is an autogenerated mock type for the IFeeService type
+type IFeeService struct {
+	mock.Mock
+}
+
+type IFeeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IFeeService) EXPECT() *IFeeService_Expecter {
+	return &IFeeService_Expecter{mock: &_m.Mock}
+}
+
+// DeleteCurrencyFeeSchedule provides a mock function with given fields: ctx, currencyCode
+func (_m *IFeeService) DeleteCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error) {
+	ret := _m.Called(ctx, currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCurrencyFeeSchedule")
+	}
+
+	var r0 models.CurrencyFeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.CurrencyFeeScheduleResponse, error)); ok {
+		return rf(ctx, currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.CurrencyFeeScheduleResponse); ok {
+		r0 = rf(ctx, currencyCode)
+	} else {
+		r0 = ret.Get(0).(models.CurrencyFeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_DeleteCurrencyFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCurrencyFeeSchedule'
+type IFeeService_DeleteCurrencyFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteCurrencyFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - currencyCode string
+func (_e *IFeeService_Expecter) DeleteCurrencyFeeSchedule(ctx interface{}, currencyCode interface{}) *IFeeService_DeleteCurrencyFeeSchedule_Call {
+	return &IFeeService_DeleteCurrencyFeeSchedule_Call{Call: _e.mock.On("DeleteCurrencyFeeSchedule", ctx, currencyCode)}
+}
+
+func (_c *IFeeService_DeleteCurrencyFeeSchedule_Call) Run(run func(ctx context.Context, currencyCode string)) *IFeeService_DeleteCurrencyFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IFeeService_DeleteCurrencyFeeSchedule_Call) Return(_a0 models.CurrencyFeeScheduleResponse, _a1 error) *IFeeService_DeleteCurrencyFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_DeleteCurrencyFeeSchedule_Call) RunAndReturn(run func(context.Context, string) (models.CurrencyFeeScheduleResponse, error)) *IFeeService_DeleteCurrencyFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteFeeSchedule provides a mock function with given fields: ctx, accountId
+func (_m *IFeeService) DeleteFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error) {
+	ret := _m.Called(ctx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFeeSchedule")
+	}
+
+	var r0 models.FeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.FeeScheduleResponse, error)); ok {
+		return rf(ctx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.FeeScheduleResponse); ok {
+		r0 = rf(ctx, accountId)
+	} else {
+		r0 = ret.Get(0).(models.FeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_DeleteFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteFeeSchedule'
+type IFeeService_DeleteFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// DeleteFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+func (_e *IFeeService_Expecter) DeleteFeeSchedule(ctx interface{}, accountId interface{}) *IFeeService_DeleteFeeSchedule_Call {
+	return &IFeeService_DeleteFeeSchedule_Call{Call: _e.mock.On("DeleteFeeSchedule", ctx, accountId)}
+}
+
+func (_c *IFeeService_DeleteFeeSchedule_Call) Run(run func(ctx context.Context, accountId int64)) *IFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IFeeService_DeleteFeeSchedule_Call) Return(_a0 models.FeeScheduleResponse, _a1 error) *IFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_DeleteFeeSchedule_Call) RunAndReturn(run func(context.Context, int64) (models.FeeScheduleResponse, error)) *IFeeService_DeleteFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCurrencyFeeSchedule provides a mock function with given fields: ctx, currencyCode
+func (_m *IFeeService) GetCurrencyFeeSchedule(ctx context.Context, currencyCode string) (models.CurrencyFeeScheduleResponse, error) {
+	ret := _m.Called(ctx, currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyFeeSchedule")
+	}
+
+	var r0 models.CurrencyFeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.CurrencyFeeScheduleResponse, error)); ok {
+		return rf(ctx, currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.CurrencyFeeScheduleResponse); ok {
+		r0 = rf(ctx, currencyCode)
+	} else {
+		r0 = ret.Get(0).(models.CurrencyFeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_GetCurrencyFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrencyFeeSchedule'
+type IFeeService_GetCurrencyFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// GetCurrencyFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - currencyCode string
+func (_e *IFeeService_Expecter) GetCurrencyFeeSchedule(ctx interface{}, currencyCode interface{}) *IFeeService_GetCurrencyFeeSchedule_Call {
+	return &IFeeService_GetCurrencyFeeSchedule_Call{Call: _e.mock.On("GetCurrencyFeeSchedule", ctx, currencyCode)}
+}
+
+func (_c *IFeeService_GetCurrencyFeeSchedule_Call) Run(run func(ctx context.Context, currencyCode string)) *IFeeService_GetCurrencyFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IFeeService_GetCurrencyFeeSchedule_Call) Return(_a0 models.CurrencyFeeScheduleResponse, _a1 error) *IFeeService_GetCurrencyFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_GetCurrencyFeeSchedule_Call) RunAndReturn(run func(context.Context, string) (models.CurrencyFeeScheduleResponse, error)) *IFeeService_GetCurrencyFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFee provides a mock function with given fields: ctx, sourceAccount, amount
+func (_m *IFeeService) GetFee(ctx context.Context, sourceAccount *models.Account, amount models.Money) (*models.TransferFee, error) {
+	ret := _m.Called(ctx, sourceAccount, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFee")
+	}
+
+	var r0 *models.TransferFee
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, models.Money) (*models.TransferFee, error)); ok {
+		return rf(ctx, sourceAccount, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Account, models.Money) *models.TransferFee); ok {
+		r0 = rf(ctx, sourceAccount, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferFee)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Account, models.Money) error); ok {
+		r1 = rf(ctx, sourceAccount, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_GetFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFee'
+type IFeeService_GetFee_Call struct {
+	*mock.Call
+}
+
+// GetFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceAccount *models.Account
+//   - amount models.Money
+func (_e *IFeeService_Expecter) GetFee(ctx interface{}, sourceAccount interface{}, amount interface{}) *IFeeService_GetFee_Call {
+	return &IFeeService_GetFee_Call{Call: _e.mock.On("GetFee", ctx, sourceAccount, amount)}
+}
+
+func (_c *IFeeService_GetFee_Call) Run(run func(ctx context.Context, sourceAccount *models.Account, amount models.Money)) *IFeeService_GetFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Account), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *IFeeService_GetFee_Call) Return(_a0 *models.TransferFee, _a1 error) *IFeeService_GetFee_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_GetFee_Call) RunAndReturn(run func(context.Context, *models.Account, models.Money) (*models.TransferFee, error)) *IFeeService_GetFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFeeSchedule provides a mock function with given fields: ctx, accountId
+func (_m *IFeeService) GetFeeSchedule(ctx context.Context, accountId int64) (models.FeeScheduleResponse, error) {
+	ret := _m.Called(ctx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFeeSchedule")
+	}
+
+	var r0 models.FeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.FeeScheduleResponse, error)); ok {
+		return rf(ctx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.FeeScheduleResponse); ok {
+		r0 = rf(ctx, accountId)
+	} else {
+		r0 = ret.Get(0).(models.FeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_GetFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFeeSchedule'
+type IFeeService_GetFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// GetFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+func (_e *IFeeService_Expecter) GetFeeSchedule(ctx interface{}, accountId interface{}) *IFeeService_GetFeeSchedule_Call {
+	return &IFeeService_GetFeeSchedule_Call{Call: _e.mock.On("GetFeeSchedule", ctx, accountId)}
+}
+
+func (_c *IFeeService_GetFeeSchedule_Call) Run(run func(ctx context.Context, accountId int64)) *IFeeService_GetFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IFeeService_GetFeeSchedule_Call) Return(_a0 models.FeeScheduleResponse, _a1 error) *IFeeService_GetFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_GetFeeSchedule_Call) RunAndReturn(run func(context.Context, int64) (models.FeeScheduleResponse, error)) *IFeeService_GetFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCurrencyFeeSchedule provides a mock function with given fields: ctx, currencyCode, schedule
+func (_m *IFeeService) SetCurrencyFeeSchedule(ctx context.Context, currencyCode string, schedule models.FeeSchedule) (models.CurrencyFeeScheduleResponse, error) {
+	ret := _m.Called(ctx, currencyCode, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCurrencyFeeSchedule")
+	}
+
+	var r0 models.CurrencyFeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeSchedule) (models.CurrencyFeeScheduleResponse, error)); ok {
+		return rf(ctx, currencyCode, schedule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeSchedule) models.CurrencyFeeScheduleResponse); ok {
+		r0 = rf(ctx, currencyCode, schedule)
+	} else {
+		r0 = ret.Get(0).(models.CurrencyFeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FeeSchedule) error); ok {
+		r1 = rf(ctx, currencyCode, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_SetCurrencyFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCurrencyFeeSchedule'
+type IFeeService_SetCurrencyFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// SetCurrencyFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - currencyCode string
+//   - schedule models.FeeSchedule
+func (_e *IFeeService_Expecter) SetCurrencyFeeSchedule(ctx interface{}, currencyCode interface{}, schedule interface{}) *IFeeService_SetCurrencyFeeSchedule_Call {
+	return &IFeeService_SetCurrencyFeeSchedule_Call{Call: _e.mock.On("SetCurrencyFeeSchedule", ctx, currencyCode, schedule)}
+}
+
+func (_c *IFeeService_SetCurrencyFeeSchedule_Call) Run(run func(ctx context.Context, currencyCode string, schedule models.FeeSchedule)) *IFeeService_SetCurrencyFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FeeSchedule))
+	})
+	return _c
+}
+
+func (_c *IFeeService_SetCurrencyFeeSchedule_Call) Return(_a0 models.CurrencyFeeScheduleResponse, _a1 error) *IFeeService_SetCurrencyFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_SetCurrencyFeeSchedule_Call) RunAndReturn(run func(context.Context, string, models.FeeSchedule) (models.CurrencyFeeScheduleResponse, error)) *IFeeService_SetCurrencyFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFeeSchedule provides a mock function with given fields: ctx, accountId, schedule
+func (_m *IFeeService) SetFeeSchedule(ctx context.Context, accountId int64, schedule models.FeeSchedule) (models.FeeScheduleResponse, error) {
+	ret := _m.Called(ctx, accountId, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFeeSchedule")
+	}
+
+	var r0 models.FeeScheduleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.FeeSchedule) (models.FeeScheduleResponse, error)); ok {
+		return rf(ctx, accountId, schedule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.FeeSchedule) models.FeeScheduleResponse); ok {
+		r0 = rf(ctx, accountId, schedule)
+	} else {
+		r0 = ret.Get(0).(models.FeeScheduleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.FeeSchedule) error); ok {
+		r1 = rf(ctx, accountId, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IFeeService_SetFeeSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFeeSchedule'
+type IFeeService_SetFeeSchedule_Call struct {
+	*mock.Call
+}
+
+// SetFeeSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+//   - schedule models.FeeSchedule
+func (_e *IFeeService_Expecter) SetFeeSchedule(ctx interface{}, accountId interface{}, schedule interface{}) *IFeeService_SetFeeSchedule_Call {
+	return &IFeeService_SetFeeSchedule_Call{Call: _e.mock.On("SetFeeSchedule", ctx, accountId, schedule)}
+}
+
+func (_c *IFeeService_SetFeeSchedule_Call) Run(run func(ctx context.Context, accountId int64, schedule models.FeeSchedule)) *IFeeService_SetFeeSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.FeeSchedule))
+	})
+	return _c
+}
+
+func (_c *IFeeService_SetFeeSchedule_Call) Return(_a0 models.FeeScheduleResponse, _a1 error) *IFeeService_SetFeeSchedule_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IFeeService_SetFeeSchedule_Call) RunAndReturn(run func(context.Context, int64, models.FeeSchedule) (models.FeeScheduleResponse, error)) *IFeeService_SetFeeSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIFeeService creates a new instance of IFeeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIFeeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IFeeService {
+	mock := &IFeeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
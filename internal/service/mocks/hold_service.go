@@ -0,0 +1,264 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IHoldService is an autogenerated mock type for the IHoldService type
+type IHoldService struct {
+	mock.Mock
+}
+
+type IHoldService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IHoldService) EXPECT() *IHoldService_Expecter {
+	return &IHoldService_Expecter{mock: &_m.Mock}
+}
+
+// CaptureHold provides a mock function with given fields: ctx, args
+func (_m *IHoldService) CaptureHold(ctx context.Context, args models.CaptureHoldArgs) (models.HoldResponse, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CaptureHold")
+	}
+
+	var r0 models.HoldResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CaptureHoldArgs) (models.HoldResponse, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CaptureHoldArgs) models.HoldResponse); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.HoldResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CaptureHoldArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldService_CaptureHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CaptureHold'
+type IHoldService_CaptureHold_Call struct {
+	*mock.Call
+}
+
+// CaptureHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.CaptureHoldArgs
+func (_e *IHoldService_Expecter) CaptureHold(ctx interface{}, args interface{}) *IHoldService_CaptureHold_Call {
+	return &IHoldService_CaptureHold_Call{Call: _e.mock.On("CaptureHold", ctx, args)}
+}
+
+func (_c *IHoldService_CaptureHold_Call) Run(run func(ctx context.Context, args models.CaptureHoldArgs)) *IHoldService_CaptureHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CaptureHoldArgs))
+	})
+	return _c
+}
+
+func (_c *IHoldService_CaptureHold_Call) Return(_a0 models.HoldResponse, _a1 error) *IHoldService_CaptureHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldService_CaptureHold_Call) RunAndReturn(run func(context.Context, models.CaptureHoldArgs) (models.HoldResponse, error)) *IHoldService_CaptureHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateHold provides a mock function with given fields: ctx, args
+func (_m *IHoldService) CreateHold(ctx context.Context, args models.CreateHoldArgs) (models.HoldResponse, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateHold")
+	}
+
+	var r0 models.HoldResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateHoldArgs) (models.HoldResponse, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateHoldArgs) models.HoldResponse); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.HoldResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CreateHoldArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldService_CreateHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateHold'
+type IHoldService_CreateHold_Call struct {
+	*mock.Call
+}
+
+// CreateHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.CreateHoldArgs
+func (_e *IHoldService_Expecter) CreateHold(ctx interface{}, args interface{}) *IHoldService_CreateHold_Call {
+	return &IHoldService_CreateHold_Call{Call: _e.mock.On("CreateHold", ctx, args)}
+}
+
+func (_c *IHoldService_CreateHold_Call) Run(run func(ctx context.Context, args models.CreateHoldArgs)) *IHoldService_CreateHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CreateHoldArgs))
+	})
+	return _c
+}
+
+func (_c *IHoldService_CreateHold_Call) Return(_a0 models.HoldResponse, _a1 error) *IHoldService_CreateHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldService_CreateHold_Call) RunAndReturn(run func(context.Context, models.CreateHoldArgs) (models.HoldResponse, error)) *IHoldService_CreateHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExpireHolds provides a mock function with given fields: ctx
+func (_m *IHoldService) ExpireHolds(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldService_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type IHoldService_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IHoldService_Expecter) ExpireHolds(ctx interface{}) *IHoldService_ExpireHolds_Call {
+	return &IHoldService_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx)}
+}
+
+func (_c *IHoldService_ExpireHolds_Call) Run(run func(ctx context.Context)) *IHoldService_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IHoldService_ExpireHolds_Call) Return(_a0 int, _a1 error) *IHoldService_ExpireHolds_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldService_ExpireHolds_Call) RunAndReturn(run func(context.Context) (int, error)) *IHoldService_ExpireHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VoidHold provides a mock function with given fields: ctx, holdId
+func (_m *IHoldService) VoidHold(ctx context.Context, holdId string) (models.HoldResponse, error) {
+	ret := _m.Called(ctx, holdId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VoidHold")
+	}
+
+	var r0 models.HoldResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.HoldResponse, error)); ok {
+		return rf(ctx, holdId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.HoldResponse); ok {
+		r0 = rf(ctx, holdId)
+	} else {
+		r0 = ret.Get(0).(models.HoldResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, holdId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IHoldService_VoidHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VoidHold'
+type IHoldService_VoidHold_Call struct {
+	*mock.Call
+}
+
+// VoidHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - holdId string
+func (_e *IHoldService_Expecter) VoidHold(ctx interface{}, holdId interface{}) *IHoldService_VoidHold_Call {
+	return &IHoldService_VoidHold_Call{Call: _e.mock.On("VoidHold", ctx, holdId)}
+}
+
+func (_c *IHoldService_VoidHold_Call) Run(run func(ctx context.Context, holdId string)) *IHoldService_VoidHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IHoldService_VoidHold_Call) Return(_a0 models.HoldResponse, _a1 error) *IHoldService_VoidHold_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IHoldService_VoidHold_Call) RunAndReturn(run func(context.Context, string) (models.HoldResponse, error)) *IHoldService_VoidHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIHoldService creates a new instance of IHoldService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIHoldService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IHoldService {
+	mock := &IHoldService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,155 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IInterestService is an autogenerated mock type for the IInterestService type
+type IInterestService struct {
+	mock.Mock
+}
+
+type IInterestService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IInterestService) EXPECT() *IInterestService_Expecter {
+	return &IInterestService_Expecter{mock: &_m.Mock}
+}
+
+// RunInterest provides a mock function with given fields: ctx, asOf, dryRun
+func (_m *IInterestService) RunInterest(ctx context.Context, asOf time.Time, dryRun bool) (models.InterestRunResult, error) {
+	ret := _m.Called(ctx, asOf, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunInterest")
+	}
+
+	var r0 models.InterestRunResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, bool) (models.InterestRunResult, error)); ok {
+		return rf(ctx, asOf, dryRun)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, bool) models.InterestRunResult); ok {
+		r0 = rf(ctx, asOf, dryRun)
+	} else {
+		r0 = ret.Get(0).(models.InterestRunResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, bool) error); ok {
+		r1 = rf(ctx, asOf, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestService_RunInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunInterest'
+type IInterestService_RunInterest_Call struct {
+	*mock.Call
+}
+
+// RunInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - asOf time.Time
+//   - dryRun bool
+func (_e *IInterestService_Expecter) RunInterest(ctx interface{}, asOf interface{}, dryRun interface{}) *IInterestService_RunInterest_Call {
+	return &IInterestService_RunInterest_Call{Call: _e.mock.On("RunInterest", ctx, asOf, dryRun)}
+}
+
+func (_c *IInterestService_RunInterest_Call) Run(run func(ctx context.Context, asOf time.Time, dryRun bool)) *IInterestService_RunInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(bool))
+	})
+	return _c
+}
+
+func (_c *IInterestService_RunInterest_Call) Return(_a0 models.InterestRunResult, _a1 error) *IInterestService_RunInterest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestService_RunInterest_Call) RunAndReturn(run func(context.Context, time.Time, bool) (models.InterestRunResult, error)) *IInterestService_RunInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetInterestRate provides a mock function with given fields: ctx, accountId, rate
+func (_m *IInterestService) SetInterestRate(ctx context.Context, accountId int64, rate models.InterestRate) (models.InterestRateResponse, error) {
+	ret := _m.Called(ctx, accountId, rate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInterestRate")
+	}
+
+	var r0 models.InterestRateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.InterestRate) (models.InterestRateResponse, error)); ok {
+		return rf(ctx, accountId, rate)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.InterestRate) models.InterestRateResponse); ok {
+		r0 = rf(ctx, accountId, rate)
+	} else {
+		r0 = ret.Get(0).(models.InterestRateResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.InterestRate) error); ok {
+		r1 = rf(ctx, accountId, rate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IInterestService_SetInterestRate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInterestRate'
+type IInterestService_SetInterestRate_Call struct {
+	*mock.Call
+}
+
+// SetInterestRate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+//   - rate models.InterestRate
+func (_e *IInterestService_Expecter) SetInterestRate(ctx interface{}, accountId interface{}, rate interface{}) *IInterestService_SetInterestRate_Call {
+	return &IInterestService_SetInterestRate_Call{Call: _e.mock.On("SetInterestRate", ctx, accountId, rate)}
+}
+
+func (_c *IInterestService_SetInterestRate_Call) Run(run func(ctx context.Context, accountId int64, rate models.InterestRate)) *IInterestService_SetInterestRate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.InterestRate))
+	})
+	return _c
+}
+
+func (_c *IInterestService_SetInterestRate_Call) Return(_a0 models.InterestRateResponse, _a1 error) *IInterestService_SetInterestRate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IInterestService_SetInterestRate_Call) RunAndReturn(run func(context.Context, int64, models.InterestRate) (models.InterestRateResponse, error)) *IInterestService_SetInterestRate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIInterestService creates a new instance of IInterestService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIInterestService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IInterestService {
+	mock := &IInterestService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IReconciliationService is an autogenerated mock type for the IReconciliationService type
+type IReconciliationService struct {
+	mock.Mock
+}
+
+type IReconciliationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IReconciliationService) EXPECT() *IReconciliationService_Expecter {
+	return &IReconciliationService_Expecter{mock: &_m.Mock}
+}
+
+// Reconcile provides a mock function with given fields: ctx, req
+func (_m *IReconciliationService) Reconcile(ctx context.Context, req models.ReconcileRequest) (models.ReconciliationReport, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 models.ReconciliationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReconcileRequest) (models.ReconciliationReport, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReconcileRequest) models.ReconciliationReport); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.ReconciliationReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReconcileRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IReconciliationService_Reconcile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconcile'
+type IReconciliationService_Reconcile_Call struct {
+	*mock.Call
+}
+
+// Reconcile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.ReconcileRequest
+func (_e *IReconciliationService_Expecter) Reconcile(ctx interface{}, req interface{}) *IReconciliationService_Reconcile_Call {
+	return &IReconciliationService_Reconcile_Call{Call: _e.mock.On("Reconcile", ctx, req)}
+}
+
+func (_c *IReconciliationService_Reconcile_Call) Run(run func(ctx context.Context, req models.ReconcileRequest)) *IReconciliationService_Reconcile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ReconcileRequest))
+	})
+	return _c
+}
+
+func (_c *IReconciliationService_Reconcile_Call) Return(_a0 models.ReconciliationReport, _a1 error) *IReconciliationService_Reconcile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IReconciliationService_Reconcile_Call) RunAndReturn(run func(context.Context, models.ReconcileRequest) (models.ReconciliationReport, error)) *IReconciliationService_Reconcile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIReconciliationService creates a new instance of IReconciliationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReconciliationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReconciliationService {
+	mock := &IReconciliationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,207 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IRequestSigningService is an autogenerated mock type for the IRequestSigningService type
+type IRequestSigningService struct {
+	mock.Mock
+}
+
+type IRequestSigningService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IRequestSigningService) EXPECT() *IRequestSigningService_Expecter {
+	return &IRequestSigningService_Expecter{mock: &_m.Mock}
+}
+
+// CreateSigningSecret provides a mock function with given fields: ctx, apiKeyId
+func (_m *IRequestSigningService) CreateSigningSecret(ctx context.Context, apiKeyId int64) (string, error) {
+	ret := _m.Called(ctx, apiKeyId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSigningSecret")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (string, error)); ok {
+		return rf(ctx, apiKeyId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) string); ok {
+		r0 = rf(ctx, apiKeyId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, apiKeyId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IRequestSigningService_CreateSigningSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSigningSecret'
+type IRequestSigningService_CreateSigningSecret_Call struct {
+	*mock.Call
+}
+
+// CreateSigningSecret is a helper method to define mock.On call
+//   - ctx context.Context
+//   - apiKeyId int64
+func (_e *IRequestSigningService_Expecter) CreateSigningSecret(ctx interface{}, apiKeyId interface{}) *IRequestSigningService_CreateSigningSecret_Call {
+	return &IRequestSigningService_CreateSigningSecret_Call{Call: _e.mock.On("CreateSigningSecret", ctx, apiKeyId)}
+}
+
+func (_c *IRequestSigningService_CreateSigningSecret_Call) Run(run func(ctx context.Context, apiKeyId int64)) *IRequestSigningService_CreateSigningSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IRequestSigningService_CreateSigningSecret_Call) Return(_a0 string, _a1 error) *IRequestSigningService_CreateSigningSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IRequestSigningService_CreateSigningSecret_Call) RunAndReturn(run func(context.Context, int64) (string, error)) *IRequestSigningService_CreateSigningSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeExpiredNonces provides a mock function with given fields: ctx
+func (_m *IRequestSigningService) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeExpiredNonces")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IRequestSigningService_PurgeExpiredNonces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredNonces'
+type IRequestSigningService_PurgeExpiredNonces_Call struct {
+	*mock.Call
+}
+
+// PurgeExpiredNonces is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IRequestSigningService_Expecter) PurgeExpiredNonces(ctx interface{}) *IRequestSigningService_PurgeExpiredNonces_Call {
+	return &IRequestSigningService_PurgeExpiredNonces_Call{Call: _e.mock.On("PurgeExpiredNonces", ctx)}
+}
+
+func (_c *IRequestSigningService_PurgeExpiredNonces_Call) Run(run func(ctx context.Context)) *IRequestSigningService_PurgeExpiredNonces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IRequestSigningService_PurgeExpiredNonces_Call) Return(_a0 int64, _a1 error) *IRequestSigningService_PurgeExpiredNonces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IRequestSigningService_PurgeExpiredNonces_Call) RunAndReturn(run func(context.Context) (int64, error)) *IRequestSigningService_PurgeExpiredNonces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifySignature provides a mock function with given fields: ctx, req
+func (_m *IRequestSigningService) VerifySignature(ctx context.Context, req models.SignedRequest) (models.ApiKey, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifySignature")
+	}
+
+	var r0 models.ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.SignedRequest) (models.ApiKey, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.SignedRequest) models.ApiKey); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.SignedRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IRequestSigningService_VerifySignature_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifySignature'
+type IRequestSigningService_VerifySignature_Call struct {
+	*mock.Call
+}
+
+// VerifySignature is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.SignedRequest
+func (_e *IRequestSigningService_Expecter) VerifySignature(ctx interface{}, req interface{}) *IRequestSigningService_VerifySignature_Call {
+	return &IRequestSigningService_VerifySignature_Call{Call: _e.mock.On("VerifySignature", ctx, req)}
+}
+
+func (_c *IRequestSigningService_VerifySignature_Call) Run(run func(ctx context.Context, req models.SignedRequest)) *IRequestSigningService_VerifySignature_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.SignedRequest))
+	})
+	return _c
+}
+
+func (_c *IRequestSigningService_VerifySignature_Call) Return(_a0 models.ApiKey, _a1 error) *IRequestSigningService_VerifySignature_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IRequestSigningService_VerifySignature_Call) RunAndReturn(run func(context.Context, models.SignedRequest) (models.ApiKey, error)) *IRequestSigningService_VerifySignature_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIRequestSigningService creates a new instance of IRequestSigningService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIRequestSigningService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IRequestSigningService {
+	mock := &IRequestSigningService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,378 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IScheduledTransferService is an autogenerated mock type for the IScheduledTransferService type
+type IScheduledTransferService struct {
+	mock.Mock
+}
+
+type IScheduledTransferService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IScheduledTransferService) EXPECT() *IScheduledTransferService_Expecter {
+	return &IScheduledTransferService_Expecter{mock: &_m.Mock}
+}
+
+// CancelScheduledTransfer provides a mock function with given fields: ctx, scheduledTransferId
+func (_m *IScheduledTransferService) CancelScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	ret := _m.Called(ctx, scheduledTransferId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelScheduledTransfer")
+	}
+
+	var r0 models.ScheduledTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ScheduledTransferResponse, error)); ok {
+		return rf(ctx, scheduledTransferId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ScheduledTransferResponse); ok {
+		r0 = rf(ctx, scheduledTransferId)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduledTransferId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_CancelScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelScheduledTransfer'
+type IScheduledTransferService_CancelScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// CancelScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduledTransferId string
+func (_e *IScheduledTransferService_Expecter) CancelScheduledTransfer(ctx interface{}, scheduledTransferId interface{}) *IScheduledTransferService_CancelScheduledTransfer_Call {
+	return &IScheduledTransferService_CancelScheduledTransfer_Call{Call: _e.mock.On("CancelScheduledTransfer", ctx, scheduledTransferId)}
+}
+
+func (_c *IScheduledTransferService_CancelScheduledTransfer_Call) Run(run func(ctx context.Context, scheduledTransferId string)) *IScheduledTransferService_CancelScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_CancelScheduledTransfer_Call) Return(_a0 models.ScheduledTransferResponse, _a1 error) *IScheduledTransferService_CancelScheduledTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_CancelScheduledTransfer_Call) RunAndReturn(run func(context.Context, string) (models.ScheduledTransferResponse, error)) *IScheduledTransferService_CancelScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateScheduledTransfer provides a mock function with given fields: ctx, args
+func (_m *IScheduledTransferService) CreateScheduledTransfer(ctx context.Context, args models.CreateScheduledTransferArgs) (models.ScheduledTransferResponse, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateScheduledTransfer")
+	}
+
+	var r0 models.ScheduledTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateScheduledTransferArgs) (models.ScheduledTransferResponse, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateScheduledTransferArgs) models.ScheduledTransferResponse); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CreateScheduledTransferArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_CreateScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateScheduledTransfer'
+type IScheduledTransferService_CreateScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// CreateScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.CreateScheduledTransferArgs
+func (_e *IScheduledTransferService_Expecter) CreateScheduledTransfer(ctx interface{}, args interface{}) *IScheduledTransferService_CreateScheduledTransfer_Call {
+	return &IScheduledTransferService_CreateScheduledTransfer_Call{Call: _e.mock.On("CreateScheduledTransfer", ctx, args)}
+}
+
+func (_c *IScheduledTransferService_CreateScheduledTransfer_Call) Run(run func(ctx context.Context, args models.CreateScheduledTransferArgs)) *IScheduledTransferService_CreateScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CreateScheduledTransferArgs))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_CreateScheduledTransfer_Call) Return(_a0 models.ScheduledTransferResponse, _a1 error) *IScheduledTransferService_CreateScheduledTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_CreateScheduledTransfer_Call) RunAndReturn(run func(context.Context, models.CreateScheduledTransferArgs) (models.ScheduledTransferResponse, error)) *IScheduledTransferService_CreateScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListScheduledTransfers provides a mock function with given fields: ctx, accountId
+func (_m *IScheduledTransferService) ListScheduledTransfers(ctx context.Context, accountId int64) (models.ListScheduledTransfersResponse, error) {
+	ret := _m.Called(ctx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListScheduledTransfers")
+	}
+
+	var r0 models.ListScheduledTransfersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.ListScheduledTransfersResponse, error)); ok {
+		return rf(ctx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.ListScheduledTransfersResponse); ok {
+		r0 = rf(ctx, accountId)
+	} else {
+		r0 = ret.Get(0).(models.ListScheduledTransfersResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_ListScheduledTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListScheduledTransfers'
+type IScheduledTransferService_ListScheduledTransfers_Call struct {
+	*mock.Call
+}
+
+// ListScheduledTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+func (_e *IScheduledTransferService_Expecter) ListScheduledTransfers(ctx interface{}, accountId interface{}) *IScheduledTransferService_ListScheduledTransfers_Call {
+	return &IScheduledTransferService_ListScheduledTransfers_Call{Call: _e.mock.On("ListScheduledTransfers", ctx, accountId)}
+}
+
+func (_c *IScheduledTransferService_ListScheduledTransfers_Call) Run(run func(ctx context.Context, accountId int64)) *IScheduledTransferService_ListScheduledTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_ListScheduledTransfers_Call) Return(_a0 models.ListScheduledTransfersResponse, _a1 error) *IScheduledTransferService_ListScheduledTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_ListScheduledTransfers_Call) RunAndReturn(run func(context.Context, int64) (models.ListScheduledTransfersResponse, error)) *IScheduledTransferService_ListScheduledTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseScheduledTransfer provides a mock function with given fields: ctx, scheduledTransferId
+func (_m *IScheduledTransferService) PauseScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	ret := _m.Called(ctx, scheduledTransferId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseScheduledTransfer")
+	}
+
+	var r0 models.ScheduledTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ScheduledTransferResponse, error)); ok {
+		return rf(ctx, scheduledTransferId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ScheduledTransferResponse); ok {
+		r0 = rf(ctx, scheduledTransferId)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduledTransferId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_PauseScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseScheduledTransfer'
+type IScheduledTransferService_PauseScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// PauseScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduledTransferId string
+func (_e *IScheduledTransferService_Expecter) PauseScheduledTransfer(ctx interface{}, scheduledTransferId interface{}) *IScheduledTransferService_PauseScheduledTransfer_Call {
+	return &IScheduledTransferService_PauseScheduledTransfer_Call{Call: _e.mock.On("PauseScheduledTransfer", ctx, scheduledTransferId)}
+}
+
+func (_c *IScheduledTransferService_PauseScheduledTransfer_Call) Run(run func(ctx context.Context, scheduledTransferId string)) *IScheduledTransferService_PauseScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_PauseScheduledTransfer_Call) Return(_a0 models.ScheduledTransferResponse, _a1 error) *IScheduledTransferService_PauseScheduledTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_PauseScheduledTransfer_Call) RunAndReturn(run func(context.Context, string) (models.ScheduledTransferResponse, error)) *IScheduledTransferService_PauseScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeScheduledTransfer provides a mock function with given fields: ctx, scheduledTransferId
+func (_m *IScheduledTransferService) ResumeScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	ret := _m.Called(ctx, scheduledTransferId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeScheduledTransfer")
+	}
+
+	var r0 models.ScheduledTransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ScheduledTransferResponse, error)); ok {
+		return rf(ctx, scheduledTransferId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ScheduledTransferResponse); ok {
+		r0 = rf(ctx, scheduledTransferId)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledTransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduledTransferId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_ResumeScheduledTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeScheduledTransfer'
+type IScheduledTransferService_ResumeScheduledTransfer_Call struct {
+	*mock.Call
+}
+
+// ResumeScheduledTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduledTransferId string
+func (_e *IScheduledTransferService_Expecter) ResumeScheduledTransfer(ctx interface{}, scheduledTransferId interface{}) *IScheduledTransferService_ResumeScheduledTransfer_Call {
+	return &IScheduledTransferService_ResumeScheduledTransfer_Call{Call: _e.mock.On("ResumeScheduledTransfer", ctx, scheduledTransferId)}
+}
+
+func (_c *IScheduledTransferService_ResumeScheduledTransfer_Call) Run(run func(ctx context.Context, scheduledTransferId string)) *IScheduledTransferService_ResumeScheduledTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_ResumeScheduledTransfer_Call) Return(_a0 models.ScheduledTransferResponse, _a1 error) *IScheduledTransferService_ResumeScheduledTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_ResumeScheduledTransfer_Call) RunAndReturn(run func(context.Context, string) (models.ScheduledTransferResponse, error)) *IScheduledTransferService_ResumeScheduledTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunDueTransfers provides a mock function with given fields: ctx
+func (_m *IScheduledTransferService) RunDueTransfers(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDueTransfers")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IScheduledTransferService_RunDueTransfers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunDueTransfers'
+type IScheduledTransferService_RunDueTransfers_Call struct {
+	*mock.Call
+}
+
+// RunDueTransfers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *IScheduledTransferService_Expecter) RunDueTransfers(ctx interface{}) *IScheduledTransferService_RunDueTransfers_Call {
+	return &IScheduledTransferService_RunDueTransfers_Call{Call: _e.mock.On("RunDueTransfers", ctx)}
+}
+
+func (_c *IScheduledTransferService_RunDueTransfers_Call) Run(run func(ctx context.Context)) *IScheduledTransferService_RunDueTransfers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *IScheduledTransferService_RunDueTransfers_Call) Return(_a0 int, _a1 error) *IScheduledTransferService_RunDueTransfers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IScheduledTransferService_RunDueTransfers_Call) RunAndReturn(run func(context.Context) (int, error)) *IScheduledTransferService_RunDueTransfers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIScheduledTransferService creates a new instance of IScheduledTransferService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIScheduledTransferService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IScheduledTransferService {
+	mock := &IScheduledTransferService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
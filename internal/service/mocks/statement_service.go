@@ -0,0 +1,94 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IStatementService is an autogenerated mock type for the IStatementService type
+type IStatementService struct {
+	mock.Mock
+}
+
+type IStatementService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *IStatementService) EXPECT() *IStatementService_Expecter {
+	return &IStatementService_Expecter{mock: &_m.Mock}
+}
+
+// GetStatement provides a mock function with given fields: ctx, args
+func (_m *IStatementService) GetStatement(ctx context.Context, args models.StatementArgs) (models.Statement, error) {
+	ret := _m.Called(ctx, args)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStatement")
+	}
+
+	var r0 models.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatementArgs) (models.Statement, error)); ok {
+		return rf(ctx, args)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.StatementArgs) models.Statement); ok {
+		r0 = rf(ctx, args)
+	} else {
+		r0 = ret.Get(0).(models.Statement)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.StatementArgs) error); ok {
+		r1 = rf(ctx, args)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IStatementService_GetStatement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatement'
+type IStatementService_GetStatement_Call struct {
+	*mock.Call
+}
+
+// GetStatement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - args models.StatementArgs
+func (_e *IStatementService_Expecter) GetStatement(ctx interface{}, args interface{}) *IStatementService_GetStatement_Call {
+	return &IStatementService_GetStatement_Call{Call: _e.mock.On("GetStatement", ctx, args)}
+}
+
+func (_c *IStatementService_GetStatement_Call) Run(run func(ctx context.Context, args models.StatementArgs)) *IStatementService_GetStatement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.StatementArgs))
+	})
+	return _c
+}
+
+func (_c *IStatementService_GetStatement_Call) Return(_a0 models.Statement, _a1 error) *IStatementService_GetStatement_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *IStatementService_GetStatement_Call) RunAndReturn(run func(context.Context, models.StatementArgs) (models.Statement, error)) *IStatementService_GetStatement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewIStatementService creates a new instance of IStatementService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatementService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatementService {
+	mock := &IStatementService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
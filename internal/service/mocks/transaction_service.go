@@ -22,6 +22,120 @@ func (_m *ITransactionService) EXPECT() *ITransactionService_Expecter {
 	return &ITransactionService_Expecter{mock: &_m.Mock}
 }
 
+// CreateBatchTransaction provides a mock function with given fields: ctx, req
+func (_m *ITransactionService) CreateBatchTransaction(ctx context.Context, req models.CreateBatchTransactionArgs) (models.CreateBatchTransactionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatchTransaction")
+	}
+
+	var r0 models.CreateBatchTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateBatchTransactionArgs) (models.CreateBatchTransactionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateBatchTransactionArgs) models.CreateBatchTransactionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.CreateBatchTransactionResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CreateBatchTransactionArgs) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionService_CreateBatchTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatchTransaction'
+type ITransactionService_CreateBatchTransaction_Call struct {
+	*mock.Call
+}
+
+// CreateBatchTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.CreateBatchTransactionArgs
+func (_e *ITransactionService_Expecter) CreateBatchTransaction(ctx interface{}, req interface{}) *ITransactionService_CreateBatchTransaction_Call {
+	return &ITransactionService_CreateBatchTransaction_Call{Call: _e.mock.On("CreateBatchTransaction", ctx, req)}
+}
+
+func (_c *ITransactionService_CreateBatchTransaction_Call) Run(run func(ctx context.Context, req models.CreateBatchTransactionArgs)) *ITransactionService_CreateBatchTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CreateBatchTransactionArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionService_CreateBatchTransaction_Call) Return(_a0 models.CreateBatchTransactionResponse, _a1 error) *ITransactionService_CreateBatchTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionService_CreateBatchTransaction_Call) RunAndReturn(run func(context.Context, models.CreateBatchTransactionArgs) (models.CreateBatchTransactionResponse, error)) *ITransactionService_CreateBatchTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuote provides a mock function with given fields: ctx, req
+func (_m *ITransactionService) CreateQuote(ctx context.Context, req models.CreateQuoteArgs) (models.QuoteResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuote")
+	}
+
+	var r0 models.QuoteResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateQuoteArgs) (models.QuoteResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.CreateQuoteArgs) models.QuoteResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.QuoteResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.CreateQuoteArgs) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionService_CreateQuote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuote'
+type ITransactionService_CreateQuote_Call struct {
+	*mock.Call
+}
+
+// CreateQuote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.CreateQuoteArgs
+func (_e *ITransactionService_Expecter) CreateQuote(ctx interface{}, req interface{}) *ITransactionService_CreateQuote_Call {
+	return &ITransactionService_CreateQuote_Call{Call: _e.mock.On("CreateQuote", ctx, req)}
+}
+
+func (_c *ITransactionService_CreateQuote_Call) Run(run func(ctx context.Context, req models.CreateQuoteArgs)) *ITransactionService_CreateQuote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.CreateQuoteArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionService_CreateQuote_Call) Return(_a0 models.QuoteResponse, _a1 error) *ITransactionService_CreateQuote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionService_CreateQuote_Call) RunAndReturn(run func(context.Context, models.CreateQuoteArgs) (models.QuoteResponse, error)) *ITransactionService_CreateQuote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTransaction provides a mock function with given fields: ctx, req
 func (_m *ITransactionService) CreateTransaction(ctx context.Context, req models.CreateTransactionArgs) (models.CreateTransactionResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -79,6 +193,177 @@ func (_c *ITransactionService_CreateTransaction_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// GetTransfer provides a mock function with given fields: ctx, reference
+func (_m *ITransactionService) GetTransfer(ctx context.Context, reference string) (models.TransferResponse, error) {
+	ret := _m.Called(ctx, reference)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransfer")
+	}
+
+	var r0 models.TransferResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.TransferResponse, error)); ok {
+		return rf(ctx, reference)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.TransferResponse); ok {
+		r0 = rf(ctx, reference)
+	} else {
+		r0 = ret.Get(0).(models.TransferResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reference)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionService_GetTransfer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransfer'
+type ITransactionService_GetTransfer_Call struct {
+	*mock.Call
+}
+
+// GetTransfer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reference string
+func (_e *ITransactionService_Expecter) GetTransfer(ctx interface{}, reference interface{}) *ITransactionService_GetTransfer_Call {
+	return &ITransactionService_GetTransfer_Call{Call: _e.mock.On("GetTransfer", ctx, reference)}
+}
+
+func (_c *ITransactionService_GetTransfer_Call) Run(run func(ctx context.Context, reference string)) *ITransactionService_GetTransfer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITransactionService_GetTransfer_Call) Return(_a0 models.TransferResponse, _a1 error) *ITransactionService_GetTransfer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionService_GetTransfer_Call) RunAndReturn(run func(context.Context, string) (models.TransferResponse, error)) *ITransactionService_GetTransfer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccountTransactions provides a mock function with given fields: ctx, req
+func (_m *ITransactionService) ListAccountTransactions(ctx context.Context, req models.ListTransactionsArgs) (models.ListTransactionsResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountTransactions")
+	}
+
+	var r0 models.ListTransactionsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListTransactionsArgs) (models.ListTransactionsResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ListTransactionsArgs) models.ListTransactionsResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.ListTransactionsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ListTransactionsArgs) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionService_ListAccountTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccountTransactions'
+type ITransactionService_ListAccountTransactions_Call struct {
+	*mock.Call
+}
+
+// ListAccountTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.ListTransactionsArgs
+func (_e *ITransactionService_Expecter) ListAccountTransactions(ctx interface{}, req interface{}) *ITransactionService_ListAccountTransactions_Call {
+	return &ITransactionService_ListAccountTransactions_Call{Call: _e.mock.On("ListAccountTransactions", ctx, req)}
+}
+
+func (_c *ITransactionService_ListAccountTransactions_Call) Run(run func(ctx context.Context, req models.ListTransactionsArgs)) *ITransactionService_ListAccountTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ListTransactionsArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionService_ListAccountTransactions_Call) Return(_a0 models.ListTransactionsResponse, _a1 error) *ITransactionService_ListAccountTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionService_ListAccountTransactions_Call) RunAndReturn(run func(context.Context, models.ListTransactionsArgs) (models.ListTransactionsResponse, error)) *ITransactionService_ListAccountTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReverseTransaction provides a mock function with given fields: ctx, req
+func (_m *ITransactionService) ReverseTransaction(ctx context.Context, req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReverseTransaction")
+	}
+
+	var r0 models.ReverseTransactionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReverseTransactionArgs) models.ReverseTransactionResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(models.ReverseTransactionResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ReverseTransactionArgs) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransactionService_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type ITransactionService_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req models.ReverseTransactionArgs
+func (_e *ITransactionService_Expecter) ReverseTransaction(ctx interface{}, req interface{}) *ITransactionService_ReverseTransaction_Call {
+	return &ITransactionService_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", ctx, req)}
+}
+
+func (_c *ITransactionService_ReverseTransaction_Call) Run(run func(ctx context.Context, req models.ReverseTransactionArgs)) *ITransactionService_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ReverseTransactionArgs))
+	})
+	return _c
+}
+
+func (_c *ITransactionService_ReverseTransaction_Call) Return(_a0 models.ReverseTransactionResponse, _a1 error) *ITransactionService_ReverseTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransactionService_ReverseTransaction_Call) RunAndReturn(run func(context.Context, models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)) *ITransactionService_ReverseTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewITransactionService creates a new instance of ITransactionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewITransactionService(t interface {
@@ -0,0 +1,324 @@
+// Code generated by mockery v2.53.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/bhuvi1021/TripleA/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITransferLimitService is an autogenerated mock type for the ITransferLimitService type
+type ITransferLimitService struct {
+	mock.Mock
+}
+
+type ITransferLimitService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ITransferLimitService) EXPECT() *ITransferLimitService_Expecter {
+	return &ITransferLimitService_Expecter{mock: &_m.Mock}
+}
+
+// GetCurrencyTransferLimits provides a mock function with given fields: ctx, currencyCode
+func (_m *ITransferLimitService) GetCurrencyTransferLimits(ctx context.Context, currencyCode string) (models.CurrencyTransferLimitsResponse, error) {
+	ret := _m.Called(ctx, currencyCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCurrencyTransferLimits")
+	}
+
+	var r0 models.CurrencyTransferLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.CurrencyTransferLimitsResponse, error)); ok {
+		return rf(ctx, currencyCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.CurrencyTransferLimitsResponse); ok {
+		r0 = rf(ctx, currencyCode)
+	} else {
+		r0 = ret.Get(0).(models.CurrencyTransferLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, currencyCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitService_GetCurrencyTransferLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCurrencyTransferLimits'
+type ITransferLimitService_GetCurrencyTransferLimits_Call struct {
+	*mock.Call
+}
+
+// GetCurrencyTransferLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - currencyCode string
+func (_e *ITransferLimitService_Expecter) GetCurrencyTransferLimits(ctx interface{}, currencyCode interface{}) *ITransferLimitService_GetCurrencyTransferLimits_Call {
+	return &ITransferLimitService_GetCurrencyTransferLimits_Call{Call: _e.mock.On("GetCurrencyTransferLimits", ctx, currencyCode)}
+}
+
+func (_c *ITransferLimitService_GetCurrencyTransferLimits_Call) Run(run func(ctx context.Context, currencyCode string)) *ITransferLimitService_GetCurrencyTransferLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitService_GetCurrencyTransferLimits_Call) Return(_a0 models.CurrencyTransferLimitsResponse, _a1 error) *ITransferLimitService_GetCurrencyTransferLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitService_GetCurrencyTransferLimits_Call) RunAndReturn(run func(context.Context, string) (models.CurrencyTransferLimitsResponse, error)) *ITransferLimitService_GetCurrencyTransferLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLimits provides a mock function with given fields: ctx, accountId
+func (_m *ITransferLimitService) GetLimits(ctx context.Context, accountId int64) (models.TransferLimits, error) {
+	ret := _m.Called(ctx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimits")
+	}
+
+	var r0 models.TransferLimits
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.TransferLimits, error)); ok {
+		return rf(ctx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.TransferLimits); ok {
+		r0 = rf(ctx, accountId)
+	} else {
+		r0 = ret.Get(0).(models.TransferLimits)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitService_GetLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLimits'
+type ITransferLimitService_GetLimits_Call struct {
+	*mock.Call
+}
+
+// GetLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+func (_e *ITransferLimitService_Expecter) GetLimits(ctx interface{}, accountId interface{}) *ITransferLimitService_GetLimits_Call {
+	return &ITransferLimitService_GetLimits_Call{Call: _e.mock.On("GetLimits", ctx, accountId)}
+}
+
+func (_c *ITransferLimitService_GetLimits_Call) Run(run func(ctx context.Context, accountId int64)) *ITransferLimitService_GetLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitService_GetLimits_Call) Return(_a0 models.TransferLimits, _a1 error) *ITransferLimitService_GetLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitService_GetLimits_Call) RunAndReturn(run func(context.Context, int64) (models.TransferLimits, error)) *ITransferLimitService_GetLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransferLimits provides a mock function with given fields: ctx, accountId
+func (_m *ITransferLimitService) GetTransferLimits(ctx context.Context, accountId int64) (models.TransferLimitsResponse, error) {
+	ret := _m.Called(ctx, accountId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransferLimits")
+	}
+
+	var r0 models.TransferLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (models.TransferLimitsResponse, error)); ok {
+		return rf(ctx, accountId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) models.TransferLimitsResponse); ok {
+		r0 = rf(ctx, accountId)
+	} else {
+		r0 = ret.Get(0).(models.TransferLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, accountId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitService_GetTransferLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransferLimits'
+type ITransferLimitService_GetTransferLimits_Call struct {
+	*mock.Call
+}
+
+// GetTransferLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+func (_e *ITransferLimitService_Expecter) GetTransferLimits(ctx interface{}, accountId interface{}) *ITransferLimitService_GetTransferLimits_Call {
+	return &ITransferLimitService_GetTransferLimits_Call{Call: _e.mock.On("GetTransferLimits", ctx, accountId)}
+}
+
+func (_c *ITransferLimitService_GetTransferLimits_Call) Run(run func(ctx context.Context, accountId int64)) *ITransferLimitService_GetTransferLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitService_GetTransferLimits_Call) Return(_a0 models.TransferLimitsResponse, _a1 error) *ITransferLimitService_GetTransferLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitService_GetTransferLimits_Call) RunAndReturn(run func(context.Context, int64) (models.TransferLimitsResponse, error)) *ITransferLimitService_GetTransferLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCurrencyTransferLimits provides a mock function with given fields: ctx, currencyCode, limits
+func (_m *ITransferLimitService) SetCurrencyTransferLimits(ctx context.Context, currencyCode string, limits models.TransferLimits) (models.CurrencyTransferLimitsResponse, error) {
+	ret := _m.Called(ctx, currencyCode, limits)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCurrencyTransferLimits")
+	}
+
+	var r0 models.CurrencyTransferLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransferLimits) (models.CurrencyTransferLimitsResponse, error)); ok {
+		return rf(ctx, currencyCode, limits)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransferLimits) models.CurrencyTransferLimitsResponse); ok {
+		r0 = rf(ctx, currencyCode, limits)
+	} else {
+		r0 = ret.Get(0).(models.CurrencyTransferLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TransferLimits) error); ok {
+		r1 = rf(ctx, currencyCode, limits)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitService_SetCurrencyTransferLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCurrencyTransferLimits'
+type ITransferLimitService_SetCurrencyTransferLimits_Call struct {
+	*mock.Call
+}
+
+// SetCurrencyTransferLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - currencyCode string
+//   - limits models.TransferLimits
+func (_e *ITransferLimitService_Expecter) SetCurrencyTransferLimits(ctx interface{}, currencyCode interface{}, limits interface{}) *ITransferLimitService_SetCurrencyTransferLimits_Call {
+	return &ITransferLimitService_SetCurrencyTransferLimits_Call{Call: _e.mock.On("SetCurrencyTransferLimits", ctx, currencyCode, limits)}
+}
+
+func (_c *ITransferLimitService_SetCurrencyTransferLimits_Call) Run(run func(ctx context.Context, currencyCode string, limits models.TransferLimits)) *ITransferLimitService_SetCurrencyTransferLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TransferLimits))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitService_SetCurrencyTransferLimits_Call) Return(_a0 models.CurrencyTransferLimitsResponse, _a1 error) *ITransferLimitService_SetCurrencyTransferLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitService_SetCurrencyTransferLimits_Call) RunAndReturn(run func(context.Context, string, models.TransferLimits) (models.CurrencyTransferLimitsResponse, error)) *ITransferLimitService_SetCurrencyTransferLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTransferLimits provides a mock function with given fields: ctx, accountId, overrides
+func (_m *ITransferLimitService) SetTransferLimits(ctx context.Context, accountId int64, overrides models.TransferLimits) (models.TransferLimitsResponse, error) {
+	ret := _m.Called(ctx, accountId, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTransferLimits")
+	}
+
+	var r0 models.TransferLimitsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TransferLimits) (models.TransferLimitsResponse, error)); ok {
+		return rf(ctx, accountId, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.TransferLimits) models.TransferLimitsResponse); ok {
+		r0 = rf(ctx, accountId, overrides)
+	} else {
+		r0 = ret.Get(0).(models.TransferLimitsResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.TransferLimits) error); ok {
+		r1 = rf(ctx, accountId, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ITransferLimitService_SetTransferLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTransferLimits'
+type ITransferLimitService_SetTransferLimits_Call struct {
+	*mock.Call
+}
+
+// SetTransferLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountId int64
+//   - overrides models.TransferLimits
+func (_e *ITransferLimitService_Expecter) SetTransferLimits(ctx interface{}, accountId interface{}, overrides interface{}) *ITransferLimitService_SetTransferLimits_Call {
+	return &ITransferLimitService_SetTransferLimits_Call{Call: _e.mock.On("SetTransferLimits", ctx, accountId, overrides)}
+}
+
+func (_c *ITransferLimitService_SetTransferLimits_Call) Run(run func(ctx context.Context, accountId int64, overrides models.TransferLimits)) *ITransferLimitService_SetTransferLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(models.TransferLimits))
+	})
+	return _c
+}
+
+func (_c *ITransferLimitService_SetTransferLimits_Call) Return(_a0 models.TransferLimitsResponse, _a1 error) *ITransferLimitService_SetTransferLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ITransferLimitService_SetTransferLimits_Call) RunAndReturn(run func(context.Context, int64, models.TransferLimits) (models.TransferLimitsResponse, error)) *ITransferLimitService_SetTransferLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewITransferLimitService creates a new instance of ITransferLimitService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITransferLimitService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITransferLimitService {
+	mock := &ITransferLimitService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"time"
+)
+
+type ReconciliationService struct {
+	reconciliationRepo repository.IReconciliationRepository
+}
+
+// NewReconciliationService creates the service checking account balances against their ledger
+func NewReconciliationService(reconciliationRepo repository.IReconciliationRepository) *ReconciliationService {
+	return &ReconciliationService{reconciliationRepo: reconciliationRepo}
+}
+
+type IReconciliationService interface {
+	Reconcile(ctx context.Context, req models.ReconcileRequest) (models.ReconciliationReport, error)
+}
+
+// Reconcile is a service method that recomputes the balance of every account, or of req.AccountId only, from its
+// initial balance and ledger entries, and reports the accounts whose balance does not match both that and the
+// balance recorded on their latest entry. With req.Flag the mismatches are recorded and the flags of reconciled accounts cleared
+func (s *ReconciliationService) Reconcile(ctx context.Context, req models.ReconcileRequest) (models.ReconciliationReport, error) {
+	fName := "ReconciliationService.Reconcile"
+	report := models.ReconciliationReport{CheckedAt: time.Now().UTC(), Flagged: req.Flag, Mismatches: []models.AccountReconciliation{}}
+	if req.AccountId < 0 {
+		return report, appErr.ErrInvalidAccountId
+	}
+
+	reconciliations, err := s.reconciliationRepo.ListAccountReconciliations(req.AccountId)
+	if err != nil {
+		log.Printf("[%s] failed to list account balances: %v", fName, err)
+		return report, err
+	}
+	if req.AccountId > 0 && len(reconciliations) == 0 {
+		return report, appErr.ErrAccountNotFound
+	}
+
+	report.AccountsChecked = len(reconciliations)
+	for _, a := range reconciliations {
+		if !a.IsReconciled() {
+			log.Printf("[%s] account %d does not reconcile: balance %s, ledger %s", fName, a.AccountId, a.Balance, a.LedgerBalance)
+			report.Mismatches = append(report.Mismatches, a)
+		}
+	}
+
+	if req.Flag {
+		if err := s.reconciliationRepo.SaveFlags(reconciliations); err != nil {
+			log.Printf("[%s] failed to flag accounts: %v", fName, err)
+			return report, err
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	reconciled := models.AccountReconciliation{AccountId: 1, Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(100)}
+	drifted := models.AccountReconciliation{AccountId: 2, Balance: models.NewMoney(100), LedgerBalance: models.NewMoney(90), Difference: models.NewMoney(10)}
+
+	tests := []struct {
+		name               string
+		req                models.ReconcileRequest
+		reconciliations    []models.AccountReconciliation
+		expectList         bool
+		expectFlag         bool
+		expectedMismatches []models.AccountReconciliation
+		expectedErr        error
+	}{
+		{
+			name:               "mismatches reported",
+			reconciliations:    []models.AccountReconciliation{reconciled, drifted},
+			expectList:         true,
+			expectedMismatches: []models.AccountReconciliation{drifted},
+		},
+		{
+			name:               "mismatches flagged",
+			req:                models.ReconcileRequest{Flag: true},
+			reconciliations:    []models.AccountReconciliation{reconciled, drifted},
+			expectList:         true,
+			expectFlag:         true,
+			expectedMismatches: []models.AccountReconciliation{drifted},
+		},
+		{
+			name:        "unknown account",
+			req:         models.ReconcileRequest{AccountId: 7},
+			expectList:  true,
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+		{
+			name:        "invalid account id",
+			req:         models.ReconcileRequest{AccountId: -1},
+			expectedErr: appErr.ErrInvalidAccountId,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciliationRepo := mocks.NewIReconciliationRepository(t)
+			if tt.expectList {
+				reconciliationRepo.On("ListAccountReconciliations", tt.req.AccountId).Return(tt.reconciliations, nil).Once()
+			}
+			if tt.expectFlag {
+				reconciliationRepo.On("SaveFlags", tt.reconciliations).Return(nil).Once()
+			}
+
+			report, err := NewReconciliationService(reconciliationRepo).Reconcile(context.Background(), tt.req)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, len(tt.reconciliations), report.AccountsChecked)
+				assert.Equal(t, tt.req.Flag, report.Flagged)
+				assert.Equal(t, tt.expectedMismatches, report.Mismatches)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"time"
+)
+
+const (
+	signingSecretPrefix = "tas_"
+	// signingSecretBytes is the number of random bytes in a signing secret, as long as the SHA-256 output it keys
+	signingSecretBytes = 32
+	maxNonceLength     = 64
+)
+
+type RequestSigningService struct {
+	apiKeyRepo repository.IApiKeyRepository
+	nonceRepo  repository.IRequestNonceRepository
+	// maxSkew is how far the timestamp of a signed request may be from the current time
+	maxSkew time.Duration
+}
+
+// NewRequestSigningService creates the service issuing signing secrets and verifying the requests signed with them
+func NewRequestSigningService(apiKeyRepo repository.IApiKeyRepository, nonceRepo repository.IRequestNonceRepository, maxSkew time.Duration) *RequestSigningService {
+	return &RequestSigningService{apiKeyRepo: apiKeyRepo, nonceRepo: nonceRepo, maxSkew: maxSkew}
+}
+
+type IRequestSigningService interface {
+	CreateSigningSecret(ctx context.Context, apiKeyId int64) (string, error)
+	VerifySignature(ctx context.Context, req models.SignedRequest) (models.ApiKey, error)
+	PurgeExpiredNonces(ctx context.Context) (int64, error)
+}
+
+// CreateSigningSecret is a service method that issues a new signing secret for an API key, replacing the previous
+// one. Like a key, the secret is returned only here
+func (s *RequestSigningService) CreateSigningSecret(ctx context.Context, apiKeyId int64) (string, error) {
+	fName := "RequestSigningService.CreateSigningSecret"
+	secret, err := generateSigningSecret()
+	if err != nil {
+		log.Printf("[%s] failed to generate a secret: %v", fName, err)
+		return "", appErr.ErrInternal
+	}
+	if err := s.apiKeyRepo.SetSigningSecret(apiKeyId, secret); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// VerifySignature is a service method that returns the API key a request was signed for. The signature is checked
+// before the nonce is recorded, so that unsigned requests cannot use up the nonces of a client. It fails with
+// ErrStaleRequest when the timestamp is off by more than maxSkew, ErrInvalidSignature when the key cannot sign
+// requests or the signature does not match, and ErrReplayedRequest when the nonce was used before
+func (s *RequestSigningService) VerifySignature(ctx context.Context, req models.SignedRequest) (models.ApiKey, error) {
+	if req.ApiKeyId <= 0 || req.Nonce == "" || len(req.Nonce) > maxNonceLength || req.Signature == "" {
+		return models.ApiKey{}, appErr.ErrMalformedSignature
+	}
+	signedAt := time.Unix(req.Timestamp, 0)
+	if skew := time.Since(signedAt); skew > s.maxSkew || skew < -s.maxSkew {
+		return models.ApiKey{}, appErr.ErrStaleRequest
+	}
+
+	apiKey, secret, err := s.apiKeyRepo.GetActiveSigningKey(req.ApiKeyId)
+	if err != nil {
+		if err == appErr.ErrApiKeyNotFound {
+			return models.ApiKey{}, appErr.ErrInvalidSignature
+		}
+		return models.ApiKey{}, err
+	}
+	signature, err := hex.DecodeString(req.Signature)
+	if err != nil || !hmac.Equal(signature, signRequest(secret, req)) {
+		return models.ApiKey{}, appErr.ErrInvalidSignature
+	}
+
+	// Past signedAt + maxSkew the request is stale, so the nonce does not need to be kept any longer
+	if err := s.nonceRepo.UseNonce(req.ApiKeyId, req.Nonce, signedAt.Add(s.maxSkew).UTC()); err != nil {
+		return models.ApiKey{}, err
+	}
+	return *apiKey, nil
+}
+
+// PurgeExpiredNonces is a service method that deletes the nonces of the requests that are too old to be replayed
+func (s *RequestSigningService) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	return s.nonceRepo.DeleteExpiredNonces(time.Now().UTC())
+}
+
+// RunNoncePurge deletes the expired nonces every interval until ctx is done
+func (s *RequestSigningService) RunNoncePurge(ctx context.Context, interval time.Duration) {
+	fName := "RequestSigningService.RunNoncePurge"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.PurgeExpiredNonces(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("[%s] failed to purge nonces: %v", fName, err)
+			}
+		}
+	}
+}
+
+// signRequest is a method that computes the HMAC-SHA256 of the signing payload of req under secret
+func signRequest(secret string, req models.SignedRequest) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(req.SigningPayload())
+	return mac.Sum(nil)
+}
+
+// generateSigningSecret is a method that creates a new random signing secret
+func generateSigningSecret() (string, error) {
+	secret := make([]byte, signingSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return signingSecretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
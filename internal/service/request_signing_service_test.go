@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRequestSigningService_CreateSigningSecret(t *testing.T) {
+	apiKeyRepo := mocks.NewIApiKeyRepository(t)
+	apiKeyRepo.On("SetSigningSecret", int64(3), mock.MatchedBy(func(secret string) bool {
+		return strings.HasPrefix(secret, signingSecretPrefix)
+	})).Return(nil).Once()
+	apiKeyRepo.On("SetSigningSecret", int64(4), mock.Anything).Return(appErr.ErrApiKeyNotFound).Once()
+	service := NewRequestSigningService(apiKeyRepo, mocks.NewIRequestNonceRepository(t), 5*time.Minute)
+
+	secret, err := service.CreateSigningSecret(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, signingSecretPrefix))
+
+	_, err = service.CreateSigningSecret(context.Background(), 4)
+	assert.Equal(t, appErr.ErrApiKeyNotFound, err)
+}
+
+func TestRequestSigningService_VerifySignature(t *testing.T) {
+	const secret = "tas_secret"
+	apiKey := &models.ApiKey{Id: 3, Name: "payroll", Scopes: []models.ApiKeyScope{models.ScopeTransactionsWrite}}
+	now := time.Now().Unix()
+
+	// signed builds a request to POST /transactions signed at timestamp, with the signature under secret
+	signed := func(timestamp int64) models.SignedRequest {
+		req := models.SignedRequest{ApiKeyId: 3, Method: "POST", Path: "/transactions", Timestamp: timestamp, Nonce: "9f8c1e", Body: []byte(`{"amount":"10"}`)}
+		req.Signature = hex.EncodeToString(signRequest(secret, req))
+		return req
+	}
+	tampered := signed(now)
+	tampered.Body = []byte(`{"amount":"1000"}`)
+
+	tests := []struct {
+		name        string
+		req         models.SignedRequest
+		setupMocks  func(*mocks.IApiKeyRepository, *mocks.IRequestNonceRepository)
+		expectedErr error
+	}{
+		{
+			name: "valid signature",
+			req:  signed(now),
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, nonceRepo *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(apiKey, secret, nil).Once()
+				nonceRepo.On("UseNonce", int64(3), "9f8c1e", time.Unix(now, 0).Add(5*time.Minute).UTC()).Return(nil).Once()
+			},
+		},
+		{
+			name: "timestamp within the allowed skew",
+			req:  signed(now - 240),
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, nonceRepo *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(apiKey, secret, nil).Once()
+				nonceRepo.On("UseNonce", int64(3), "9f8c1e", mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:        "missing nonce",
+			req:         models.SignedRequest{ApiKeyId: 3, Timestamp: now, Signature: "ab"},
+			expectedErr: appErr.ErrMalformedSignature,
+		},
+		{
+			name:        "nonce too long",
+			req:         models.SignedRequest{ApiKeyId: 3, Timestamp: now, Nonce: strings.Repeat("n", maxNonceLength+1), Signature: "ab"},
+			expectedErr: appErr.ErrMalformedSignature,
+		},
+		{
+			name:        "stale timestamp",
+			req:         signed(now - 600),
+			expectedErr: appErr.ErrStaleRequest,
+		},
+		{
+			name:        "timestamp in the future",
+			req:         signed(now + 600),
+			expectedErr: appErr.ErrStaleRequest,
+		},
+		{
+			name: "key without signing secret",
+			req:  signed(now),
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, _ *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(nil, "", appErr.ErrApiKeyNotFound).Once()
+			},
+			expectedErr: appErr.ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			req:  tampered,
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, _ *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(apiKey, secret, nil).Once()
+			},
+			expectedErr: appErr.ErrInvalidSignature,
+		},
+		{
+			name: "signature not hex",
+			req:  models.SignedRequest{ApiKeyId: 3, Method: "POST", Path: "/transactions", Timestamp: now, Nonce: "9f8c1e", Signature: "not-hex"},
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, _ *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(apiKey, secret, nil).Once()
+			},
+			expectedErr: appErr.ErrInvalidSignature,
+		},
+		{
+			name: "replayed nonce",
+			req:  signed(now),
+			setupMocks: func(apiKeyRepo *mocks.IApiKeyRepository, nonceRepo *mocks.IRequestNonceRepository) {
+				apiKeyRepo.On("GetActiveSigningKey", int64(3)).Return(apiKey, secret, nil).Once()
+				nonceRepo.On("UseNonce", int64(3), "9f8c1e", mock.Anything).Return(appErr.ErrReplayedRequest).Once()
+			},
+			expectedErr: appErr.ErrReplayedRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKeyRepo := mocks.NewIApiKeyRepository(t)
+			nonceRepo := mocks.NewIRequestNonceRepository(t)
+			if tt.setupMocks != nil {
+				tt.setupMocks(apiKeyRepo, nonceRepo)
+			}
+
+			key, err := NewRequestSigningService(apiKeyRepo, nonceRepo, 5*time.Minute).VerifySignature(context.Background(), tt.req)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, *apiKey, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"github.com/bhuvi1021/TripleA/internal/schedule"
+	uuid "github.com/google/uuid"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	scheduledTransferIdPrefix = "SCH-"
+	// runDueBatchSize is the number of due scheduled transfers run per DB transaction by the scheduler
+	runDueBatchSize = 50
+)
+
+type ScheduledTransferService struct {
+	scheduledTransferRepo repository.IScheduledTransferRepository
+	accountRepo           repository.IAccountRepository
+	transactionService    ITransactionService
+}
+
+// NewScheduledTransferService creates the scheduled transfer service. Due transfers are made through
+// transactionService so that they follow the same rules as POST /transactions
+func NewScheduledTransferService(scheduledTransferRepo repository.IScheduledTransferRepository, accountRepo repository.IAccountRepository,
+	transactionService ITransactionService) *ScheduledTransferService {
+	return &ScheduledTransferService{
+		scheduledTransferRepo: scheduledTransferRepo,
+		accountRepo:           accountRepo,
+		transactionService:    transactionService,
+	}
+}
+
+type IScheduledTransferService interface {
+	CreateScheduledTransfer(ctx context.Context, args models.CreateScheduledTransferArgs) (models.ScheduledTransferResponse, error)
+	ListScheduledTransfers(ctx context.Context, accountId int64) (models.ListScheduledTransfersResponse, error)
+	PauseScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error)
+	ResumeScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error)
+	CancelScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error)
+	RunDueTransfers(ctx context.Context) (int, error)
+}
+
+// CreateScheduledTransfer is a service method that schedules a one-off transfer at RunAt, or a recurring transfer
+// following the cron Schedule, evaluated in UTC
+func (s *ScheduledTransferService) CreateScheduledTransfer(ctx context.Context, args models.CreateScheduledTransferArgs) (models.ScheduledTransferResponse, error) {
+	fName := "ScheduledTransferService.CreateScheduledTransfer"
+	if !args.Amount.IsPositive() {
+		return models.ScheduledTransferResponse{}, appErr.ErrInvalidAmount
+	}
+	if (args.RunAt == nil) == (args.Schedule == "") {
+		return models.ScheduledTransferResponse{}, appErr.ErrScheduleTimingRequired
+	}
+
+	_, _, err := validateTransfer(ctx, s.accountRepo, models.CreateTransactionArgs{
+		SourceAccountId:      args.SourceAccountId,
+		DestinationAccountId: args.DestinationAccountId,
+		Amount:               args.Amount,
+	})
+	if err != nil {
+		return models.ScheduledTransferResponse{}, err
+	}
+
+	timeNow := time.Now().UTC()
+	st := models.ScheduledTransfer{
+		ScheduledTransferId:  generateScheduledTransferId(),
+		SourceAccountId:      args.SourceAccountId,
+		DestinationAccountId: args.DestinationAccountId,
+		Amount:               args.Amount,
+		Status:               models.ScheduledTransferStatusActive,
+		CreatedAt:            timeNow,
+		UpdatedAt:            timeNow,
+	}
+	if args.RunAt != nil {
+		if !args.RunAt.After(timeNow) {
+			return models.ScheduledTransferResponse{}, appErr.ErrInvalidRunAt
+		}
+		st.NextRunAt = sql.NullTime{Time: args.RunAt.UTC(), Valid: true}
+	} else {
+		if _, err := schedule.Parse(args.Schedule); err != nil {
+			return models.ScheduledTransferResponse{}, appErr.ErrInvalidSchedule
+		}
+		st.Schedule = sql.NullString{String: strings.Join(strings.Fields(args.Schedule), " "), Valid: true}
+		if st.NextRunAt = st.NextRunAfter(timeNow); !st.NextRunAt.Valid {
+			return models.ScheduledTransferResponse{}, appErr.ErrInvalidSchedule
+		}
+	}
+
+	if err := s.scheduledTransferRepo.Create(st); err != nil {
+		log.Printf("[%s] failed to create scheduled transfer: %v", fName, err)
+		return models.ScheduledTransferResponse{}, err
+	}
+	return models.NewScheduledTransferResponse(st), nil
+}
+
+// ListScheduledTransfers is a service method that returns the scheduled transfers paid from an account
+func (s *ScheduledTransferService) ListScheduledTransfers(ctx context.Context, accountId int64) (models.ListScheduledTransfersResponse, error) {
+	if accountId <= 0 {
+		return models.ListScheduledTransfersResponse{}, appErr.ErrInvalidAccountId
+	}
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		return models.ListScheduledTransfersResponse{}, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return models.ListScheduledTransfersResponse{}, err
+	}
+
+	scheduledTransfers, err := s.scheduledTransferRepo.ListBySourceAccount(accountId)
+	if err != nil {
+		return models.ListScheduledTransfersResponse{}, err
+	}
+	resp := models.ListScheduledTransfersResponse{ScheduledTransfers: make([]models.ScheduledTransferResponse, 0, len(scheduledTransfers))}
+	for _, st := range scheduledTransfers {
+		resp.ScheduledTransfers = append(resp.ScheduledTransfers, models.NewScheduledTransferResponse(st))
+	}
+	return resp, nil
+}
+
+// PauseScheduledTransfer is a service method that stops an active transfer from running until it is resumed
+func (s *ScheduledTransferService) PauseScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	return s.changeStatus(ctx, scheduledTransferId, func(st *models.ScheduledTransfer) ([]models.ScheduledTransferStatus, sql.NullTime) {
+		return []models.ScheduledTransferStatus{models.ScheduledTransferStatusActive}, st.NextRunAt
+	}, models.ScheduledTransferStatusPaused)
+}
+
+// ResumeScheduledTransfer is a service method that makes a paused transfer active again. A recurring transfer
+// skips the runs it missed while paused, a one-off transfer whose time has passed runs straight away
+func (s *ScheduledTransferService) ResumeScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	return s.changeStatus(ctx, scheduledTransferId, func(st *models.ScheduledTransfer) ([]models.ScheduledTransferStatus, sql.NullTime) {
+		nextRunAt := st.NextRunAt
+		if now := time.Now().UTC(); st.Schedule.Valid && (!nextRunAt.Valid || nextRunAt.Time.Before(now)) {
+			nextRunAt = st.NextRunAfter(now)
+		}
+		return []models.ScheduledTransferStatus{models.ScheduledTransferStatusPaused}, nextRunAt
+	}, models.ScheduledTransferStatusActive)
+}
+
+// CancelScheduledTransfer is a service method that stops an active or paused transfer for good
+func (s *ScheduledTransferService) CancelScheduledTransfer(ctx context.Context, scheduledTransferId string) (models.ScheduledTransferResponse, error) {
+	return s.changeStatus(ctx, scheduledTransferId, func(*models.ScheduledTransfer) ([]models.ScheduledTransferStatus, sql.NullTime) {
+		return []models.ScheduledTransferStatus{models.ScheduledTransferStatusActive, models.ScheduledTransferStatusPaused}, sql.NullTime{}
+	}, models.ScheduledTransferStatusCancelled)
+}
+
+// changeStatus moves a scheduled transfer to the to status. transition returns the statuses it can be moved from
+// and its next run time once moved. The client of ctx must own the account the transfer is paid from
+func (s *ScheduledTransferService) changeStatus(ctx context.Context, scheduledTransferId string,
+	transition func(st *models.ScheduledTransfer) ([]models.ScheduledTransferStatus, sql.NullTime), to models.ScheduledTransferStatus) (models.ScheduledTransferResponse, error) {
+	fName := "ScheduledTransferService.changeStatus"
+	if !isValidScheduledTransferId(scheduledTransferId) {
+		return models.ScheduledTransferResponse{}, appErr.ErrInvalidScheduledTransferId
+	}
+
+	st, err := s.scheduledTransferRepo.GetById(scheduledTransferId)
+	if err != nil {
+		return models.ScheduledTransferResponse{}, err
+	}
+	if err := authorizeAccountId(ctx, s.accountRepo, st.SourceAccountId); err != nil {
+		return models.ScheduledTransferResponse{}, err
+	}
+
+	from, nextRunAt := transition(st)
+	if err := s.scheduledTransferRepo.UpdateStatus(scheduledTransferId, from, to, nextRunAt); err != nil {
+		log.Printf("[%s] failed to move %s to %s: %v", fName, scheduledTransferId, to, err)
+		return models.ScheduledTransferResponse{}, err
+	}
+
+	st.Status = to
+	st.NextRunAt = nextRunAt
+	return models.NewScheduledTransferResponse(*st), nil
+}
+
+// RunDueTransfers is a service method that makes every transfer that is due and returns how many were run
+func (s *ScheduledTransferService) RunDueTransfers(ctx context.Context) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		run, err := s.scheduledTransferRepo.RunDue(time.Now().UTC(), runDueBatchSize, func(st models.ScheduledTransfer) models.ScheduledTransferRun {
+			return s.runTransfer(ctx, st)
+		})
+		total += run
+		if err != nil || run < runDueBatchSize {
+			return total, err
+		}
+	}
+}
+
+// RunScheduler runs due transfers every interval until ctx is done
+func (s *ScheduledTransferService) RunScheduler(ctx context.Context, interval time.Duration) {
+	fName := "ScheduledTransferService.RunScheduler"
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run, err := s.RunDueTransfers(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Printf("[%s] failed to run due transfers: %v", fName, err)
+			}
+			if run > 0 {
+				log.Printf("[%s] ran %d scheduled transfers", fName, run)
+			}
+		}
+	}
+}
+
+// runTransfer makes one due run of st through the transaction service. The run is keyed by the scheduled
+// transfer and its due time, so that a run whose outcome could not be recorded is replayed rather than paid twice
+func (s *ScheduledTransferService) runTransfer(ctx context.Context, st models.ScheduledTransfer) models.ScheduledTransferRun {
+	fName := "ScheduledTransferService.runTransfer"
+	run := models.ScheduledTransferRun{ScheduledTransferId: st.ScheduledTransferId, ScheduledFor: st.NextRunAt.Time}
+
+	resp, err := s.transactionService.CreateTransaction(ctx, models.CreateTransactionArgs{
+		SourceAccountId:      st.SourceAccountId,
+		DestinationAccountId: st.DestinationAccountId,
+		Amount:               st.Amount,
+		Idempotency:          scheduledRunIdempotency(st),
+	})
+	run.ExecutedAt = time.Now().UTC()
+	if err != nil {
+		_, err = appErr.HTTPStatus(err)
+		log.Printf("[%s] run of %s due at %s failed: %v", fName, st.ScheduledTransferId, run.ScheduledFor.Format(time.RFC3339), err)
+		run.Status = models.ScheduledRunStatusFailed
+		run.ErrorMessage = sql.NullString{String: err.Error(), Valid: true}
+		return run
+	}
+
+	run.Status = models.ScheduledRunStatusSucceeded
+	run.Reference = sql.NullString{String: resp.Reference, Valid: true}
+	return run
+}
+
+// scheduledRunIdempotency returns the idempotency key of the run of st due at its next run time
+func scheduledRunIdempotency(st models.ScheduledTransfer) models.Idempotency {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s", st.SourceAccountId, st.DestinationAccountId, st.Amount)))
+	return models.Idempotency{
+		Key:         fmt.Sprintf("%s@%s", st.ScheduledTransferId, st.NextRunAt.Time.UTC().Format(time.RFC3339)),
+		RequestHash: hex.EncodeToString(sum[:]),
+	}
+}
+
+// generateScheduledTransferId is a method that creates a unique scheduled transfer id
+func generateScheduledTransferId() string {
+	return fmt.Sprintf("%s%s", scheduledTransferIdPrefix, uuid.New().String())
+}
+
+// isValidScheduledTransferId is a method that checks the id was created by generateScheduledTransferId
+func isValidScheduledTransferId(scheduledTransferId string) bool {
+	id, ok := strings.CutPrefix(scheduledTransferId, scheduledTransferIdPrefix)
+	if !ok {
+		return false
+	}
+	_, err := uuid.Parse(id)
+	return err == nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	serviceMocks "github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testScheduledTransferId = "SCH-3f2c1b0a-9d8e-4f7a-b6c5-d4e3f2a1b0c9"
+
+func TestScheduledTransferService_CreateScheduledTransfer(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name        string
+		args        models.CreateScheduledTransferArgs
+		setupMocks  func(repo *mocks.IScheduledTransferRepository)
+		expectedErr error
+	}{
+		{
+			name: "Recurring",
+			args: models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100), Schedule: " 0  9 1 * *"},
+			setupMocks: func(repo *mocks.IScheduledTransferRepository) {
+				repo.On("Create", mock.MatchedBy(func(st models.ScheduledTransfer) bool {
+					next := st.NextRunAt.Time
+					return isValidScheduledTransferId(st.ScheduledTransferId) && st.Schedule.String == "0 9 1 * *" &&
+						next.After(time.Now()) && next.Day() == 1 && next.Hour() == 9 && next.Minute() == 0
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "One-Off",
+			args: models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100), RunAt: &future},
+			setupMocks: func(repo *mocks.IScheduledTransferRepository) {
+				repo.On("Create", mock.MatchedBy(func(st models.ScheduledTransfer) bool {
+					return !st.Schedule.Valid && st.NextRunAt.Time.Equal(future)
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:        "Both Run At And Schedule",
+			args:        models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100), RunAt: &future, Schedule: "0 9 1 * *"},
+			expectedErr: appErr.ErrScheduleTimingRequired,
+		},
+		{
+			name:        "Run At In The Past",
+			args:        models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100), RunAt: &past},
+			expectedErr: appErr.ErrInvalidRunAt,
+		},
+		{
+			name:        "Invalid Schedule",
+			args:        models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100), Schedule: "monthly"},
+			expectedErr: appErr.ErrInvalidSchedule,
+		},
+		{
+			name:        "Invalid Amount",
+			args:        models.CreateScheduledTransferArgs{SourceAccountId: 1, DestinationAccountId: 2, Schedule: "0 9 1 * *"},
+			expectedErr: appErr.ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewIScheduledTransferRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, CurrencyCode: "USD"}, nil).Maybe()
+			accountRepo.On("GetByAccountId", int64(2)).Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil).Maybe()
+			if tt.setupMocks != nil {
+				tt.setupMocks(repo)
+			}
+			svc := NewScheduledTransferService(repo, accountRepo, serviceMocks.NewITransactionService(t))
+
+			resp, err := svc.CreateScheduledTransfer(context.Background(), tt.args)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedErr == nil {
+				assert.Equal(t, models.ScheduledTransferStatusActive, resp.Status)
+				assert.NotNil(t, resp.NextRunAt)
+			}
+		})
+	}
+}
+
+func TestScheduledTransferService_ChangeStatus(t *testing.T) {
+	missedRun := time.Now().Add(-48 * time.Hour).UTC().Truncate(time.Minute)
+	recurring := &models.ScheduledTransfer{
+		ScheduledTransferId: testScheduledTransferId,
+		Schedule:            sql.NullString{String: "*/5 * * * *", Valid: true},
+		Status:              models.ScheduledTransferStatusPaused,
+		NextRunAt:           sql.NullTime{Time: missedRun, Valid: true},
+	}
+
+	t.Run("Resume Skips Missed Runs", func(t *testing.T) {
+		repo := mocks.NewIScheduledTransferRepository(t)
+		svc := NewScheduledTransferService(repo, mocks.NewIAccountRepository(t), serviceMocks.NewITransactionService(t))
+		repo.On("GetById", testScheduledTransferId).Return(recurring, nil).Once()
+		repo.On("UpdateStatus", testScheduledTransferId, []models.ScheduledTransferStatus{models.ScheduledTransferStatusPaused},
+			models.ScheduledTransferStatusActive, mock.MatchedBy(func(next sql.NullTime) bool {
+				return next.Valid && next.Time.After(time.Now()) && next.Time.Minute()%5 == 0
+			})).Return(nil).Once()
+
+		resp, err := svc.ResumeScheduledTransfer(context.Background(), testScheduledTransferId)
+		assert.NoError(t, err)
+		assert.Equal(t, models.ScheduledTransferStatusActive, resp.Status)
+	})
+
+	t.Run("Pause When Not Active", func(t *testing.T) {
+		repo := mocks.NewIScheduledTransferRepository(t)
+		svc := NewScheduledTransferService(repo, mocks.NewIAccountRepository(t), serviceMocks.NewITransactionService(t))
+		repo.On("GetById", testScheduledTransferId).Return(recurring, nil).Once()
+		repo.On("UpdateStatus", testScheduledTransferId, []models.ScheduledTransferStatus{models.ScheduledTransferStatusActive},
+			models.ScheduledTransferStatusPaused, recurring.NextRunAt).Return(appErr.ErrInvalidScheduleTransition).Once()
+
+		_, err := svc.PauseScheduledTransfer(context.Background(), testScheduledTransferId)
+		assert.Equal(t, appErr.ErrInvalidScheduleTransition, err)
+	})
+
+	t.Run("Cancel With Invalid Id", func(t *testing.T) {
+		svc := NewScheduledTransferService(mocks.NewIScheduledTransferRepository(t), mocks.NewIAccountRepository(t), serviceMocks.NewITransactionService(t))
+
+		_, err := svc.CancelScheduledTransfer(context.Background(), "HLD-3f2c1b0a-9d8e-4f7a-b6c5-d4e3f2a1b0c9")
+		assert.Equal(t, appErr.ErrInvalidScheduledTransferId, err)
+	})
+}
+
+func TestScheduledTransferService_RunDueTransfers(t *testing.T) {
+	dueAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	due := []models.ScheduledTransfer{
+		{ScheduledTransferId: testScheduledTransferId, SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100),
+			NextRunAt: sql.NullTime{Time: dueAt, Valid: true}},
+		{ScheduledTransferId: testScheduledTransferId, SourceAccountId: 1, DestinationAccountId: 3, Amount: models.NewMoney(5),
+			NextRunAt: sql.NullTime{Time: dueAt, Valid: true}},
+	}
+
+	repo := mocks.NewIScheduledTransferRepository(t)
+	txnService := serviceMocks.NewITransactionService(t)
+	svc := NewScheduledTransferService(repo, mocks.NewIAccountRepository(t), txnService)
+
+	txnService.On("CreateTransaction", mock.Anything, mock.MatchedBy(func(args models.CreateTransactionArgs) bool {
+		return args.DestinationAccountId == 2 && args.Idempotency.Key == testScheduledTransferId+"@2025-03-01T09:00:00Z" && len(args.Idempotency.RequestHash) == 64
+	})).Return(models.CreateTransactionResponse{Reference: "TXN-1"}, nil).Once()
+	txnService.On("CreateTransaction", mock.Anything, mock.Anything).
+		Return(models.CreateTransactionResponse{}, appErr.ErrInsufficientBalance).Once()
+
+	var runs []models.ScheduledTransferRun
+	repo.On("RunDue", mock.AnythingOfType("time.Time"), runDueBatchSize, mock.Anything).
+		Run(func(args mock.Arguments) {
+			execute := args.Get(2).(func(models.ScheduledTransfer) models.ScheduledTransferRun)
+			for _, st := range due {
+				runs = append(runs, execute(st))
+			}
+		}).Return(len(due), nil).Once()
+
+	run, err := svc.RunDueTransfers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, run)
+	assert.Equal(t, models.ScheduledRunStatusSucceeded, runs[0].Status)
+	assert.Equal(t, "TXN-1", runs[0].Reference.String)
+	assert.Equal(t, dueAt, runs[0].ScheduledFor)
+	assert.Equal(t, models.ScheduledRunStatusFailed, runs[1].Status)
+	assert.Equal(t, "insufficient funds in sender account", runs[1].ErrorMessage.String)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+	"time"
+)
+
+type StatementService struct {
+	statementRepo repository.IStatementRepository
+	accountRepo   repository.IAccountRepository
+}
+
+// NewStatementService creates the service building account statements from the ledger
+func NewStatementService(statementRepo repository.IStatementRepository, accountRepo repository.IAccountRepository) *StatementService {
+	return &StatementService{statementRepo: statementRepo, accountRepo: accountRepo}
+}
+
+type IStatementService interface {
+	GetStatement(ctx context.Context, args models.StatementArgs) (models.Statement, error)
+}
+
+// GetStatement is a service method that builds the statement of an account over the days of args: its opening
+// balance, every ledger entry of the period with the running balance, the debit and credit totals and the closing balance
+func (s *StatementService) GetStatement(ctx context.Context, args models.StatementArgs) (models.Statement, error) {
+	fName := "StatementService.GetStatement"
+	account, err := s.accountRepo.GetByAccountId(args.AccountId)
+	if err != nil {
+		return models.Statement{}, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return models.Statement{}, err
+	}
+
+	openingBalance, transactions, err := s.statementRepo.GetStatementEntries(args)
+	if err != nil {
+		log.Printf("[%s] failed to read the entries of account %d: %v", fName, args.AccountId, err)
+		return models.Statement{}, err
+	}
+
+	statement := models.NewStatement(*account, args, openingBalance, transactions)
+	statement.GeneratedAt = time.Now().UTC()
+	return statement, nil
+}
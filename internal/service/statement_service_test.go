@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatementService_GetStatement(t *testing.T) {
+	args := models.StatementArgs{AccountId: 1, From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}
+	at := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+	transactions := []models.Transaction{
+		{Id: 5, AccountId: 1, Amount: models.NewMoney(25), AvailableBalance: models.NewMoney(75), Reference: "TXN-5", CreatedAt: at},
+	}
+
+	tests := []struct {
+		name        string
+		setupMocks  func(*mocks.IStatementRepository, *mocks.IAccountRepository)
+		expectedErr error
+	}{
+		{
+			name: "statement built from the ledger",
+			setupMocks: func(statementRepo *mocks.IStatementRepository, accountRepo *mocks.IAccountRepository) {
+				accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, CurrencyCode: "USD"}, nil).Once()
+				statementRepo.On("GetStatementEntries", args).Return(models.NewMoney(100), transactions, nil).Once()
+			},
+		},
+		{
+			name: "account not found",
+			setupMocks: func(statementRepo *mocks.IStatementRepository, accountRepo *mocks.IAccountRepository) {
+				accountRepo.On("GetByAccountId", int64(1)).Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedErr: appErr.ErrAccountNotFound,
+		},
+		{
+			name: "ledger unavailable",
+			setupMocks: func(statementRepo *mocks.IStatementRepository, accountRepo *mocks.IAccountRepository) {
+				accountRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, CurrencyCode: "USD"}, nil).Once()
+				statementRepo.On("GetStatementEntries", args).Return(models.Money{}, nil, errors.New("db error")).Once()
+			},
+			expectedErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statementRepo := new(mocks.IStatementRepository)
+			accountRepo := new(mocks.IAccountRepository)
+			tt.setupMocks(statementRepo, accountRepo)
+
+			statement, err := NewStatementService(statementRepo, accountRepo).GetStatement(context.Background(), args)
+			assert.Equal(t, tt.expectedErr, err)
+			if err == nil {
+				assert.Equal(t, "USD", statement.CurrencyCode)
+				assert.Equal(t, models.NewMoney(100), statement.OpeningBalance)
+				assert.Equal(t, models.NewMoney(75), statement.ClosingBalance)
+				assert.Len(t, statement.Lines, 1)
+				assert.False(t, statement.GeneratedAt.IsZero())
+			}
+			statementRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -2,39 +2,75 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/fx"
 	uuid "github.com/google/uuid"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/repository"
 )
 
+const (
+	defaultPageSize      = 20
+	maxPageSize          = 100
+	transactionRefPrefix = "TXN-"
+	quoteIdPrefix        = "QTE-"
+	// maxBatchSize is the largest number of transfers accepted in one batch
+	maxBatchSize = 1000
+)
+
 type TransactionService struct {
 	transactionRepo repository.ITransactionRepository
 	accountRepo     repository.IAccountRepository
+	fxQuoteRepo     repository.IFxQuoteRepository
+	rateProvider    fx.IRateProvider
+	quoteTTL        time.Duration
+	limitService    ITransferLimitService
+	feeService      IFeeService
 }
 
-func NewTransactionService(transactionRepo repository.ITransactionRepository, accountRepo repository.IAccountRepository) *TransactionService {
+// NewTransactionService creates the transaction service. With a nil rateProvider transfers between accounts of
+// different currencies are rejected, with a nil limitService transfers are not capped and with a nil feeService
+// they are free
+func NewTransactionService(transactionRepo repository.ITransactionRepository, accountRepo repository.IAccountRepository,
+	fxQuoteRepo repository.IFxQuoteRepository, rateProvider fx.IRateProvider, quoteTTL time.Duration,
+	limitService ITransferLimitService, feeService IFeeService) *TransactionService {
 	return &TransactionService{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
+		fxQuoteRepo:     fxQuoteRepo,
+		rateProvider:    rateProvider,
+		quoteTTL:        quoteTTL,
+		limitService:    limitService,
+		feeService:      feeService,
 	}
 }
 
 type ITransactionService interface {
 	CreateTransaction(ctx context.Context, req models.CreateTransactionArgs) (models.CreateTransactionResponse, error)
+	ListAccountTransactions(ctx context.Context, req models.ListTransactionsArgs) (models.ListTransactionsResponse, error)
+	GetTransfer(ctx context.Context, reference string) (models.TransferResponse, error)
+	ReverseTransaction(ctx context.Context, req models.ReverseTransactionArgs) (models.ReverseTransactionResponse, error)
+	CreateQuote(ctx context.Context, req models.CreateQuoteArgs) (models.QuoteResponse, error)
+	CreateBatchTransaction(ctx context.Context, req models.CreateBatchTransactionArgs) (models.CreateBatchTransactionResponse, error)
 }
 
 // CreateTransaction is a service method that creates the transaction for money transfer.
-// For each internal money transfer 1 credit and 1 debit transaction will be logged
+// For each internal money transfer 1 credit and 1 debit transaction will be logged.
+// Between accounts of different currencies the amount is converted at the rate of the given quote, or at the
+// current rate when there is no quote
 func (ts *TransactionService) CreateTransaction(ctx context.Context, req models.CreateTransactionArgs) (resp models.CreateTransactionResponse, err error) {
-	if err = ts.validateCreateTransactionRequest(req); err != nil {
+	if req, err = ts.prepareTransfer(ctx, req); err != nil {
 		return resp, err
 	}
 
-	req.CurrencyCode = "USD"
-	req.Reference = generateTransactionRef() // this is to refer the transaction set
 	if resp, err = ts.transactionRepo.CreateTransaction(req); err != nil {
 		return resp, err
 	}
@@ -42,51 +78,537 @@ func (ts *TransactionService) CreateTransaction(ctx context.Context, req models.
 	return resp, nil
 }
 
-// validateCreateTransactionRequest is a method that validates the payload values
-func (ts *TransactionService) validateCreateTransactionRequest(req models.CreateTransactionArgs) error {
+// CreateBatchTransaction is a service method that runs a list of transfers. In atomic mode they are posted in one
+// DB transaction and the whole batch fails with the error of the first transfer that cannot be made, whose index
+// is set in FailedIndex. In best-effort mode each transfer is made on its own and its outcome is reported in results.
+// A transfer whose amount could not be parsed fails with that error in both modes
+func (ts *TransactionService) CreateBatchTransaction(ctx context.Context, req models.CreateBatchTransactionArgs) (resp models.CreateBatchTransactionResponse, err error) {
+	fName := "TransactionService.CreateBatchTransaction"
+	if !req.Mode.IsValid() {
+		return resp, appErr.ErrInvalidBatchMode
+	}
+	if len(req.Transfers) == 0 {
+		return resp, appErr.ErrEmptyBatch
+	}
+	if len(req.Transfers) > maxBatchSize {
+		return resp, appErr.ErrBatchTooLarge
+	}
+
+	resp.Mode = req.Mode
+	resp.Results = make([]models.BatchTransactionResult, len(req.Transfers))
+
+	if req.Mode == models.BatchModeBestEffort {
+		for i, transfer := range req.Transfers {
+			var transferResp models.CreateTransactionResponse
+			err := req.AmountError(i)
+			if err == nil {
+				transferResp, err = ts.CreateTransaction(ctx, transfer)
+			}
+			resp.Results[i] = newBatchTransactionResult(i, transferResp, err)
+			if err != nil {
+				resp.Failed++
+			} else {
+				resp.Succeeded++
+			}
+		}
+		return resp, nil
+	}
+
+	transfers := make([]models.CreateTransactionArgs, len(req.Transfers))
+	for i, transfer := range req.Transfers {
+		if err := req.AmountError(i); err != nil {
+			return failedBatch(resp, i, err)
+		}
+		if transfers[i], err = ts.prepareTransfer(ctx, transfer); err != nil {
+			return failedBatch(resp, i, err)
+		}
+	}
+
+	transferResps, failedIndex, err := ts.transactionRepo.CreateBatchTransaction(transfers)
+	if err != nil {
+		log.Printf("[%s] batch rolled back at transfer %d: %v", fName, failedIndex, err)
+		return failedBatch(resp, failedIndex, err)
+	}
+	for i := range transferResps {
+		resp.Results[i] = newBatchTransactionResult(i, transferResps[i], nil)
+	}
+	resp.Succeeded = len(transferResps)
+	return resp, nil
+}
+
+// failedBatch returns the response of an atomic batch rolled back because of the transfer at index
+func failedBatch(resp models.CreateBatchTransactionResponse, index int, err error) (models.CreateBatchTransactionResponse, error) {
+	resp.Succeeded = 0
+	resp.Failed = 1
+	resp.Results = []models.BatchTransactionResult{newBatchTransactionResult(index, models.CreateTransactionResponse{}, err)}
+	resp.FailedIndex = &index
+	return resp, err
+}
+
+// newBatchTransactionResult builds the outcome of the transfer at index, with the HTTP status the transfer would
+// have had on its own
+func newBatchTransactionResult(index int, transferResp models.CreateTransactionResponse, err error) models.BatchTransactionResult {
+	if err != nil {
+		statusCode, err := appErr.HTTPStatus(err)
+		return models.BatchTransactionResult{Index: index, StatusCode: statusCode, ErrorMessage: err.Error()}
+	}
+	return models.BatchTransactionResult{Index: index, StatusCode: http.StatusOK, Transaction: &transferResp}
+}
+
+// prepareTransfer is a method that validates req and fills in the currencies, the converted amount, the limits of
+// the source account, the fee and a new reference, leaving it ready to be posted. The per-transfer cap is checked here; the
+// caps counting past transfers are checked when the transfer is posted, under the lock of the source account
+func (ts *TransactionService) prepareTransfer(ctx context.Context, req models.CreateTransactionArgs) (models.CreateTransactionArgs, error) {
+	fName := "TransactionService.prepareTransfer"
+	sourceAccount, destAccount, err := ts.validateCreateTransactionRequest(ctx, req)
+	if err != nil {
+		return req, err
+	}
+
+	if ts.limitService != nil {
+		if req.Limits, err = ts.limitService.GetLimits(ctx, req.SourceAccountId); err != nil {
+			log.Printf("[%s] failed to get limits of account %d: %v", fName, req.SourceAccountId, err)
+			return req, err
+		}
+		if err := req.Limits.CheckAmount(req.Amount); err != nil {
+			return req, err
+		}
+	}
+
+	req.CurrencyCode = sourceAccount.CurrencyCode
+	req.DestinationCurrencyCode = destAccount.CurrencyCode
+	if req.QuoteId != "" {
+		quote, err := ts.getMatchingQuote(req)
+		if err != nil {
+			return req, err
+		}
+		req.FxRate = quote.Rate
+		req.DestinationAmount = quote.DestinationAmount
+	} else {
+		req.FxRate, req.DestinationAmount, err = ts.convert(ctx, req.CurrencyCode, req.DestinationCurrencyCode, req.Amount)
+		if err != nil {
+			return req, err
+		}
+	}
+
+	if ts.feeService != nil {
+		if req.Fee, err = ts.feeService.GetFee(ctx, sourceAccount, req.Amount); err != nil {
+			log.Printf("[%s] failed to get fee of account %d: %v", fName, req.SourceAccountId, err)
+			return req, err
+		}
+		if req.Fee != nil {
+			// The fee is converted at the live rate, a quote only covers the amount of the transfer
+			req.Fee.FxRate, req.Fee.RevenueAmount, err = ts.convert(ctx, req.Fee.CurrencyCode, req.Fee.RevenueCurrencyCode, req.Fee.Breakdown.Amount)
+			if err != nil {
+				return req, err
+			}
+		}
+	}
+
+	req.Reference = generateTransactionRef() // this is to refer the transaction set
+	return req, nil
+}
+
+// validateCreateTransactionRequest is a method that validates the payload values and returns the sender and the
+// receiver accounts. The amount must fit the minor units of the sender's currency
+func (ts *TransactionService) validateCreateTransactionRequest(ctx context.Context, req models.CreateTransactionArgs) (*models.Account, *models.Account, error) {
+	return validateTransfer(ctx, ts.accountRepo, req)
+}
+
+// validateTransfer checks that money can move between the two accounts of req and returns the sender and the
+// receiver accounts. It is shared by every operation that ends in a transfer. The client of ctx must own the sender
+func validateTransfer(ctx context.Context, accountRepo repository.IAccountRepository, req models.CreateTransactionArgs) (*models.Account, *models.Account, error) {
 	if req.SourceAccountId <= 0 {
-		return appErr.ErrInvalidSourceAccountId
+		return nil, nil, appErr.ErrInvalidSourceAccountId
 	}
 
 	if req.DestinationAccountId <= 0 {
-		return appErr.ErrInvalidDestinationAccountId
+		return nil, nil, appErr.ErrInvalidDestinationAccountId
 	}
 
 	if req.SourceAccountId == req.DestinationAccountId {
-		return appErr.ErrSameSourceAndDestinationId
+		return nil, nil, appErr.ErrSameSourceAndDestinationId
 	}
 
-	if req.Amount < 0 {
-		return appErr.ErrInvalidAmount
+	if !req.Amount.IsPositive() {
+		return nil, nil, appErr.ErrInvalidAmount
 	}
 
-	sourceAccount, err := ts.accountRepo.GetByAccountId(req.SourceAccountId)
+	sourceAccount, err := accountRepo.GetByAccountId(req.SourceAccountId)
 	if err != nil {
 		if err == appErr.ErrAccountNotFound {
-			return appErr.ErrSourceAccountNotFound
+			return nil, nil, appErr.ErrSourceAccountNotFound
 		}
-		return err
+		return nil, nil, err
+	}
+	if sourceAccount == nil {
+		return nil, nil, appErr.ErrSourceAccountNotFound
+	}
+	if err := authorizeAccount(ctx, sourceAccount); err != nil {
+		return nil, nil, err
 	}
-	if sourceAccount == nil || sourceAccount.DeletedAt.Valid {
-		return appErr.ErrSourceAccountNotFound
+	if sourceAccount.Status == models.AccountStatusClosed || sourceAccount.DeletedAt.Valid {
+		return nil, nil, appErr.ErrSourceAccountClosed
+	}
+	if !sourceAccount.Status.AllowsDebit() {
+		return nil, nil, appErr.ErrSourceAccountFrozen
 	}
 
-	destAccount, err := ts.accountRepo.GetByAccountId(req.DestinationAccountId)
+	destAccount, err := accountRepo.GetByAccountId(req.DestinationAccountId)
 	if err != nil {
 		if err == appErr.ErrAccountNotFound {
-			return appErr.ErrDestinationAccountNotFound
+			return nil, nil, appErr.ErrDestinationAccountNotFound
+		}
+		return nil, nil, err
+	}
+	if destAccount == nil {
+		return nil, nil, appErr.ErrDestinationAccountNotFound
+	}
+	if !destAccount.Status.AllowsCredit() || destAccount.DeletedAt.Valid {
+		return nil, nil, appErr.ErrDestinationAccountClosed
+	}
+
+	currency, err := models.LookupCurrency(sourceAccount.CurrencyCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !currency.Allows(req.Amount) {
+		return nil, nil, appErr.ErrInvalidCurrencyPrecision
+	}
+
+	return sourceAccount, destAccount, nil
+}
+
+// convert is a method that returns the rate and the amount credited in the destination currency for amount
+// debited in the source currency, rounded to the destination currency's minor units
+func (ts *TransactionService) convert(ctx context.Context, sourceCurrency, destCurrency string, amount models.Money) (models.ExchangeRate, models.Money, error) {
+	fName := "TransactionService.convert"
+	if sourceCurrency == destCurrency {
+		return models.OneExchangeRate, amount, nil
+	}
+	if ts.rateProvider == nil {
+		return models.ExchangeRate{}, models.Money{}, appErr.ErrCurrencyMismatch
+	}
+
+	currency, err := models.LookupCurrency(destCurrency)
+	if err != nil {
+		return models.ExchangeRate{}, models.Money{}, err
+	}
+
+	rate, err := ts.rateProvider.GetRate(ctx, sourceCurrency, destCurrency)
+	if err != nil {
+		log.Printf("[%s] failed to get %s/%s rate: %v", fName, sourceCurrency, destCurrency, err)
+		return models.ExchangeRate{}, models.Money{}, appErr.ErrExchangeRateUnavailable
+	}
+
+	converted := rate.Convert(amount, currency)
+	// an amount too small to be worth a single minor unit of the destination currency cannot be transferred
+	if amount.IsPositive() && !converted.IsPositive() {
+		return models.ExchangeRate{}, models.Money{}, appErr.ErrInvalidAmount
+	}
+	return rate, converted, nil
+}
+
+// CreateQuote is a service method that locks in the current exchange rate for a transfer between two accounts.
+// The quote can be executed once with POST /transactions until it expires
+func (ts *TransactionService) CreateQuote(ctx context.Context, req models.CreateQuoteArgs) (resp models.QuoteResponse, err error) {
+	fName := "TransactionService.CreateQuote"
+	if !req.Amount.IsPositive() {
+		return resp, appErr.ErrInvalidAmount
+	}
+
+	sourceAccount, destAccount, err := ts.validateCreateTransactionRequest(ctx, models.CreateTransactionArgs{
+		SourceAccountId:      req.SourceAccountId,
+		DestinationAccountId: req.DestinationAccountId,
+		Amount:               req.Amount,
+	})
+	if err != nil {
+		return resp, err
+	}
+
+	rate, destAmount, err := ts.convert(ctx, sourceAccount.CurrencyCode, destAccount.CurrencyCode, req.Amount)
+	if err != nil {
+		return resp, err
+	}
+
+	now := time.Now().UTC()
+	quote := models.FxQuote{
+		QuoteId:                 generateQuoteId(),
+		SourceAccountId:         req.SourceAccountId,
+		DestinationAccountId:    req.DestinationAccountId,
+		SourceCurrencyCode:      sourceAccount.CurrencyCode,
+		DestinationCurrencyCode: destAccount.CurrencyCode,
+		SourceAmount:            req.Amount,
+		DestinationAmount:       destAmount,
+		Rate:                    rate,
+		ExpiresAt:               now.Add(ts.quoteTTL),
+		CreatedAt:               now,
+	}
+	if err := ts.fxQuoteRepo.CreateQuote(quote); err != nil {
+		log.Printf("[%s] failed to store quote: %v", fName, err)
+		return resp, err
+	}
+
+	return models.NewQuoteResponse(quote), nil
+}
+
+// getMatchingQuote is a method that loads the quote of a transfer and checks that it was issued for exactly this
+// transfer. Whether it expired or was used is only checked by the repository while it consumes the quote, after
+// the idempotency key of the request is acquired, so that a replayed transfer still gets its stored response
+func (ts *TransactionService) getMatchingQuote(req models.CreateTransactionArgs) (*models.FxQuote, error) {
+	if !isValidQuoteId(req.QuoteId) {
+		return nil, appErr.ErrInvalidQuoteId
+	}
+
+	quote, err := ts.fxQuoteRepo.GetQuote(req.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.SourceAccountId != req.SourceAccountId || quote.DestinationAccountId != req.DestinationAccountId ||
+		quote.SourceAmount.Cmp(req.Amount) != 0 || quote.SourceCurrencyCode != req.CurrencyCode ||
+		quote.DestinationCurrencyCode != req.DestinationCurrencyCode {
+		return nil, appErr.ErrQuoteMismatch
+	}
+	return quote, nil
+}
+
+// ListAccountTransactions is a service method that returns a page of the account's ledger entries, newest first.
+// The next_cursor of the response can be passed back to fetch the following page
+func (ts *TransactionService) ListAccountTransactions(ctx context.Context, req models.ListTransactionsArgs) (resp models.ListTransactionsResponse, err error) {
+	filter, err := ts.buildTransactionFilter(req)
+	if err != nil {
+		return resp, err
+	}
+
+	account, err := ts.accountRepo.GetByAccountId(req.AccountId)
+	if err != nil {
+		return resp, err
+	}
+	if err := authorizeAccount(ctx, account); err != nil {
+		return resp, err
+	}
+
+	// one extra row is read to know whether there is a next page
+	pageSize := filter.Limit
+	filter.Limit++
+	transactions, err := ts.transactionRepo.ListTransactions(filter)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(transactions) > pageSize {
+		transactions = transactions[:pageSize]
+		last := transactions[pageSize-1]
+		resp.NextCursor = encodeCursor(last.CreatedAt, last.Id)
+	}
+
+	resp.Transactions = make([]models.TransactionResponse, 0, len(transactions))
+	for _, t := range transactions {
+		resp.Transactions = append(resp.Transactions, models.NewTransactionResponse(t))
+	}
+	return resp, nil
+}
+
+// buildTransactionFilter is a method that validates the list payload and converts it to a repository filter
+func (ts *TransactionService) buildTransactionFilter(req models.ListTransactionsArgs) (models.TransactionFilter, error) {
+	filter := models.TransactionFilter{
+		AccountId: req.AccountId,
+		From:      req.From,
+		To:        req.To,
+		IsCredit:  req.IsCredit,
+		MinAmount: req.MinAmount,
+		MaxAmount: req.MaxAmount,
+		Limit:     req.Limit,
+	}
+
+	if req.AccountId <= 0 {
+		return filter, appErr.ErrInvalidAccountId
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		return filter, appErr.ErrInvalidDateRange
+	}
+
+	if req.MinAmount != nil && req.MaxAmount != nil && req.MinAmount.GreaterThan(*req.MaxAmount) {
+		return filter, appErr.ErrInvalidAmountRange
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageSize {
+		return filter, appErr.ErrInvalidLimit
+	}
+
+	if req.Cursor != "" {
+		createdAt, id, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return filter, err
+		}
+		filter.CursorCreatedAt = createdAt
+		filter.CursorId = id
+	}
+
+	return filter, nil
+}
+
+// encodeCursor is a method that creates an opaque pagination cursor pointing at the given ledger entry
+func encodeCursor(createdAt time.Time, id int64) string {
+	raw := fmt.Sprintf("%s|%d", createdAt.Format(time.RFC3339Nano), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor is a method that reads back a cursor created by encodeCursor
+func decodeCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, appErr.ErrInvalidCursor
+	}
+
+	tsPart, idPart, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, 0, appErr.ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, tsPart)
+	if err != nil {
+		return time.Time{}, 0, appErr.ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || id <= 0 {
+		return time.Time{}, 0, appErr.ErrInvalidCursor
+	}
+
+	return createdAt, id, nil
+}
+
+// GetTransfer is a service method that returns both legs of a transfer along with the resulting balances. The
+// client of ctx must own the sender or the receiver
+func (ts *TransactionService) GetTransfer(ctx context.Context, reference string) (resp models.TransferResponse, err error) {
+	if !isValidTransactionRef(reference) {
+		return resp, appErr.ErrInvalidReference
+	}
+
+	legs, err := ts.transactionRepo.GetByReference(reference)
+	if err != nil {
+		return resp, err
+	}
+
+	resp.Reference = reference
+	resp.Legs = make([]models.TransactionResponse, 0, len(legs))
+	debitFound, creditFound := false, false
+	for _, leg := range legs {
+		resp.Legs = append(resp.Legs, models.NewTransactionResponse(leg))
+		if leg.UpdatedAt.After(resp.UpdatedAt) {
+			resp.UpdatedAt = leg.UpdatedAt
+		}
+
+		// the first debit and credit are the principal legs, anything after them belongs to the same transfer set
+		if !leg.IsCredit && !debitFound {
+			debitFound = true
+			resp.SourceAccountId = leg.AccountId
+			resp.SourceAvailableBalance = leg.AvailableBalance
+			resp.Amount = leg.Amount
+			resp.CurrencyCode = leg.CurrencyCode
+			resp.FxRate = models.OneExchangeRate
+			if leg.FxRate != nil {
+				resp.FxRate = *leg.FxRate
+			}
+			resp.OriginalReference = leg.OriginalReference.String
+			resp.CreatedAt = leg.CreatedAt
+		}
+		if leg.IsCredit && !creditFound {
+			creditFound = true
+			resp.DestinationAccountId = leg.AccountId
+			resp.DestinationAvailableBalance = leg.AvailableBalance
+			resp.DestinationAmount = leg.Amount
+			resp.DestinationCurrencyCode = leg.CurrencyCode
 		}
-		return err
 	}
-	if destAccount == nil || destAccount.DeletedAt.Valid {
-		return appErr.ErrDestinationAccountNotFound
+
+	if err := ts.authorizeTransferParty(ctx, resp.SourceAccountId, resp.DestinationAccountId); err != nil {
+		return models.TransferResponse{}, err
 	}
+	return resp, nil
+}
 
-	return nil
+// authorizeTransferParty checks that the client of ctx owns one of the accounts of a transfer
+func (ts *TransactionService) authorizeTransferParty(ctx context.Context, accountIds ...int64) error {
+	for _, accountId := range accountIds {
+		if err := authorizeAccountId(ctx, ts.accountRepo, accountId); err != appErr.ErrAccountForbidden {
+			return err
+		}
+	}
+	return appErr.ErrAccountForbidden
+}
+
+// ReverseTransaction is a service method that reverses a transfer fully or partially by posting
+// mirrored debit and credit entries under a new reference linked to the original one. The reversal takes the money
+// back from the receiver, so the client of ctx must own the receiver
+func (ts *TransactionService) ReverseTransaction(ctx context.Context, req models.ReverseTransactionArgs) (resp models.ReverseTransactionResponse, err error) {
+	if !isValidTransactionRef(req.OriginalReference) {
+		return resp, appErr.ErrInvalidReference
+	}
+
+	if req.Amount != nil && !req.Amount.IsPositive() {
+		return resp, appErr.ErrInvalidAmount
+	}
+
+	if err := ts.authorizeReversal(ctx, req.OriginalReference); err != nil {
+		return resp, err
+	}
+
+	req.Reference = generateTransactionRef()
+	return ts.transactionRepo.ReverseTransaction(req)
 }
 
 // generateTransactionRef is a method that creates unique transaction reference number to link both credit and debit entries
 func generateTransactionRef() string {
 	txnRef := uuid.New()
-	return fmt.Sprintf("TXN-%s", txnRef.String())
+	return fmt.Sprintf("%s%s", transactionRefPrefix, txnRef.String())
+}
+
+// generateQuoteId is a method that creates a unique FX quote id
+func generateQuoteId() string {
+	return fmt.Sprintf("%s%s", quoteIdPrefix, uuid.New().String())
+}
+
+// isValidQuoteId is a method that checks the id was created by generateQuoteId
+func isValidQuoteId(quoteId string) bool {
+	id, ok := strings.CutPrefix(quoteId, quoteIdPrefix)
+	if !ok {
+		return false
+	}
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// isValidTransactionRef is a method that checks the reference was created by generateTransactionRef
+func isValidTransactionRef(reference string) bool {
+	id, ok := strings.CutPrefix(reference, transactionRefPrefix)
+	if !ok {
+		return false
+	}
+	_, err := uuid.Parse(id)
+	return err == nil
+}
+
+// authorizeReversal checks that the client of ctx owns the receiver of the transfer with the given reference, which
+// is the account a reversal debits
+func (ts *TransactionService) authorizeReversal(ctx context.Context, reference string) error {
+	if _, ok := restrictedApiKey(ctx); !ok {
+		return nil
+	}
+	legs, err := ts.transactionRepo.GetByReference(reference)
+	if err != nil {
+		return err
+	}
+	for _, leg := range legs {
+		if leg.IsCredit {
+			return authorizeAccountId(ctx, ts.accountRepo, leg.AccountId)
+		}
+	}
+	return appErr.ErrAccountForbidden
 }
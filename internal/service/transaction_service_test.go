@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/fx"
 	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +18,7 @@ import (
 func TestTransactionService_CreateTransaction(t *testing.T) {
 	mockTxnRepo := new(mocks.ITransactionRepository)
 	mockAcctRepo := new(mocks.IAccountRepository)
-	svc := NewTransactionService(mockTxnRepo, mockAcctRepo)
+	svc := NewTransactionService(mockTxnRepo, mockAcctRepo, nil, nil, time.Minute, nil, nil)
 
 	ctx := context.Background()
 
@@ -25,31 +28,36 @@ func TestTransactionService_CreateTransaction(t *testing.T) {
 		mockAccounts         map[int64]*models.Account
 		repoError            error
 		expectedError        error
-		expectedAvailableBal string
+		expectedAvailableBal models.Money
 	}{
 		{
 			name:          "invalid source account ID",
-			req:           models.CreateTransactionArgs{SourceAccountId: 0, DestinationAccountId: 2, Amount: 100},
+			req:           models.CreateTransactionArgs{SourceAccountId: 0, DestinationAccountId: 2, Amount: models.NewMoney(100)},
 			expectedError: appErr.ErrInvalidSourceAccountId,
 		},
 		{
 			name:          "invalid destination account ID",
-			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 0, Amount: 100},
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 0, Amount: models.NewMoney(100)},
 			expectedError: appErr.ErrInvalidDestinationAccountId,
 		},
 		{
 			name:          "same source and destination account ID",
-			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 1, Amount: 100},
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 1, Amount: models.NewMoney(100)},
 			expectedError: appErr.ErrSameSourceAndDestinationId,
 		},
 		{
 			name:          "negative amount",
-			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: -50},
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(-50)},
+			expectedError: appErr.ErrInvalidAmount,
+		},
+		{
+			name:          "zero amount",
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(0)},
 			expectedError: appErr.ErrInvalidAmount,
 		},
 		{
 			name: "source account not found",
-			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: 100},
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
 			mockAccounts: map[int64]*models.Account{
 				1: nil,
 			},
@@ -57,31 +65,83 @@ func TestTransactionService_CreateTransaction(t *testing.T) {
 		},
 		{
 			name: "destination account not found",
-			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: 100},
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
 			mockAccounts: map[int64]*models.Account{
-				1: {},
+				1: {CurrencyCode: "USD"},
 				2: nil,
 			},
 			expectedError: appErr.ErrDestinationAccountNotFound,
 		},
+		{
+			name: "source account frozen",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "USD", Status: models.AccountStatusFrozen},
+			},
+			expectedError: appErr.ErrSourceAccountFrozen,
+		},
+		{
+			name: "source account closed",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			},
+			expectedError: appErr.ErrSourceAccountClosed,
+		},
+		{
+			name: "destination account closed",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "USD", Status: models.AccountStatusActive},
+				2: {CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			},
+			expectedError: appErr.ErrDestinationAccountClosed,
+		},
+		{
+			name: "frozen destination account can be credited",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "USD", Status: models.AccountStatusActive},
+				2: {CurrencyCode: "USD", Status: models.AccountStatusFrozen},
+			},
+			expectedAvailableBal: models.NewMoney(900),
+		},
 		{
 			name: "repository error during transaction",
-			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: 100},
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
 			mockAccounts: map[int64]*models.Account{
-				1: {},
-				2: {},
+				1: {CurrencyCode: "USD"},
+				2: {CurrencyCode: "USD"},
 			},
 			repoError:     errors.New("db error"),
 			expectedError: errors.New("db error"),
 		},
+		{
+			name: "accounts in different currencies",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "USD"},
+				2: {CurrencyCode: "EUR"},
+			},
+			expectedError: appErr.ErrCurrencyMismatch,
+		},
+		{
+			name: "amount finer than the currency minor unit",
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("100.5")},
+			mockAccounts: map[int64]*models.Account{
+				1: {CurrencyCode: "JPY"},
+				2: {CurrencyCode: "JPY"},
+			},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
 		{
 			name: "successful transaction",
-			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: 100},
+			req:  models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100)},
 			mockAccounts: map[int64]*models.Account{
-				1: {},
-				2: {},
+				1: {CurrencyCode: "USD"},
+				2: {CurrencyCode: "USD"},
 			},
-			expectedAvailableBal: "900.00000",
+			expectedAvailableBal: models.NewMoney(900),
 		},
 	}
 
@@ -100,8 +160,11 @@ func TestTransactionService_CreateTransaction(t *testing.T) {
 			if tc.repoError != nil {
 				mockTxnRepo.On("CreateTransaction", mock.Anything).
 					Return(models.CreateTransactionResponse{}, tc.repoError).Once()
-			} else if tc.expectedAvailableBal != "" {
-				mockTxnRepo.On("CreateTransaction", mock.Anything).
+			} else if !tc.expectedAvailableBal.IsZero() {
+				// the transfer is posted in the currency of the accounts
+				mockTxnRepo.On("CreateTransaction", mock.MatchedBy(func(args models.CreateTransactionArgs) bool {
+					return args.CurrencyCode == "USD"
+				})).
 					Return(models.CreateTransactionResponse{
 						SourceAccountId:  tc.req.SourceAccountId,
 						AvailableBalance: tc.expectedAvailableBal,
@@ -120,3 +183,656 @@ func TestTransactionService_CreateTransaction(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionService_ListAccountTransactions(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	from := base
+	to := base.Add(-time.Hour)
+	min := models.NewMoney(50)
+	max := models.NewMoney(10)
+
+	ledger := []models.Transaction{
+		{Id: 3, AccountId: 1, Amount: models.NewMoney(10), IsCredit: true, Reference: "TXN-3", CreatedAt: base},
+		{Id: 2, AccountId: 1, Amount: models.NewMoney(20), IsCredit: false, Reference: "TXN-2", CreatedAt: base.Add(-time.Minute)},
+		{Id: 1, AccountId: 1, Amount: models.NewMoney(30), IsCredit: true, Reference: "TXN-1", CreatedAt: base.Add(-2 * time.Minute)},
+	}
+
+	tests := []struct {
+		name           string
+		req            models.ListTransactionsArgs
+		setupMocks     func(txnRepo *mocks.ITransactionRepository, acctRepo *mocks.IAccountRepository)
+		expectedError  error
+		expectedIds    []int64
+		expectNextPage bool
+	}{
+		{
+			name:          "invalid account id",
+			req:           models.ListTransactionsArgs{AccountId: 0},
+			setupMocks:    func(*mocks.ITransactionRepository, *mocks.IAccountRepository) {},
+			expectedError: appErr.ErrInvalidAccountId,
+		},
+		{
+			name:          "invalid date range",
+			req:           models.ListTransactionsArgs{AccountId: 1, From: &from, To: &to},
+			setupMocks:    func(*mocks.ITransactionRepository, *mocks.IAccountRepository) {},
+			expectedError: appErr.ErrInvalidDateRange,
+		},
+		{
+			name:          "invalid amount range",
+			req:           models.ListTransactionsArgs{AccountId: 1, MinAmount: &min, MaxAmount: &max},
+			setupMocks:    func(*mocks.ITransactionRepository, *mocks.IAccountRepository) {},
+			expectedError: appErr.ErrInvalidAmountRange,
+		},
+		{
+			name:          "limit too large",
+			req:           models.ListTransactionsArgs{AccountId: 1, Limit: 101},
+			setupMocks:    func(*mocks.ITransactionRepository, *mocks.IAccountRepository) {},
+			expectedError: appErr.ErrInvalidLimit,
+		},
+		{
+			name:          "malformed cursor",
+			req:           models.ListTransactionsArgs{AccountId: 1, Cursor: "not-a-cursor"},
+			setupMocks:    func(*mocks.ITransactionRepository, *mocks.IAccountRepository) {},
+			expectedError: appErr.ErrInvalidCursor,
+		},
+		{
+			name: "account not found",
+			req:  models.ListTransactionsArgs{AccountId: 1},
+			setupMocks: func(_ *mocks.ITransactionRepository, acctRepo *mocks.IAccountRepository) {
+				acctRepo.On("GetByAccountId", int64(1)).Return(nil, appErr.ErrAccountNotFound).Once()
+			},
+			expectedError: appErr.ErrAccountNotFound,
+		},
+		{
+			name: "first page with more results",
+			req:  models.ListTransactionsArgs{AccountId: 1, Limit: 2},
+			setupMocks: func(txnRepo *mocks.ITransactionRepository, acctRepo *mocks.IAccountRepository) {
+				acctRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1}, nil).Once()
+				txnRepo.On("ListTransactions", models.TransactionFilter{AccountId: 1, Limit: 3}).
+					Return(ledger, nil).Once()
+			},
+			expectedIds:    []int64{3, 2},
+			expectNextPage: true,
+		},
+		{
+			name: "next page from cursor",
+			req:  models.ListTransactionsArgs{AccountId: 1, Limit: 2, Cursor: encodeCursor(ledger[1].CreatedAt, 2)},
+			setupMocks: func(txnRepo *mocks.ITransactionRepository, acctRepo *mocks.IAccountRepository) {
+				acctRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1}, nil).Once()
+				txnRepo.On("ListTransactions", models.TransactionFilter{
+					AccountId:       1,
+					Limit:           3,
+					CursorCreatedAt: ledger[1].CreatedAt,
+					CursorId:        2,
+				}).Return(ledger[2:], nil).Once()
+			},
+			expectedIds: []int64{1},
+		},
+		{
+			name: "repository error",
+			req:  models.ListTransactionsArgs{AccountId: 1},
+			setupMocks: func(txnRepo *mocks.ITransactionRepository, acctRepo *mocks.IAccountRepository) {
+				acctRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1}, nil).Once()
+				txnRepo.On("ListTransactions", mock.Anything).Return(nil, appErr.ErrInternal).Once()
+			},
+			expectedError: appErr.ErrInternal,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxnRepo := mocks.NewITransactionRepository(t)
+			mockAcctRepo := mocks.NewIAccountRepository(t)
+			tc.setupMocks(mockTxnRepo, mockAcctRepo)
+			svc := NewTransactionService(mockTxnRepo, mockAcctRepo, nil, nil, time.Minute, nil, nil)
+
+			resp, err := svc.ListAccountTransactions(ctx, tc.req)
+
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			ids := make([]int64, 0, len(resp.Transactions))
+			for _, txn := range resp.Transactions {
+				ids = append(ids, txn.Id)
+			}
+			assert.Equal(t, tc.expectedIds, ids)
+			assert.Equal(t, tc.expectNextPage, resp.NextCursor != "")
+		})
+	}
+}
+
+func TestTransactionService_Cursor(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 30, 15, 123456000, time.UTC)
+
+	gotAt, gotId, err := decodeCursor(encodeCursor(createdAt, 42))
+	assert.NoError(t, err)
+	assert.True(t, createdAt.Equal(gotAt))
+	assert.Equal(t, int64(42), gotId)
+
+	_, _, err = decodeCursor("!!!")
+	assert.Equal(t, appErr.ErrInvalidCursor, err)
+}
+
+func TestTransactionService_GetTransfer(t *testing.T) {
+	ctx := context.Background()
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	legs := []models.Transaction{
+		{Id: 10, AccountId: 1, Amount: models.NewMoney(100), CurrencyCode: "USD", AvailableBalance: models.NewMoney(900), IsCredit: false, Reference: reference, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{Id: 11, AccountId: 2, Amount: models.NewMoney(100), CurrencyCode: "USD", AvailableBalance: models.NewMoney(600), IsCredit: true, Reference: reference, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	tests := []struct {
+		name          string
+		reference     string
+		setupMocks    func(txnRepo *mocks.ITransactionRepository)
+		expectedError error
+		expected      models.TransferResponse
+	}{
+		{
+			name:          "missing prefix",
+			reference:     "5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11",
+			setupMocks:    func(*mocks.ITransactionRepository) {},
+			expectedError: appErr.ErrInvalidReference,
+		},
+		{
+			name:          "not a uuid",
+			reference:     "TXN-123",
+			setupMocks:    func(*mocks.ITransactionRepository) {},
+			expectedError: appErr.ErrInvalidReference,
+		},
+		{
+			name:      "not found",
+			reference: reference,
+			setupMocks: func(txnRepo *mocks.ITransactionRepository) {
+				txnRepo.On("GetByReference", reference).Return(nil, appErr.ErrTransactionNotFound).Once()
+			},
+			expectedError: appErr.ErrTransactionNotFound,
+		},
+		{
+			name:      "success",
+			reference: reference,
+			setupMocks: func(txnRepo *mocks.ITransactionRepository) {
+				txnRepo.On("GetByReference", reference).Return(legs, nil).Once()
+			},
+			expected: models.TransferResponse{
+				Reference:                   reference,
+				SourceAccountId:             1,
+				DestinationAccountId:        2,
+				Amount:                      models.NewMoney(100),
+				CurrencyCode:                "USD",
+				DestinationAmount:           models.NewMoney(100),
+				DestinationCurrencyCode:     "USD",
+				FxRate:                      models.OneExchangeRate,
+				SourceAvailableBalance:      models.NewMoney(900),
+				DestinationAvailableBalance: models.NewMoney(600),
+				CreatedAt:                   createdAt,
+				UpdatedAt:                   createdAt,
+				Legs: []models.TransactionResponse{
+					models.NewTransactionResponse(legs[0]),
+					models.NewTransactionResponse(legs[1]),
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxnRepo := mocks.NewITransactionRepository(t)
+			tc.setupMocks(mockTxnRepo)
+			svc := NewTransactionService(mockTxnRepo, mocks.NewIAccountRepository(t), nil, nil, time.Minute, nil, nil)
+
+			resp, err := svc.GetTransfer(ctx, tc.reference)
+			assert.Equal(t, tc.expectedError, err)
+			if tc.expectedError == nil {
+				assert.Equal(t, tc.expected, resp)
+			}
+		})
+	}
+}
+
+func TestTransactionService_Ownership(t *testing.T) {
+	reference := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+	sender := &models.Account{AccountId: 1, CurrencyCode: "USD", Status: models.AccountStatusActive, Balance: models.NewMoney(1000), OwnerClientId: sql.NullInt64{Int64: 7, Valid: true}}
+	receiver := &models.Account{AccountId: 2, CurrencyCode: "USD", Status: models.AccountStatusActive, OwnerClientId: sql.NullInt64{Int64: 8, Valid: true}}
+	legs := []models.Transaction{
+		{Id: 10, AccountId: 1, Amount: models.NewMoney(100), CurrencyCode: "USD", Reference: reference},
+		{Id: 11, AccountId: 2, Amount: models.NewMoney(100), CurrencyCode: "USD", IsCredit: true, Reference: reference},
+	}
+
+	t.Run("transfer from another client's account", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(sender, nil).Once()
+		svc := NewTransactionService(mocks.NewITransactionRepository(t), accountRepo, nil, nil, time.Minute, nil, nil)
+
+		_, err := svc.CreateTransaction(clientContext(8, models.ScopeTransactionsWrite), models.CreateTransactionArgs{
+			SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(100),
+		})
+		assert.Equal(t, appErr.ErrAccountForbidden, err)
+	})
+
+	t.Run("receiver can read the transfer", func(t *testing.T) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		txnRepo.On("GetByReference", reference).Return(legs, nil).Once()
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(sender, nil).Once()
+		accountRepo.On("GetByAccountId", int64(2)).Return(receiver, nil).Once()
+		svc := NewTransactionService(txnRepo, accountRepo, nil, nil, time.Minute, nil, nil)
+
+		resp, err := svc.GetTransfer(clientContext(8, models.ScopeTransactionsRead), reference)
+		assert.NoError(t, err)
+		assert.Equal(t, reference, resp.Reference)
+	})
+
+	t.Run("transfer between other clients cannot be read", func(t *testing.T) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		txnRepo.On("GetByReference", reference).Return(legs, nil).Once()
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(1)).Return(sender, nil).Once()
+		accountRepo.On("GetByAccountId", int64(2)).Return(receiver, nil).Once()
+		svc := NewTransactionService(txnRepo, accountRepo, nil, nil, time.Minute, nil, nil)
+
+		_, err := svc.GetTransfer(clientContext(9, models.ScopeTransactionsRead), reference)
+		assert.Equal(t, appErr.ErrAccountForbidden, err)
+	})
+
+	t.Run("sender cannot reverse a transfer", func(t *testing.T) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		txnRepo.On("GetByReference", reference).Return(legs, nil).Once()
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(2)).Return(receiver, nil).Once()
+		svc := NewTransactionService(txnRepo, accountRepo, nil, nil, time.Minute, nil, nil)
+
+		_, err := svc.ReverseTransaction(clientContext(7, models.ScopeTransactionsWrite), models.ReverseTransactionArgs{OriginalReference: reference})
+		assert.Equal(t, appErr.ErrAccountForbidden, err)
+	})
+
+	t.Run("receiver reverses a transfer", func(t *testing.T) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		txnRepo.On("GetByReference", reference).Return(legs, nil).Once()
+		txnRepo.On("ReverseTransaction", mock.Anything).Return(models.ReverseTransactionResponse{OriginalReference: reference}, nil).Once()
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(2)).Return(receiver, nil).Once()
+		svc := NewTransactionService(txnRepo, accountRepo, nil, nil, time.Minute, nil, nil)
+
+		_, err := svc.ReverseTransaction(clientContext(8, models.ScopeTransactionsWrite), models.ReverseTransactionArgs{OriginalReference: reference})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGenerateTransactionRef(t *testing.T) {
+	assert.True(t, isValidTransactionRef(generateTransactionRef()))
+}
+
+func TestTransactionService_ReverseTransaction(t *testing.T) {
+	ctx := context.Background()
+	original := "TXN-5b7e2a4e-4d1f-4a55-9a8e-0c3f1f5d2c11"
+	zero := models.Money{}
+	partial := models.NewMoney(25)
+
+	tests := []struct {
+		name          string
+		req           models.ReverseTransactionArgs
+		setupMocks    func(txnRepo *mocks.ITransactionRepository)
+		expectedError error
+	}{
+		{
+			name:          "invalid reference",
+			req:           models.ReverseTransactionArgs{OriginalReference: "abc"},
+			setupMocks:    func(*mocks.ITransactionRepository) {},
+			expectedError: appErr.ErrInvalidReference,
+		},
+		{
+			name:          "zero amount",
+			req:           models.ReverseTransactionArgs{OriginalReference: original, Amount: &zero},
+			setupMocks:    func(*mocks.ITransactionRepository) {},
+			expectedError: appErr.ErrInvalidAmount,
+		},
+		{
+			name: "partial reversal gets a new reference",
+			req:  models.ReverseTransactionArgs{OriginalReference: original, Amount: &partial},
+			setupMocks: func(txnRepo *mocks.ITransactionRepository) {
+				txnRepo.On("ReverseTransaction", mock.MatchedBy(func(args models.ReverseTransactionArgs) bool {
+					return args.OriginalReference == original && *args.Amount == partial &&
+						args.Reference != original && isValidTransactionRef(args.Reference)
+				})).Return(models.ReverseTransactionResponse{OriginalReference: original, Amount: partial}, nil).Once()
+			},
+		},
+		{
+			name: "repository error",
+			req:  models.ReverseTransactionArgs{OriginalReference: original},
+			setupMocks: func(txnRepo *mocks.ITransactionRepository) {
+				txnRepo.On("ReverseTransaction", mock.Anything).
+					Return(models.ReverseTransactionResponse{}, appErr.ErrTransactionAlreadyReversed).Once()
+			},
+			expectedError: appErr.ErrTransactionAlreadyReversed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTxnRepo := mocks.NewITransactionRepository(t)
+			tc.setupMocks(mockTxnRepo)
+			svc := NewTransactionService(mockTxnRepo, mocks.NewIAccountRepository(t), nil, nil, time.Minute, nil, nil)
+
+			_, err := svc.ReverseTransaction(ctx, tc.req)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_CrossCurrency(t *testing.T) {
+	ctx := context.Background()
+	rates, err := fx.NewStaticRateProvider(map[string]models.ExchangeRate{
+		"USD/JPY": models.MustParseExchangeRate("151.255"),
+	})
+	assert.NoError(t, err)
+
+	quoteId := "QTE-0f8fad5b-d9cb-469f-a165-70867728950e"
+	usd := &models.Account{AccountId: 1, CurrencyCode: "USD"}
+	jpy := &models.Account{AccountId: 2, CurrencyCode: "JPY"}
+	eur := &models.Account{AccountId: 3, CurrencyCode: "EUR"}
+	validQuote := func() *models.FxQuote {
+		return &models.FxQuote{
+			QuoteId:                 quoteId,
+			SourceAccountId:         1,
+			DestinationAccountId:    2,
+			SourceCurrencyCode:      "USD",
+			DestinationCurrencyCode: "JPY",
+			SourceAmount:            models.MustParseMoney("10.01"),
+			DestinationAmount:       models.NewMoney(1500),
+			Rate:                    models.MustParseExchangeRate("149.85"),
+			ExpiresAt:               time.Now().Add(time.Minute),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		req           models.CreateTransactionArgs
+		accounts      map[int64]*models.Account
+		quote         *models.FxQuote
+		expectedArgs  func(args models.CreateTransactionArgs) bool
+		repoError     error
+		expectedError error
+	}{
+		{
+			name:     "converted at the current rate and rounded to the destination minor unit",
+			req:      models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01")},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			expectedArgs: func(args models.CreateTransactionArgs) bool {
+				// 10.01 × 151.255 = 1514.06255 JPY, rounded to 1514
+				return args.CurrencyCode == "USD" && args.DestinationCurrencyCode == "JPY" &&
+					args.FxRate == models.MustParseExchangeRate("151.255") && args.DestinationAmount == models.NewMoney(1514)
+			},
+		},
+		{
+			name:     "converted at the inverse of the configured pair",
+			req:      models.CreateTransactionArgs{SourceAccountId: 2, DestinationAccountId: 1, Amount: models.NewMoney(1000)},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			expectedArgs: func(args models.CreateTransactionArgs) bool {
+				// 1000 / 151.255 = 6.6113... USD
+				return args.CurrencyCode == "JPY" && args.DestinationAmount == models.MustParseMoney("6.61")
+			},
+		},
+		{
+			name:     "executed at the quoted rate",
+			req:      models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01"), QuoteId: quoteId},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			quote:    validQuote(),
+			expectedArgs: func(args models.CreateTransactionArgs) bool {
+				return args.QuoteId == quoteId && args.FxRate == models.MustParseExchangeRate("149.85") && args.DestinationAmount == models.NewMoney(1500)
+			},
+		},
+		{
+			name:          "quote for another amount",
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.NewMoney(20), QuoteId: quoteId},
+			accounts:      map[int64]*models.Account{1: usd, 2: jpy},
+			quote:         validQuote(),
+			expectedError: appErr.ErrQuoteMismatch,
+		},
+		{
+			name:     "expired quote",
+			req:      models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01"), QuoteId: quoteId},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			quote: func() *models.FxQuote {
+				q := validQuote()
+				q.ExpiresAt = time.Now().Add(-time.Second)
+				return q
+			}(),
+			// The repository rejects the quote while consuming it
+			expectedArgs:  func(args models.CreateTransactionArgs) bool { return args.QuoteId == quoteId },
+			repoError:     appErr.ErrQuoteExpired,
+			expectedError: appErr.ErrQuoteExpired,
+		},
+		{
+			name:     "used quote",
+			req:      models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01"), QuoteId: quoteId},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			quote: func() *models.FxQuote {
+				q := validQuote()
+				q.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				return q
+			}(),
+			expectedArgs:  func(args models.CreateTransactionArgs) bool { return args.QuoteId == quoteId },
+			repoError:     appErr.ErrQuoteAlreadyUsed,
+			expectedError: appErr.ErrQuoteAlreadyUsed,
+		},
+		{
+			name: "replay of a quoted transfer gets the stored response",
+			req: models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01"), QuoteId: quoteId,
+				Idempotency: models.Idempotency{Key: "key-1", RequestHash: "hash-1"}},
+			accounts: map[int64]*models.Account{1: usd, 2: jpy},
+			// The quote was used by the original request, and has expired since
+			quote: func() *models.FxQuote {
+				q := validQuote()
+				q.UsedAt = sql.NullTime{Time: time.Now().Add(-2 * time.Minute), Valid: true}
+				q.ExpiresAt = time.Now().Add(-time.Minute)
+				return q
+			}(),
+			expectedArgs: func(args models.CreateTransactionArgs) bool {
+				return args.QuoteId == quoteId && args.Idempotency.Key == "key-1"
+			},
+		},
+		{
+			name:          "malformed quote id",
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("10.01"), QuoteId: "QTE-1"},
+			accounts:      map[int64]*models.Account{1: usd, 2: jpy},
+			expectedError: appErr.ErrInvalidQuoteId,
+		},
+		{
+			name:          "no rate for the pair",
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 3, Amount: models.NewMoney(10)},
+			accounts:      map[int64]*models.Account{1: usd, 3: eur},
+			expectedError: appErr.ErrExchangeRateUnavailable,
+		},
+		{
+			name:          "amount finer than the source currency minor unit",
+			req:           models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("0.001")},
+			accounts:      map[int64]*models.Account{1: usd, 2: jpy},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			txnRepo := mocks.NewITransactionRepository(t)
+			acctRepo := mocks.NewIAccountRepository(t)
+			quoteRepo := mocks.NewIFxQuoteRepository(t)
+			svc := NewTransactionService(txnRepo, acctRepo, quoteRepo, rates, time.Minute, nil, nil)
+
+			acctRepo.On("GetByAccountId", tc.req.SourceAccountId).Return(tc.accounts[tc.req.SourceAccountId], nil).Once()
+			acctRepo.On("GetByAccountId", tc.req.DestinationAccountId).Return(tc.accounts[tc.req.DestinationAccountId], nil).Once()
+			if tc.quote != nil {
+				quoteRepo.On("GetQuote", quoteId).Return(tc.quote, nil).Once()
+			}
+			if tc.expectedArgs != nil {
+				txnRepo.On("CreateTransaction", mock.MatchedBy(tc.expectedArgs)).
+					Return(models.CreateTransactionResponse{SourceAccountId: tc.req.SourceAccountId}, tc.repoError).Once()
+			}
+
+			_, err := svc.CreateTransaction(ctx, tc.req)
+			assert.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestTransactionService_CreateQuote(t *testing.T) {
+	ctx := context.Background()
+	rates, err := fx.NewStaticRateProvider(map[string]models.ExchangeRate{
+		"EUR/USD": models.MustParseExchangeRate("1.0825"),
+	})
+	assert.NoError(t, err)
+
+	acctRepo := mocks.NewIAccountRepository(t)
+	quoteRepo := mocks.NewIFxQuoteRepository(t)
+	svc := NewTransactionService(mocks.NewITransactionRepository(t), acctRepo, quoteRepo, rates, 30*time.Second, nil, nil)
+
+	acctRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, CurrencyCode: "EUR"}, nil).Once()
+	acctRepo.On("GetByAccountId", int64(2)).Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil).Once()
+	quoteRepo.On("CreateQuote", mock.AnythingOfType("models.FxQuote")).Return(nil).Once()
+
+	before := time.Now()
+	resp, err := svc.CreateQuote(ctx, models.CreateQuoteArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("99.99")})
+	assert.NoError(t, err)
+	assert.True(t, isValidQuoteId(resp.QuoteId))
+	assert.Equal(t, "EUR", resp.SourceCurrencyCode)
+	assert.Equal(t, "USD", resp.DestinationCurrencyCode)
+	// 99.99 × 1.0825 = 108.239175, rounded half away from zero to cents
+	assert.Equal(t, models.MustParseMoney("108.24"), resp.DestinationAmount)
+	assert.Equal(t, models.MustParseExchangeRate("1.0825"), resp.Rate)
+	assert.WithinDuration(t, before.Add(30*time.Second), resp.ExpiresAt, time.Second)
+
+	_, err = svc.CreateQuote(ctx, models.CreateQuoteArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.Money{}})
+	assert.Equal(t, appErr.ErrInvalidAmount, err)
+}
+
+func TestTransactionService_CreateBatchTransaction(t *testing.T) {
+	ctx := context.Background()
+	accounts := map[int64]*models.Account{
+		1: {AccountId: 1, Balance: models.NewMoney(100), CurrencyCode: "USD"},
+		2: {AccountId: 2, CurrencyCode: "USD"},
+		3: {AccountId: 3, CurrencyCode: "USD", Status: models.AccountStatusClosed},
+	}
+	newService := func(t *testing.T) (*TransactionService, *mocks.ITransactionRepository) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		acctRepo := mocks.NewIAccountRepository(t)
+		for id, account := range accounts {
+			acctRepo.On("GetByAccountId", id).Return(account, nil).Maybe()
+		}
+		return NewTransactionService(txnRepo, acctRepo, nil, nil, time.Minute, nil, nil), txnRepo
+	}
+	transfer := func(source, destination int64) models.CreateTransactionArgs {
+		return models.CreateTransactionArgs{SourceAccountId: source, DestinationAccountId: destination, Amount: models.NewMoney(10)}
+	}
+
+	t.Run("invalid batches", func(t *testing.T) {
+		svc, _ := newService(t)
+
+		_, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{Mode: "partial", Transfers: []models.CreateTransactionArgs{transfer(1, 2)}})
+		assert.Equal(t, appErr.ErrInvalidBatchMode, err)
+
+		_, err = svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{Mode: models.BatchModeAtomic})
+		assert.Equal(t, appErr.ErrEmptyBatch, err)
+
+		_, err = svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{Mode: models.BatchModeAtomic, Transfers: make([]models.CreateTransactionArgs, maxBatchSize+1)})
+		assert.Equal(t, appErr.ErrBatchTooLarge, err)
+	})
+
+	t.Run("atomic batch posted", func(t *testing.T) {
+		svc, txnRepo := newService(t)
+		txnRepo.On("CreateBatchTransaction", mock.MatchedBy(func(reqs []models.CreateTransactionArgs) bool {
+			return len(reqs) == 2 && reqs[0].CurrencyCode == "USD" && isValidTransactionRef(reqs[1].Reference) && reqs[0].Reference != reqs[1].Reference
+		})).Return([]models.CreateTransactionResponse{{Reference: "TXN-1"}, {Reference: "TXN-2"}}, 0, nil).Once()
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:      models.BatchModeAtomic,
+			Transfers: []models.CreateTransactionArgs{transfer(1, 2), transfer(1, 2)},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, resp.Succeeded)
+		assert.Equal(t, 0, resp.Failed)
+		assert.Equal(t, 200, resp.Results[1].StatusCode)
+		assert.Equal(t, "TXN-2", resp.Results[1].Transaction.Reference)
+	})
+
+	t.Run("atomic batch rejected before posting", func(t *testing.T) {
+		svc, _ := newService(t)
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:      models.BatchModeAtomic,
+			Transfers: []models.CreateTransactionArgs{transfer(1, 2), transfer(1, 3)},
+		})
+		assert.Equal(t, appErr.ErrDestinationAccountClosed, err)
+		assert.Equal(t, 1, *resp.FailedIndex)
+		assert.Equal(t, []models.BatchTransactionResult{{Index: 1, StatusCode: 422, ErrorMessage: "receiver account is closed"}}, resp.Results)
+	})
+
+	t.Run("atomic batch rolled back", func(t *testing.T) {
+		svc, txnRepo := newService(t)
+		txnRepo.On("CreateBatchTransaction", mock.Anything).Return(nil, 1, appErr.ErrInsufficientBalance).Once()
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:      models.BatchModeAtomic,
+			Transfers: []models.CreateTransactionArgs{transfer(1, 2), transfer(1, 2)},
+		})
+		assert.Equal(t, appErr.ErrInsufficientBalance, err)
+		assert.Equal(t, 1, *resp.FailedIndex)
+		assert.Equal(t, 1, resp.Failed)
+	})
+
+	t.Run("best effort reports each transfer", func(t *testing.T) {
+		svc, txnRepo := newService(t)
+		txnRepo.On("CreateTransaction", mock.MatchedBy(func(req models.CreateTransactionArgs) bool { return req.DestinationAccountId == 2 })).
+			Return(models.CreateTransactionResponse{Reference: "TXN-1"}, nil).Once()
+		txnRepo.On("CreateTransaction", mock.Anything).Return(models.CreateTransactionResponse{}, errors.New("connection reset")).Once()
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:      models.BatchModeBestEffort,
+			Transfers: []models.CreateTransactionArgs{transfer(1, 2), transfer(1, 3), transfer(2, 1)},
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, resp.FailedIndex)
+		assert.Equal(t, 1, resp.Succeeded)
+		assert.Equal(t, 2, resp.Failed)
+		assert.Equal(t, []models.BatchTransactionResult{
+			{Index: 0, StatusCode: 200, Transaction: &models.CreateTransactionResponse{Reference: "TXN-1"}},
+			{Index: 1, StatusCode: 422, ErrorMessage: "receiver account is closed"},
+			{Index: 2, StatusCode: 500, ErrorMessage: "internal server error"},
+		}, resp.Results)
+	})
+
+	t.Run("best effort reports unparsed amounts", func(t *testing.T) {
+		svc, txnRepo := newService(t)
+		txnRepo.On("CreateTransaction", mock.Anything).Return(models.CreateTransactionResponse{Reference: "TXN-1"}, nil).Once()
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:         models.BatchModeBestEffort,
+			Transfers:    []models.CreateTransactionArgs{{SourceAccountId: 1, DestinationAccountId: 2}, transfer(1, 2)},
+			AmountErrors: []error{appErr.ErrInvalidAmountScale, nil},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, resp.Succeeded)
+		assert.Equal(t, 1, resp.Failed)
+		assert.Equal(t, []models.BatchTransactionResult{
+			{Index: 0, StatusCode: 400, ErrorMessage: "amount cannot have more than 5 decimal places"},
+			{Index: 1, StatusCode: 200, Transaction: &models.CreateTransactionResponse{Reference: "TXN-1"}},
+		}, resp.Results)
+	})
+
+	t.Run("atomic batch rejects unparsed amounts", func(t *testing.T) {
+		svc, _ := newService(t)
+
+		resp, err := svc.CreateBatchTransaction(ctx, models.CreateBatchTransactionArgs{
+			Mode:         models.BatchModeAtomic,
+			Transfers:    []models.CreateTransactionArgs{transfer(1, 2), {SourceAccountId: 1, DestinationAccountId: 2}},
+			AmountErrors: []error{nil, appErr.ErrInvalidAmount},
+		})
+		assert.Equal(t, appErr.ErrInvalidAmount, err)
+		assert.Equal(t, 1, *resp.FailedIndex)
+	})
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository"
+	"log"
+)
+
+type TransferLimitService struct {
+	limitRepo   repository.ITransferLimitRepository
+	accountRepo repository.IAccountRepository
+}
+
+// NewTransferLimitService creates the transfer limit service. The global limits of a currency apply to every
+// account in that currency unless the account has its own limit for the same cap
+func NewTransferLimitService(limitRepo repository.ITransferLimitRepository, accountRepo repository.IAccountRepository) *TransferLimitService {
+	return &TransferLimitService{limitRepo: limitRepo, accountRepo: accountRepo}
+}
+
+type ITransferLimitService interface {
+	GetLimits(ctx context.Context, accountId int64) (models.TransferLimits, error)
+	GetTransferLimits(ctx context.Context, accountId int64) (models.TransferLimitsResponse, error)
+	SetTransferLimits(ctx context.Context, accountId int64, overrides models.TransferLimits) (models.TransferLimitsResponse, error)
+	GetCurrencyTransferLimits(ctx context.Context, currencyCode string) (models.CurrencyTransferLimitsResponse, error)
+	SetCurrencyTransferLimits(ctx context.Context, currencyCode string, limits models.TransferLimits) (models.CurrencyTransferLimitsResponse, error)
+}
+
+// GetLimits is a service method that returns the caps in force for the transfers leaving an account: the global
+// limits of its currency, overridden by its own
+func (s *TransferLimitService) GetLimits(ctx context.Context, accountId int64) (models.TransferLimits, error) {
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		return models.TransferLimits{}, err
+	}
+	overrides, err := s.limitRepo.GetByAccountId(accountId)
+	if err != nil {
+		return models.TransferLimits{}, err
+	}
+	global, err := s.limitRepo.GetByCurrency(account.CurrencyCode)
+	if err != nil {
+		return models.TransferLimits{}, err
+	}
+	return global.Override(overrides), nil
+}
+
+// GetTransferLimits is a service method that returns the caps in force for an account together with the ones set
+// for it in place of the global ones
+func (s *TransferLimitService) GetTransferLimits(ctx context.Context, accountId int64) (models.TransferLimitsResponse, error) {
+	fName := "TransferLimitService.GetTransferLimits"
+	if accountId <= 0 {
+		return models.TransferLimitsResponse{}, appErr.ErrInvalidAccountId
+	}
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.TransferLimitsResponse{}, err
+	}
+
+	overrides, err := s.limitRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get limits of account %d: %v", fName, accountId, err)
+		return models.TransferLimitsResponse{}, err
+	}
+	return s.newTransferLimitsResponse(account, overrides)
+}
+
+// SetTransferLimits is a service method that replaces the limits of an account. A cap left out falls back to the
+// global one. Amounts are in the currency of the account
+func (s *TransferLimitService) SetTransferLimits(ctx context.Context, accountId int64, overrides models.TransferLimits) (models.TransferLimitsResponse, error) {
+	fName := "TransferLimitService.SetTransferLimits"
+	if accountId <= 0 {
+		return models.TransferLimitsResponse{}, appErr.ErrInvalidAccountId
+	}
+	if !overrides.IsValid() {
+		return models.TransferLimitsResponse{}, appErr.ErrInvalidTransferLimits
+	}
+
+	account, err := s.accountRepo.GetByAccountId(accountId)
+	if err != nil {
+		log.Printf("[%s] failed to get account %d: %v", fName, accountId, err)
+		return models.TransferLimitsResponse{}, err
+	}
+	if account.Status == models.AccountStatusClosed {
+		return models.TransferLimitsResponse{}, appErr.ErrAccountClosed
+	}
+	currency, err := models.LookupCurrency(account.CurrencyCode)
+	if err != nil {
+		log.Printf("[%s] failed to look up currency %q: %v", fName, account.CurrencyCode, err)
+		return models.TransferLimitsResponse{}, appErr.ErrInternal
+	}
+	for _, amount := range []*models.Money{overrides.MaxAmount, overrides.MaxDailyAmount} {
+		if amount != nil && !currency.Allows(*amount) {
+			return models.TransferLimitsResponse{}, appErr.ErrInvalidCurrencyPrecision
+		}
+	}
+
+	if err := s.limitRepo.Upsert(accountId, overrides); err != nil {
+		log.Printf("[%s] failed to set limits of account %d: %v", fName, accountId, err)
+		return models.TransferLimitsResponse{}, err
+	}
+	return s.newTransferLimitsResponse(account, overrides)
+}
+
+// GetCurrencyTransferLimits is a service method that returns the global limits of a currency
+func (s *TransferLimitService) GetCurrencyTransferLimits(ctx context.Context, currencyCode string) (models.CurrencyTransferLimitsResponse, error) {
+	fName := "TransferLimitService.GetCurrencyTransferLimits"
+	currency, err := models.LookupCurrency(currencyCode)
+	if err != nil {
+		return models.CurrencyTransferLimitsResponse{}, err
+	}
+
+	limits, err := s.limitRepo.GetByCurrency(currency.Code)
+	if err != nil {
+		log.Printf("[%s] failed to get limits of %s: %v", fName, currency.Code, err)
+		return models.CurrencyTransferLimitsResponse{}, err
+	}
+	return models.CurrencyTransferLimitsResponse{CurrencyCode: currency.Code, Limits: limits}, nil
+}
+
+// SetCurrencyTransferLimits is a service method that replaces the global limits of a currency, which cap the
+// transfers of the accounts in it. A cap left out is not enforced. Amounts are in the currency
+func (s *TransferLimitService) SetCurrencyTransferLimits(ctx context.Context, currencyCode string, limits models.TransferLimits) (models.CurrencyTransferLimitsResponse, error) {
+	fName := "TransferLimitService.SetCurrencyTransferLimits"
+	if !limits.IsValid() {
+		return models.CurrencyTransferLimitsResponse{}, appErr.ErrInvalidTransferLimits
+	}
+	currency, err := models.LookupCurrency(currencyCode)
+	if err != nil {
+		return models.CurrencyTransferLimitsResponse{}, err
+	}
+	for _, amount := range []*models.Money{limits.MaxAmount, limits.MaxDailyAmount} {
+		if amount != nil && !currency.Allows(*amount) {
+			return models.CurrencyTransferLimitsResponse{}, appErr.ErrInvalidCurrencyPrecision
+		}
+	}
+
+	if err := s.limitRepo.UpsertCurrency(currency.Code, limits); err != nil {
+		log.Printf("[%s] failed to set limits of %s: %v", fName, currency.Code, err)
+		return models.CurrencyTransferLimitsResponse{}, err
+	}
+	return models.CurrencyTransferLimitsResponse{CurrencyCode: currency.Code, Limits: limits}, nil
+}
+
+// newTransferLimitsResponse builds the API view of the limits of an account
+func (s *TransferLimitService) newTransferLimitsResponse(account *models.Account, overrides models.TransferLimits) (models.TransferLimitsResponse, error) {
+	global, err := s.limitRepo.GetByCurrency(account.CurrencyCode)
+	if err != nil {
+		return models.TransferLimitsResponse{}, err
+	}
+	return models.TransferLimitsResponse{
+		AccountId: account.AccountId,
+		Limits:    global.Override(overrides),
+		Overrides: overrides,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appErr "github.com/bhuvi1021/TripleA/internal/errors"
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/bhuvi1021/TripleA/internal/repository/mocks"
+	serviceMocks "github.com/bhuvi1021/TripleA/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransferLimitService_GetLimits(t *testing.T) {
+	maxAmount, overrideAmount := models.MustParseMoney("10000"), models.MustParseMoney("25000")
+	maxHourlyCount := 20
+	global := models.TransferLimits{MaxAmount: &maxAmount, MaxHourlyCount: &maxHourlyCount}
+
+	t.Run("global limits of the currency of the account overridden by its own", func(t *testing.T) {
+		limitRepo := mocks.NewITransferLimitRepository(t)
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(7)).Return(&models.Account{AccountId: 7, CurrencyCode: "JPY"}, nil).Once()
+		limitRepo.On("GetByAccountId", int64(7)).Return(models.TransferLimits{MaxAmount: &overrideAmount}, nil).Once()
+		limitRepo.On("GetByCurrency", "JPY").Return(global, nil).Once()
+
+		limits, err := NewTransferLimitService(limitRepo, accountRepo).GetLimits(context.Background(), 7)
+		assert.NoError(t, err)
+		assert.Equal(t, models.TransferLimits{MaxAmount: &overrideAmount, MaxHourlyCount: &maxHourlyCount}, limits)
+	})
+
+	t.Run("account not found", func(t *testing.T) {
+		accountRepo := mocks.NewIAccountRepository(t)
+		accountRepo.On("GetByAccountId", int64(7)).Return(nil, appErr.ErrAccountNotFound).Once()
+
+		_, err := NewTransferLimitService(mocks.NewITransferLimitRepository(t), accountRepo).GetLimits(context.Background(), 7)
+		assert.Equal(t, appErr.ErrAccountNotFound, err)
+	})
+}
+
+func TestTransferLimitService_SetCurrencyTransferLimits(t *testing.T) {
+	maxAmount, fractionalAmount, zero := models.MustParseMoney("1500000"), models.MustParseMoney("0.5"), 0
+
+	tests := []struct {
+		name          string
+		currencyCode  string
+		limits        models.TransferLimits
+		expectUpsert  bool
+		expectedError error
+	}{
+		{
+			name:         "limits set",
+			currencyCode: "jpy",
+			limits:       models.TransferLimits{MaxAmount: &maxAmount},
+			expectUpsert: true,
+		},
+		{
+			name:          "zero cap",
+			currencyCode:  "JPY",
+			limits:        models.TransferLimits{MaxHourlyCount: &zero},
+			expectedError: appErr.ErrInvalidTransferLimits,
+		},
+		{
+			name:          "unknown currency",
+			currencyCode:  "XYZ",
+			limits:        models.TransferLimits{MaxAmount: &maxAmount},
+			expectedError: appErr.ErrUnsupportedCurrency,
+		},
+		{
+			name:          "more decimals than the currency allows",
+			currencyCode:  "JPY",
+			limits:        models.TransferLimits{MaxDailyAmount: &fractionalAmount},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limitRepo := mocks.NewITransferLimitRepository(t)
+			if tt.expectUpsert {
+				limitRepo.On("UpsertCurrency", "JPY", tt.limits).Return(nil).Once()
+			}
+
+			resp, err := NewTransferLimitService(limitRepo, mocks.NewIAccountRepository(t)).SetCurrencyTransferLimits(context.Background(), tt.currencyCode, tt.limits)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.CurrencyTransferLimitsResponse{CurrencyCode: "JPY", Limits: tt.limits}, resp)
+			}
+		})
+	}
+}
+
+func TestTransferLimitService_SetTransferLimits(t *testing.T) {
+	maxAmount := models.MustParseMoney("10000")
+	global := models.TransferLimits{MaxAmount: &maxAmount}
+	dailyAmount, fractionalAmount, zero := models.MustParseMoney("50000"), models.MustParseMoney("0.5"), 0
+
+	tests := []struct {
+		name          string
+		overrides     models.TransferLimits
+		account       *models.Account
+		expectUpsert  bool
+		expectedError error
+	}{
+		{
+			name:         "limits set",
+			overrides:    models.TransferLimits{MaxDailyAmount: &dailyAmount},
+			account:      &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusActive},
+			expectUpsert: true,
+		},
+		{
+			name:          "zero cap",
+			overrides:     models.TransferLimits{MaxHourlyCount: &zero},
+			expectedError: appErr.ErrInvalidTransferLimits,
+		},
+		{
+			name:          "more decimals than the currency allows",
+			overrides:     models.TransferLimits{MaxDailyAmount: &fractionalAmount},
+			account:       &models.Account{AccountId: 7, CurrencyCode: "JPY", Status: models.AccountStatusActive},
+			expectedError: appErr.ErrInvalidCurrencyPrecision,
+		},
+		{
+			name:          "closed account",
+			overrides:     models.TransferLimits{MaxDailyAmount: &dailyAmount},
+			account:       &models.Account{AccountId: 7, CurrencyCode: "USD", Status: models.AccountStatusClosed},
+			expectedError: appErr.ErrAccountClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limitRepo := mocks.NewITransferLimitRepository(t)
+			accountRepo := mocks.NewIAccountRepository(t)
+			if tt.account != nil {
+				accountRepo.On("GetByAccountId", int64(7)).Return(tt.account, nil).Once()
+			}
+			if tt.expectUpsert {
+				limitRepo.On("Upsert", int64(7), tt.overrides).Return(nil).Once()
+				limitRepo.On("GetByCurrency", "USD").Return(global, nil).Once()
+			}
+			svc := NewTransferLimitService(limitRepo, accountRepo)
+
+			resp, err := svc.SetTransferLimits(context.Background(), 7, tt.overrides)
+			assert.Equal(t, tt.expectedError, err)
+			if tt.expectedError == nil {
+				assert.Equal(t, models.TransferLimitsResponse{
+					AccountId: 7,
+					Limits:    models.TransferLimits{MaxAmount: &maxAmount, MaxDailyAmount: &dailyAmount},
+					Overrides: tt.overrides,
+				}, resp)
+			}
+		})
+	}
+}
+
+func TestTransactionService_CreateTransaction_Limits(t *testing.T) {
+	maxAmount := models.MustParseMoney("100")
+	maxHourlyCount := 20
+	limits := models.TransferLimits{MaxAmount: &maxAmount, MaxHourlyCount: &maxHourlyCount}
+
+	newService := func(t *testing.T) (*TransactionService, *mocks.ITransactionRepository) {
+		txnRepo := mocks.NewITransactionRepository(t)
+		acctRepo := mocks.NewIAccountRepository(t)
+		acctRepo.On("GetByAccountId", int64(1)).Return(&models.Account{AccountId: 1, CurrencyCode: "USD"}, nil)
+		acctRepo.On("GetByAccountId", int64(2)).Return(&models.Account{AccountId: 2, CurrencyCode: "USD"}, nil)
+		limitService := serviceMocks.NewITransferLimitService(t)
+		limitService.On("GetLimits", mock.Anything, int64(1)).Return(limits, nil).Once()
+		return NewTransactionService(txnRepo, acctRepo, nil, nil, time.Minute, limitService, nil), txnRepo
+	}
+
+	t.Run("over the per-transfer cap is rejected before posting", func(t *testing.T) {
+		svc, _ := newService(t)
+		_, err := svc.CreateTransaction(context.Background(), models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("100.01")})
+		assert.Equal(t, appErr.ErrTransferAmountLimitExceeded, err)
+	})
+
+	t.Run("limits are passed on to be checked under the lock", func(t *testing.T) {
+		svc, txnRepo := newService(t)
+		txnRepo.On("CreateTransaction", mock.MatchedBy(func(req models.CreateTransactionArgs) bool {
+			return req.Limits.MaxHourlyCount == &maxHourlyCount
+		})).Return(models.CreateTransactionResponse{}, appErr.ErrHourlyCountLimitExceeded).Once()
+
+		_, err := svc.CreateTransaction(context.Background(), models.CreateTransactionArgs{SourceAccountId: 1, DestinationAccountId: 2, Amount: models.MustParseMoney("100")})
+		assert.Equal(t, appErr.ErrHourlyCountLimitExceeded, err)
+	})
+}
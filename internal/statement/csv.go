@@ -0,0 +1,54 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+)
+
+// csvHeader is the header row of the ledger lines of a CSV statement
+var csvHeader = []string{"id", "created_at", "reference", "original_reference", "is_fee", "debit", "credit", "balance"}
+
+// WriteCSV renders s as CSV: one row per ledger line, preceded by the opening balance and followed by the debit
+// and credit totals and the closing balance, so that the file can be summed up in a spreadsheet
+func WriteCSV(w io.Writer, s models.Statement) error {
+	records := [][]string{
+		{"account_id", strconv.FormatInt(s.AccountId, 10)},
+		{"currency_code", s.CurrencyCode},
+		{"from", s.From},
+		{"to", s.To},
+		{"opening_balance", s.OpeningBalance.String()},
+		nil,
+		csvHeader,
+	}
+	for _, line := range s.Lines {
+		records = append(records, []string{
+			strconv.FormatInt(line.Id, 10),
+			line.CreatedAt.UTC().Format(time.RFC3339),
+			line.Reference,
+			line.OriginalReference,
+			strconv.FormatBool(line.IsFee),
+			formatAmount(line.Debit),
+			formatAmount(line.Credit),
+			line.Balance.String(),
+		})
+	}
+	records = append(records,
+		nil,
+		[]string{"total_debits", s.TotalDebits.String()},
+		[]string{"total_credits", s.TotalCredits.String()},
+		[]string{"closing_balance", s.ClosingBalance.String()},
+	)
+	return csv.NewWriter(w).WriteAll(records)
+}
+
+// formatAmount formats the debit or credit of a line, which is empty on the other side
+func formatAmount(m *models.Money) string {
+	if m == nil {
+		return ""
+	}
+	return m.String()
+}
@@ -0,0 +1,134 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+)
+
+// Geometry of a PDF statement page, in points: A4 landscape with the text set in 8pt Courier, so that the
+// columns line up without measuring glyphs
+const (
+	pageWidth    = 842
+	pageHeight   = 595
+	pageMargin   = 40
+	fontSize     = 8
+	lineHeight   = 11
+	linesPerPage = (pageHeight-2*pageMargin)/lineHeight - 2
+)
+
+// pdfLineFormat lays out a ledger line: date, reference, type, debit, credit and balance
+const pdfLineFormat = "%-19s  %-40s  %-8s  %20s  %20s  %20s"
+
+// WritePDF renders s as a plain text PDF of as many pages as its lines need, each page numbered at the bottom
+func WritePDF(w io.Writer, s models.Statement) error {
+	text := []string{
+		fmt.Sprintf("Statement of account %d (%s)", s.AccountId, s.CurrencyCode),
+		fmt.Sprintf("Period %s to %s, generated %s", s.From, s.To, s.GeneratedAt.UTC().Format(time.RFC3339)),
+		"",
+		fmt.Sprintf("%-30s %20s", "Opening balance", s.OpeningBalance),
+		"",
+		fmt.Sprintf(pdfLineFormat, "Date (UTC)", "Reference", "Type", "Debit", "Credit", "Balance"),
+	}
+	for _, line := range s.Lines {
+		text = append(text, fmt.Sprintf(pdfLineFormat, line.CreatedAt.UTC().Format(time.DateTime), line.Reference, lineType(line),
+			formatAmount(line.Debit), formatAmount(line.Credit), line.Balance))
+	}
+	text = append(text,
+		"",
+		fmt.Sprintf("%-30s %20s", "Total debits", s.TotalDebits),
+		fmt.Sprintf("%-30s %20s", "Total credits", s.TotalCredits),
+		fmt.Sprintf("%-30s %20s", "Closing balance", s.ClosingBalance),
+	)
+
+	var pages [][]string
+	for len(text) > linesPerPage {
+		pages = append(pages, text[:linesPerPage])
+		text = text[linesPerPage:]
+	}
+	pages = append(pages, text)
+	return writePDF(w, pages)
+}
+
+// lineType describes the kind of entry a statement line is
+func lineType(line models.StatementLine) string {
+	switch {
+	case line.IsFee:
+		return "fee"
+	case line.OriginalReference != "":
+		return "reversal"
+	default:
+		return "transfer"
+	}
+}
+
+// writePDF writes a PDF 1.4 document with one page per element of pages, each holding lines of text. Objects 1 to 3
+// are the catalog, the page tree and the font, followed by the page and content stream of every page
+func writePDF(w io.Writer, pages [][]string) error {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	for i, lines := range pages {
+		content := pageContent(lines, fmt.Sprintf("Page %d of %d", i+1, len(pages)))
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, 5+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// pageContent is the content stream drawing lines from the top margin down, and footer in the bottom margin
+func pageContent(lines []string, footer string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BT /F1 %d Tf %d TL %d %d Td\n", fontSize, lineHeight, pageMargin, pageHeight-pageMargin-fontSize)
+	for _, line := range lines {
+		fmt.Fprintf(&b, "(%s) Tj T*\n", escapePDFText(line))
+	}
+	b.WriteString("ET\n")
+	fmt.Fprintf(&b, "BT /F1 %d Tf %d %d Td (%s) Tj ET", fontSize, pageMargin, pageMargin/2, escapePDFText(footer))
+	return b.String()
+}
+
+// escapePDFText escapes s for a PDF string literal. Characters outside printable ASCII, which the standard font
+// encoding does not cover the same way, are replaced by '?'
+func escapePDFText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > '~':
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhuvi1021/TripleA/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStatement(lines int) models.Statement {
+	at := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+	s := models.Statement{
+		AccountId:      1001,
+		CurrencyCode:   "USD",
+		From:           "2025-03-01",
+		To:             "2025-03-31",
+		OpeningBalance: models.NewMoney(100),
+		ClosingBalance: models.NewMoney(100),
+		GeneratedAt:    time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < lines; i++ {
+		amount := models.NewMoney(1)
+		s.ClosingBalance = s.ClosingBalance.Sub(amount)
+		s.TotalDebits = s.TotalDebits.Add(amount)
+		s.Lines = append(s.Lines, models.StatementLine{Id: int64(i + 1), CreatedAt: at, Reference: fmt.Sprintf("TXN-%d", i+1), Debit: &amount, Balance: s.ClosingBalance})
+	}
+	return s
+}
+
+func TestWriteCSV(t *testing.T) {
+	s := newTestStatement(1)
+	credit := models.MustParseMoney("10.5")
+	s.Lines = append(s.Lines, models.StatementLine{Id: 2, CreatedAt: s.Lines[0].CreatedAt, Reference: "TXN-2", OriginalReference: "TXN-0", Credit: &credit, Balance: models.MustParseMoney("109.5")})
+	s.TotalCredits, s.ClosingBalance = credit, models.MustParseMoney("109.5")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, s))
+	assert.Equal(t, `account_id,1001
+currency_code,USD
+from,2025-03-01
+to,2025-03-31
+opening_balance,100.00000
+
+id,created_at,reference,original_reference,is_fee,debit,credit,balance
+1,2025-03-10T09:30:00Z,TXN-1,,false,1.00000,,99.00000
+2,2025-03-10T09:30:00Z,TXN-2,TXN-0,false,,10.50000,109.50000
+
+total_debits,1.00000
+total_credits,10.50000
+closing_balance,109.50000
+`, buf.String())
+}
+
+func TestWritePDF(t *testing.T) {
+	tests := []struct {
+		name          string
+		lines         int
+		expectedPages int
+	}{
+		{name: "no entries", lines: 0, expectedPages: 1},
+		{name: "one page", lines: 10, expectedPages: 1},
+		{name: "several pages", lines: 2 * linesPerPage, expectedPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WritePDF(&buf, newTestStatement(tt.lines)))
+			pdf := buf.String()
+
+			assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+			assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+			assert.Contains(t, pdf, fmt.Sprintf("/Count %d", tt.expectedPages))
+			assert.Contains(t, pdf, fmt.Sprintf("(Page %d of %d)", tt.expectedPages, tt.expectedPages))
+			assert.Contains(t, pdf, "(Statement of account 1001 \\(USD\\)) Tj")
+			assert.Contains(t, pdf, "Closing balance")
+			if tt.lines > 0 {
+				assert.Contains(t, pdf, fmt.Sprintf("TXN-%d ", tt.lines), "every line is written")
+			}
+
+			// every object of the cross-reference table starts where its offset says
+			startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+			require.Len(t, startxref, 2)
+			xref, _ := strconv.Atoi(startxref[1])
+			require.True(t, strings.HasPrefix(pdf[xref:], "xref\n"))
+			offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xref:], -1)
+			assert.Len(t, offsets, 3+2*tt.expectedPages)
+			for i, offset := range offsets {
+				at, _ := strconv.Atoi(offset[1])
+				assert.True(t, strings.HasPrefix(pdf[at:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+			}
+
+			// each content stream is as long as it says
+			for _, stream := range regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)\nendstream`).FindAllStringSubmatch(pdf, -1) {
+				length, _ := strconv.Atoi(stream[1])
+				assert.Equal(t, length, len(stream[2]))
+			}
+		})
+	}
+}
+
+func TestEscapePDFText(t *testing.T) {
+	assert.Equal(t, `a \(b\) c\\d`, escapePDFText(`a (b) c\d`))
+	assert.Equal(t, "caf? ?", escapePDFText("café \t"))
+}
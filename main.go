@@ -1,14 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"github.com/bhuvi1021/TripleA/config"
-	"github.com/bhuvi1021/TripleA/database"
+	"github.com/bhuvi1021/TripleA/database/migrations"
+	"github.com/bhuvi1021/TripleA/internal/fx"
+	"github.com/bhuvi1021/TripleA/internal/models"
 	"github.com/bhuvi1021/TripleA/internal/repository"
 	"github.com/bhuvi1021/TripleA/internal/server/handlers"
 	"github.com/bhuvi1021/TripleA/internal/service"
 	"log"
 	"net/http"
+	"os"
 
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
@@ -30,28 +34,128 @@ func main() {
 		log.Fatal("Failed to ping database:", err)
 	}
 
+	// Run a CLI subcommand instead of the server, eg `migrate status`
+	if len(os.Args) > 1 {
+		if err := runCommand(db, cfg, os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	// Run database migrations
-	if err := database.RunMigrations(db); err != nil {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
 	// Initialize repositories
 	accountRepo := repository.NewAccountRepository(db)
 	transactionRepo := repository.NewTransactionRepository(db)
+	idempotencyRepo := repository.NewIdempotencyRepository(db)
+	fxQuoteRepo := repository.NewFxQuoteRepository(db)
+	holdRepo := repository.NewHoldRepository(db)
+	scheduledTransferRepo := repository.NewScheduledTransferRepository(db)
+	transferLimitRepo := repository.NewTransferLimitRepository(db)
+	feeScheduleRepo := repository.NewFeeScheduleRepository(db)
+	interestRepo := repository.NewInterestRepository(db)
+	reconciliationRepo := repository.NewReconciliationRepository(db)
+	statementRepo := repository.NewStatementRepository(db)
+	balanceSnapshotRepo := repository.NewBalanceSnapshotRepository(db)
+	apiKeyRepo := repository.NewApiKeyRepository(db)
+	requestNonceRepo := repository.NewRequestNonceRepository(db)
+
+	// Load the exchange rates of cross-currency transfers, which are disabled without a rates file
+	var rateProvider fx.IRateProvider
+	if cfg.FxRatesFile != "" {
+		provider, err := fx.LoadRateFile(cfg.FxRatesFile)
+		if err != nil {
+			log.Fatal("Failed to load FX rates:", err)
+		}
+		rateProvider = provider
+	}
 
 	// Initialize services
-	accountService := service.NewAccountService(accountRepo)
-	transactionService := service.NewTransactionService(transactionRepo, accountRepo)
+	accountService := service.NewAccountService(accountRepo, idempotencyRepo, cfg.FundingAccountIds)
+	transferLimitService := service.NewTransferLimitService(transferLimitRepo, accountRepo)
+	feeService := service.NewFeeService(feeScheduleRepo, accountRepo, cfg.FeeRevenueAccountId)
+	// Fees are only charged once there is a revenue account to pay them into
+	var transferFeeService service.IFeeService
+	if cfg.FeeRevenueAccountId > 0 {
+		transferFeeService = feeService
+	}
+	transactionService := service.NewTransactionService(transactionRepo, accountRepo, fxQuoteRepo, rateProvider, cfg.FxQuoteTTL, transferLimitService, transferFeeService)
+	holdService := service.NewHoldService(holdRepo, accountRepo, transferLimitService, cfg.HoldTTL)
+	scheduledTransferService := service.NewScheduledTransferService(scheduledTransferRepo, accountRepo, transactionService)
+	interestService := service.NewInterestService(interestRepo, accountRepo, cfg.InterestExpenseAccountId)
+	reconciliationService := service.NewReconciliationService(reconciliationRepo)
+	statementService := service.NewStatementService(statementRepo, accountRepo)
+	balanceSnapshotService := service.NewBalanceSnapshotService(balanceSnapshotRepo)
+	apiKeyService := service.NewApiKeyService(apiKeyRepo)
+	requestSigningService := service.NewRequestSigningService(apiKeyRepo, requestNonceRepo, cfg.SignatureMaxSkew)
+
+	// Release expired holds, run due scheduled transfers and take end-of-day balance snapshots in the background
+	go holdService.RunExpirySweeper(context.Background(), cfg.HoldSweepInterval)
+	go scheduledTransferService.RunScheduler(context.Background(), cfg.SchedulerInterval)
+	go balanceSnapshotService.RunSnapshotJob(context.Background(), cfg.SnapshotInterval)
+	// Forget the nonces of signed requests once their timestamp is too old to be accepted
+	go requestSigningService.RunNoncePurge(context.Background(), cfg.SignatureMaxSkew)
+	// Accrue and pay interest once there is an expense account to pay it from
+	if cfg.InterestExpenseAccountId > 0 {
+		go interestService.RunInterestJob(context.Background(), cfg.InterestInterval)
+	}
 
 	// Initialize handlers
 	accountHandler := handlers.NewAccountHandler(accountService)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
+	holdHandler := handlers.NewHoldHandler(holdService)
+	scheduledTransferHandler := handlers.NewScheduledTransferHandler(scheduledTransferService)
+	transferLimitHandler := handlers.NewTransferLimitHandler(transferLimitService)
+	feeHandler := handlers.NewFeeHandler(feeService)
+	interestHandler := handlers.NewInterestHandler(interestService)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	statementHandler := handlers.NewStatementHandler(statementService)
+	authMiddleware := handlers.NewAuthMiddleware(apiKeyService)
+	signatureMiddleware := handlers.NewSignatureMiddleware(requestSigningService)
 
-	// Setup routes
+	// Setup routes, each requiring its scope from the API key of the request
 	router := mux.NewRouter()
-	router.HandleFunc("/accounts", accountHandler.CreateAccount).Methods("POST")
-	router.HandleFunc("/accounts/{account_id}", accountHandler.GetAccount).Methods("GET")
-	router.HandleFunc("/transactions", transactionHandler.CreateTransaction).Methods("POST")
+	router.HandleFunc("/accounts", authMiddleware.Require(models.ScopeAccountsWrite, accountHandler.CreateAccount)).Methods("POST")
+	router.HandleFunc("/accounts/{account_id}", authMiddleware.Require(models.ScopeAccountsRead, accountHandler.GetAccount)).Methods("GET")
+	router.HandleFunc("/accounts/{account_id}/freeze", authMiddleware.Require(models.ScopeAccountsWrite, accountHandler.FreezeAccount)).Methods("POST")
+	router.HandleFunc("/accounts/{account_id}/unfreeze", authMiddleware.Require(models.ScopeAdmin, accountHandler.UnfreezeAccount)).Methods("POST")
+	router.HandleFunc("/accounts/{account_id}/close", authMiddleware.Require(models.ScopeAccountsWrite, accountHandler.CloseAccount)).Methods("POST")
+	router.HandleFunc("/admin/accounts/{account_id}/overdraft-limit", authMiddleware.Require(models.ScopeAdmin, accountHandler.SetOverdraftLimit)).Methods("PUT")
+	router.HandleFunc("/admin/accounts/{account_id}/owner", authMiddleware.Require(models.ScopeAdmin, accountHandler.SetAccountOwner)).Methods("PUT")
+	router.HandleFunc("/admin/accounts/{account_id}/transfer-limits", authMiddleware.Require(models.ScopeAdmin, transferLimitHandler.GetTransferLimits)).Methods("GET")
+	router.HandleFunc("/admin/accounts/{account_id}/transfer-limits", authMiddleware.Require(models.ScopeAdmin, transferLimitHandler.SetTransferLimits)).Methods("PUT")
+	router.HandleFunc("/admin/transfer-limits/{currency_code}", authMiddleware.Require(models.ScopeAdmin, transferLimitHandler.GetCurrencyTransferLimits)).Methods("GET")
+	router.HandleFunc("/admin/transfer-limits/{currency_code}", authMiddleware.Require(models.ScopeAdmin, transferLimitHandler.SetCurrencyTransferLimits)).Methods("PUT")
+	router.HandleFunc("/admin/accounts/{account_id}/fee-schedule", authMiddleware.Require(models.ScopeAdmin, feeHandler.GetFeeSchedule)).Methods("GET")
+	router.HandleFunc("/admin/accounts/{account_id}/fee-schedule", authMiddleware.Require(models.ScopeAdmin, feeHandler.SetFeeSchedule)).Methods("PUT")
+	router.HandleFunc("/admin/accounts/{account_id}/fee-schedule", authMiddleware.Require(models.ScopeAdmin, feeHandler.DeleteFeeSchedule)).Methods("DELETE")
+	router.HandleFunc("/admin/fee-schedules/{currency_code}", authMiddleware.Require(models.ScopeAdmin, feeHandler.GetCurrencyFeeSchedule)).Methods("GET")
+	router.HandleFunc("/admin/fee-schedules/{currency_code}", authMiddleware.Require(models.ScopeAdmin, feeHandler.SetCurrencyFeeSchedule)).Methods("PUT")
+	router.HandleFunc("/admin/fee-schedules/{currency_code}", authMiddleware.Require(models.ScopeAdmin, feeHandler.DeleteCurrencyFeeSchedule)).Methods("DELETE")
+	router.HandleFunc("/admin/accounts/{account_id}/interest-rate", authMiddleware.Require(models.ScopeAdmin, interestHandler.SetInterestRate)).Methods("PUT")
+	router.HandleFunc("/admin/reconciliation", authMiddleware.Require(models.ScopeAdmin, reconciliationHandler.Reconcile)).Methods("POST")
+	router.HandleFunc("/accounts/{account_id}/transactions", authMiddleware.Require(models.ScopeTransactionsRead, transactionHandler.ListAccountTransactions)).Methods("GET")
+	router.HandleFunc("/accounts/{account_id}/statement", authMiddleware.Require(models.ScopeAccountsRead, statementHandler.GetStatement)).Methods("GET")
+	router.HandleFunc("/transactions", authMiddleware.Require(models.ScopeTransactionsWrite, transactionHandler.CreateTransaction)).Methods("POST")
+	router.HandleFunc("/transactions/batch", authMiddleware.Require(models.ScopeTransactionsWrite, transactionHandler.CreateBatchTransaction)).Methods("POST")
+	router.HandleFunc("/transactions/{reference}", authMiddleware.Require(models.ScopeTransactionsRead, transactionHandler.GetTransfer)).Methods("GET")
+	router.HandleFunc("/transactions/{reference}/reverse", authMiddleware.Require(models.ScopeTransactionsWrite, transactionHandler.ReverseTransaction)).Methods("POST")
+	router.HandleFunc("/fx/quotes", authMiddleware.Require(models.ScopeTransactionsWrite, transactionHandler.CreateQuote)).Methods("POST")
+	router.HandleFunc("/holds", authMiddleware.Require(models.ScopeTransactionsWrite, holdHandler.CreateHold)).Methods("POST")
+	router.HandleFunc("/holds/{hold_id}/capture", authMiddleware.Require(models.ScopeTransactionsWrite, holdHandler.CaptureHold)).Methods("POST")
+	router.HandleFunc("/holds/{hold_id}/void", authMiddleware.Require(models.ScopeTransactionsWrite, holdHandler.VoidHold)).Methods("POST")
+	router.HandleFunc("/accounts/{account_id}/scheduled-transfers", authMiddleware.Require(models.ScopeTransactionsRead, scheduledTransferHandler.ListScheduledTransfers)).Methods("GET")
+	router.HandleFunc("/scheduled-transfers", authMiddleware.Require(models.ScopeTransactionsWrite, scheduledTransferHandler.CreateScheduledTransfer)).Methods("POST")
+	router.HandleFunc("/scheduled-transfers/{scheduled_transfer_id}/pause", authMiddleware.Require(models.ScopeTransactionsWrite, scheduledTransferHandler.PauseScheduledTransfer)).Methods("POST")
+	router.HandleFunc("/scheduled-transfers/{scheduled_transfer_id}/resume", authMiddleware.Require(models.ScopeTransactionsWrite, scheduledTransferHandler.ResumeScheduledTransfer)).Methods("POST")
+	router.HandleFunc("/scheduled-transfers/{scheduled_transfer_id}/cancel", authMiddleware.Require(models.ScopeTransactionsWrite, scheduledTransferHandler.CancelScheduledTransfer)).Methods("POST")
 
 	// Add middleware for JSON content type
 	router.Use(func(next http.Handler) http.Handler {
@@ -60,6 +164,9 @@ func main() {
 			next.ServeHTTP(w, r)
 		})
 	})
+	// Authenticate every request by its signature or its API key, see the `apikey` command to issue keys and
+	// signing secrets
+	router.Use(signatureMiddleware.Verify, authMiddleware.Authenticate)
 
 	log.Printf("Server starting on port %s", cfg.Port)
 	log.Fatal(http.ListenAndServe(":"+cfg.Port, router))